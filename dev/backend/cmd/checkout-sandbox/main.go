@@ -0,0 +1,325 @@
+// Command checkout-sandbox is a stand-in for a hosted-checkout gateway, for
+// local development and integration tests. It speaks the protocol of
+// payment_providers.CheckoutProvider (initialize, hosted page, verify) and
+// sends the payer back to the callback URL like the real gateways do.
+//
+// The checkout page offers one button per outcome:
+//
+//	success    the payment goes through
+//	failed     the card or bank declines it
+//	abandoned  the payer cancels; the transaction stays unpaid
+//	underpay   the gateway reports less than was asked for, to exercise the
+//	           amount check
+//
+// Scripts skip the page with GET /checkout/{code}?outcome=success, or start
+// the sandbox with -auto success so every checkout resolves on first visit.
+//
+// Usage:
+//
+//	go run ./cmd/checkout-sandbox -secret-key sk_test_local
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"html/template"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+var outcomes = []string{"success", "failed", "abandoned", "underpay"}
+
+type initializeRequest struct {
+	Email       string            `json:"email"`
+	Amount      int64             `json:"amount"`
+	Currency    string            `json:"currency"`
+	Reference   string            `json:"reference"`
+	CallbackURL string            `json:"callback_url"`
+	Channels    []string          `json:"channels"`
+	Metadata    map[string]string `json:"metadata"`
+}
+
+type transaction struct {
+	ID              int64             `json:"id"`
+	Status          string            `json:"status"`
+	Reference       string            `json:"reference"`
+	Amount          int64             `json:"amount"`
+	Currency        string            `json:"currency"`
+	GatewayResponse string            `json:"gateway_response"`
+	Channel         string            `json:"channel"`
+	PaidAt          *time.Time        `json:"paid_at"`
+	CreatedAt       time.Time         `json:"created_at"`
+	Customer        map[string]string `json:"customer"`
+	Metadata        map[string]string `json:"metadata"`
+
+	accessCode  string
+	callbackURL string
+	channels    []string
+}
+
+type sandbox struct {
+	secretKey string
+	publicURL string
+	auto      string
+
+	mu     sync.Mutex
+	nextID int64
+	byRef  map[string]*transaction
+	byCode map[string]*transaction
+}
+
+var page = template.Must(template.New("checkout").Parse(`<!doctype html>
+<html><head><meta charset="utf-8"><title>Sandbox checkout</title>
+<style>body{font-family:sans-serif;max-width:28rem;margin:3rem auto}button{display:block;width:100%;margin:.5rem 0;padding:.75rem}</style>
+</head><body>
+<h1>Sandbox checkout</h1>
+<p>{{.Currency}} {{printf "%.2f" .Amount}} &middot; {{.Reference}}</p>
+<p>{{.Email}} &middot; {{.Channels}}</p>
+<form method="post">
+{{range .Outcomes}}<button name="outcome" value="{{.}}">{{.}}</button>
+{{end}}</form>
+</body></html>`))
+
+func main() {
+	sb := &sandbox{
+		byRef:  map[string]*transaction{},
+		byCode: map[string]*transaction{},
+	}
+	var addr string
+	flag.StringVar(&addr, "addr", env("CHECKOUT_SANDBOX_ADDR", ":8091"), "listen address")
+	flag.StringVar(&sb.publicURL, "public-url", env("CHECKOUT_SANDBOX_PUBLIC_URL", "http://localhost:8091"), "base URL payers' browsers reach the sandbox on")
+	flag.StringVar(&sb.secretKey, "secret-key", env("CHECKOUT_SECRET_KEY", ""), "secret key required from the API (empty accepts any)")
+	flag.StringVar(&sb.auto, "auto", env("CHECKOUT_SANDBOX_AUTO", ""), "resolve every checkout on first visit: "+strings.Join(outcomes, ", "))
+	flag.Parse()
+
+	sb.publicURL = strings.TrimRight(sb.publicURL, "/")
+	if sb.auto != "" && !slices.Contains(outcomes, sb.auto) {
+		log.Fatalf("unknown outcome %q (want one of %s)", sb.auto, strings.Join(outcomes, ", "))
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /transaction/initialize", sb.authorized(sb.handleInitialize))
+	mux.HandleFunc("GET /transaction/verify/{reference}", sb.authorized(sb.handleVerify))
+	mux.HandleFunc("GET /checkout/{code}", sb.handleCheckoutPage)
+	mux.HandleFunc("POST /checkout/{code}", sb.handleCheckoutSubmit)
+	mux.HandleFunc("GET /sandbox/transactions", sb.handleListTransactions)
+
+	log.Printf("checkout sandbox listening on %s", addr)
+	srv := &http.Server{Addr: addr, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Fatal(err)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Gateway API
+// ---------------------------------------------------------------------------
+
+// handleInitialize handles POST /transaction/initialize
+func (sb *sandbox) handleInitialize(w http.ResponseWriter, r *http.Request) {
+	var req initializeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeEnvelope(w, http.StatusBadRequest, false, "Invalid JSON body", nil)
+		return
+	}
+	switch {
+	case !strings.Contains(req.Email, "@"):
+		writeEnvelope(w, http.StatusBadRequest, false, "Invalid Email Address Passed", nil)
+		return
+	case req.Amount <= 0:
+		writeEnvelope(w, http.StatusBadRequest, false, "Invalid Amount Sent", nil)
+		return
+	case req.Reference == "":
+		writeEnvelope(w, http.StatusBadRequest, false, "Reference is required", nil)
+		return
+	}
+	if u, err := url.Parse(req.CallbackURL); err != nil || !u.IsAbs() {
+		writeEnvelope(w, http.StatusBadRequest, false, "Invalid callback_url", nil)
+		return
+	}
+	if req.Currency == "" {
+		req.Currency = "GHS"
+	}
+
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+	if _, exists := sb.byRef[req.Reference]; exists {
+		writeEnvelope(w, http.StatusBadRequest, false, "Duplicate Transaction Reference", nil)
+		return
+	}
+
+	sb.nextID++
+	t := &transaction{
+		ID:          sb.nextID,
+		Status:      "abandoned", // initialized but not paid, as the gateways report it
+		Reference:   req.Reference,
+		Amount:      req.Amount,
+		Currency:    req.Currency,
+		CreatedAt:   time.Now(),
+		Customer:    map[string]string{"email": req.Email},
+		Metadata:    req.Metadata,
+		accessCode:  randomHex(8),
+		callbackURL: req.CallbackURL,
+		channels:    req.Channels,
+	}
+	sb.byRef[t.Reference] = t
+	sb.byCode[t.accessCode] = t
+
+	log.Printf("initialize %s: %d %s for %s", t.Reference, t.Amount, t.Currency, req.Email)
+	writeEnvelope(w, http.StatusOK, true, "Authorization URL created", map[string]string{
+		"authorization_url": sb.publicURL + "/checkout/" + t.accessCode,
+		"access_code":       t.accessCode,
+		"reference":         t.Reference,
+	})
+}
+
+// handleVerify handles GET /transaction/verify/{reference}
+func (sb *sandbox) handleVerify(w http.ResponseWriter, r *http.Request) {
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+	t, ok := sb.byRef[r.PathValue("reference")]
+	if !ok {
+		writeEnvelope(w, http.StatusBadRequest, false, "Transaction reference not found", nil)
+		return
+	}
+	writeEnvelope(w, http.StatusOK, true, "Verification successful", t)
+}
+
+func (sb *sandbox) authorized(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		key, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || (sb.secretKey != "" && key != sb.secretKey) {
+			writeEnvelope(w, http.StatusUnauthorized, false, "Invalid key", nil)
+			return
+		}
+		next(w, r)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Hosted checkout page
+// ---------------------------------------------------------------------------
+
+// handleCheckoutPage handles GET /checkout/{code}
+func (sb *sandbox) handleCheckoutPage(w http.ResponseWriter, r *http.Request) {
+	outcome := r.URL.Query().Get("outcome")
+	if outcome == "" {
+		outcome = sb.auto
+	}
+	if outcome != "" {
+		sb.resolve(w, r, outcome)
+		return
+	}
+
+	sb.mu.Lock()
+	t, ok := sb.byCode[r.PathValue("code")]
+	sb.mu.Unlock()
+	if !ok {
+		http.Error(w, "Unknown checkout", http.StatusNotFound)
+		return
+	}
+	_ = page.Execute(w, map[string]any{
+		"Amount":    float64(t.Amount) / 100,
+		"Currency":  t.Currency,
+		"Reference": t.Reference,
+		"Email":     t.Customer["email"],
+		"Channels":  strings.Join(t.channels, ", "),
+		"Outcomes":  outcomes,
+	})
+}
+
+// handleCheckoutSubmit handles POST /checkout/{code}
+func (sb *sandbox) handleCheckoutSubmit(w http.ResponseWriter, r *http.Request) {
+	sb.resolve(w, r, r.FormValue("outcome"))
+}
+
+// resolve settles a checkout and sends the payer back to the callback URL.
+func (sb *sandbox) resolve(w http.ResponseWriter, r *http.Request, outcome string) {
+	if !slices.Contains(outcomes, outcome) {
+		http.Error(w, "outcome must be one of "+strings.Join(outcomes, ", "), http.StatusBadRequest)
+		return
+	}
+
+	sb.mu.Lock()
+	t, ok := sb.byCode[r.PathValue("code")]
+	if !ok {
+		sb.mu.Unlock()
+		http.Error(w, "Unknown checkout", http.StatusNotFound)
+		return
+	}
+	// A finished checkout cannot be paid again
+	if t.Status == "abandoned" {
+		channel := "card"
+		if len(t.channels) > 0 {
+			channel = t.channels[0]
+		}
+		now := time.Now()
+		switch outcome {
+		case "success":
+			t.Status, t.GatewayResponse, t.Channel, t.PaidAt = "success", "Approved", channel, &now
+		case "underpay":
+			t.Status, t.GatewayResponse, t.Channel, t.PaidAt = "success", "Approved", channel, &now
+			t.Amount /= 2
+		case "failed":
+			t.Status, t.GatewayResponse, t.Channel = "failed", "Declined", channel
+		}
+	}
+	target, _ := url.Parse(t.callbackURL)
+	status := t.Status
+	sb.mu.Unlock()
+
+	log.Printf("checkout %s: %s (now %s)", t.Reference, outcome, status)
+	params := target.Query()
+	params.Set("trxref", t.Reference)
+	params.Set("reference", t.Reference)
+	target.RawQuery = params.Encode()
+	http.Redirect(w, r, target.String(), http.StatusSeeOther)
+}
+
+// handleListTransactions handles GET /sandbox/transactions
+func (sb *sandbox) handleListTransactions(w http.ResponseWriter, _ *http.Request) {
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+	out := make([]*transaction, 0, len(sb.byRef))
+	for _, t := range sb.byRef {
+		out = append(out, t)
+	}
+	slices.SortFunc(out, func(a, b *transaction) int { return b.CreatedAt.Compare(a.CreatedAt) })
+	writeJSON(w, http.StatusOK, out)
+}
+
+// ---------------------------------------------------------------------------
+// Helpers
+// ---------------------------------------------------------------------------
+
+func writeEnvelope(w http.ResponseWriter, status int, ok bool, message string, data any) {
+	writeJSON(w, status, map[string]any{"status": ok, "message": message, "data": data})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+func env(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
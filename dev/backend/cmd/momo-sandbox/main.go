@@ -0,0 +1,426 @@
+// Command momo-sandbox is a stand-in for the MoMo collection API, for local
+// development and integration tests. It speaks the same protocol as
+// payment_providers.MomoProvider (OAuth token, request-to-pay, status) and
+// decides each request's outcome from a scenario:
+//
+//	approve           PENDING for -delay, then SUCCESSFUL
+//	decline           PENDING for -delay, then FAILED (APPROVAL_REJECTED)
+//	timeout           PENDING until -expiry, then FAILED (EXPIRED)
+//	delayed-callback  SUCCESSFUL after -delay, but the callback is only sent
+//	                  after -callback-delay, so callers must poll
+//
+// The scenario comes from, in order: a rule for the payer's number set with
+// PUT /sandbox/scenarios/{msisdn} or -rules, then -scenario. State is kept in
+// memory; every API replica shares it by pointing MOMO_BASE_URL here.
+//
+// Usage:
+//
+//	go run ./cmd/momo-sandbox -scenario approve -rules 233241111111=decline
+package main
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/ghana-police/ticketing-backend/internal/adapters/payment_providers"
+)
+
+const (
+	scenarioApprove         = "approve"
+	scenarioDecline         = "decline"
+	scenarioTimeout         = "timeout"
+	scenarioDelayedCallback = "delayed-callback"
+)
+
+var scenarios = []string{scenarioApprove, scenarioDecline, scenarioTimeout, scenarioDelayedCallback}
+
+type party struct {
+	PartyIDType string `json:"partyIdType"`
+	PartyID     string `json:"partyId"`
+}
+
+type requestToPay struct {
+	Amount       string `json:"amount"`
+	Currency     string `json:"currency"`
+	ExternalID   string `json:"externalId"`
+	Payer        party  `json:"payer"`
+	PayerMessage string `json:"payerMessage"`
+	PayeeNote    string `json:"payeeNote"`
+}
+
+type requestToPayStatus struct {
+	requestToPay
+	FinancialTransactionID string `json:"financialTransactionId,omitempty"`
+	Status                 string `json:"status"`
+	Reason                 string `json:"reason,omitempty"`
+}
+
+type transaction struct {
+	ReferenceID string       `json:"referenceId"`
+	Scenario    string       `json:"scenario"`
+	Network     string       `json:"network"`
+	CallbackURL string       `json:"callbackUrl,omitempty"`
+	CreatedAt   time.Time    `json:"createdAt"`
+	ResolveAt   time.Time    `json:"resolveAt"`
+	CallbackAt  time.Time    `json:"callbackAt"`
+	Request     requestToPay `json:"request"`
+
+	finalStatus string
+	reason      string
+	txID        string
+}
+
+// status reports the transaction as the provider would at time now.
+func (t *transaction) status(now time.Time) requestToPayStatus {
+	s := requestToPayStatus{requestToPay: t.Request, Status: "PENDING"}
+	if now.Before(t.ResolveAt) {
+		return s
+	}
+	s.Status, s.Reason = t.finalStatus, t.reason
+	if s.Status == "SUCCESSFUL" {
+		s.FinancialTransactionID = t.txID
+	}
+	return s
+}
+
+type sandbox struct {
+	apiUser         string
+	apiKey          string
+	subscriptionKey string
+	scenario        string
+	delay           time.Duration
+	expiry          time.Duration
+	callbackDelay   time.Duration
+	tokenTTL        time.Duration
+	client          *http.Client
+
+	mu     sync.Mutex
+	rules  map[string]string    // msisdn → scenario
+	tokens map[string]time.Time // access token → expiry
+	txns   map[string]*transaction
+}
+
+func main() {
+	sb := &sandbox{
+		client: &http.Client{Timeout: 10 * time.Second},
+		rules:  map[string]string{},
+		tokens: map[string]time.Time{},
+		txns:   map[string]*transaction{},
+	}
+	var addr, rules string
+	flag.StringVar(&addr, "addr", env("MOMO_SANDBOX_ADDR", ":8090"), "listen address")
+	flag.StringVar(&sb.scenario, "scenario", env("MOMO_SANDBOX_SCENARIO", scenarioApprove), "default scenario: "+strings.Join(scenarios, ", "))
+	flag.StringVar(&rules, "rules", env("MOMO_SANDBOX_RULES", ""), "per-number scenarios, e.g. 0241111111=decline,0242222222=timeout")
+	flag.DurationVar(&sb.delay, "delay", envDuration("MOMO_SANDBOX_DELAY", 5*time.Second), "time until a request is approved or declined")
+	flag.DurationVar(&sb.expiry, "expiry", envDuration("MOMO_SANDBOX_EXPIRY", 2*time.Minute), "time until an unanswered request expires")
+	flag.DurationVar(&sb.callbackDelay, "callback-delay", envDuration("MOMO_SANDBOX_CALLBACK_DELAY", time.Minute), "callback lag in the delayed-callback scenario")
+	flag.DurationVar(&sb.tokenTTL, "token-ttl", envDuration("MOMO_SANDBOX_TOKEN_TTL", time.Hour), "lifetime of issued access tokens")
+	flag.StringVar(&sb.apiUser, "api-user", env("MOMO_API_USER", ""), "API user accepted for tokens (empty accepts any)")
+	flag.StringVar(&sb.apiKey, "api-key", env("MOMO_API_KEY", ""), "API key accepted for tokens")
+	flag.StringVar(&sb.subscriptionKey, "subscription-key", env("MOMO_SUBSCRIPTION_KEY", ""), "subscription key required on every call (empty accepts any)")
+	flag.Parse()
+
+	if !slices.Contains(scenarios, sb.scenario) {
+		log.Fatalf("unknown scenario %q (want one of %s)", sb.scenario, strings.Join(scenarios, ", "))
+	}
+	for _, rule := range strings.Split(rules, ",") {
+		if strings.TrimSpace(rule) == "" {
+			continue
+		}
+		phone, scenario, _ := strings.Cut(rule, "=")
+		msisdn, ok := payment_providers.NormalizeMSISDN(phone)
+		if !ok || !slices.Contains(scenarios, scenario) {
+			log.Fatalf("invalid rule %q", rule)
+		}
+		sb.rules[msisdn] = scenario
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /collection/token/", sb.handleToken)
+	mux.HandleFunc("POST /collection/v1_0/requesttopay", sb.authorized(sb.handleRequestToPay))
+	mux.HandleFunc("GET /collection/v1_0/requesttopay/{referenceId}", sb.authorized(sb.handleStatus))
+	mux.HandleFunc("GET /sandbox/transactions", sb.handleListTransactions)
+	mux.HandleFunc("GET /sandbox/scenarios", sb.handleListScenarios)
+	mux.HandleFunc("PUT /sandbox/scenarios/{msisdn}", sb.handleSetScenario)
+	mux.HandleFunc("DELETE /sandbox/scenarios/{msisdn}", sb.handleDeleteScenario)
+
+	log.Printf("momo sandbox listening on %s (default scenario %s)", addr, sb.scenario)
+	srv := &http.Server{Addr: addr, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Fatal(err)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Collection API
+// ---------------------------------------------------------------------------
+
+// handleToken handles POST /collection/token/
+func (sb *sandbox) handleToken(w http.ResponseWriter, r *http.Request) {
+	if !sb.subscriptionOK(r) {
+		writeError(w, http.StatusUnauthorized, "INVALID_SUBSCRIPTION_KEY", "Access denied due to invalid subscription key")
+		return
+	}
+	user, key, ok := r.BasicAuth()
+	if !ok || (sb.apiUser != "" && (user != sb.apiUser || key != sb.apiKey)) {
+		writeError(w, http.StatusUnauthorized, "INVALID_CREDENTIALS", "Invalid API user or key")
+		return
+	}
+
+	token := randomHex(32)
+	sb.mu.Lock()
+	sb.tokens[token] = time.Now().Add(sb.tokenTTL)
+	sb.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"access_token": token,
+		"token_type":   "access_token",
+		"expires_in":   int(sb.tokenTTL.Seconds()),
+	})
+}
+
+// handleRequestToPay handles POST /collection/v1_0/requesttopay
+func (sb *sandbox) handleRequestToPay(w http.ResponseWriter, r *http.Request) {
+	referenceID := r.Header.Get("X-Reference-Id")
+	if _, err := uuid.Parse(referenceID); err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_REFERENCE_ID", "X-Reference-Id must be a UUID")
+		return
+	}
+
+	var req requestToPay
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_BODY", "Request body is not valid JSON")
+		return
+	}
+	if amount, err := strconv.ParseFloat(req.Amount, 64); err != nil || amount <= 0 {
+		writeError(w, http.StatusBadRequest, "INVALID_AMOUNT", "amount must be a positive number")
+		return
+	}
+	if req.Currency == "" {
+		writeError(w, http.StatusBadRequest, "INVALID_CURRENCY", "currency is required")
+		return
+	}
+	network, msisdn, ok := payment_providers.DetectNetwork(req.Payer.PartyID)
+	if req.Payer.PartyIDType != "MSISDN" || !ok {
+		writeError(w, http.StatusBadRequest, "PAYER_NOT_FOUND", "payer must be a Ghanaian mobile number")
+		return
+	}
+
+	now := time.Now()
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+
+	if _, exists := sb.txns[referenceID]; exists {
+		writeError(w, http.StatusConflict, "RESOURCE_ALREADY_EXIST", "Duplicated reference id")
+		return
+	}
+
+	scenario := sb.scenario
+	if s, ok := sb.rules[msisdn]; ok {
+		scenario = s
+	}
+	t := &transaction{
+		ReferenceID: referenceID,
+		Scenario:    scenario,
+		Network:     network,
+		CallbackURL: r.Header.Get("X-Callback-Url"),
+		CreatedAt:   now,
+		Request:     req,
+		txID:        strconv.FormatInt(now.UnixNano()%1e10, 10),
+	}
+	switch scenario {
+	case scenarioApprove:
+		t.ResolveAt, t.finalStatus = now.Add(sb.delay), "SUCCESSFUL"
+		t.CallbackAt = t.ResolveAt
+	case scenarioDecline:
+		t.ResolveAt, t.finalStatus, t.reason = now.Add(sb.delay), "FAILED", "APPROVAL_REJECTED"
+		t.CallbackAt = t.ResolveAt
+	case scenarioTimeout:
+		t.ResolveAt, t.finalStatus, t.reason = now.Add(sb.expiry), "FAILED", "EXPIRED"
+		t.CallbackAt = t.ResolveAt
+	case scenarioDelayedCallback:
+		t.ResolveAt, t.finalStatus = now.Add(sb.delay), "SUCCESSFUL"
+		t.CallbackAt = t.ResolveAt.Add(sb.callbackDelay)
+	}
+	sb.txns[referenceID] = t
+
+	if t.CallbackURL != "" {
+		time.AfterFunc(time.Until(t.CallbackAt), func() { sb.sendCallback(t) })
+	}
+	log.Printf("request-to-pay %s: %s %s from %s (%s), scenario %s", referenceID, req.Amount, req.Currency, msisdn, network, scenario)
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// handleStatus handles GET /collection/v1_0/requesttopay/{referenceId}
+func (sb *sandbox) handleStatus(w http.ResponseWriter, r *http.Request) {
+	sb.mu.Lock()
+	t, ok := sb.txns[r.PathValue("referenceId")]
+	sb.mu.Unlock()
+	if !ok {
+		writeError(w, http.StatusNotFound, "RESOURCE_NOT_FOUND", "Requested resource was not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, t.status(time.Now()))
+}
+
+// sendCallback posts the final status to the caller's callback URL, as the
+// provider does once a request is resolved.
+func (sb *sandbox) sendCallback(t *transaction) {
+	body, _ := json.Marshal(t.status(time.Now()))
+	req, err := http.NewRequest(http.MethodPost, t.CallbackURL, bytes.NewReader(body))
+	if err != nil {
+		log.Printf("callback %s: %v", t.ReferenceID, err)
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := sb.client.Do(req)
+	if err != nil {
+		log.Printf("callback %s: %v", t.ReferenceID, err)
+		return
+	}
+	resp.Body.Close()
+	log.Printf("callback %s: %s answered %d", t.ReferenceID, t.CallbackURL, resp.StatusCode)
+}
+
+// authorized checks the subscription key and bearer token of a collection call.
+func (sb *sandbox) authorized(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !sb.subscriptionOK(r) {
+			writeError(w, http.StatusUnauthorized, "INVALID_SUBSCRIPTION_KEY", "Access denied due to invalid subscription key")
+			return
+		}
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		sb.mu.Lock()
+		expiry, known := sb.tokens[token]
+		sb.mu.Unlock()
+		if !ok || !known || time.Now().After(expiry) {
+			writeError(w, http.StatusUnauthorized, "INVALID_TOKEN", "Access token is missing, invalid or expired")
+			return
+		}
+		if r.Header.Get("X-Target-Environment") == "" {
+			writeError(w, http.StatusBadRequest, "INVALID_TARGET_ENVIRONMENT", "X-Target-Environment is required")
+			return
+		}
+		next(w, r)
+	}
+}
+
+func (sb *sandbox) subscriptionOK(r *http.Request) bool {
+	return sb.subscriptionKey == "" || r.Header.Get("Ocp-Apim-Subscription-Key") == sb.subscriptionKey
+}
+
+// ---------------------------------------------------------------------------
+// Sandbox control
+// ---------------------------------------------------------------------------
+
+// handleListTransactions handles GET /sandbox/transactions
+func (sb *sandbox) handleListTransactions(w http.ResponseWriter, _ *http.Request) {
+	now := time.Now()
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+
+	type view struct {
+		*transaction
+		Status requestToPayStatus `json:"status"`
+	}
+	out := make([]view, 0, len(sb.txns))
+	for _, t := range sb.txns {
+		out = append(out, view{transaction: t, Status: t.status(now)})
+	}
+	slices.SortFunc(out, func(a, b view) int { return b.CreatedAt.Compare(a.CreatedAt) })
+	writeJSON(w, http.StatusOK, out)
+}
+
+// handleListScenarios handles GET /sandbox/scenarios
+func (sb *sandbox) handleListScenarios(w http.ResponseWriter, _ *http.Request) {
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+	writeJSON(w, http.StatusOK, map[string]any{"default": sb.scenario, "rules": sb.rules})
+}
+
+// handleSetScenario handles PUT /sandbox/scenarios/{msisdn}
+func (sb *sandbox) handleSetScenario(w http.ResponseWriter, r *http.Request) {
+	msisdn, ok := payment_providers.NormalizeMSISDN(r.PathValue("msisdn"))
+	if !ok {
+		writeError(w, http.StatusBadRequest, "INVALID_MSISDN", "not a Ghanaian mobile number")
+		return
+	}
+	var body struct {
+		Scenario string `json:"scenario"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil || !slices.Contains(scenarios, body.Scenario) {
+		writeError(w, http.StatusBadRequest, "INVALID_SCENARIO", "scenario must be one of "+strings.Join(scenarios, ", "))
+		return
+	}
+	sb.mu.Lock()
+	sb.rules[msisdn] = body.Scenario
+	sb.mu.Unlock()
+	writeJSON(w, http.StatusOK, map[string]string{"msisdn": msisdn, "scenario": body.Scenario})
+}
+
+// handleDeleteScenario handles DELETE /sandbox/scenarios/{msisdn}
+func (sb *sandbox) handleDeleteScenario(w http.ResponseWriter, r *http.Request) {
+	msisdn, ok := payment_providers.NormalizeMSISDN(r.PathValue("msisdn"))
+	if !ok {
+		writeError(w, http.StatusBadRequest, "INVALID_MSISDN", "not a Ghanaian mobile number")
+		return
+	}
+	sb.mu.Lock()
+	delete(sb.rules, msisdn)
+	sb.mu.Unlock()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ---------------------------------------------------------------------------
+// Helpers
+// ---------------------------------------------------------------------------
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	writeJSON(w, status, map[string]string{"code": code, "message": message})
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+func env(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
+
+func envDuration(key string, fallback time.Duration) time.Duration {
+	if v := os.Getenv(key); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "invalid %s: %v\n", key, err)
+			os.Exit(2)
+		}
+		return d
+	}
+	return fallback
+}
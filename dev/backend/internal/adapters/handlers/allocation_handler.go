@@ -0,0 +1,173 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	apperrors "github.com/ghana-police/ticketing-backend/internal/domain/errors"
+	"github.com/ghana-police/ticketing-backend/internal/domain/models"
+	portservices "github.com/ghana-police/ticketing-backend/internal/ports/services"
+	"github.com/ghana-police/ticketing-backend/pkg/pagination"
+	"github.com/ghana-police/ticketing-backend/pkg/response"
+)
+
+type AllocationHandler struct {
+	svc portservices.AllocationService
+}
+
+func NewAllocationHandler(svc portservices.AllocationService) *AllocationHandler {
+	return &AllocationHandler{svc: svc}
+}
+
+var allocationSorts = []string{"createdAt", "amount"}
+
+// GET /api/allocations/beneficiaries
+func (h *AllocationHandler) ListBeneficiaries(w http.ResponseWriter, r *http.Request) {
+	beneficiaries, err := h.svc.ListBeneficiaries(r.Context(), parseOptionalBool(r, "isActive"))
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, beneficiaries)
+}
+
+// GET /api/allocations/beneficiaries/{id}
+func (h *AllocationHandler) GetBeneficiary(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseID(w, r, "id")
+	if !ok {
+		return
+	}
+	b, err := h.svc.GetBeneficiary(r.Context(), id)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, b)
+}
+
+// POST /api/allocations/beneficiaries
+func (h *AllocationHandler) CreateBeneficiary(w http.ResponseWriter, r *http.Request) {
+	var req portservices.CreateBeneficiaryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, apperrors.NewValidationError("Invalid request body", nil))
+		return
+	}
+	b, err := h.svc.CreateBeneficiary(r.Context(), &req)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusCreated, b)
+}
+
+// PUT /api/allocations/beneficiaries/{id}
+func (h *AllocationHandler) UpdateBeneficiary(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseID(w, r, "id")
+	if !ok {
+		return
+	}
+	var req portservices.UpdateBeneficiaryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, apperrors.NewValidationError("Invalid request body", nil))
+		return
+	}
+	b, err := h.svc.UpdateBeneficiary(r.Context(), id, &req)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, b)
+}
+
+// GET /api/allocations/rules
+func (h *AllocationHandler) ListRules(w http.ResponseWriter, r *http.Request) {
+	rules, err := h.svc.ListRules(r.Context(), parseOptionalBool(r, "isActive"))
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, rules)
+}
+
+// GET /api/allocations/rules/{id}
+func (h *AllocationHandler) GetRule(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseID(w, r, "id")
+	if !ok {
+		return
+	}
+	rule, err := h.svc.GetRule(r.Context(), id)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, rule)
+}
+
+// POST /api/allocations/rules
+func (h *AllocationHandler) CreateRule(w http.ResponseWriter, r *http.Request) {
+	var req portservices.AllocationRuleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, apperrors.NewValidationError("Invalid request body", nil))
+		return
+	}
+	rule, err := h.svc.CreateRule(r.Context(), &req)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusCreated, rule)
+}
+
+// PUT /api/allocations/rules/{id}
+func (h *AllocationHandler) UpdateRule(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseID(w, r, "id")
+	if !ok {
+		return
+	}
+	var req portservices.AllocationRuleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, apperrors.NewValidationError("Invalid request body", nil))
+		return
+	}
+	rule, err := h.svc.UpdateRule(r.Context(), id, &req)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, rule)
+}
+
+// GET /api/allocations
+func (h *AllocationHandler) ListAllocations(w http.ResponseWriter, r *http.Request) {
+	filter := models.PaymentAllocationFilter{
+		PaymentID:     parseOptionalUUID(r, "paymentId"),
+		BeneficiaryID: parseOptionalUUID(r, "beneficiaryId"),
+		Period:        parseOptionalString(r, "period"),
+		StationID:     parseOptionalUUID(r, "stationId"),
+		RegionID:      parseOptionalUUID(r, "regionId"),
+	}
+	p := pagination.Parse(r, allocationSorts, "createdAt")
+
+	items, total, err := h.svc.ListAllocations(r.Context(), filter, p)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+	response.Paginated(w, http.StatusOK, items, pagination.NewMeta(p.Page, p.Limit, total))
+}
+
+// GET /api/allocations/disbursements
+func (h *AllocationHandler) DisbursementReport(w http.ResponseWriter, r *http.Request) {
+	req := portservices.DisbursementReportRequest{
+		Period:    r.URL.Query().Get("period"),
+		StationID: parseOptionalUUID(r, "stationId"),
+		RegionID:  parseOptionalUUID(r, "regionId"),
+		GroupBy:   r.URL.Query().Get("groupBy"),
+	}
+	report, err := h.svc.DisbursementReport(r.Context(), &req)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, report)
+}
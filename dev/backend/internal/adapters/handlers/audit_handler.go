@@ -63,6 +63,7 @@ func parseAuditFilter(r *http.Request) models.AuditFilter {
 		Action:     parseOptionalString(r, "action"),
 		EntityType: parseOptionalString(r, "entityType"),
 		UserID:     parseOptionalUUID(r, "userId"),
+		APIKeyID:   parseOptionalUUID(r, "apiKeyId"),
 		Severity:   parseOptionalString(r, "severity"),
 		StationID:  parseOptionalUUID(r, "stationId"),
 		RegionID:   parseOptionalUUID(r, "regionId"),
@@ -25,6 +25,18 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 		response.Error(w, apperrors.NewValidationError("Invalid request body", nil))
 		return
 	}
+	if req.DeviceID == nil {
+		if v := r.Header.Get("X-Device-ID"); v != "" {
+			req.DeviceID = &v
+		}
+	}
+	if req.DeviceKey == nil {
+		if v := r.Header.Get("X-Device-Key"); v != "" {
+			req.DeviceKey = &v
+		}
+	}
+	req.IPAddress = middleware.ClientIP(r)
+	req.UserAgent = r.UserAgent()
 
 	result, err := h.authService.Login(r.Context(), &req)
 	if err != nil {
@@ -36,14 +48,70 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	response.JSON(w, http.StatusOK, map[string]any{
+	if result.MFA != nil {
+		response.JSON(w, http.StatusOK, map[string]any{
+			"mfaRequired":    true,
+			"challengeToken": result.MFA.ChallengeToken,
+			"setupRequired":  result.MFA.SetupRequired,
+			"expiresIn":      result.MFA.ExpiresIn,
+		})
+		return
+	}
+
+	writeSession(w, result)
+}
+
+// POST /api/auth/mfa/verify
+func (h *AuthHandler) VerifyMFA(w http.ResponseWriter, r *http.Request) {
+	var req portservices.VerifyMFARequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, apperrors.NewValidationError("Invalid request body", nil))
+		return
+	}
+	req.IPAddress = middleware.ClientIP(r)
+	req.UserAgent = r.UserAgent()
+
+	result, err := h.authService.VerifyMFA(r.Context(), &req)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	writeSession(w, result)
+}
+
+// POST /api/auth/mfa/challenge/enroll
+func (h *AuthHandler) EnrollMFAChallenge(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		ChallengeToken string `json:"challengeToken"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.ChallengeToken == "" {
+		response.Error(w, apperrors.NewValidationError("Challenge token is required", nil))
+		return
+	}
+
+	enrollment, err := h.authService.EnrollMFAChallenge(r.Context(), body.ChallengeToken)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, enrollment)
+}
+
+func writeSession(w http.ResponseWriter, result *portservices.LoginResult) {
+	body := map[string]any{
 		"user": result.User,
 		"tokens": map[string]any{
 			"accessToken":  result.AccessToken,
 			"refreshToken": result.RefreshToken,
 			"expiresIn":    result.ExpiresIn,
 		},
-	})
+	}
+	if result.RecoveryCodes != nil {
+		body["recoveryCodes"] = result.RecoveryCodes
+	}
+	response.JSON(w, http.StatusOK, body)
 }
 
 // POST /api/auth/logout
@@ -65,15 +133,21 @@ func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
 
 // POST /api/auth/refresh
 func (h *AuthHandler) Refresh(w http.ResponseWriter, r *http.Request) {
-	var body struct {
-		RefreshToken string `json:"refreshToken"`
-	}
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.RefreshToken == "" {
+	var req portservices.RefreshRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.RefreshToken == "" {
 		response.Error(w, apperrors.NewValidationError("Refresh token is required", nil))
 		return
 	}
+	if req.DeviceID == "" {
+		req.DeviceID = r.Header.Get("X-Device-ID")
+	}
+	if req.DeviceKey == "" {
+		req.DeviceKey = r.Header.Get("X-Device-Key")
+	}
+	req.IPAddress = middleware.ClientIP(r)
+	req.UserAgent = r.UserAgent()
 
-	result, err := h.authService.RefreshToken(r.Context(), body.RefreshToken)
+	result, err := h.authService.RefreshToken(r.Context(), &req)
 	if err != nil {
 		if appErr, ok := err.(*apperrors.AppError); ok {
 			response.Error(w, appErr)
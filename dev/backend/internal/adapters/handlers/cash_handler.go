@@ -0,0 +1,213 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	apperrors "github.com/ghana-police/ticketing-backend/internal/domain/errors"
+	"github.com/ghana-police/ticketing-backend/internal/domain/models"
+	portservices "github.com/ghana-police/ticketing-backend/internal/ports/services"
+	"github.com/ghana-police/ticketing-backend/pkg/pagination"
+	"github.com/ghana-police/ticketing-backend/pkg/response"
+)
+
+type CashHandler struct {
+	svc portservices.CashService
+}
+
+func NewCashHandler(svc portservices.CashService) *CashHandler {
+	return &CashHandler{svc: svc}
+}
+
+var (
+	shiftSorts      = []string{"openedAt", "closedAt", "variance"}
+	remittanceSorts = []string{"depositedAt", "createdAt", "depositedAmount", "variance"}
+)
+
+// parseDateRange reads optional dateFrom and dateTo (YYYY-MM-DD) query
+// parameters; dateTo is taken to the end of its day.
+func parseDateRange(r *http.Request) (from, to *time.Time) {
+	if v := r.URL.Query().Get("dateFrom"); v != "" {
+		if t, err := time.Parse("2006-01-02", v); err == nil {
+			from = &t
+		}
+	}
+	if v := r.URL.Query().Get("dateTo"); v != "" {
+		if t, err := time.Parse("2006-01-02", v); err == nil {
+			endOfDay := t.Add(24*time.Hour - time.Nanosecond)
+			to = &endOfDay
+		}
+	}
+	return from, to
+}
+
+// POST /api/cash/shifts
+func (h *CashHandler) OpenShift(w http.ResponseWriter, r *http.Request) {
+	var req portservices.OpenShiftRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, apperrors.NewValidationError("Invalid request body", nil))
+		return
+	}
+	shift, err := h.svc.OpenShift(r.Context(), &req)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusCreated, shift)
+}
+
+// GET /api/cash/shifts/current
+func (h *CashHandler) CurrentShift(w http.ResponseWriter, r *http.Request) {
+	shift, err := h.svc.CurrentShift(r.Context())
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, shift)
+}
+
+// GET /api/cash/shifts
+func (h *CashHandler) ListShifts(w http.ResponseWriter, r *http.Request) {
+	filter := models.CashierShiftFilter{
+		StationID:  parseOptionalUUID(r, "stationId"),
+		CashierID:  parseOptionalUUID(r, "cashierId"),
+		Status:     parseOptionalString(r, "status"),
+		Unremitted: parseOptionalBool(r, "unremitted"),
+	}
+	filter.DateFrom, filter.DateTo = parseDateRange(r)
+	p := pagination.Parse(r, shiftSorts, "openedAt")
+
+	items, total, err := h.svc.ListShifts(r.Context(), filter, p)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+	response.Paginated(w, http.StatusOK, items, pagination.NewMeta(p.Page, p.Limit, total))
+}
+
+// GET /api/cash/shifts/{id}
+func (h *CashHandler) GetShift(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseID(w, r, "id")
+	if !ok {
+		return
+	}
+	shift, err := h.svc.GetShift(r.Context(), id)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, shift)
+}
+
+// POST /api/cash/shifts/{id}/close
+func (h *CashHandler) CloseShift(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseID(w, r, "id")
+	if !ok {
+		return
+	}
+	var req portservices.CloseShiftRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, apperrors.NewValidationError("Invalid request body", nil))
+		return
+	}
+	shift, err := h.svc.CloseShift(r.Context(), id, &req)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, shift)
+}
+
+// POST /api/cash/remittances
+func (h *CashHandler) SubmitRemittance(w http.ResponseWriter, r *http.Request) {
+	var req portservices.SubmitRemittanceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, apperrors.NewValidationError("Invalid request body", nil))
+		return
+	}
+	remittance, err := h.svc.SubmitRemittance(r.Context(), &req)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusCreated, remittance)
+}
+
+// GET /api/cash/remittances
+func (h *CashHandler) ListRemittances(w http.ResponseWriter, r *http.Request) {
+	filter := models.CashRemittanceFilter{
+		StationID: parseOptionalUUID(r, "stationId"),
+		Status:    parseOptionalString(r, "status"),
+	}
+	filter.DateFrom, filter.DateTo = parseDateRange(r)
+	p := pagination.Parse(r, remittanceSorts, "depositedAt")
+
+	items, total, err := h.svc.ListRemittances(r.Context(), filter, p)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+	response.Paginated(w, http.StatusOK, items, pagination.NewMeta(p.Page, p.Limit, total))
+}
+
+// GET /api/cash/remittances/{id}
+func (h *CashHandler) GetRemittance(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseID(w, r, "id")
+	if !ok {
+		return
+	}
+	remittance, err := h.svc.GetRemittance(r.Context(), id)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, remittance)
+}
+
+// POST /api/cash/remittances/{id}/review
+func (h *CashHandler) ReviewRemittance(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseID(w, r, "id")
+	if !ok {
+		return
+	}
+	var req portservices.ReviewRemittanceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, apperrors.NewValidationError("Invalid request body", nil))
+		return
+	}
+	remittance, err := h.svc.ReviewRemittance(r.Context(), id, &req)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, remittance)
+}
+
+// GET /api/cash/report
+func (h *CashHandler) Report(w http.ResponseWriter, r *http.Request) {
+	var from, to time.Time
+	for _, d := range []struct {
+		key string
+		dst *time.Time
+	}{{"dateFrom", &from}, {"dateTo", &to}} {
+		v := r.URL.Query().Get(d.key)
+		if v == "" {
+			continue
+		}
+		t, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			response.Error(w, apperrors.NewValidationError("Invalid date",
+				map[string][]string{d.key: {"must be a date (YYYY-MM-DD)"}}))
+			return
+		}
+		*d.dst = t
+	}
+
+	report, err := h.svc.TreasuryReport(r.Context(), parseOptionalUUID(r, "stationId"), from, to)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, report)
+}
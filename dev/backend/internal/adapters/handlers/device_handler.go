@@ -0,0 +1,171 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	apperrors "github.com/ghana-police/ticketing-backend/internal/domain/errors"
+	"github.com/ghana-police/ticketing-backend/internal/domain/models"
+	portservices "github.com/ghana-police/ticketing-backend/internal/ports/services"
+	"github.com/ghana-police/ticketing-backend/pkg/pagination"
+	"github.com/ghana-police/ticketing-backend/pkg/response"
+)
+
+type DeviceHandler struct {
+	svc portservices.DeviceService
+}
+
+func NewDeviceHandler(svc portservices.DeviceService) *DeviceHandler {
+	return &DeviceHandler{svc: svc}
+}
+
+var deviceSorts = []string{"name", "status", "lastSeenAt", "createdAt"}
+
+// List handles GET /devices
+func (h *DeviceHandler) List(w http.ResponseWriter, r *http.Request) {
+	filter := models.DeviceFilter{
+		Status:    parseOptionalString(r, "status"),
+		StationID: parseOptionalUUID(r, "stationId"),
+		RegionID:  parseOptionalUUID(r, "regionId"),
+		OfficerID: parseOptionalUUID(r, "officerId"),
+	}
+	p := pagination.Parse(r, deviceSorts, "createdAt")
+
+	devices, total, err := h.svc.List(r.Context(), filter, p.Search, p)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	response.Paginated(w, http.StatusOK, devices, pagination.NewMeta(p.Page, p.Limit, total))
+}
+
+// Get handles GET /devices/{id}
+func (h *DeviceHandler) Get(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseID(w, r, "id")
+	if !ok {
+		return
+	}
+	device, err := h.svc.Get(r.Context(), id)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, device)
+}
+
+// Register handles POST /devices
+func (h *DeviceHandler) Register(w http.ResponseWriter, r *http.Request) {
+	var req portservices.RegisterDeviceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, apperrors.NewValidationError("Invalid request body", nil))
+		return
+	}
+	result, err := h.svc.Register(r.Context(), &req)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusCreated, result)
+}
+
+// ReEnroll handles POST /devices/{id}/re-enroll
+func (h *DeviceHandler) ReEnroll(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseID(w, r, "id")
+	if !ok {
+		return
+	}
+	result, err := h.svc.ReEnroll(r.Context(), id)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, result)
+}
+
+// UpdateStatus handles PATCH /devices/{id}/status
+func (h *DeviceHandler) UpdateStatus(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseID(w, r, "id")
+	if !ok {
+		return
+	}
+	var req portservices.UpdateDeviceStatusRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, apperrors.NewValidationError("Invalid request body", nil))
+		return
+	}
+	device, err := h.svc.UpdateStatus(r.Context(), id, &req)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, device)
+}
+
+// ListCommands handles GET /devices/{id}/commands
+func (h *DeviceHandler) ListCommands(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseID(w, r, "id")
+	if !ok {
+		return
+	}
+	commands, err := h.svc.ListCommands(r.Context(), id)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, commands)
+}
+
+// IssueCommand handles POST /devices/{id}/commands
+func (h *DeviceHandler) IssueCommand(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseID(w, r, "id")
+	if !ok {
+		return
+	}
+	var req portservices.IssueDeviceCommandRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, apperrors.NewValidationError("Invalid request body", nil))
+		return
+	}
+	cmd, err := h.svc.IssueCommand(r.Context(), id, &req)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusCreated, cmd)
+}
+
+// CancelCommand handles POST /devices/{id}/commands/{commandId}/cancel
+func (h *DeviceHandler) CancelCommand(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseID(w, r, "id")
+	if !ok {
+		return
+	}
+	commandID, ok := parseID(w, r, "commandId")
+	if !ok {
+		return
+	}
+	if err := h.svc.CancelCommand(r.Context(), id, commandID); err != nil {
+		handleError(w, err)
+		return
+	}
+	response.JSONMessage(w, http.StatusOK, "Command cancelled")
+}
+
+// Enroll handles POST /devices/enroll (public; authenticated by the enrollment code)
+func (h *DeviceHandler) Enroll(w http.ResponseWriter, r *http.Request) {
+	var req portservices.EnrollDeviceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, apperrors.NewValidationError("Invalid request body", nil))
+		return
+	}
+	if req.DeviceID == "" {
+		req.DeviceID = r.Header.Get("X-Device-ID")
+	}
+	result, err := h.svc.Enroll(r.Context(), &req)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, result)
+}
@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	apperrors "github.com/ghana-police/ticketing-backend/internal/domain/errors"
+	portservices "github.com/ghana-police/ticketing-backend/internal/ports/services"
+	"github.com/ghana-police/ticketing-backend/pkg/response"
+)
+
+type InstallmentHandler struct {
+	svc portservices.InstallmentService
+}
+
+func NewInstallmentHandler(svc portservices.InstallmentService) *InstallmentHandler {
+	return &InstallmentHandler{svc: svc}
+}
+
+// POST /api/tickets/{id}/installment-plan
+func (h *InstallmentHandler) Create(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseID(w, r, "id")
+	if !ok {
+		return
+	}
+	var req portservices.CreateInstallmentPlanRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, apperrors.NewValidationError("Invalid request body", nil))
+		return
+	}
+	plan, err := h.svc.CreatePlan(r.Context(), id, &req)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusCreated, plan)
+}
+
+// GET /api/tickets/{id}/installment-plan
+func (h *InstallmentHandler) Get(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseID(w, r, "id")
+	if !ok {
+		return
+	}
+	plan, err := h.svc.GetPlan(r.Context(), id)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, plan)
+}
+
+// POST /api/tickets/{id}/installment-plan/cancel
+func (h *InstallmentHandler) Cancel(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseID(w, r, "id")
+	if !ok {
+		return
+	}
+	// The reason is optional, so an empty body is fine
+	var req portservices.CancelInstallmentPlanRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		response.Error(w, apperrors.NewValidationError("Invalid request body", nil))
+		return
+	}
+	plan, err := h.svc.CancelPlan(r.Context(), id, &req)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, plan)
+}
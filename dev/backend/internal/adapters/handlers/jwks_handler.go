@@ -0,0 +1,31 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	portservices "github.com/ghana-police/ticketing-backend/internal/ports/services"
+)
+
+type JWKSHandler struct {
+	svc portservices.SigningKeyService
+}
+
+func NewJWKSHandler(svc portservices.SigningKeyService) *JWKSHandler {
+	return &JWKSHandler{svc: svc}
+}
+
+// Get handles GET /.well-known/jwks.json. The key set is served bare (not in
+// the response envelope) as verifiers expect.
+func (h *JWKSHandler) Get(w http.ResponseWriter, r *http.Request) {
+	set, err := h.svc.JWKS(r.Context())
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(set)
+}
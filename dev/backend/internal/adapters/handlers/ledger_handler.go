@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	apperrors "github.com/ghana-police/ticketing-backend/internal/domain/errors"
+	"github.com/ghana-police/ticketing-backend/internal/domain/models"
+	portservices "github.com/ghana-police/ticketing-backend/internal/ports/services"
+	"github.com/ghana-police/ticketing-backend/pkg/pagination"
+	"github.com/ghana-police/ticketing-backend/pkg/response"
+)
+
+type LedgerHandler struct {
+	svc portservices.LedgerService
+}
+
+func NewLedgerHandler(svc portservices.LedgerService) *LedgerHandler {
+	return &LedgerHandler{svc: svc}
+}
+
+var journalSorts = []string{"postedAt", "occurredAt"}
+
+// GET /api/ledger/accounts
+func (h *LedgerHandler) ListAccounts(w http.ResponseWriter, r *http.Request) {
+	accounts, err := h.svc.ListAccounts(r.Context())
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, accounts)
+}
+
+// GET /api/ledger/journals
+func (h *LedgerHandler) ListJournals(w http.ResponseWriter, r *http.Request) {
+	filter := models.LedgerJournalFilter{
+		Kind:      parseOptionalString(r, "kind"),
+		Period:    parseOptionalString(r, "period"),
+		TicketID:  parseOptionalUUID(r, "ticketId"),
+		PaymentID: parseOptionalUUID(r, "paymentId"),
+		StationID: parseOptionalUUID(r, "stationId"),
+		RegionID:  parseOptionalUUID(r, "regionId"),
+		Account:   parseOptionalString(r, "account"),
+	}
+	filter.DateFrom, filter.DateTo = parseDateRange(r)
+	p := pagination.Parse(r, journalSorts, "postedAt")
+
+	items, total, err := h.svc.ListJournals(r.Context(), filter, p)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+	response.Paginated(w, http.StatusOK, items, pagination.NewMeta(p.Page, p.Limit, total))
+}
+
+// GET /api/ledger/journals/{id}
+func (h *LedgerHandler) GetJournal(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseID(w, r, "id")
+	if !ok {
+		return
+	}
+	journal, err := h.svc.GetJournal(r.Context(), id)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, journal)
+}
+
+// GET /api/ledger/trial-balance
+func (h *LedgerHandler) TrialBalance(w http.ResponseWriter, r *http.Request) {
+	req := portservices.TrialBalanceRequest{
+		Period:    r.URL.Query().Get("period"),
+		StationID: parseOptionalUUID(r, "stationId"),
+		RegionID:  parseOptionalUUID(r, "regionId"),
+		GroupBy:   r.URL.Query().Get("groupBy"),
+	}
+	tb, err := h.svc.TrialBalance(r.Context(), &req)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, tb)
+}
+
+// GET /api/ledger/periods
+func (h *LedgerHandler) ListPeriods(w http.ResponseWriter, r *http.Request) {
+	periods, err := h.svc.ListPeriods(r.Context())
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, periods)
+}
+
+// POST /api/ledger/periods/{period}/close
+func (h *LedgerHandler) ClosePeriod(w http.ResponseWriter, r *http.Request) {
+	period, err := h.svc.ClosePeriod(r.Context(), chi.URLParam(r, "period"))
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, period)
+}
+
+// POST /api/ledger/periods/{period}/reopen
+func (h *LedgerHandler) ReopenPeriod(w http.ResponseWriter, r *http.Request) {
+	var req portservices.ReopenPeriodRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, apperrors.NewValidationError("Invalid request body", nil))
+		return
+	}
+	period, err := h.svc.ReopenPeriod(r.Context(), chi.URLParam(r, "period"), &req)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, period)
+}
@@ -18,24 +18,30 @@ func NewLookupHandler(svc portservices.LookupService) *LookupHandler {
 
 // GET /api/lookup
 func (h *LookupHandler) GetLookupData(w http.ResponseWriter, r *http.Request) {
-	var ifModifiedSince *time.Time
+	req := &portservices.LookupRequest{
+		Cursor:      r.URL.Query().Get("cursor"),
+		IfNoneMatch: r.Header.Get("If-None-Match"),
+	}
 	if v := r.Header.Get("If-Modified-Since"); v != "" {
 		if t, err := time.Parse(time.RFC1123, v); err == nil {
-			ifModifiedSince = &t
+			req.IfModifiedSince = &t
 		}
 	}
 
-	data, modified, err := h.svc.GetLookupData(r.Context(), ifModifiedSince)
+	data, modified, err := h.svc.GetLookupData(r.Context(), req)
 	if err != nil {
 		handleError(w, err)
 		return
 	}
 
+	w.Header().Set("ETag", data.ETag)
+	w.Header().Set("Last-Modified", data.LastUpdated.UTC().Format(http.TimeFormat))
+	w.Header().Set("Cache-Control", "no-cache")
+
 	if !modified {
 		w.WriteHeader(http.StatusNotModified)
 		return
 	}
 
-	w.Header().Set("Last-Modified", data.LastUpdated.UTC().Format(time.RFC1123))
 	response.JSON(w, http.StatusOK, data)
 }
@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	apperrors "github.com/ghana-police/ticketing-backend/internal/domain/errors"
+	"github.com/ghana-police/ticketing-backend/internal/middleware"
+	portservices "github.com/ghana-police/ticketing-backend/internal/ports/services"
+	"github.com/ghana-police/ticketing-backend/pkg/response"
+)
+
+type MFAHandler struct {
+	svc portservices.MFAService
+}
+
+func NewMFAHandler(svc portservices.MFAService) *MFAHandler {
+	return &MFAHandler{svc: svc}
+}
+
+// Status handles GET /auth/mfa
+func (h *MFAHandler) Status(w http.ResponseWriter, r *http.Request) {
+	status, err := h.svc.Status(r.Context(), middleware.GetUserID(r.Context()))
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, status)
+}
+
+// Enroll handles POST /auth/mfa/enroll
+func (h *MFAHandler) Enroll(w http.ResponseWriter, r *http.Request) {
+	enrollment, err := h.svc.Enroll(r.Context(), middleware.GetUserID(r.Context()))
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, enrollment)
+}
+
+// Activate handles POST /auth/mfa/activate
+func (h *MFAHandler) Activate(w http.ResponseWriter, r *http.Request) {
+	req, ok := decodeMFACode(w, r)
+	if !ok {
+		return
+	}
+	codes, err := h.svc.Activate(r.Context(), middleware.GetUserID(r.Context()), req.Code)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, codes)
+}
+
+// Disable handles POST /auth/mfa/disable
+func (h *MFAHandler) Disable(w http.ResponseWriter, r *http.Request) {
+	req, ok := decodeMFACode(w, r)
+	if !ok {
+		return
+	}
+	if err := h.svc.Disable(r.Context(), middleware.GetUserID(r.Context()), req.Code); err != nil {
+		handleError(w, err)
+		return
+	}
+	response.JSONMessage(w, http.StatusOK, "Multi-factor authentication disabled")
+}
+
+// RegenerateRecoveryCodes handles POST /auth/mfa/recovery-codes
+func (h *MFAHandler) RegenerateRecoveryCodes(w http.ResponseWriter, r *http.Request) {
+	req, ok := decodeMFACode(w, r)
+	if !ok {
+		return
+	}
+	codes, err := h.svc.RegenerateRecoveryCodes(r.Context(), middleware.GetUserID(r.Context()), req.Code)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, codes)
+}
+
+// Reset handles POST /users/{id}/mfa/reset
+func (h *MFAHandler) Reset(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseID(w, r, "id")
+	if !ok {
+		return
+	}
+	if err := h.svc.Reset(r.Context(), id); err != nil {
+		handleError(w, err)
+		return
+	}
+	response.JSONMessage(w, http.StatusOK, "Multi-factor authentication reset")
+}
+
+func decodeMFACode(w http.ResponseWriter, r *http.Request) (*portservices.MFACodeRequest, bool) {
+	var req portservices.MFACodeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Code == "" {
+		response.Error(w, apperrors.NewValidationError("Verification code is required", nil))
+		return nil, false
+	}
+	return &req, true
+}
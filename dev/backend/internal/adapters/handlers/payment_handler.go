@@ -3,6 +3,7 @@ package handlers
 import (
 	"encoding/json"
 	"net/http"
+	"net/url"
 	"strconv"
 	"time"
 
@@ -14,11 +15,12 @@ import (
 )
 
 type PaymentHandler struct {
-	svc portservices.PaymentService
+	svc       portservices.PaymentService
+	resultURL string // page payers land on after a hosted checkout; empty returns JSON
 }
 
-func NewPaymentHandler(svc portservices.PaymentService) *PaymentHandler {
-	return &PaymentHandler{svc: svc}
+func NewPaymentHandler(svc portservices.PaymentService, resultURL string) *PaymentHandler {
+	return &PaymentHandler{svc: svc, resultURL: resultURL}
 }
 
 var paymentSorts = []string{"createdAt", "amount", "status", "method", "completedAt"}
@@ -68,6 +70,48 @@ func (h *PaymentHandler) Verify(w http.ResponseWriter, r *http.Request) {
 	response.JSON(w, http.StatusOK, result)
 }
 
+// GET /api/payments/return
+// The hosted checkout sends the payer's browser here with the transaction
+// reference (Paystack uses reference and trxref, others tx_ref).
+func (h *PaymentHandler) Return(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	ref := q.Get("reference")
+	if ref == "" {
+		ref = q.Get("trxref")
+	}
+	if ref == "" {
+		ref = q.Get("tx_ref")
+	}
+
+	result, err := h.svc.HandleReturn(r.Context(), ref)
+	if h.resultURL == "" {
+		if err != nil {
+			handleError(w, err)
+			return
+		}
+		response.JSON(w, http.StatusOK, result)
+		return
+	}
+
+	// Hand the outcome to the result page; it must not trust it for anything
+	// but display, and can look the payment up by reference.
+	target, perr := url.Parse(h.resultURL)
+	if perr != nil {
+		response.InternalError(w)
+		return
+	}
+	params := target.Query()
+	if err != nil {
+		params.Set("status", "error")
+	} else {
+		params.Set("paymentReference", result.PaymentReference)
+		params.Set("ticketNumber", result.TicketNumber)
+		params.Set("status", result.Status)
+	}
+	target.RawQuery = params.Encode()
+	http.Redirect(w, r, target.String(), http.StatusSeeOther)
+}
+
 // GET /api/payments
 func (h *PaymentHandler) List(w http.ResponseWriter, r *http.Request) {
 	filter := parsePaymentFilter(r)
@@ -120,10 +164,30 @@ func (h *PaymentHandler) Receipt(w http.ResponseWriter, r *http.Request) {
 	response.JSON(w, http.StatusOK, receipt)
 }
 
+// POST /api/payments/{id}/refund
+func (h *PaymentHandler) Refund(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseID(w, r, "id")
+	if !ok {
+		return
+	}
+	var req portservices.RefundPaymentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, apperrors.NewValidationError("Invalid request body", nil))
+		return
+	}
+	payment, err := h.svc.Refund(r.Context(), id, &req)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, payment)
+}
+
 func parsePaymentFilter(r *http.Request) models.PaymentFilter {
 	filter := models.PaymentFilter{
 		Status:        parseOptionalString(r, "status"),
 		Method:        parseOptionalString(r, "method"),
+		TicketID:      parseOptionalUUID(r, "ticketId"),
 		StationID:     parseOptionalUUID(r, "stationId"),
 		ProcessedByID: parseOptionalUUID(r, "processedById"),
 	}
@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	apperrors "github.com/ghana-police/ticketing-backend/internal/domain/errors"
+	portservices "github.com/ghana-police/ticketing-backend/internal/ports/services"
+	"github.com/ghana-police/ticketing-backend/pkg/response"
+)
+
+type PermissionHandler struct {
+	svc portservices.PermissionService
+}
+
+func NewPermissionHandler(svc portservices.PermissionService) *PermissionHandler {
+	return &PermissionHandler{svc: svc}
+}
+
+// ListPermissions handles GET /permissions
+func (h *PermissionHandler) ListPermissions(w http.ResponseWriter, r *http.Request) {
+	perms, err := h.svc.ListPermissions(r.Context())
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, perms)
+}
+
+// ListRoles handles GET /roles
+func (h *PermissionHandler) ListRoles(w http.ResponseWriter, r *http.Request) {
+	roles, err := h.svc.ListRoles(r.Context())
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, roles)
+}
+
+// GetRole handles GET /roles/{role}
+func (h *PermissionHandler) GetRole(w http.ResponseWriter, r *http.Request) {
+	role, err := h.svc.GetRole(r.Context(), chi.URLParam(r, "role"))
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, role)
+}
+
+// UpdateRole handles PUT /roles/{role}/permissions
+func (h *PermissionHandler) UpdateRole(w http.ResponseWriter, r *http.Request) {
+	var req portservices.UpdateRolePermissionsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, apperrors.NewValidationError("Invalid request body", nil))
+		return
+	}
+	role, err := h.svc.UpdateRole(r.Context(), chi.URLParam(r, "role"), &req)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, role)
+}
+
+// GetUserPermissions handles GET /users/{id}/permissions
+func (h *PermissionHandler) GetUserPermissions(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseID(w, r, "id")
+	if !ok {
+		return
+	}
+	perms, err := h.svc.GetUserPermissions(r.Context(), id)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, perms)
+}
+
+// SetUserPermission handles PUT /users/{id}/permissions
+func (h *PermissionHandler) SetUserPermission(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseID(w, r, "id")
+	if !ok {
+		return
+	}
+	var req portservices.SetUserPermissionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, apperrors.NewValidationError("Invalid request body", nil))
+		return
+	}
+	override, err := h.svc.SetUserPermission(r.Context(), id, &req)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, override)
+}
+
+// RemoveUserPermission handles DELETE /users/{id}/permissions/{permission}
+func (h *PermissionHandler) RemoveUserPermission(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseID(w, r, "id")
+	if !ok {
+		return
+	}
+	if err := h.svc.RemoveUserPermission(r.Context(), id, chi.URLParam(r, "permission")); err != nil {
+		handleError(w, err)
+		return
+	}
+	response.JSONMessage(w, http.StatusOK, "Permission override removed")
+}
@@ -0,0 +1,164 @@
+package handlers
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	apperrors "github.com/ghana-police/ticketing-backend/internal/domain/errors"
+	"github.com/ghana-police/ticketing-backend/internal/domain/models"
+	portservices "github.com/ghana-police/ticketing-backend/internal/ports/services"
+	"github.com/ghana-police/ticketing-backend/pkg/pagination"
+	"github.com/ghana-police/ticketing-backend/pkg/response"
+)
+
+// maxStatementSize bounds an uploaded settlement statement.
+const maxStatementSize = 10 * 1024 * 1024
+
+type ReconciliationHandler struct {
+	svc portservices.ReconciliationService
+}
+
+func NewReconciliationHandler(svc portservices.ReconciliationService) *ReconciliationHandler {
+	return &ReconciliationHandler{svc: svc}
+}
+
+var settlementLineSorts = []string{"settledAt", "amount", "lineNumber", "createdAt"}
+
+// POST /api/reconciliation/imports
+func (h *ReconciliationHandler) Import(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxStatementSize+1024)
+	if err := r.ParseMultipartForm(maxStatementSize); err != nil {
+		response.Error(w, apperrors.NewValidationError("File too large (max 10MB)", nil))
+		return
+	}
+
+	provider := r.FormValue("provider")
+	if provider == "" {
+		response.Error(w, apperrors.NewValidationError("Provider is required",
+			map[string][]string{"provider": {"is required"}}))
+		return
+	}
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		response.Error(w, apperrors.NewValidationError("Statement file is required",
+			map[string][]string{"file": {"is required"}}))
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		response.Error(w, apperrors.NewValidationError("Failed to read file", nil))
+		return
+	}
+
+	result, err := h.svc.Import(r.Context(), provider, header.Filename, data)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusCreated, result)
+}
+
+// GET /api/reconciliation/imports
+func (h *ReconciliationHandler) ListImports(w http.ResponseWriter, r *http.Request) {
+	p := pagination.Parse(r, nil, "")
+	items, total, err := h.svc.ListImports(r.Context(), parseOptionalString(r, "provider"), p)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+	response.Paginated(w, http.StatusOK, items, pagination.NewMeta(p.Page, p.Limit, total))
+}
+
+// GET /api/reconciliation/imports/{id}
+func (h *ReconciliationHandler) GetImport(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseID(w, r, "id")
+	if !ok {
+		return
+	}
+	imp, err := h.svc.GetImport(r.Context(), id)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, imp)
+}
+
+// GET /api/reconciliation/items
+func (h *ReconciliationHandler) ListItems(w http.ResponseWriter, r *http.Request) {
+	filter := models.SettlementLineFilter{
+		Provider:     parseOptionalString(r, "provider"),
+		ImportID:     parseOptionalUUID(r, "importId"),
+		MatchStatus:  parseOptionalString(r, "matchStatus"),
+		ReviewStatus: parseOptionalString(r, "reviewStatus"),
+	}
+	if v := r.URL.Query().Get("dateFrom"); v != "" {
+		if t, err := time.Parse("2006-01-02", v); err == nil {
+			filter.DateFrom = &t
+		}
+	}
+	if v := r.URL.Query().Get("dateTo"); v != "" {
+		if t, err := time.Parse("2006-01-02", v); err == nil {
+			endOfDay := t.Add(24*time.Hour - time.Nanosecond)
+			filter.DateTo = &endOfDay
+		}
+	}
+	p := pagination.Parse(r, settlementLineSorts, "settledAt")
+
+	items, total, err := h.svc.ListItems(r.Context(), filter, p)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+	response.Paginated(w, http.StatusOK, items, pagination.NewMeta(p.Page, p.Limit, total))
+}
+
+// POST /api/reconciliation/items/{id}/resolve
+func (h *ReconciliationHandler) ResolveItem(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseID(w, r, "id")
+	if !ok {
+		return
+	}
+	var req portservices.ResolveReconciliationItemRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, apperrors.NewValidationError("Invalid request body", nil))
+		return
+	}
+	item, err := h.svc.ResolveItem(r.Context(), id, &req)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, item)
+}
+
+// GET /api/reconciliation/report
+func (h *ReconciliationHandler) Report(w http.ResponseWriter, r *http.Request) {
+	var from, to time.Time
+	for _, d := range []struct {
+		key string
+		dst *time.Time
+	}{{"dateFrom", &from}, {"dateTo", &to}} {
+		v := r.URL.Query().Get(d.key)
+		if v == "" {
+			continue
+		}
+		t, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			response.Error(w, apperrors.NewValidationError("Invalid date",
+				map[string][]string{d.key: {"must be a date (YYYY-MM-DD)"}}))
+			return
+		}
+		*d.dst = t
+	}
+
+	report, err := h.svc.Report(r.Context(), parseOptionalString(r, "provider"), from, to)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, report)
+}
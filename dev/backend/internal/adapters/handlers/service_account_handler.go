@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	apperrors "github.com/ghana-police/ticketing-backend/internal/domain/errors"
+	portservices "github.com/ghana-police/ticketing-backend/internal/ports/services"
+	"github.com/ghana-police/ticketing-backend/pkg/response"
+)
+
+type ServiceAccountHandler struct {
+	svc portservices.ServiceAccountService
+}
+
+func NewServiceAccountHandler(svc portservices.ServiceAccountService) *ServiceAccountHandler {
+	return &ServiceAccountHandler{svc: svc}
+}
+
+// List handles GET /service-accounts
+func (h *ServiceAccountHandler) List(w http.ResponseWriter, r *http.Request) {
+	accounts, err := h.svc.List(r.Context())
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, accounts)
+}
+
+// Get handles GET /service-accounts/{id}
+func (h *ServiceAccountHandler) Get(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseID(w, r, "id")
+	if !ok {
+		return
+	}
+	account, err := h.svc.Get(r.Context(), id)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, account)
+}
+
+// Create handles POST /service-accounts
+func (h *ServiceAccountHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req portservices.CreateServiceAccountRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, apperrors.NewValidationError("Invalid request body", nil))
+		return
+	}
+	account, err := h.svc.Create(r.Context(), &req)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusCreated, account)
+}
+
+// Update handles PUT /service-accounts/{id}
+func (h *ServiceAccountHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseID(w, r, "id")
+	if !ok {
+		return
+	}
+	var req portservices.UpdateServiceAccountRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, apperrors.NewValidationError("Invalid request body", nil))
+		return
+	}
+	account, err := h.svc.Update(r.Context(), id, &req)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, account)
+}
+
+// ListKeys handles GET /service-accounts/{id}/keys
+func (h *ServiceAccountHandler) ListKeys(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseID(w, r, "id")
+	if !ok {
+		return
+	}
+	keys, err := h.svc.ListKeys(r.Context(), id)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, keys)
+}
+
+// CreateKey handles POST /service-accounts/{id}/keys
+func (h *ServiceAccountHandler) CreateKey(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseID(w, r, "id")
+	if !ok {
+		return
+	}
+	var req portservices.CreateAPIKeyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, apperrors.NewValidationError("Invalid request body", nil))
+		return
+	}
+	result, err := h.svc.CreateKey(r.Context(), id, &req)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusCreated, result)
+}
+
+// RevokeKey handles DELETE /service-accounts/{id}/keys/{keyId}
+func (h *ServiceAccountHandler) RevokeKey(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseID(w, r, "id")
+	if !ok {
+		return
+	}
+	keyID, ok := parseID(w, r, "keyId")
+	if !ok {
+		return
+	}
+	if err := h.svc.RevokeKey(r.Context(), id, keyID); err != nil {
+		handleError(w, err)
+		return
+	}
+	response.JSONMessage(w, http.StatusOK, "API key revoked")
+}
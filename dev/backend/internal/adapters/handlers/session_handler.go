@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/ghana-police/ticketing-backend/internal/middleware"
+	portservices "github.com/ghana-police/ticketing-backend/internal/ports/services"
+	"github.com/ghana-police/ticketing-backend/pkg/response"
+	"github.com/google/uuid"
+)
+
+type SessionHandler struct {
+	svc portservices.SessionService
+}
+
+func NewSessionHandler(svc portservices.SessionService) *SessionHandler {
+	return &SessionHandler{svc: svc}
+}
+
+// List handles GET /auth/sessions
+func (h *SessionHandler) List(w http.ResponseWriter, r *http.Request) {
+	h.list(w, r, middleware.GetUserID(r.Context()))
+}
+
+// Revoke handles DELETE /auth/sessions/{sessionId}
+func (h *SessionHandler) Revoke(w http.ResponseWriter, r *http.Request) {
+	h.revoke(w, r, middleware.GetUserID(r.Context()))
+}
+
+// ListForUser handles GET /users/{id}/sessions
+func (h *SessionHandler) ListForUser(w http.ResponseWriter, r *http.Request) {
+	userID, ok := parseID(w, r, "id")
+	if !ok {
+		return
+	}
+	h.list(w, r, userID)
+}
+
+// RevokeForUser handles DELETE /users/{id}/sessions/{sessionId}
+func (h *SessionHandler) RevokeForUser(w http.ResponseWriter, r *http.Request) {
+	userID, ok := parseID(w, r, "id")
+	if !ok {
+		return
+	}
+	h.revoke(w, r, userID)
+}
+
+func (h *SessionHandler) list(w http.ResponseWriter, r *http.Request, userID uuid.UUID) {
+	sessions, err := h.svc.List(r.Context(), userID)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, sessions)
+}
+
+func (h *SessionHandler) revoke(w http.ResponseWriter, r *http.Request, userID uuid.UUID) {
+	sessionID, ok := parseID(w, r, "sessionId")
+	if !ok {
+		return
+	}
+	if err := h.svc.Revoke(r.Context(), userID, sessionID); err != nil {
+		handleError(w, err)
+		return
+	}
+	response.JSONMessage(w, http.StatusOK, "Session signed out")
+}
@@ -2,11 +2,19 @@ package handlers
 
 import (
 	"encoding/json"
+	"io"
+	"math"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
 
 	apperrors "github.com/ghana-police/ticketing-backend/internal/domain/errors"
 	"github.com/ghana-police/ticketing-backend/internal/domain/models"
 	portservices "github.com/ghana-police/ticketing-backend/internal/ports/services"
+	"github.com/ghana-police/ticketing-backend/pkg/pagination"
 	"github.com/ghana-police/ticketing-backend/pkg/response"
 )
 
@@ -20,8 +28,9 @@ func NewSyncHandler(service portservices.SyncService) *SyncHandler {
 
 // BatchSync handles POST /sync
 func (h *SyncHandler) BatchSync(w http.ResponseWriter, r *http.Request) {
+	body := &countingReader{r: r.Body}
 	var req models.SyncRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+	if err := json.NewDecoder(body).Decode(&req); err != nil {
 		response.Error(w, apperrors.NewValidationError("Invalid request body", nil))
 		return
 	}
@@ -32,7 +41,18 @@ func (h *SyncHandler) BatchSync(w http.ResponseWriter, r *http.Request) {
 		deviceID = r.URL.Query().Get("deviceId")
 	}
 
-	result, err := h.service.BatchSync(r.Context(), &req, deviceID)
+	client := models.SyncClientInfo{
+		DeviceID:     deviceID,
+		DeviceKey:    r.Header.Get("X-Device-Key"),
+		AppVersion:   headerString(r, "X-App-Version"),
+		BatteryLevel: headerInt(r, "X-Battery-Level", 0, 100),
+		Charging:     headerBool(r, "X-Battery-Charging"),
+		NetworkType:  headerString(r, "X-Network-Type"),
+		PendingItems: headerInt(r, "X-Pending-Items", 0, math.MaxInt32),
+		RequestBytes: body.n,
+	}
+
+	result, err := h.service.BatchSync(r.Context(), &req, client)
 	if err != nil {
 		handleError(w, err)
 		return
@@ -41,6 +61,72 @@ func (h *SyncHandler) BatchSync(w http.ResponseWriter, r *http.Request) {
 	response.JSON(w, http.StatusOK, result)
 }
 
+// countingReader counts the bytes read from the request body.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// headerString returns a trimmed telemetry header (max 50 chars), or nil when absent.
+func headerString(r *http.Request, key string) *string {
+	v := strings.TrimSpace(r.Header.Get(key))
+	if v == "" {
+		return nil
+	}
+	if len(v) > 50 {
+		v = v[:50]
+	}
+	return &v
+}
+
+// headerInt returns an integer telemetry header within [min, max], or nil when absent or invalid.
+func headerInt(r *http.Request, key string, min, max int) *int {
+	v, err := strconv.Atoi(strings.TrimSpace(r.Header.Get(key)))
+	if err != nil || v < min || v > max {
+		return nil
+	}
+	return &v
+}
+
+// headerBool returns a boolean telemetry header, or nil when absent or invalid.
+func headerBool(r *http.Request, key string) *bool {
+	v, err := strconv.ParseBool(strings.TrimSpace(r.Header.Get(key)))
+	if err != nil {
+		return nil
+	}
+	return &v
+}
+
+// GetChanges handles GET /sync/changes
+func (h *SyncHandler) GetChanges(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	var since *time.Time
+	if v := q.Get("since"); v != "" {
+		t, err := time.Parse(time.RFC3339Nano, v)
+		if err != nil {
+			response.Error(w, apperrors.NewValidationError("since must be an RFC 3339 timestamp", nil))
+			return
+		}
+		since = &t
+	}
+	limit, _ := strconv.Atoi(q.Get("limit"))
+
+	updates, err := h.service.GetChanges(r.Context(), q.Get("cursor"), since, limit)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, updates)
+}
+
 // GetStatus handles GET /sync/status
 func (h *SyncHandler) GetStatus(w http.ResponseWriter, r *http.Request) {
 	deviceID := r.URL.Query().Get("deviceId")
@@ -56,3 +142,121 @@ func (h *SyncHandler) GetStatus(w http.ResponseWriter, r *http.Request) {
 
 	response.JSON(w, http.StatusOK, status)
 }
+
+var syncConflictSorts = []string{"createdAt", "clientTimestamp", "status"}
+
+// ListConflicts handles GET /sync/conflicts
+func (h *SyncHandler) ListConflicts(w http.ResponseWriter, r *http.Request) {
+	filter := models.SyncConflictFilter{
+		Status:    parseOptionalString(r, "status"),
+		TicketID:  parseOptionalUUID(r, "ticketId"),
+		StationID: parseOptionalUUID(r, "stationId"),
+		RegionID:  parseOptionalUUID(r, "regionId"),
+	}
+	p := pagination.Parse(r, syncConflictSorts, "createdAt")
+
+	items, total, err := h.service.ListConflicts(r.Context(), filter, p)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+	response.Paginated(w, http.StatusOK, items, pagination.NewMeta(p.Page, p.Limit, total))
+}
+
+// GetConflict handles GET /sync/conflicts/{id}
+func (h *SyncHandler) GetConflict(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseID(w, r, "id")
+	if !ok {
+		return
+	}
+	conflict, err := h.service.GetConflict(r.Context(), id)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, conflict)
+}
+
+// ResolveConflict handles POST /sync/conflicts/{id}/resolve
+func (h *SyncHandler) ResolveConflict(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseID(w, r, "id")
+	if !ok {
+		return
+	}
+	var req portservices.ResolveConflictRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, apperrors.NewValidationError("Invalid request body", nil))
+		return
+	}
+	conflict, err := h.service.ResolveConflict(r.Context(), id, &req)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, conflict)
+}
+
+// ---------------------------------------------------------------------------
+// Device health
+// ---------------------------------------------------------------------------
+
+var deviceHealthSorts = []string{"lastSessionAt", "station", "health", "pendingItems"}
+
+func parseDeviceHealthFilter(r *http.Request) models.DeviceHealthFilter {
+	return models.DeviceHealthFilter{
+		StationID: parseOptionalUUID(r, "stationId"),
+		RegionID:  parseOptionalUUID(r, "regionId"),
+		Health:    parseOptionalString(r, "health"),
+	}
+}
+
+// ListDeviceHealth handles GET /sync/health/devices
+func (h *SyncHandler) ListDeviceHealth(w http.ResponseWriter, r *http.Request) {
+	p := pagination.Parse(r, deviceHealthSorts, "lastSessionAt")
+
+	items, total, err := h.service.ListDeviceHealth(r.Context(), parseDeviceHealthFilter(r), p)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+	response.Paginated(w, http.StatusOK, items, pagination.NewMeta(p.Page, p.Limit, total))
+}
+
+// ListDeviceSessions handles GET /sync/health/devices/{deviceId}/sessions
+func (h *SyncHandler) ListDeviceSessions(w http.ResponseWriter, r *http.Request) {
+	deviceID := chi.URLParam(r, "deviceId")
+	p := pagination.Parse(r, []string{"startedAt"}, "startedAt")
+
+	items, total, err := h.service.ListSessions(r.Context(), deviceID, p)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+	response.Paginated(w, http.StatusOK, items, pagination.NewMeta(p.Page, p.Limit, total))
+}
+
+// ListStationHealth handles GET /sync/health/stations
+func (h *SyncHandler) ListStationHealth(w http.ResponseWriter, r *http.Request) {
+	filter := parseDeviceHealthFilter(r)
+	filter.Health = nil
+
+	items, err := h.service.ListStationHealth(r.Context(), filter)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, items)
+}
+
+// GetHealthAlerts handles GET /sync/health/alerts
+func (h *SyncHandler) GetHealthAlerts(w http.ResponseWriter, r *http.Request) {
+	filter := parseDeviceHealthFilter(r)
+	filter.Health = nil
+
+	alerts, err := h.service.GetHealthAlerts(r.Context(), filter)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, alerts)
+}
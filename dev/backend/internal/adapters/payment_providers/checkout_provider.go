@@ -0,0 +1,222 @@
+package payment_providers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	apperrors "github.com/ghana-police/ticketing-backend/internal/domain/errors"
+	portservices "github.com/ghana-police/ticketing-backend/internal/ports/services"
+)
+
+// CheckoutConfig holds the credentials and endpoints of a hosted-checkout
+// gateway.
+type CheckoutConfig struct {
+	BaseURL   string // e.g. https://api.paystack.co or the local sandbox
+	SecretKey string
+	ReturnURL string // where the gateway sends the payer back: GET /api/payments/return
+	Timeout   time.Duration
+}
+
+// CheckoutProvider takes card and bank payments through a hosted checkout page,
+// following the initialize / redirect / verify flow of the common West African
+// gateways (Paystack, Flutterwave, Hubtel). The payer completes the payment on
+// the gateway's page and is sent back to the return URL with the reference.
+type CheckoutProvider struct {
+	cfg    CheckoutConfig
+	client *http.Client
+	logger *zap.Logger
+}
+
+func NewCheckoutProvider(cfg CheckoutConfig, logger *zap.Logger) *CheckoutProvider {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 10 * time.Second
+	}
+	cfg.BaseURL = strings.TrimRight(cfg.BaseURL, "/")
+	return &CheckoutProvider{
+		cfg:    cfg,
+		client: &http.Client{Timeout: cfg.Timeout},
+		logger: logger,
+	}
+}
+
+func (p *CheckoutProvider) Name() string {
+	return "checkout"
+}
+
+func (p *CheckoutProvider) SupportedMethods() []string {
+	return []string{"card", "bank"}
+}
+
+// checkoutChannels limits the checkout page to the chosen method.
+var checkoutChannels = map[string][]string{
+	"card": {"card"},
+	"bank": {"bank", "bank_transfer"},
+}
+
+type checkoutEnvelope struct {
+	Status  bool            `json:"status"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data"`
+}
+
+type checkoutInitializeRequest struct {
+	Email       string            `json:"email"`
+	Amount      int64             `json:"amount"` // minor units (pesewas)
+	Currency    string            `json:"currency"`
+	Reference   string            `json:"reference"`
+	CallbackURL string            `json:"callback_url"`
+	Channels    []string          `json:"channels,omitempty"`
+	Metadata    map[string]string `json:"metadata,omitempty"`
+}
+
+type checkoutInitializeData struct {
+	AuthorizationURL string `json:"authorization_url"`
+	AccessCode       string `json:"access_code"`
+	Reference        string `json:"reference"`
+}
+
+type checkoutTransaction struct {
+	ID              int64  `json:"id"`
+	Status          string `json:"status"` // success, failed, abandoned, ongoing, pending, reversed
+	Reference       string `json:"reference"`
+	Amount          int64  `json:"amount"`
+	Currency        string `json:"currency"`
+	GatewayResponse string `json:"gateway_response"`
+	Channel         string `json:"channel"`
+}
+
+func (p *CheckoutProvider) Initiate(ctx context.Context, req *portservices.ProviderInitiateRequest) (*portservices.ProviderInitiateResult, error) {
+	email := strings.TrimSpace(req.PayerEmail)
+	if email == "" {
+		return nil, apperrors.NewValidationError("Payer email is required for card and bank payments",
+			map[string][]string{"payerEmail": {"is required"}})
+	}
+
+	// Gateways refuse a reused reference, and a ticket keeps its payment
+	// reference across attempts, so each attempt gets its own suffix.
+	reference := req.PaymentReference + "-" + uuid.NewString()[:8]
+	body, err := json.Marshal(checkoutInitializeRequest{
+		Email:       email,
+		Amount:      int64(math.Round(req.Amount * 100)),
+		Currency:    req.Currency,
+		Reference:   reference,
+		CallbackURL: p.cfg.ReturnURL,
+		Channels:    checkoutChannels[req.Method],
+		Metadata: map[string]string{
+			"paymentReference": req.PaymentReference,
+			"payerName":        req.PayerName,
+			"description":      req.Description,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	status, env, err := p.call(ctx, http.MethodPost, "/transaction/initialize", body)
+	if err != nil {
+		return nil, fmt.Errorf("checkout initialize: %w", err)
+	}
+	if status != http.StatusOK || !env.Status {
+		return nil, fmt.Errorf("checkout initialize: status %d: %s", status, env.Message)
+	}
+	var data checkoutInitializeData
+	if err := json.Unmarshal(env.Data, &data); err != nil {
+		return nil, fmt.Errorf("checkout initialize: decode: %w", err)
+	}
+	if data.AuthorizationURL == "" {
+		return nil, fmt.Errorf("checkout initialize: no authorization URL")
+	}
+
+	return &portservices.ProviderInitiateResult{
+		ProviderRef:  reference,
+		Status:       "pending",
+		RedirectURL:  data.AuthorizationURL,
+		Instructions: fmt.Sprintf("Open the checkout page to pay GHS %.2f by %s. You will be returned here when the payment is done.", req.Amount, req.Method),
+	}, nil
+}
+
+func (p *CheckoutProvider) Verify(ctx context.Context, providerRef string) (*portservices.ProviderVerifyResult, error) {
+	status, env, err := p.call(ctx, http.MethodGet, "/transaction/verify/"+url.PathEscape(providerRef), nil)
+	if err != nil {
+		return nil, fmt.Errorf("checkout verify: %w", err)
+	}
+	raw, _ := json.Marshal(env)
+	if status == http.StatusNotFound || (status == http.StatusBadRequest && !env.Status) {
+		return &portservices.ProviderVerifyResult{
+			Status:        "failed",
+			StatusMessage: "Transaction not found",
+			RawResponse:   string(raw),
+		}, nil
+	}
+	if status != http.StatusOK || !env.Status {
+		return nil, fmt.Errorf("checkout verify: status %d: %s", status, env.Message)
+	}
+
+	var tx checkoutTransaction
+	if err := json.Unmarshal(env.Data, &tx); err != nil {
+		return nil, fmt.Errorf("checkout verify: decode: %w", err)
+	}
+
+	// The reference stays the transaction ID, so the return URL can find the payment
+	result := &portservices.ProviderVerifyResult{
+		TransactionID: providerRef,
+		Amount:        float64(tx.Amount) / 100,
+		Currency:      tx.Currency,
+		RawResponse:   string(raw),
+	}
+	switch tx.Status {
+	case "success":
+		result.Status = "completed"
+		result.StatusMessage = "Payment approved"
+	case "failed", "reversed":
+		result.Status = "failed"
+		result.StatusMessage = "Payment failed"
+		if tx.GatewayResponse != "" {
+			result.StatusMessage += ": " + tx.GatewayResponse
+		}
+	default:
+		// abandoned means the payer has not finished on the checkout page yet
+		result.Status = "pending"
+		result.StatusMessage = "Waiting for the payer to complete checkout"
+	}
+	return result, nil
+}
+
+// call sends an authenticated request and decodes the gateway's envelope.
+func (p *CheckoutProvider) call(ctx context.Context, method, path string, body []byte) (int, *checkoutEnvelope, error) {
+	req, err := http.NewRequestWithContext(ctx, method, p.cfg.BaseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return 0, nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+p.cfg.SecretKey)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return 0, nil, err
+	}
+
+	var env checkoutEnvelope
+	if err := json.Unmarshal(respBody, &env); err != nil {
+		return resp.StatusCode, nil, fmt.Errorf("status %d: %s", resp.StatusCode, truncate(respBody))
+	}
+	return resp.StatusCode, &env, nil
+}
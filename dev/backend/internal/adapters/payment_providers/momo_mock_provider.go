@@ -12,7 +12,8 @@ import (
 )
 
 // MomoMockProvider simulates mobile money payments (MTN, Vodafone, AirtelTigo).
-// In production, replace this with the real provider adapter.
+// Sessions live in memory, so it only suits a single local instance; set
+// MOMO_PROVIDER=http to use MomoProvider against the sandbox server instead.
 type MomoMockProvider struct {
 	mu       sync.RWMutex
 	sessions map[string]*mockSession // keyed by provider ref
@@ -51,13 +52,17 @@ func (p *MomoMockProvider) Initiate(_ context.Context, req *portservices.Provide
 	}
 	p.mu.Unlock()
 
-	network := "MTN"
+	network := NetworkMTN
+	if detected, _, ok := DetectNetwork(req.PhoneNumber); ok {
+		network = detected
+	}
 	ussd := fmt.Sprintf("*170*1*1*%s*%.0f#", req.PaymentReference, req.Amount)
 
 	return &portservices.ProviderInitiateResult{
 		ProviderRef:  providerRef,
 		Status:       "pending",
 		USSDCode:     ussd,
+		Network:      network,
 		Instructions: fmt.Sprintf("Dial %s on your %s phone (%s) to approve payment of GHS %.2f.", ussd, network, req.PhoneNumber, req.Amount),
 	}, nil
 }
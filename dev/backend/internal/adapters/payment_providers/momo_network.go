@@ -0,0 +1,109 @@
+package payment_providers
+
+import (
+	"strings"
+
+	apperrors "github.com/ghana-police/ticketing-backend/internal/domain/errors"
+)
+
+// Mobile money networks in Ghana.
+const (
+	NetworkMTN        = "MTN"
+	NetworkTelecel    = "Telecel" // formerly Vodafone Cash
+	NetworkAirtelTigo = "AirtelTigo"
+)
+
+// networkPrefixes maps the national trunk prefix of a mobile number to the
+// network that issued it. Ported numbers keep their original prefix, so this
+// is a best guess the provider may still reject.
+var networkPrefixes = map[string]string{
+	"024": NetworkMTN,
+	"025": NetworkMTN,
+	"053": NetworkMTN,
+	"054": NetworkMTN,
+	"055": NetworkMTN,
+	"059": NetworkMTN,
+	"020": NetworkTelecel,
+	"050": NetworkTelecel,
+	"026": NetworkAirtelTigo,
+	"027": NetworkAirtelTigo,
+	"056": NetworkAirtelTigo,
+	"057": NetworkAirtelTigo,
+}
+
+// methodNetworks maps payment methods to the network that collects them.
+var methodNetworks = map[string]string{
+	"momo":       NetworkMTN,
+	"vodacash":   NetworkTelecel,
+	"airteltigo": NetworkAirtelTigo,
+}
+
+// approvalCodes is the USSD menu a payer dials to find a pending approval
+// when the push prompt does not arrive.
+var approvalCodes = map[string]string{
+	NetworkMTN:        "*170#",
+	NetworkTelecel:    "*110#",
+	NetworkAirtelTigo: "*110#",
+}
+
+// NormalizeMSISDN converts a Ghanaian mobile number in local (0241234567),
+// international (+233241234567, 233241234567) or bare (241234567) form to
+// the international form without the plus sign.
+func NormalizeMSISDN(phone string) (string, bool) {
+	digits := strings.Map(func(r rune) rune {
+		switch {
+		case r >= '0' && r <= '9':
+			return r
+		case r == ' ' || r == '-' || r == '+' || r == '(' || r == ')':
+			return -1
+		}
+		return 'x'
+	}, phone)
+	if strings.ContainsRune(digits, 'x') {
+		return "", false
+	}
+
+	switch {
+	case len(digits) == 12 && strings.HasPrefix(digits, "233"):
+		digits = digits[3:]
+	case len(digits) == 10 && strings.HasPrefix(digits, "0"):
+		digits = digits[1:]
+	case len(digits) == 9:
+	default:
+		return "", false
+	}
+	if digits[0] == '0' {
+		return "", false
+	}
+	return "233" + digits, true
+}
+
+// DetectNetwork returns the network of a mobile number from its prefix and
+// the number in international form.
+func DetectNetwork(phone string) (network, msisdn string, ok bool) {
+	msisdn, ok = NormalizeMSISDN(phone)
+	if !ok {
+		return "", "", false
+	}
+	network, ok = networkPrefixes["0"+msisdn[3:5]]
+	return network, msisdn, ok
+}
+
+// resolvePayer checks that the payer's number belongs to the network of the
+// chosen payment method. An empty method accepts any known network.
+func resolvePayer(method, phone string) (network, msisdn string, err error) {
+	if strings.TrimSpace(phone) == "" {
+		return "", "", apperrors.NewValidationError("Phone number is required for mobile money payments",
+			map[string][]string{"phoneNumber": {"is required"}})
+	}
+	network, msisdn, ok := DetectNetwork(phone)
+	if !ok {
+		return "", "", apperrors.NewValidationError("Phone number is not a Ghanaian mobile money number",
+			map[string][]string{"phoneNumber": {"is not a recognised mobile number"}})
+	}
+	if want, known := methodNetworks[method]; known && want != network {
+		return "", "", apperrors.NewValidationError("Phone number does not match the payment method",
+			map[string][]string{"phoneNumber": {"is a " + network + " number; method " + method + " needs a " + want + " number"}})
+	}
+	return network, msisdn, nil
+}
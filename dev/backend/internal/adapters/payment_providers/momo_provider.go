@@ -0,0 +1,318 @@
+package payment_providers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	portservices "github.com/ghana-police/ticketing-backend/internal/ports/services"
+)
+
+// tokenRefreshMargin renews the access token this long before it expires, so
+// a request never goes out with a token that lapses in flight.
+const tokenRefreshMargin = time.Minute
+
+// MomoConfig holds the credentials and endpoints of a MoMo collection API.
+type MomoConfig struct {
+	BaseURL           string // e.g. https://proxy.momoapi.mtn.com or the local sandbox
+	APIUser           string
+	APIKey            string
+	SubscriptionKey   string // Ocp-Apim-Subscription-Key of the collection product
+	TargetEnvironment string // "sandbox" or the production environment name
+	Timeout           time.Duration
+}
+
+// MomoProvider collects mobile money payments through the MoMo collection API:
+// a request-to-pay pushes an approval prompt to the payer's phone, and the
+// payment is confirmed by polling the request's status. No callback URL is
+// sent, so the provider has nothing to post to.
+type MomoProvider struct {
+	cfg    MomoConfig
+	client *http.Client
+	logger *zap.Logger
+
+	mu          sync.Mutex
+	token       string
+	tokenExpiry time.Time
+}
+
+func NewMomoProvider(cfg MomoConfig, logger *zap.Logger) *MomoProvider {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 10 * time.Second
+	}
+	cfg.BaseURL = strings.TrimRight(cfg.BaseURL, "/")
+	return &MomoProvider{
+		cfg:    cfg,
+		client: &http.Client{Timeout: cfg.Timeout},
+		logger: logger,
+	}
+}
+
+func (p *MomoProvider) Name() string {
+	return "momo"
+}
+
+func (p *MomoProvider) SupportedMethods() []string {
+	return []string{"momo", "vodacash", "airteltigo"}
+}
+
+type momoParty struct {
+	PartyIDType string `json:"partyIdType"`
+	PartyID     string `json:"partyId"`
+}
+
+type momoRequestToPay struct {
+	Amount       string    `json:"amount"`
+	Currency     string    `json:"currency"`
+	ExternalID   string    `json:"externalId"`
+	Payer        momoParty `json:"payer"`
+	PayerMessage string    `json:"payerMessage"`
+	PayeeNote    string    `json:"payeeNote"`
+}
+
+type momoRequestToPayStatus struct {
+	Amount                 string    `json:"amount"`
+	Currency               string    `json:"currency"`
+	FinancialTransactionID string    `json:"financialTransactionId"`
+	ExternalID             string    `json:"externalId"`
+	Payer                  momoParty `json:"payer"`
+	Status                 string    `json:"status"` // PENDING, SUCCESSFUL, FAILED
+	Reason                 string    `json:"reason,omitempty"`
+}
+
+func (p *MomoProvider) Initiate(ctx context.Context, req *portservices.ProviderInitiateRequest) (*portservices.ProviderInitiateResult, error) {
+	network, msisdn, err := resolvePayer(req.Method, req.PhoneNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	// The reference ID is ours to choose and identifies the request from now on
+	referenceID := uuid.NewString()
+	body, err := json.Marshal(momoRequestToPay{
+		Amount:       strconv.FormatFloat(req.Amount, 'f', 2, 64),
+		Currency:     req.Currency,
+		ExternalID:   req.PaymentReference,
+		Payer:        momoParty{PartyIDType: "MSISDN", PartyID: msisdn},
+		PayerMessage: req.Description,
+		PayeeNote:    req.PaymentReference,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	headers := map[string]string{"X-Reference-Id": referenceID}
+	status, respBody, err := p.do(ctx, http.MethodPost, "/collection/v1_0/requesttopay", body, headers)
+	if err != nil {
+		return nil, fmt.Errorf("momo request-to-pay: %w", err)
+	}
+	if status != http.StatusAccepted {
+		return nil, fmt.Errorf("momo request-to-pay: unexpected status %d: %s", status, truncate(respBody))
+	}
+
+	ussd := approvalCodes[network]
+	return &portservices.ProviderInitiateResult{
+		ProviderRef: referenceID,
+		Status:      "pending",
+		Network:     network,
+		USSDCode:    ussd,
+		Instructions: fmt.Sprintf("Approve the prompt sent to your %s phone (%s) to pay GHS %.2f. If no prompt appears, dial %s and check your pending approvals.",
+			network, req.PhoneNumber, req.Amount, ussd),
+	}, nil
+}
+
+func (p *MomoProvider) Verify(ctx context.Context, providerRef string) (*portservices.ProviderVerifyResult, error) {
+	if _, err := uuid.Parse(providerRef); err != nil {
+		return &portservices.ProviderVerifyResult{
+			Status:        "failed",
+			StatusMessage: "Transaction not found",
+		}, nil
+	}
+
+	status, respBody, err := p.do(ctx, http.MethodGet, "/collection/v1_0/requesttopay/"+providerRef, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("momo request-to-pay status: %w", err)
+	}
+	switch status {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return &portservices.ProviderVerifyResult{
+			Status:        "failed",
+			StatusMessage: "Transaction not found",
+			RawResponse:   string(respBody),
+		}, nil
+	default:
+		return nil, fmt.Errorf("momo request-to-pay status: unexpected status %d: %s", status, truncate(respBody))
+	}
+
+	var rtp momoRequestToPayStatus
+	if err := json.Unmarshal(respBody, &rtp); err != nil {
+		return nil, fmt.Errorf("momo request-to-pay status: decode: %w", err)
+	}
+
+	result := &portservices.ProviderVerifyResult{
+		TransactionID: providerRef,
+		Currency:      rtp.Currency,
+		RawResponse:   string(respBody),
+	}
+	if rtp.Amount != "" {
+		if result.Amount, err = strconv.ParseFloat(rtp.Amount, 64); err != nil {
+			return nil, fmt.Errorf("momo request-to-pay status: amount %q: %w", rtp.Amount, err)
+		}
+	}
+	switch rtp.Status {
+	case "SUCCESSFUL":
+		// The payment service checks the collected amount against the fine,
+		// so a success that does not say what was collected is not accepted
+		if result.Amount <= 0 || result.Currency == "" {
+			return nil, fmt.Errorf("momo request-to-pay status: successful response without amount and currency")
+		}
+		result.Status = "completed"
+		result.StatusMessage = "Payment approved"
+		if rtp.FinancialTransactionID != "" {
+			result.TransactionID = rtp.FinancialTransactionID
+		}
+	case "PENDING":
+		result.Status = "pending"
+		result.StatusMessage = "Waiting for the payer to approve"
+	default:
+		result.Status = "failed"
+		result.StatusMessage = failureMessage(rtp.Reason)
+	}
+	return result, nil
+}
+
+// do sends an authenticated request. A 401 drops the cached token and the
+// request is retried once with a fresh one.
+func (p *MomoProvider) do(ctx context.Context, method, path string, body []byte, headers map[string]string) (int, []byte, error) {
+	for attempt := 0; ; attempt++ {
+		token, err := p.accessToken(ctx)
+		if err != nil {
+			return 0, nil, err
+		}
+
+		req, err := http.NewRequestWithContext(ctx, method, p.cfg.BaseURL+path, bytes.NewReader(body))
+		if err != nil {
+			return 0, nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("X-Target-Environment", p.cfg.TargetEnvironment)
+		req.Header.Set("Ocp-Apim-Subscription-Key", p.cfg.SubscriptionKey)
+		if body != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+
+		resp, err := p.client.Do(req)
+		if err != nil {
+			return 0, nil, err
+		}
+		respBody, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+		resp.Body.Close()
+		if err != nil {
+			return 0, nil, err
+		}
+
+		if resp.StatusCode == http.StatusUnauthorized && attempt == 0 {
+			p.mu.Lock()
+			if p.token == token {
+				p.token = ""
+			}
+			p.mu.Unlock()
+			continue
+		}
+		return resp.StatusCode, respBody, nil
+	}
+}
+
+// accessToken returns the cached OAuth token, fetching a new one when it is
+// missing or about to expire. The lock is held during the fetch so concurrent
+// requests wait for one token instead of each asking for their own.
+func (p *MomoProvider) accessToken(ctx context.Context) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.token != "" && time.Now().Before(p.tokenExpiry) {
+		return p.token, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.cfg.BaseURL+"/collection/token/", nil)
+	if err != nil {
+		return "", err
+	}
+	req.SetBasicAuth(p.cfg.APIUser, p.cfg.APIKey)
+	req.Header.Set("Ocp-Apim-Subscription-Key", p.cfg.SubscriptionKey)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("momo token: %w", err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return "", fmt.Errorf("momo token: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("momo token: unexpected status %d: %s", resp.StatusCode, truncate(respBody))
+	}
+
+	var tok struct {
+		AccessToken string `json:"access_token"`
+		TokenType   string `json:"token_type"`
+		ExpiresIn   int    `json:"expires_in"` // seconds
+	}
+	if err := json.Unmarshal(respBody, &tok); err != nil {
+		return "", fmt.Errorf("momo token: decode: %w", err)
+	}
+	if tok.AccessToken == "" {
+		return "", fmt.Errorf("momo token: empty access token")
+	}
+
+	lifetime := time.Duration(tok.ExpiresIn) * time.Second
+	if lifetime > 2*tokenRefreshMargin {
+		lifetime -= tokenRefreshMargin
+	}
+	p.token = tok.AccessToken
+	p.tokenExpiry = time.Now().Add(lifetime)
+	p.logger.Debug("momo access token refreshed", zap.Duration("valid_for", lifetime))
+	return p.token, nil
+}
+
+// failureMessage turns a MoMo failure reason into a message for the payment.
+func failureMessage(reason string) string {
+	switch reason {
+	case "APPROVAL_REJECTED":
+		return "Payer declined the payment"
+	case "EXPIRED":
+		return "Payer did not approve the payment in time"
+	case "NOT_ENOUGH_FUNDS":
+		return "Payer has insufficient funds"
+	case "PAYER_LIMIT_REACHED":
+		return "Payer has reached their transaction limit"
+	case "PAYER_NOT_FOUND":
+		return "Phone number is not registered for mobile money"
+	case "":
+		return "Payment failed"
+	}
+	return "Payment failed: " + reason
+}
+
+// truncate shortens a response body for error messages.
+func truncate(b []byte) string {
+	const max = 200
+	if len(b) > max {
+		return string(b[:max]) + "..."
+	}
+	return string(b)
+}
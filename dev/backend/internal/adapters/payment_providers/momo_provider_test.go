@@ -0,0 +1,111 @@
+package payment_providers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+
+	portservices "github.com/ghana-police/ticketing-backend/internal/ports/services"
+)
+
+// momoServer answers the token call and serves status as the request-to-pay
+// status body. Headers of the request-to-pay call are kept in initiated.
+func momoServer(t *testing.T, status string, initiated *http.Header) *MomoProvider {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/collection/token/":
+			w.Write([]byte(`{"access_token":"tok","token_type":"Bearer","expires_in":3600}`))
+		case r.Method == http.MethodPost:
+			*initiated = r.Header.Clone()
+			w.WriteHeader(http.StatusAccepted)
+		default:
+			w.Write([]byte(status))
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return NewMomoProvider(MomoConfig{BaseURL: srv.URL, TargetEnvironment: "sandbox"}, zap.NewNop())
+}
+
+func TestMomoInitiateSendsNoCallback(t *testing.T) {
+	var headers http.Header
+	p := momoServer(t, "", &headers)
+
+	_, err := p.Initiate(context.Background(), &portservices.ProviderInitiateRequest{
+		Method: "momo", Amount: 150, Currency: "GHS", PaymentReference: "PAY-1", PhoneNumber: "0241234567",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if headers.Get("X-Reference-Id") == "" {
+		t.Error("request-to-pay sent without X-Reference-Id")
+	}
+	if v := headers.Get("X-Callback-Url"); v != "" {
+		t.Errorf("X-Callback-Url = %q, want none", v)
+	}
+}
+
+func TestMomoVerifyAmount(t *testing.T) {
+	const ref = "5b0a2c1e-9d7f-4d1a-8a7b-0c6e1f2a3b4c"
+
+	tests := []struct {
+		name         string
+		body         string
+		wantStatus   string
+		wantAmount   float64
+		wantCurrency string
+		wantErr      string
+	}{
+		{
+			name:       "successful",
+			body:       `{"amount":"150.00","currency":"GHS","status":"SUCCESSFUL","financialTransactionId":"991"}`,
+			wantStatus: "completed", wantAmount: 150, wantCurrency: "GHS",
+		},
+		{
+			name:       "pending",
+			body:       `{"amount":"150.00","currency":"GHS","status":"PENDING"}`,
+			wantStatus: "pending", wantAmount: 150, wantCurrency: "GHS",
+		},
+		{
+			name:    "successful without amount",
+			body:    `{"currency":"GHS","status":"SUCCESSFUL"}`,
+			wantErr: "without amount",
+		},
+		{
+			name:    "successful without currency",
+			body:    `{"amount":"150.00","status":"SUCCESSFUL"}`,
+			wantErr: "without amount",
+		},
+		{
+			name:    "unreadable amount",
+			body:    `{"amount":"lots","currency":"GHS","status":"SUCCESSFUL"}`,
+			wantErr: "amount",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var headers http.Header
+			p := momoServer(t, tt.body, &headers)
+
+			result, err := p.Verify(context.Background(), ref)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want mention of %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result.Status != tt.wantStatus || result.Amount != tt.wantAmount || result.Currency != tt.wantCurrency {
+				t.Errorf("result = %s %.2f %s, want %s %.2f %s", result.Status, result.Amount, result.Currency,
+					tt.wantStatus, tt.wantAmount, tt.wantCurrency)
+			}
+		})
+	}
+}
@@ -0,0 +1,208 @@
+package payment_providers
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	portservices "github.com/ghana-police/ticketing-backend/internal/ports/services"
+)
+
+// CSVSettlementFormat describes a provider's CSV settlement statement: the
+// header names each field may appear under and how amounts and dates are
+// written. Header names are matched ignoring case and surrounding spaces.
+type CSVSettlementFormat struct {
+	Provider        string
+	Methods         []string
+	TransactionID   []string
+	Reference       []string
+	Amount          []string
+	Fee             []string
+	Currency        []string
+	Date            []string
+	Status          []string // optional; rows whose status is not settled are skipped
+	SettledStatuses []string
+	MinorUnits      bool // amounts are in pesewas
+	DateLayouts     []string
+}
+
+// MomoSettlementFormat reads the collection statement exported from the MoMo
+// partner portal. The external ID is the payment reference we sent.
+var MomoSettlementFormat = CSVSettlementFormat{
+	Provider:        "momo",
+	Methods:         []string{"momo", "vodacash", "airteltigo"},
+	TransactionID:   []string{"Financial Transaction Id", "Transaction Id", "Id"},
+	Reference:       []string{"External Transaction Id", "External Id", "Reference"},
+	Amount:          []string{"Amount"},
+	Fee:             []string{"Fee", "Fee Amount"},
+	Currency:        []string{"Currency"},
+	Date:            []string{"Date", "Transaction Date", "Completed At"},
+	Status:          []string{"Status"},
+	SettledStatuses: []string{"successful", "success", "completed"},
+	DateLayouts:     []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02T15:04:05", "02/01/2006 15:04:05", "02/01/2006 15:04", "2006-01-02"},
+}
+
+// CheckoutSettlementFormat reads the transactions export of a hosted-checkout
+// gateway. The reference is the one CheckoutProvider gave the transaction.
+var CheckoutSettlementFormat = CSVSettlementFormat{
+	Provider:        "checkout",
+	Methods:         []string{"card", "bank"},
+	TransactionID:   []string{"Transaction Id", "Id"},
+	Reference:       []string{"Reference", "Transaction Reference"},
+	Amount:          []string{"Amount"},
+	Fee:             []string{"Fees", "Fee"},
+	Currency:        []string{"Currency"},
+	Date:            []string{"Paid At", "Transaction Date", "Date"},
+	Status:          []string{"Status"},
+	SettledStatuses: []string{"success", "successful"},
+	DateLayouts:     []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02T15:04:05", "2006-01-02"},
+}
+
+// CSVSettlementParser reads settlement statements laid out as described by a
+// CSVSettlementFormat.
+type CSVSettlementParser struct {
+	format CSVSettlementFormat
+}
+
+func NewCSVSettlementParser(format CSVSettlementFormat) *CSVSettlementParser {
+	return &CSVSettlementParser{format: format}
+}
+
+func (p *CSVSettlementParser) Provider() string {
+	return p.format.Provider
+}
+
+func (p *CSVSettlementParser) Methods() []string {
+	return p.format.Methods
+}
+
+func (p *CSVSettlementParser) Parse(r io.Reader) ([]portservices.SettlementRecord, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, &portservices.SettlementParseError{Line: 1, Message: "Statement is empty"}
+		}
+		return nil, parseError(cr, err)
+	}
+	if len(header) > 0 {
+		header[0] = strings.TrimPrefix(header[0], "\ufeff") // spreadsheet exports start with a BOM
+	}
+
+	f := p.format
+	col := func(names []string) int {
+		for _, name := range names {
+			for i, h := range header {
+				if strings.EqualFold(strings.TrimSpace(h), name) {
+					return i
+				}
+			}
+		}
+		return -1
+	}
+	txCol, refCol := col(f.TransactionID), col(f.Reference)
+	amountCol, dateCol := col(f.Amount), col(f.Date)
+	feeCol, currencyCol, statusCol := col(f.Fee), col(f.Currency), col(f.Status)
+	switch {
+	case txCol < 0 && refCol < 0:
+		return nil, &portservices.SettlementParseError{Line: 1, Message: fmt.Sprintf("No transaction ID or reference column (expected one of %s)",
+			strings.Join(append(slices.Clone(f.TransactionID), f.Reference...), ", "))}
+	case amountCol < 0:
+		return nil, &portservices.SettlementParseError{Line: 1, Message: "No amount column (expected one of " + strings.Join(f.Amount, ", ") + ")"}
+	case dateCol < 0:
+		return nil, &portservices.SettlementParseError{Line: 1, Message: "No date column (expected one of " + strings.Join(f.Date, ", ") + ")"}
+	}
+
+	var records []portservices.SettlementRecord
+	for {
+		row, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, parseError(cr, err)
+		}
+		line, _ := cr.FieldPos(0)
+		field := func(i int) string {
+			if i < 0 || i >= len(row) {
+				return ""
+			}
+			return strings.TrimSpace(row[i])
+		}
+		if strings.TrimSpace(strings.Join(row, "")) == "" {
+			continue
+		}
+		if statusCol >= 0 && !slices.ContainsFunc(f.SettledStatuses, func(s string) bool { return strings.EqualFold(s, field(statusCol)) }) {
+			continue
+		}
+
+		rec := portservices.SettlementRecord{
+			LineNumber:    line,
+			TransactionID: field(txCol),
+			Reference:     field(refCol),
+			Currency:      strings.ToUpper(field(currencyCol)),
+		}
+		if rec.TransactionID == "" && rec.Reference == "" {
+			return nil, &portservices.SettlementParseError{Line: line, Message: "Transaction ID and reference are both empty"}
+		}
+		if rec.Currency == "" {
+			rec.Currency = "GHS"
+		}
+		if rec.Amount, err = p.amount(field(amountCol)); err != nil || rec.Amount <= 0 {
+			return nil, &portservices.SettlementParseError{Line: line, Message: fmt.Sprintf("Invalid amount %q", field(amountCol))}
+		}
+		if v := field(feeCol); v != "" {
+			if rec.Fee, err = p.amount(v); err != nil || rec.Fee < 0 {
+				return nil, &portservices.SettlementParseError{Line: line, Message: fmt.Sprintf("Invalid fee %q", v)}
+			}
+		}
+		if rec.SettledAt, err = p.date(field(dateCol)); err != nil {
+			return nil, &portservices.SettlementParseError{Line: line, Message: fmt.Sprintf("Invalid date %q", field(dateCol))}
+		}
+		records = append(records, rec)
+	}
+	return records, nil
+}
+
+// amount reads a money value, allowing thousands separators and a currency
+// prefix, and rounds it to pesewas.
+func (p *CSVSettlementParser) amount(s string) (float64, error) {
+	s = strings.TrimSpace(strings.TrimPrefix(strings.TrimPrefix(s, "GHS"), "GH₵"))
+	v, err := strconv.ParseFloat(strings.ReplaceAll(s, ",", ""), 64)
+	if err != nil || math.IsNaN(v) || math.IsInf(v, 0) {
+		return 0, fmt.Errorf("invalid amount %q", s)
+	}
+	if p.format.MinorUnits {
+		v /= 100
+	}
+	return math.Round(v*100) / 100, nil
+}
+
+// date reads a timestamp in any of the format's layouts; times without a zone
+// are taken as UTC, which is Ghana time.
+func (p *CSVSettlementParser) date(s string) (time.Time, error) {
+	for _, layout := range p.format.DateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.UTC(), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q", s)
+}
+
+func parseError(cr *csv.Reader, err error) error {
+	var pe *csv.ParseError
+	if errors.As(err, &pe) {
+		return &portservices.SettlementParseError{Line: pe.Line, Message: "Malformed CSV: " + pe.Err.Error()}
+	}
+	line, _ := cr.FieldPos(0)
+	return &portservices.SettlementParseError{Line: line, Message: "Could not read the statement: " + err.Error()}
+}
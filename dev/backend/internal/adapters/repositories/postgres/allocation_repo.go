@@ -0,0 +1,445 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ghana-police/ticketing-backend/internal/domain/models"
+	"github.com/ghana-police/ticketing-backend/internal/ports/repositories"
+	"github.com/ghana-police/ticketing-backend/pkg/pagination"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type allocationRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewAllocationRepo(db *pgxpool.Pool) repositories.AllocationRepository {
+	return &allocationRepo{db: db}
+}
+
+// ---------------------------------------------------------------------------
+// Beneficiaries
+// ---------------------------------------------------------------------------
+
+const beneficiaryCols = `id, code, name, bank_name, bank_account, is_active, created_at, updated_at`
+
+func scanBeneficiary(scanner interface{ Scan(dest ...any) error }) (*models.RevenueBeneficiary, error) {
+	var b models.RevenueBeneficiary
+	err := scanner.Scan(&b.ID, &b.Code, &b.Name, &b.BankName, &b.BankAccount, &b.IsActive, &b.CreatedAt, &b.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func (r *allocationRepo) ListBeneficiaries(ctx context.Context, isActive *bool) ([]models.RevenueBeneficiary, error) {
+	query := "SELECT " + beneficiaryCols + " FROM revenue_beneficiaries"
+	var args []any
+	if isActive != nil {
+		query += " WHERE is_active = $1"
+		args = append(args, *isActive)
+	}
+	query += " ORDER BY name"
+
+	rows, err := conn(ctx, r.db).Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var beneficiaries []models.RevenueBeneficiary
+	for rows.Next() {
+		b, err := scanBeneficiary(rows)
+		if err != nil {
+			return nil, err
+		}
+		beneficiaries = append(beneficiaries, *b)
+	}
+	return beneficiaries, rows.Err()
+}
+
+func (r *allocationRepo) GetBeneficiary(ctx context.Context, id uuid.UUID) (*models.RevenueBeneficiary, error) {
+	return scanBeneficiary(conn(ctx, r.db).QueryRow(ctx,
+		"SELECT "+beneficiaryCols+" FROM revenue_beneficiaries WHERE id = $1", id))
+}
+
+func (r *allocationRepo) BeneficiaryCodeExists(ctx context.Context, code string, excludeID *uuid.UUID) (bool, error) {
+	var exists bool
+	err := conn(ctx, r.db).QueryRow(ctx,
+		`SELECT EXISTS(SELECT 1 FROM revenue_beneficiaries WHERE code = $1 AND ($2::uuid IS NULL OR id != $2))`,
+		code, excludeID).Scan(&exists)
+	return exists, err
+}
+
+func (r *allocationRepo) CreateBeneficiary(ctx context.Context, b *models.RevenueBeneficiary) error {
+	return conn(ctx, r.db).QueryRow(ctx,
+		`INSERT INTO revenue_beneficiaries (code, name, bank_name, bank_account)
+		 VALUES ($1, $2, $3, $4)
+		 RETURNING id, is_active, created_at, updated_at`,
+		b.Code, b.Name, b.BankName, b.BankAccount).
+		Scan(&b.ID, &b.IsActive, &b.CreatedAt, &b.UpdatedAt)
+}
+
+func (r *allocationRepo) UpdateBeneficiary(ctx context.Context, b *models.RevenueBeneficiary) error {
+	return conn(ctx, r.db).QueryRow(ctx,
+		`UPDATE revenue_beneficiaries SET code = $1, name = $2, bank_name = $3, bank_account = $4,
+		        is_active = $5, updated_at = NOW()
+		 WHERE id = $6 RETURNING updated_at`,
+		b.Code, b.Name, b.BankName, b.BankAccount, b.IsActive, b.ID).
+		Scan(&b.UpdatedAt)
+}
+
+func (r *allocationRepo) BeneficiaryInActiveRules(ctx context.Context, id uuid.UUID) (bool, error) {
+	var exists bool
+	err := conn(ctx, r.db).QueryRow(ctx,
+		`SELECT EXISTS(
+			SELECT 1 FROM allocation_rule_shares s
+			JOIN allocation_rules ar ON ar.id = s.rule_id
+			WHERE s.beneficiary_id = $1 AND ar.is_active
+		)`, id).Scan(&exists)
+	return exists, err
+}
+
+// ---------------------------------------------------------------------------
+// Rules
+// ---------------------------------------------------------------------------
+
+var ruleSelect = `SELECT ar.id, ar.name, ar.offence_category, ar.region_id, rg.name, ar.payment_method,
+	ar.is_active, ar.created_by_id, ar.updated_by_id, ar.created_at, ar.updated_at
+	FROM allocation_rules ar
+	LEFT JOIN regions rg ON rg.id = ar.region_id`
+
+func scanRule(scanner interface{ Scan(dest ...any) error }) (*models.AllocationRule, error) {
+	var ar models.AllocationRule
+	err := scanner.Scan(&ar.ID, &ar.Name, &ar.OffenceCategory, &ar.RegionID, &ar.RegionName, &ar.PaymentMethod,
+		&ar.IsActive, &ar.CreatedByID, &ar.UpdatedByID, &ar.CreatedAt, &ar.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &ar, nil
+}
+
+// loadShares fills in the shares of the rules.
+func (r *allocationRepo) loadShares(ctx context.Context, rules []models.AllocationRule) error {
+	if len(rules) == 0 {
+		return nil
+	}
+	ids := make([]uuid.UUID, len(rules))
+	index := make(map[uuid.UUID]int, len(rules))
+	for i := range rules {
+		ids[i] = rules[i].ID
+		index[rules[i].ID] = i
+		rules[i].Shares = []models.AllocationShare{}
+	}
+
+	rows, err := conn(ctx, r.db).Query(ctx,
+		`SELECT s.rule_id, s.beneficiary_id, b.name, s.percentage
+		 FROM allocation_rule_shares s
+		 JOIN revenue_beneficiaries b ON b.id = s.beneficiary_id
+		 WHERE s.rule_id = ANY($1) ORDER BY s.rule_id, s.percentage DESC, b.name`, ids)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var ruleID uuid.UUID
+		var s models.AllocationShare
+		if err := rows.Scan(&ruleID, &s.BeneficiaryID, &s.BeneficiaryName, &s.Percentage); err != nil {
+			return err
+		}
+		i := index[ruleID]
+		rules[i].Shares = append(rules[i].Shares, s)
+	}
+	return rows.Err()
+}
+
+func (r *allocationRepo) ListRules(ctx context.Context, isActive *bool) ([]models.AllocationRule, error) {
+	query := ruleSelect
+	var args []any
+	if isActive != nil {
+		query += " WHERE ar.is_active = $1"
+		args = append(args, *isActive)
+	}
+	query += ` ORDER BY (ar.offence_category IS NOT NULL)::int * 4 + (ar.region_id IS NOT NULL)::int * 2
+		+ (ar.payment_method IS NOT NULL)::int DESC, ar.is_active DESC, ar.name`
+
+	rows, err := conn(ctx, r.db).Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rules []models.AllocationRule
+	for rows.Next() {
+		ar, err := scanRule(rows)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, *ar)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	if err := r.loadShares(ctx, rules); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+func (r *allocationRepo) GetRule(ctx context.Context, id uuid.UUID) (*models.AllocationRule, error) {
+	ar, err := scanRule(conn(ctx, r.db).QueryRow(ctx, ruleSelect+` WHERE ar.id = $1`, id))
+	if err != nil {
+		return nil, err
+	}
+	rules := []models.AllocationRule{*ar}
+	if err := r.loadShares(ctx, rules); err != nil {
+		return nil, err
+	}
+	return &rules[0], nil
+}
+
+func (r *allocationRepo) RuleKeysTaken(ctx context.Context, category *string, regionID *uuid.UUID, method *string, excludeID *uuid.UUID) (bool, error) {
+	var exists bool
+	err := conn(ctx, r.db).QueryRow(ctx,
+		`SELECT EXISTS(
+			SELECT 1 FROM allocation_rules
+			WHERE is_active
+			  AND offence_category IS NOT DISTINCT FROM $1
+			  AND region_id IS NOT DISTINCT FROM $2
+			  AND payment_method IS NOT DISTINCT FROM $3
+			  AND ($4::uuid IS NULL OR id != $4)
+		)`, category, regionID, method, excludeID).Scan(&exists)
+	return exists, err
+}
+
+func (r *allocationRepo) CreateRule(ctx context.Context, rule *models.AllocationRule) error {
+	tx, err := conn(ctx, r.db).Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	err = tx.QueryRow(ctx,
+		`INSERT INTO allocation_rules (name, offence_category, region_id, payment_method, is_active, created_by_id, updated_by_id)
+		 VALUES ($1, $2, $3, $4, $5, $6, $6)
+		 RETURNING id, created_at, updated_at`,
+		rule.Name, rule.OffenceCategory, rule.RegionID, rule.PaymentMethod, rule.IsActive, rule.CreatedByID).
+		Scan(&rule.ID, &rule.CreatedAt, &rule.UpdatedAt)
+	if err != nil {
+		return err
+	}
+	rule.UpdatedByID = rule.CreatedByID
+
+	if err := insertShares(ctx, tx, rule); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func (r *allocationRepo) UpdateRule(ctx context.Context, rule *models.AllocationRule) error {
+	tx, err := conn(ctx, r.db).Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	err = tx.QueryRow(ctx,
+		`UPDATE allocation_rules SET name = $1, offence_category = $2, region_id = $3, payment_method = $4,
+		        is_active = $5, updated_by_id = $6, updated_at = NOW()
+		 WHERE id = $7 RETURNING updated_at`,
+		rule.Name, rule.OffenceCategory, rule.RegionID, rule.PaymentMethod, rule.IsActive, rule.UpdatedByID, rule.ID).
+		Scan(&rule.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM allocation_rule_shares WHERE rule_id = $1`, rule.ID); err != nil {
+		return err
+	}
+	if err := insertShares(ctx, tx, rule); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func insertShares(ctx context.Context, tx pgx.Tx, rule *models.AllocationRule) error {
+	for _, s := range rule.Shares {
+		_, err := tx.Exec(ctx,
+			`INSERT INTO allocation_rule_shares (rule_id, beneficiary_id, percentage) VALUES ($1, $2, $3)`,
+			rule.ID, s.BeneficiaryID, s.Percentage)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ---------------------------------------------------------------------------
+// Allocations
+// ---------------------------------------------------------------------------
+
+func (r *allocationRepo) CreateAllocations(ctx context.Context, allocations []models.PaymentAllocation) error {
+	for i := range allocations {
+		a := &allocations[i]
+		err := conn(ctx, r.db).QueryRow(ctx,
+			`INSERT INTO payment_allocations (payment_id, beneficiary_id, rule_id, offence_category, percentage,
+				amount, reversal, period, station_id, region_id)
+			 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+			 RETURNING id, created_at`,
+			a.PaymentID, a.BeneficiaryID, a.RuleID, a.OffenceCategory, a.Percentage,
+			a.Amount, a.Reversal, a.Period, a.StationID, a.RegionID).
+			Scan(&a.ID, &a.CreatedAt)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *allocationRepo) ReverseForPayment(ctx context.Context, paymentID uuid.UUID, period string) error {
+	_, err := conn(ctx, r.db).Exec(ctx,
+		`INSERT INTO payment_allocations (payment_id, beneficiary_id, rule_id, offence_category, percentage,
+			amount, reversal, period, station_id, region_id)
+		 SELECT a.payment_id, a.beneficiary_id, a.rule_id, a.offence_category, a.percentage,
+			-a.amount, TRUE, $2, a.station_id, a.region_id
+		 FROM payment_allocations a
+		 WHERE a.payment_id = $1 AND NOT a.reversal
+		   AND NOT EXISTS (SELECT 1 FROM payment_allocations rv WHERE rv.payment_id = $1 AND rv.reversal)`,
+		paymentID, period)
+	return err
+}
+
+var allocationSelect = `SELECT a.id, a.payment_id, p.payment_reference, p.method, a.beneficiary_id, b.name,
+	a.rule_id, a.offence_category, a.percentage, a.amount, a.reversal, a.period,
+	a.station_id, a.region_id, a.created_at
+	FROM payment_allocations a
+	JOIN payments p ON p.id = a.payment_id
+	JOIN revenue_beneficiaries b ON b.id = a.beneficiary_id`
+
+// allocationConditions builds the WHERE conditions of an allocation filter.
+func allocationConditions(filter models.PaymentAllocationFilter, argIdx int) ([]string, []any, int) {
+	var conditions []string
+	var args []any
+
+	if filter.PaymentID != nil {
+		conditions = append(conditions, fmt.Sprintf("a.payment_id = $%d", argIdx))
+		args = append(args, *filter.PaymentID)
+		argIdx++
+	}
+	if filter.BeneficiaryID != nil {
+		conditions = append(conditions, fmt.Sprintf("a.beneficiary_id = $%d", argIdx))
+		args = append(args, *filter.BeneficiaryID)
+		argIdx++
+	}
+	if filter.Period != nil {
+		conditions = append(conditions, fmt.Sprintf("a.period = $%d", argIdx))
+		args = append(args, *filter.Period)
+		argIdx++
+	}
+	if filter.StationID != nil {
+		conditions = append(conditions, fmt.Sprintf("a.station_id = $%d", argIdx))
+		args = append(args, *filter.StationID)
+		argIdx++
+	}
+	if filter.RegionID != nil {
+		conditions = append(conditions, fmt.Sprintf("a.region_id = $%d", argIdx))
+		args = append(args, *filter.RegionID)
+		argIdx++
+	}
+	return appendJurisdiction(conditions, args, argIdx, filter.Scope, "a.station_id", "a.region_id")
+}
+
+var allocationSortColumns = map[string]string{
+	"createdAt": "a.created_at",
+	"amount":    "a.amount",
+}
+
+func (r *allocationRepo) ListAllocations(ctx context.Context, filter models.PaymentAllocationFilter, p pagination.Params) ([]models.PaymentAllocation, int, error) {
+	conditions, args, argIdx := allocationConditions(filter, 1)
+	where := ""
+	if len(conditions) > 0 {
+		where = " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var total int
+	if err := conn(ctx, r.db).QueryRow(ctx, "SELECT COUNT(*) FROM payment_allocations a"+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	orderCol := allocationSortColumns[p.SortBy]
+	if orderCol == "" {
+		orderCol = "a.created_at"
+	}
+
+	query := fmt.Sprintf("%s%s ORDER BY %s %s, a.id LIMIT $%d OFFSET $%d",
+		allocationSelect, where, orderCol, p.SortOrder, argIdx, argIdx+1)
+	args = append(args, p.Limit, p.Offset())
+
+	rows, err := conn(ctx, r.db).Query(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var items []models.PaymentAllocation
+	for rows.Next() {
+		var a models.PaymentAllocation
+		if err := rows.Scan(&a.ID, &a.PaymentID, &a.PaymentReference, &a.PaymentMethod, &a.BeneficiaryID, &a.BeneficiaryName,
+			&a.RuleID, &a.OffenceCategory, &a.Percentage, &a.Amount, &a.Reversal, &a.Period,
+			&a.StationID, &a.RegionID, &a.CreatedAt); err != nil {
+			return nil, 0, err
+		}
+		items = append(items, a)
+	}
+	return items, total, rows.Err()
+}
+
+// ---------------------------------------------------------------------------
+// Disbursements
+// ---------------------------------------------------------------------------
+
+func (r *allocationRepo) DisbursementReport(ctx context.Context, period string, filter models.PaymentAllocationFilter, groupBy string) ([]models.DisbursementLine, error) {
+	filter.Period = &period
+	conditions, args, _ := allocationConditions(filter, 1)
+
+	dimCols, dimJoin, dimGroup, dimOrder := "NULL::uuid, NULL::text", "", "", ""
+	if groupBy == "region" {
+		dimCols = "rg.id, rg.name"
+		dimJoin = " LEFT JOIN regions rg ON rg.id = a.region_id"
+		dimGroup = ", rg.id, rg.name"
+		dimOrder = ", rg.name"
+	}
+
+	rows, err := conn(ctx, r.db).Query(ctx,
+		`SELECT b.id, b.code, b.name, b.bank_name, b.bank_account, `+dimCols+`,
+			COUNT(DISTINCT a.payment_id),
+			COALESCE(SUM(a.amount) FILTER (WHERE NOT a.reversal), 0),
+			COALESCE(-SUM(a.amount) FILTER (WHERE a.reversal), 0),
+			COALESCE(SUM(a.amount), 0)
+		FROM payment_allocations a
+		JOIN revenue_beneficiaries b ON b.id = a.beneficiary_id`+dimJoin+`
+		WHERE `+strings.Join(conditions, " AND ")+`
+		GROUP BY b.id, b.code, b.name, b.bank_name, b.bank_account`+dimGroup+`
+		ORDER BY b.name`+dimOrder, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var lines []models.DisbursementLine
+	for rows.Next() {
+		var l models.DisbursementLine
+		if err := rows.Scan(&l.BeneficiaryID, &l.BeneficiaryCode, &l.BeneficiaryName, &l.BankName, &l.BankAccount,
+			&l.RegionID, &l.RegionName, &l.PaymentCount, &l.Allocated, &l.Reversed, &l.Due); err != nil {
+			return nil, err
+		}
+		lines = append(lines, l)
+	}
+	return lines, rows.Err()
+}
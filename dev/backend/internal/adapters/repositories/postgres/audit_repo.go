@@ -26,16 +26,16 @@ func (r *auditRepo) Create(ctx context.Context, entry *models.AuditLog) error {
 			user_id, user_name, user_role, user_badge_number,
 			action, entity_type, entity_id, entity_name, description,
 			old_value, new_value, metadata,
-			ip_address, user_agent, session_id,
+			ip_address, user_agent, session_id, api_key_id,
 			station_id, station_name, region_id, region_name,
 			severity, success, error_message
 		) VALUES (
-			$1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16,$17,$18,$19,$20,$21,$22
+			$1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16,$17,$18,$19,$20,$21,$22,$23
 		) RETURNING id, timestamp, created_at`,
 		entry.UserID, entry.UserName, entry.UserRole, entry.UserBadgeNumber,
 		entry.Action, entry.EntityType, entry.EntityID, entry.EntityName, entry.Description,
 		entry.OldValue, entry.NewValue, entry.Metadata,
-		entry.IPAddress, entry.UserAgent, entry.SessionID,
+		entry.IPAddress, entry.UserAgent, entry.SessionID, entry.APIKeyID,
 		entry.StationID, entry.StationName, entry.RegionID, entry.RegionName,
 		entry.Severity, entry.Success, entry.ErrorMessage,
 	).Scan(&entry.ID, &entry.Timestamp, &entry.CreatedAt)
@@ -48,7 +48,7 @@ func (r *auditRepo) Create(ctx context.Context, entry *models.AuditLog) error {
 var auditScanCols = `a.id, a.timestamp, a.user_id, a.user_name, a.user_role, a.user_badge_number,
 	a.action, a.entity_type, a.entity_id, a.entity_name, a.description,
 	a.old_value, a.new_value, a.metadata,
-	a.ip_address, a.user_agent, a.session_id,
+	a.ip_address, a.user_agent, a.session_id, a.api_key_id,
 	a.station_id, a.station_name, a.region_id, a.region_name,
 	a.severity, a.success, a.error_message, a.created_at`
 
@@ -58,7 +58,7 @@ func scanAuditLog(scanner interface{ Scan(dest ...any) error }) (*models.AuditLo
 		&a.ID, &a.Timestamp, &a.UserID, &a.UserName, &a.UserRole, &a.UserBadgeNumber,
 		&a.Action, &a.EntityType, &a.EntityID, &a.EntityName, &a.Description,
 		&a.OldValue, &a.NewValue, &a.Metadata,
-		&a.IPAddress, &a.UserAgent, &a.SessionID,
+		&a.IPAddress, &a.UserAgent, &a.SessionID, &a.APIKeyID,
 		&a.StationID, &a.StationName, &a.RegionID, &a.RegionName,
 		&a.Severity, &a.Success, &a.ErrorMessage, &a.CreatedAt,
 	)
@@ -257,6 +257,11 @@ func buildAuditConditions(filter models.AuditFilter, search string) ([]string, [
 		args = append(args, *filter.UserID)
 		argIdx++
 	}
+	if filter.APIKeyID != nil {
+		conditions = append(conditions, fmt.Sprintf("a.api_key_id = $%d", argIdx))
+		args = append(args, *filter.APIKeyID)
+		argIdx++
+	}
 	if filter.Severity != nil && *filter.Severity != "" {
 		conditions = append(conditions, fmt.Sprintf("a.severity = $%d", argIdx))
 		args = append(args, *filter.Severity)
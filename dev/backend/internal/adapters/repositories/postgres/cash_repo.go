@@ -0,0 +1,480 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ghana-police/ticketing-backend/internal/domain/models"
+	"github.com/ghana-police/ticketing-backend/internal/ports/repositories"
+	"github.com/ghana-police/ticketing-backend/pkg/pagination"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type cashRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewCashRepo(db *pgxpool.Pool) repositories.CashRepository {
+	return &cashRepo{db: db}
+}
+
+// ---------------------------------------------------------------------------
+// Shifts
+// ---------------------------------------------------------------------------
+
+var shiftSelect = `SELECT c.id, c.station_id, s.name, c.cashier_id, u.first_name || ' ' || u.last_name,
+	c.status, c.opening_float, COALESCE(pc.n, 0), COALESCE(pc.amount, 0),
+	c.opened_at, c.closed_at, c.closed_by_id, c.expected_cash, c.counted_cash, c.variance,
+	c.close_notes, c.remittance_id, c.created_at, c.updated_at
+	FROM cashier_shifts c
+	JOIN stations s ON s.id = c.station_id
+	JOIN users u ON u.id = c.cashier_id
+	LEFT JOIN LATERAL (
+		SELECT COUNT(*) AS n, SUM(p.amount) AS amount FROM payments p
+		WHERE p.shift_id = c.id AND p.status = 'completed'
+	) pc ON TRUE`
+
+func scanShift(scanner interface{ Scan(dest ...any) error }) (*models.CashierShift, error) {
+	var sh models.CashierShift
+	err := scanner.Scan(&sh.ID, &sh.StationID, &sh.StationName, &sh.CashierID, &sh.CashierName,
+		&sh.Status, &sh.OpeningFloat, &sh.PaymentCount, &sh.CashCollected,
+		&sh.OpenedAt, &sh.ClosedAt, &sh.ClosedByID, &sh.ExpectedCash, &sh.CountedCash, &sh.Variance,
+		&sh.CloseNotes, &sh.RemittanceID, &sh.CreatedAt, &sh.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &sh, nil
+}
+
+func (r *cashRepo) CreateShift(ctx context.Context, sh *models.CashierShift) error {
+	return conn(ctx, r.db).QueryRow(ctx,
+		`INSERT INTO cashier_shifts (station_id, cashier_id, status, opening_float)
+		 VALUES ($1, $2, $3, $4) RETURNING id, opened_at, created_at, updated_at`,
+		sh.StationID, sh.CashierID, sh.Status, sh.OpeningFloat,
+	).Scan(&sh.ID, &sh.OpenedAt, &sh.CreatedAt, &sh.UpdatedAt)
+}
+
+func (r *cashRepo) GetShift(ctx context.Context, id uuid.UUID) (*models.CashierShift, error) {
+	return scanShift(conn(ctx, r.db).QueryRow(ctx, shiftSelect+` WHERE c.id = $1`, id))
+}
+
+// GetShiftForUpdate locks the row first and reads it in a second statement,
+// so the payment totals include everything committed before the lock.
+func (r *cashRepo) GetShiftForUpdate(ctx context.Context, id uuid.UUID) (*models.CashierShift, error) {
+	var locked uuid.UUID
+	if err := conn(ctx, r.db).QueryRow(ctx,
+		`SELECT id FROM cashier_shifts WHERE id = $1 FOR UPDATE`, id).Scan(&locked); err != nil {
+		return nil, err
+	}
+	return r.GetShift(ctx, locked)
+}
+
+func (r *cashRepo) GetOpenShift(ctx context.Context, cashierID uuid.UUID) (*models.CashierShift, error) {
+	return scanShift(conn(ctx, r.db).QueryRow(ctx, shiftSelect+` WHERE c.cashier_id = $1 AND c.status = 'open'`, cashierID))
+}
+
+func (r *cashRepo) GetOpenShiftForUpdate(ctx context.Context, cashierID uuid.UUID) (*models.CashierShift, error) {
+	var locked uuid.UUID
+	if err := conn(ctx, r.db).QueryRow(ctx,
+		`SELECT id FROM cashier_shifts WHERE cashier_id = $1 AND status = 'open' FOR UPDATE`, cashierID).Scan(&locked); err != nil {
+		return nil, err
+	}
+	return r.GetShift(ctx, locked)
+}
+
+func (r *cashRepo) CloseShift(ctx context.Context, sh *models.CashierShift) error {
+	_, err := conn(ctx, r.db).Exec(ctx,
+		`UPDATE cashier_shifts SET status = 'closed', closed_at = $1, closed_by_id = $2,
+		 expected_cash = $3, counted_cash = $4, variance = $5, close_notes = $6, updated_at = $1
+		 WHERE id = $7`,
+		sh.ClosedAt, sh.ClosedByID, sh.ExpectedCash, sh.CountedCash, sh.Variance, sh.CloseNotes, sh.ID)
+	return err
+}
+
+var shiftSortColumns = map[string]string{
+	"openedAt": "c.opened_at",
+	"closedAt": "c.closed_at",
+	"variance": "c.variance",
+}
+
+func (r *cashRepo) ListShifts(ctx context.Context, filter models.CashierShiftFilter, p pagination.Params) ([]models.CashierShift, int, error) {
+	var conditions []string
+	var args []any
+	argIdx := 1
+
+	if filter.StationID != nil {
+		conditions = append(conditions, fmt.Sprintf("c.station_id = $%d", argIdx))
+		args = append(args, *filter.StationID)
+		argIdx++
+	}
+	if filter.CashierID != nil {
+		conditions = append(conditions, fmt.Sprintf("c.cashier_id = $%d", argIdx))
+		args = append(args, *filter.CashierID)
+		argIdx++
+	}
+	if filter.Status != nil {
+		conditions = append(conditions, fmt.Sprintf("c.status = $%d", argIdx))
+		args = append(args, *filter.Status)
+		argIdx++
+	}
+	if filter.Unremitted != nil {
+		cond := "c.status = 'closed' AND c.remittance_id IS NULL"
+		if !*filter.Unremitted {
+			cond = "c.remittance_id IS NOT NULL"
+		}
+		conditions = append(conditions, cond)
+	}
+	if filter.DateFrom != nil {
+		conditions = append(conditions, fmt.Sprintf("c.opened_at >= $%d", argIdx))
+		args = append(args, *filter.DateFrom)
+		argIdx++
+	}
+	if filter.DateTo != nil {
+		conditions = append(conditions, fmt.Sprintf("c.opened_at <= $%d", argIdx))
+		args = append(args, *filter.DateTo)
+		argIdx++
+	}
+	conditions, args, argIdx = appendJurisdiction(conditions, args, argIdx, filter.Scope, "c.station_id", "s.region_id")
+
+	where := ""
+	if len(conditions) > 0 {
+		where = " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var total int
+	if err := conn(ctx, r.db).QueryRow(ctx,
+		"SELECT COUNT(*) FROM cashier_shifts c JOIN stations s ON s.id = c.station_id"+where, args...,
+	).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	orderCol := shiftSortColumns[p.SortBy]
+	if orderCol == "" {
+		orderCol = "c.opened_at"
+	}
+
+	query := fmt.Sprintf("%s%s ORDER BY %s %s LIMIT $%d OFFSET $%d",
+		shiftSelect, where, orderCol, p.SortOrder, argIdx, argIdx+1)
+	args = append(args, p.Limit, p.Offset())
+
+	rows, err := conn(ctx, r.db).Query(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var items []models.CashierShift
+	for rows.Next() {
+		sh, err := scanShift(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		items = append(items, *sh)
+	}
+	return items, total, rows.Err()
+}
+
+// ---------------------------------------------------------------------------
+// Remittances
+// ---------------------------------------------------------------------------
+
+var remittanceSelect = `SELECT r.id, r.station_id, s.name, r.deposit_slip_number, r.bank_name, r.bank_account,
+	r.deposited_at, r.expected_amount, r.deposited_amount, r.variance, r.status, r.notes,
+	r.submitted_by_id, su.first_name || ' ' || su.last_name,
+	r.reviewed_by_id, ru.first_name || ' ' || ru.last_name, r.review_notes, r.reviewed_at,
+	r.created_at, r.updated_at
+	FROM cash_remittances r
+	JOIN stations s ON s.id = r.station_id
+	JOIN users su ON su.id = r.submitted_by_id
+	LEFT JOIN users ru ON ru.id = r.reviewed_by_id`
+
+func scanRemittance(scanner interface{ Scan(dest ...any) error }) (*models.CashRemittance, error) {
+	var rm models.CashRemittance
+	err := scanner.Scan(&rm.ID, &rm.StationID, &rm.StationName, &rm.DepositSlipNumber, &rm.BankName, &rm.BankAccount,
+		&rm.DepositedAt, &rm.ExpectedAmount, &rm.DepositedAmount, &rm.Variance, &rm.Status, &rm.Notes,
+		&rm.SubmittedByID, &rm.SubmittedByName,
+		&rm.ReviewedByID, &rm.ReviewedByName, &rm.ReviewNotes, &rm.ReviewedAt,
+		&rm.CreatedAt, &rm.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &rm, nil
+}
+
+func (r *cashRepo) CreateRemittance(ctx context.Context, rm *models.CashRemittance, shiftIDs []uuid.UUID) error {
+	tx, err := conn(ctx, r.db).Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	err = tx.QueryRow(ctx,
+		`INSERT INTO cash_remittances (
+			station_id, deposit_slip_number, bank_name, bank_account, deposited_at,
+			expected_amount, deposited_amount, variance, status, notes, submitted_by_id
+		) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11) RETURNING id, created_at, updated_at`,
+		rm.StationID, rm.DepositSlipNumber, rm.BankName, rm.BankAccount, rm.DepositedAt,
+		rm.ExpectedAmount, rm.DepositedAmount, rm.Variance, rm.Status, rm.Notes, rm.SubmittedByID,
+	).Scan(&rm.ID, &rm.CreatedAt, &rm.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx,
+		`UPDATE cashier_shifts SET remittance_id = $1, updated_at = NOW() WHERE id = ANY($2)`,
+		rm.ID, shiftIDs)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *cashRepo) SlipExists(ctx context.Context, bankName, slipNumber string) (bool, error) {
+	var exists bool
+	err := conn(ctx, r.db).QueryRow(ctx,
+		`SELECT EXISTS(SELECT 1 FROM cash_remittances WHERE bank_name = $1 AND deposit_slip_number = $2)`,
+		bankName, slipNumber).Scan(&exists)
+	return exists, err
+}
+
+func (r *cashRepo) GetRemittance(ctx context.Context, id uuid.UUID) (*models.CashRemittance, error) {
+	rm, err := scanRemittance(conn(ctx, r.db).QueryRow(ctx, remittanceSelect+` WHERE r.id = $1`, id))
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := conn(ctx, r.db).Query(ctx, shiftSelect+` WHERE c.remittance_id = $1 ORDER BY c.opened_at`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		sh, err := scanShift(rows)
+		if err != nil {
+			return nil, err
+		}
+		rm.Shifts = append(rm.Shifts, *sh)
+	}
+	return rm, rows.Err()
+}
+
+func (r *cashRepo) ReviewRemittance(ctx context.Context, id uuid.UUID, status string, reviewerID uuid.UUID, notes *string) error {
+	tx, err := conn(ctx, r.db).Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var remittanceID uuid.UUID
+	err = tx.QueryRow(ctx,
+		`UPDATE cash_remittances SET status = $1, reviewed_by_id = $2, review_notes = $3,
+		 reviewed_at = NOW(), updated_at = NOW()
+		 WHERE id = $4 AND status = 'pending' RETURNING id`,
+		status, reviewerID, notes, id).Scan(&remittanceID)
+	if err != nil {
+		return err
+	}
+
+	if status == models.RemittanceRejected {
+		_, err = tx.Exec(ctx,
+			`UPDATE cashier_shifts SET remittance_id = NULL, updated_at = NOW() WHERE remittance_id = $1`, id)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+var remittanceSortColumns = map[string]string{
+	"depositedAt":     "r.deposited_at",
+	"createdAt":       "r.created_at",
+	"depositedAmount": "r.deposited_amount",
+	"variance":        "r.variance",
+}
+
+func (r *cashRepo) ListRemittances(ctx context.Context, filter models.CashRemittanceFilter, p pagination.Params) ([]models.CashRemittance, int, error) {
+	var conditions []string
+	var args []any
+	argIdx := 1
+
+	if filter.StationID != nil {
+		conditions = append(conditions, fmt.Sprintf("r.station_id = $%d", argIdx))
+		args = append(args, *filter.StationID)
+		argIdx++
+	}
+	if filter.Status != nil {
+		conditions = append(conditions, fmt.Sprintf("r.status = $%d", argIdx))
+		args = append(args, *filter.Status)
+		argIdx++
+	}
+	if filter.DateFrom != nil {
+		conditions = append(conditions, fmt.Sprintf("r.deposited_at >= $%d", argIdx))
+		args = append(args, *filter.DateFrom)
+		argIdx++
+	}
+	if filter.DateTo != nil {
+		conditions = append(conditions, fmt.Sprintf("r.deposited_at <= $%d", argIdx))
+		args = append(args, *filter.DateTo)
+		argIdx++
+	}
+	conditions, args, argIdx = appendJurisdiction(conditions, args, argIdx, filter.Scope, "r.station_id", "s.region_id")
+
+	where := ""
+	if len(conditions) > 0 {
+		where = " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var total int
+	if err := conn(ctx, r.db).QueryRow(ctx,
+		"SELECT COUNT(*) FROM cash_remittances r JOIN stations s ON s.id = r.station_id"+where, args...,
+	).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	orderCol := remittanceSortColumns[p.SortBy]
+	if orderCol == "" {
+		orderCol = "r.deposited_at"
+	}
+
+	query := fmt.Sprintf("%s%s ORDER BY %s %s LIMIT $%d OFFSET $%d",
+		remittanceSelect, where, orderCol, p.SortOrder, argIdx, argIdx+1)
+	args = append(args, p.Limit, p.Offset())
+
+	rows, err := conn(ctx, r.db).Query(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var items []models.CashRemittance
+	for rows.Next() {
+		rm, err := scanRemittance(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		items = append(items, *rm)
+	}
+	return items, total, rows.Err()
+}
+
+// ---------------------------------------------------------------------------
+// Treasury report
+// ---------------------------------------------------------------------------
+
+func (r *cashRepo) TreasuryReport(ctx context.Context, scope models.Jurisdiction, stationID *uuid.UUID, from, to time.Time) (*models.TreasuryReport, error) {
+	// Both queries filter stations the same way, numbering their own args
+	stationFilter := func(conditions []string, args []any) (string, []any) {
+		argIdx := len(args) + 1
+		if stationID != nil {
+			conditions = append(conditions, fmt.Sprintf("s.id = $%d", argIdx))
+			args = append(args, *stationID)
+			argIdx++
+		}
+		conditions, args, _ = appendJurisdiction(conditions, args, argIdx, &scope, "s.id", "s.region_id")
+		if len(conditions) == 0 {
+			return "", args
+		}
+		return " WHERE " + strings.Join(conditions, " AND "), args
+	}
+
+	// Days are UTC days, which is Ghana time
+	where, args := stationFilter(nil, []any{from, to})
+	rows, err := conn(ctx, r.db).Query(ctx,
+		`WITH collected AS (
+			SELECT p.station_id, (p.completed_at AT TIME ZONE 'UTC')::date AS day,
+				COUNT(*) AS n, SUM(p.amount) AS amount
+			FROM payments p
+			WHERE p.method = 'cash' AND p.status = 'completed' AND p.station_id IS NOT NULL
+			  AND p.completed_at >= $1::date AT TIME ZONE 'UTC'
+			  AND p.completed_at < ($2::date + 1) AT TIME ZONE 'UTC'
+			GROUP BY 1, 2
+		), cashed_up AS (
+			SELECT c.station_id, (c.closed_at AT TIME ZONE 'UTC')::date AS day,
+				COUNT(*) AS n, SUM(c.variance) AS variance
+			FROM cashier_shifts c
+			WHERE c.status = 'closed'
+			  AND c.closed_at >= $1::date AT TIME ZONE 'UTC'
+			  AND c.closed_at < ($2::date + 1) AT TIME ZONE 'UTC'
+			GROUP BY 1, 2
+		), deposited AS (
+			SELECT r.station_id, (r.deposited_at AT TIME ZONE 'UTC')::date AS day,
+				SUM(r.deposited_amount) FILTER (WHERE r.status = 'approved') AS approved,
+				SUM(r.deposited_amount) FILTER (WHERE r.status = 'pending') AS pending
+			FROM cash_remittances r
+			WHERE r.status IN ('approved', 'pending')
+			  AND r.deposited_at >= $1::date AT TIME ZONE 'UTC'
+			  AND r.deposited_at < ($2::date + 1) AT TIME ZONE 'UTC'
+			GROUP BY 1, 2
+		), days AS (
+			SELECT station_id, day FROM collected
+			UNION SELECT station_id, day FROM cashed_up
+			UNION SELECT station_id, day FROM deposited
+		)
+		SELECT to_char(d.day, 'YYYY-MM-DD'), s.id, s.name,
+			COALESCE(col.n, 0), COALESCE(col.amount, 0),
+			COALESCE(cu.n, 0), COALESCE(cu.variance, 0),
+			COALESCE(dep.approved, 0), COALESCE(dep.pending, 0)
+		FROM days d
+		JOIN stations s ON s.id = d.station_id
+		LEFT JOIN collected col ON col.station_id = d.station_id AND col.day = d.day
+		LEFT JOIN cashed_up cu ON cu.station_id = d.station_id AND cu.day = d.day
+		LEFT JOIN deposited dep ON dep.station_id = d.station_id AND dep.day = d.day`+where+`
+		ORDER BY d.day, s.name`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	report := &models.TreasuryReport{Days: []models.TreasuryDay{}, Stations: []models.StationCashHeld{}}
+	for rows.Next() {
+		var d models.TreasuryDay
+		if err := rows.Scan(&d.Date, &d.StationID, &d.StationName,
+			&d.PaymentCount, &d.CashCollected, &d.ShiftsClosed, &d.CashUpVariance,
+			&d.Deposited, &d.AwaitingSignOff); err != nil {
+			return nil, err
+		}
+		report.Days = append(report.Days, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// What each station holds now, whatever the dates asked for
+	heldWhere, heldArgs := stationFilter([]string{
+		"(c.status = 'open' OR c.remittance_id IS NULL OR rm.status = 'pending')",
+	}, nil)
+	rows, err = conn(ctx, r.db).Query(ctx,
+		`SELECT s.id, s.name,
+			COUNT(*) FILTER (WHERE c.status = 'open'),
+			COALESCE(SUM(pc.amount) FILTER (WHERE c.status = 'open'), 0),
+			COALESCE(SUM(c.counted_cash - c.opening_float) FILTER (WHERE c.status = 'closed' AND c.remittance_id IS NULL), 0),
+			COALESCE(SUM(c.counted_cash - c.opening_float) FILTER (WHERE rm.status = 'pending'), 0)
+		FROM cashier_shifts c
+		JOIN stations s ON s.id = c.station_id
+		LEFT JOIN cash_remittances rm ON rm.id = c.remittance_id
+		LEFT JOIN LATERAL (
+			SELECT SUM(p.amount) AS amount FROM payments p
+			WHERE p.shift_id = c.id AND p.status = 'completed'
+		) pc ON TRUE`+heldWhere+`
+		GROUP BY s.id, s.name
+		ORDER BY s.name`, heldArgs...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var h models.StationCashHeld
+		if err := rows.Scan(&h.StationID, &h.StationName, &h.OpenShifts, &h.InOpenShifts,
+			&h.AwaitingDeposit, &h.AwaitingSignOff); err != nil {
+			return nil, err
+		}
+		report.Stations = append(report.Stations, h)
+	}
+	return report, rows.Err()
+}
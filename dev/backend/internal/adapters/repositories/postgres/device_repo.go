@@ -0,0 +1,296 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ghana-police/ticketing-backend/internal/domain/models"
+	"github.com/ghana-police/ticketing-backend/internal/ports/repositories"
+	"github.com/ghana-police/ticketing-backend/pkg/pagination"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type deviceRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewDeviceRepo(db *pgxpool.Pool) repositories.DeviceRepository {
+	return &deviceRepo{db: db}
+}
+
+// ---------------------------------------------------------------------------
+// Devices
+// ---------------------------------------------------------------------------
+
+func (r *deviceRepo) Create(ctx context.Context, d *models.Device) error {
+	return r.db.QueryRow(ctx,
+		`INSERT INTO devices (name, status, station_id, officer_id, enrollment_code_hash, enrollment_expires_at, registered_by)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7)
+		 RETURNING id, created_at, updated_at`,
+		d.Name, d.Status, d.StationID, d.OfficerID, d.EnrollmentCodeHash, d.EnrollmentExpiresAt, d.RegisteredBy,
+	).Scan(&d.ID, &d.CreatedAt, &d.UpdatedAt)
+}
+
+const deviceCols = `d.id, d.device_id, d.name, d.platform, d.model, d.app_version, d.status, d.status_reason,
+	d.station_id, d.officer_id, d.enrollment_code_hash, d.enrollment_expires_at, d.key_hash, d.registered_by,
+	d.enrolled_at, d.last_seen_at, d.created_at, d.updated_at,
+	s.name, s.region_id, NULLIF(TRIM(COALESCE(u.first_name, '') || ' ' || COALESCE(u.last_name, '')), '')`
+
+const deviceJoins = ` FROM devices d
+	LEFT JOIN stations s ON d.station_id = s.id
+	LEFT JOIN officers o ON d.officer_id = o.id
+	LEFT JOIN users u ON o.user_id = u.id`
+
+func scanDevice(scanner interface{ Scan(dest ...any) error }) (*models.Device, error) {
+	var d models.Device
+	err := scanner.Scan(
+		&d.ID, &d.DeviceID, &d.Name, &d.Platform, &d.Model, &d.AppVersion, &d.Status, &d.StatusReason,
+		&d.StationID, &d.OfficerID, &d.EnrollmentCodeHash, &d.EnrollmentExpiresAt, &d.KeyHash, &d.RegisteredBy,
+		&d.EnrolledAt, &d.LastSeenAt, &d.CreatedAt, &d.UpdatedAt,
+		&d.StationName, &d.RegionID, &d.OfficerName)
+	if err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+func (r *deviceRepo) GetByID(ctx context.Context, id uuid.UUID) (*models.Device, error) {
+	return scanDevice(r.db.QueryRow(ctx, "SELECT "+deviceCols+deviceJoins+" WHERE d.id = $1", id))
+}
+
+func (r *deviceRepo) GetByDeviceID(ctx context.Context, deviceID string) (*models.Device, error) {
+	return scanDevice(r.db.QueryRow(ctx, "SELECT "+deviceCols+deviceJoins+" WHERE d.device_id = $1", deviceID))
+}
+
+func (r *deviceRepo) GetByEnrollmentCode(ctx context.Context, codeHash string) (*models.Device, error) {
+	return scanDevice(r.db.QueryRow(ctx,
+		"SELECT "+deviceCols+deviceJoins+" WHERE d.enrollment_code_hash = $1 AND d.status = 'pending'", codeHash))
+}
+
+var deviceSortColumns = map[string]string{
+	"name":       "d.name",
+	"status":     "d.status",
+	"lastSeenAt": "d.last_seen_at",
+	"createdAt":  "d.created_at",
+}
+
+func (r *deviceRepo) List(ctx context.Context, filter models.DeviceFilter, search string, p pagination.Params) ([]models.Device, int, error) {
+	var conditions []string
+	var args []any
+	argIdx := 1
+
+	if filter.Status != nil {
+		conditions = append(conditions, fmt.Sprintf("d.status = $%d", argIdx))
+		args = append(args, *filter.Status)
+		argIdx++
+	}
+	if filter.StationID != nil {
+		conditions = append(conditions, fmt.Sprintf("d.station_id = $%d", argIdx))
+		args = append(args, *filter.StationID)
+		argIdx++
+	}
+	if filter.RegionID != nil {
+		conditions = append(conditions, fmt.Sprintf("s.region_id = $%d", argIdx))
+		args = append(args, *filter.RegionID)
+		argIdx++
+	}
+	if filter.OfficerID != nil {
+		conditions = append(conditions, fmt.Sprintf("d.officer_id = $%d", argIdx))
+		args = append(args, *filter.OfficerID)
+		argIdx++
+	}
+	if search != "" {
+		conditions = append(conditions, fmt.Sprintf("(d.name ILIKE $%d OR d.device_id ILIKE $%d)", argIdx, argIdx))
+		args = append(args, "%"+search+"%")
+		argIdx++
+	}
+	conditions, args, argIdx = appendJurisdiction(conditions, args, argIdx, filter.Scope, "d.station_id", "s.region_id")
+
+	where := ""
+	if len(conditions) > 0 {
+		where = " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var total int
+	if err := r.db.QueryRow(ctx, "SELECT COUNT(*)"+deviceJoins+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	orderCol := deviceSortColumns[p.SortBy]
+	if orderCol == "" {
+		orderCol = "d.created_at"
+	}
+
+	query := fmt.Sprintf("SELECT %s%s%s ORDER BY %s %s LIMIT $%d OFFSET $%d",
+		deviceCols, deviceJoins, where, orderCol, p.SortOrder, argIdx, argIdx+1)
+	args = append(args, p.Limit, p.Offset())
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	items := []models.Device{}
+	for rows.Next() {
+		d, err := scanDevice(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		items = append(items, *d)
+	}
+	return items, total, rows.Err()
+}
+
+func (r *deviceRepo) Enroll(ctx context.Context, id uuid.UUID, codeHash, deviceID string, platform, model, appVersion *string, keyHash string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// Only a pending device still holding this code is updated, so of two
+	// concurrent enrollments with one code the second finds no row.
+	var officerID *uuid.UUID
+	err = tx.QueryRow(ctx,
+		`UPDATE devices SET device_id = $1, platform = $2, model = $3, app_version = $4, key_hash = $5,
+		 status = 'active', status_reason = NULL, enrollment_code_hash = NULL, enrollment_expires_at = NULL,
+		 enrolled_at = NOW(), last_seen_at = NOW(), updated_at = NOW()
+		 WHERE id = $6 AND status = 'pending' AND enrollment_code_hash = $7 RETURNING officer_id`,
+		deviceID, platform, model, appVersion, keyHash, id, codeHash).Scan(&officerID)
+	if err != nil {
+		return err
+	}
+
+	if officerID != nil {
+		_, err = tx.Exec(ctx,
+			`UPDATE officers SET assigned_device_id = $1, updated_at = NOW() WHERE id = $2`, deviceID, *officerID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *deviceRepo) ResetEnrollment(ctx context.Context, id uuid.UUID, codeHash string, expiresAt time.Time) error {
+	_, err := r.db.Exec(ctx,
+		`UPDATE devices SET status = 'pending', key_hash = NULL, enrollment_code_hash = $1,
+		 enrollment_expires_at = $2, updated_at = NOW() WHERE id = $3`,
+		codeHash, expiresAt, id)
+	return err
+}
+
+func (r *deviceRepo) UpdateStatus(ctx context.Context, id uuid.UUID, status string, reason *string) error {
+	_, err := r.db.Exec(ctx,
+		`UPDATE devices SET status = $1, status_reason = $2, updated_at = NOW() WHERE id = $3`,
+		status, reason, id)
+	return err
+}
+
+func (r *deviceRepo) TouchLastSeen(ctx context.Context, id uuid.UUID, appVersion *string) error {
+	_, err := r.db.Exec(ctx,
+		`UPDATE devices SET last_seen_at = NOW(), app_version = COALESCE($1, app_version) WHERE id = $2`,
+		appVersion, id)
+	return err
+}
+
+func (r *deviceRepo) RevokeTokens(ctx context.Context, id uuid.UUID) error {
+	_, err := r.db.Exec(ctx,
+		"UPDATE refresh_tokens SET revoked_at = $2 WHERE device_ref = $1 AND revoked_at IS NULL",
+		id, time.Now())
+	return err
+}
+
+// ---------------------------------------------------------------------------
+// Commands
+// ---------------------------------------------------------------------------
+
+const deviceCommandCols = `id, device_id, command, status, reason, issued_by, delivered_at, acknowledged_at, created_at`
+
+func scanDeviceCommand(scanner interface{ Scan(dest ...any) error }) (*models.DeviceCommand, error) {
+	var c models.DeviceCommand
+	err := scanner.Scan(&c.ID, &c.DeviceID, &c.Command, &c.Status, &c.Reason, &c.IssuedBy,
+		&c.DeliveredAt, &c.AcknowledgedAt, &c.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (r *deviceRepo) CreateCommand(ctx context.Context, cmd *models.DeviceCommand) error {
+	return r.db.QueryRow(ctx,
+		`INSERT INTO device_commands (device_id, command, reason, issued_by)
+		 VALUES ($1, $2, $3, $4)
+		 RETURNING id, status, created_at`,
+		cmd.DeviceID, cmd.Command, cmd.Reason, cmd.IssuedBy,
+	).Scan(&cmd.ID, &cmd.Status, &cmd.CreatedAt)
+}
+
+func (r *deviceRepo) ListCommands(ctx context.Context, deviceRef uuid.UUID) ([]models.DeviceCommand, error) {
+	rows, err := r.db.Query(ctx,
+		"SELECT "+deviceCommandCols+" FROM device_commands WHERE device_id = $1 ORDER BY created_at DESC", deviceRef)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	commands := []models.DeviceCommand{}
+	for rows.Next() {
+		c, err := scanDeviceCommand(rows)
+		if err != nil {
+			return nil, err
+		}
+		commands = append(commands, *c)
+	}
+	return commands, rows.Err()
+}
+
+func (r *deviceRepo) TakeOutstandingCommands(ctx context.Context, deviceRef uuid.UUID) ([]models.DeviceCommand, error) {
+	// Delivered commands are re-sent until the device acknowledges them
+	rows, err := r.db.Query(ctx,
+		`UPDATE device_commands
+		 SET status = 'delivered', delivered_at = COALESCE(delivered_at, NOW()), updated_at = NOW()
+		 WHERE device_id = $1 AND status IN ('pending', 'delivered')
+		 RETURNING `+deviceCommandCols, deviceRef)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	commands := []models.DeviceCommand{}
+	for rows.Next() {
+		c, err := scanDeviceCommand(rows)
+		if err != nil {
+			return nil, err
+		}
+		commands = append(commands, *c)
+	}
+	return commands, rows.Err()
+}
+
+func (r *deviceRepo) AcknowledgeCommands(ctx context.Context, deviceRef uuid.UUID, ids []uuid.UUID) error {
+	_, err := r.db.Exec(ctx,
+		`UPDATE device_commands SET status = 'acknowledged', acknowledged_at = NOW(), updated_at = NOW()
+		 WHERE device_id = $1 AND id = ANY($2) AND status IN ('pending', 'delivered')`,
+		deviceRef, ids)
+	return err
+}
+
+func (r *deviceRepo) CancelCommand(ctx context.Context, deviceRef, commandID uuid.UUID) error {
+	tag, err := r.db.Exec(ctx,
+		`UPDATE device_commands SET status = 'cancelled', updated_at = NOW()
+		 WHERE device_id = $1 AND id = $2 AND status IN ('pending', 'delivered')`,
+		deviceRef, commandID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
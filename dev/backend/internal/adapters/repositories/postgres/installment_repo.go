@@ -0,0 +1,262 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+
+	"github.com/ghana-police/ticketing-backend/internal/domain/models"
+	"github.com/ghana-police/ticketing-backend/internal/ports/repositories"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type installmentRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewInstallmentRepo(db *pgxpool.Pool) repositories.InstallmentRepository {
+	return &installmentRepo{db: db}
+}
+
+func (r *installmentRepo) Create(ctx context.Context, plan *models.InstallmentPlan) error {
+	tx, err := conn(ctx, r.db).Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	err = tx.QueryRow(ctx,
+		`INSERT INTO installment_plans (ticket_id, total_amount, status, notes, created_by_id)
+		 VALUES ($1, $2, $3, $4, $5) RETURNING id, created_at, updated_at`,
+		plan.TicketID, plan.TotalAmount, plan.Status, plan.Notes, plan.CreatedByID,
+	).Scan(&plan.ID, &plan.CreatedAt, &plan.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	for i := range plan.Installments {
+		inst := &plan.Installments[i]
+		err = tx.QueryRow(ctx,
+			`INSERT INTO installments (plan_id, sequence, due_date, amount)
+			 VALUES ($1, $2, $3, $4) RETURNING id`,
+			plan.ID, inst.Sequence, inst.DueDate, inst.Amount,
+		).Scan(&inst.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+const installmentPlanCols = `ip.id, ip.ticket_id, t.ticket_number, ip.total_amount, ip.status,
+	ip.notes, ip.created_by_id, ip.cancelled_by_id, ip.cancel_reason,
+	ip.cancelled_at, ip.completed_at, ip.created_at, ip.updated_at`
+
+func (r *installmentRepo) GetActiveByTicket(ctx context.Context, ticketID uuid.UUID) (*models.InstallmentPlan, error) {
+	return r.getPlan(ctx, `ip.ticket_id = $1 AND ip.status = 'active'`, ticketID)
+}
+
+func (r *installmentRepo) GetLatestByTicket(ctx context.Context, ticketID uuid.UUID) (*models.InstallmentPlan, error) {
+	return r.getPlan(ctx, `ip.ticket_id = $1`, ticketID)
+}
+
+func (r *installmentRepo) getPlan(ctx context.Context, where string, arg any) (*models.InstallmentPlan, error) {
+	q := conn(ctx, r.db)
+
+	var p models.InstallmentPlan
+	err := q.QueryRow(ctx,
+		`SELECT `+installmentPlanCols+`
+		 FROM installment_plans ip
+		 JOIN tickets t ON t.id = ip.ticket_id
+		 WHERE `+where+`
+		 ORDER BY ip.created_at DESC LIMIT 1`, arg,
+	).Scan(
+		&p.ID, &p.TicketID, &p.TicketNumber, &p.TotalAmount, &p.Status,
+		&p.Notes, &p.CreatedByID, &p.CancelledByID, &p.CancelReason,
+		&p.CancelledAt, &p.CompletedAt, &p.CreatedAt, &p.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := q.Query(ctx,
+		`SELECT id, sequence, due_date, amount, paid_amount, paid_at
+		 FROM installments WHERE plan_id = $1 ORDER BY sequence`, p.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	now := time.Now()
+	p.Installments = []models.Installment{}
+	for rows.Next() {
+		var inst models.Installment
+		if err := rows.Scan(&inst.ID, &inst.Sequence, &inst.DueDate, &inst.Amount, &inst.PaidAmount, &inst.PaidAt); err != nil {
+			return nil, err
+		}
+		inst.SetStatus(now)
+		p.PaidAmount += inst.PaidAmount
+		p.Installments = append(p.Installments, inst)
+	}
+	return &p, rows.Err()
+}
+
+func (r *installmentRepo) Cancel(ctx context.Context, planID uuid.UUID, cancelledBy uuid.UUID, reason *string) error {
+	_, err := conn(ctx, r.db).Exec(ctx,
+		`UPDATE installment_plans SET status = 'cancelled', cancelled_by_id = $1, cancel_reason = $2,
+		 cancelled_at = NOW(), updated_at = NOW() WHERE id = $3 AND status = 'active'`,
+		cancelledBy, reason, planID)
+	return err
+}
+
+func (r *installmentRepo) ApplyPayment(ctx context.Context, ticketID uuid.UUID, amount float64) error {
+	tx, err := conn(ctx, r.db).Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var planID uuid.UUID
+	err = tx.QueryRow(ctx,
+		`SELECT id FROM installment_plans WHERE ticket_id = $1 AND status = 'active' FOR UPDATE`,
+		ticketID).Scan(&planID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	rows, err := tx.Query(ctx,
+		`SELECT id, amount - paid_amount FROM installments
+		 WHERE plan_id = $1 AND paid_amount < amount ORDER BY sequence`, planID)
+	if err != nil {
+		return err
+	}
+	type due struct {
+		id        uuid.UUID
+		remaining float64
+	}
+	var open []due
+	for rows.Next() {
+		var d due
+		if err := rows.Scan(&d.id, &d.remaining); err != nil {
+			rows.Close()
+			return err
+		}
+		open = append(open, d)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	// Anything paid beyond the schedule stays on the ticket, not the plan
+	left := amount
+	for i, d := range open {
+		if left < 0.005 {
+			break
+		}
+		part := math.Min(left, d.remaining)
+		_, err = tx.Exec(ctx,
+			`UPDATE installments SET paid_amount = paid_amount + $1,
+			 paid_at = CASE WHEN paid_amount + $1 >= amount THEN NOW() ELSE paid_at END
+			 WHERE id = $2`, part, d.id)
+		if err != nil {
+			return err
+		}
+		left -= part
+		if i == len(open)-1 && part >= d.remaining-0.005 {
+			_, err = tx.Exec(ctx,
+				`UPDATE installment_plans SET status = 'completed', completed_at = NOW(), updated_at = NOW()
+				 WHERE id = $1`, planID)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *installmentRepo) ReversePayment(ctx context.Context, ticketID uuid.UUID, paidAt time.Time, amount float64) error {
+	tx, err := conn(ctx, r.db).Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var planID uuid.UUID
+	var status string
+	var createdAt time.Time
+	err = tx.QueryRow(ctx,
+		`SELECT id, status, created_at FROM installment_plans WHERE ticket_id = $1
+		 ORDER BY created_at DESC LIMIT 1 FOR UPDATE`,
+		ticketID).Scan(&planID, &status, &createdAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if status == "cancelled" || createdAt.After(paidAt) {
+		return nil
+	}
+
+	rows, err := tx.Query(ctx,
+		`SELECT id, paid_amount FROM installments
+		 WHERE plan_id = $1 AND paid_amount > 0 ORDER BY sequence DESC`, planID)
+	if err != nil {
+		return err
+	}
+	type paid struct {
+		id     uuid.UUID
+		amount float64
+	}
+	var settled []paid
+	for rows.Next() {
+		var p paid
+		if err := rows.Scan(&p.id, &p.amount); err != nil {
+			rows.Close()
+			return err
+		}
+		settled = append(settled, p)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	// Payments are applied earliest installment first, so money taken back
+	// comes off the latest installments
+	left := amount
+	for _, p := range settled {
+		if left < 0.005 {
+			break
+		}
+		part := math.Min(left, p.amount)
+		_, err = tx.Exec(ctx,
+			`UPDATE installments SET paid_amount = paid_amount - $1,
+			 paid_at = CASE WHEN paid_amount - $1 < amount THEN NULL ELSE paid_at END
+			 WHERE id = $2`, part, p.id)
+		if err != nil {
+			return err
+		}
+		left -= part
+	}
+
+	if status == "completed" && left < amount {
+		_, err = tx.Exec(ctx,
+			`UPDATE installment_plans SET status = 'active', completed_at = NULL, updated_at = NOW()
+			 WHERE id = $1`, planID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,87 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/ghana-police/ticketing-backend/internal/domain/models"
+	"github.com/ghana-police/ticketing-backend/internal/ports/repositories"
+)
+
+// jurisdictionCondition returns a condition limiting rows to j, given the
+// columns holding each row's station and region. A district scope is
+// resolved through the caller's station. Returns "" when j is unrestricted.
+func jurisdictionCondition(j models.Jurisdiction, stationCol, regionCol string, argIdx int) (string, []any, int) {
+	switch j.Level {
+	case models.ScopeNational:
+		return "", nil, argIdx
+	case models.ScopeStation:
+		return fmt.Sprintf("%s = $%d", stationCol, argIdx), []any{*j.StationID}, argIdx + 1
+	case models.ScopeDistrict:
+		return fmt.Sprintf(
+			"%s IN (SELECT id FROM stations WHERE district_id = (SELECT district_id FROM stations WHERE id = $%d))",
+			stationCol, argIdx), []any{*j.StationID}, argIdx + 1
+	case models.ScopeRegion:
+		return fmt.Sprintf("%s = $%d", regionCol, argIdx), []any{*j.RegionID}, argIdx + 1
+	}
+	return "FALSE", nil, argIdx
+}
+
+// appendJurisdiction adds the condition for scope (if any) to a filter
+// builder's conditions.
+func appendJurisdiction(conditions []string, args []any, argIdx int, scope *models.Jurisdiction, stationCol, regionCol string) ([]string, []any, int) {
+	if scope == nil {
+		return conditions, args, argIdx
+	}
+	cond, scopeArgs, next := jurisdictionCondition(*scope, stationCol, regionCol, argIdx)
+	if cond == "" {
+		return conditions, args, argIdx
+	}
+	return append(conditions, cond), append(args, scopeArgs...), next
+}
+
+// jurisdictionSources maps each scoped entity to the query selecting its id,
+// station and region.
+var jurisdictionSources = map[string]struct {
+	from, id, station, region string
+}{
+	models.EntityTicket:         {"tickets t", "t.id", "t.station_id", "t.region_id"},
+	models.EntityPayment:        {"payments p JOIN tickets t ON t.id = p.ticket_id", "p.id", "t.station_id", "t.region_id"},
+	models.EntityObjection:      {"objections o", "o.id", "o.station_id", "o.region_id"},
+	models.EntityOfficer:        {"officers o", "o.id", "o.station_id", "o.region_id"},
+	models.EntityDevice:         {"devices d LEFT JOIN stations s ON s.id = d.station_id", "d.id", "d.station_id", "s.region_id"},
+	models.EntitySyncConflict:   {"sync_conflicts c", "c.id", "c.station_id", "c.region_id"},
+	models.EntityStation:        {"stations s", "s.id", "s.id", "s.region_id"},
+	models.EntityCashierShift:   {"cashier_shifts c JOIN stations s ON s.id = c.station_id", "c.id", "c.station_id", "s.region_id"},
+	models.EntityCashRemittance: {"cash_remittances c JOIN stations s ON s.id = c.station_id", "c.id", "c.station_id", "s.region_id"},
+	models.EntityLedgerJournal:  {"ledger_journals j", "j.id", "j.station_id", "j.region_id"},
+}
+
+type jurisdictionRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewJurisdictionRepo(db *pgxpool.Pool) repositories.JurisdictionRepository {
+	return &jurisdictionRepo{db: db}
+}
+
+func (r *jurisdictionRepo) Contains(ctx context.Context, entity string, id uuid.UUID, j models.Jurisdiction) (bool, error) {
+	src, ok := jurisdictionSources[entity]
+	if !ok {
+		return false, fmt.Errorf("entity %q is not jurisdiction-scoped", entity)
+	}
+	if j.Unrestricted() {
+		return true, nil
+	}
+
+	cond, args, _ := jurisdictionCondition(j, src.station, src.region, 2)
+	var found bool
+	err := r.db.QueryRow(ctx, fmt.Sprintf(
+		"SELECT EXISTS(SELECT 1 FROM %s WHERE %s = $1 AND %s)", src.from, src.id, cond),
+		append([]any{id}, args...)...,
+	).Scan(&found)
+	return found, err
+}
@@ -0,0 +1,40 @@
+package postgres
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/ghana-police/ticketing-backend/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+func TestJurisdictionCondition(t *testing.T) {
+	station, region := uuid.New(), uuid.New()
+
+	tests := []struct {
+		role     string
+		want     string
+		wantArgs []any
+	}{
+		{"officer", "t.station_id = $3", []any{station}},
+		{"supervisor", "t.station_id IN (SELECT id FROM stations WHERE district_id = (SELECT district_id FROM stations WHERE id = $3))", []any{station}},
+		{"admin", "t.region_id = $3", []any{region}},
+		{"accountant", "t.region_id = $3", []any{region}},
+		{"super_admin", "", nil},
+		{"unknown", "FALSE", nil},
+	}
+
+	for _, tt := range tests {
+		j := models.JurisdictionFor(tt.role, &station, &region)
+		got, args, next := jurisdictionCondition(j, "t.station_id", "t.region_id", 3)
+		if got != tt.want {
+			t.Errorf("%s: condition = %q, want %q", tt.role, got, tt.want)
+		}
+		if !slices.Equal(args, tt.wantArgs) {
+			t.Errorf("%s: args = %v, want %v", tt.role, args, tt.wantArgs)
+		}
+		if want := 3 + len(tt.wantArgs); next != want {
+			t.Errorf("%s: next arg = %d, want %d", tt.role, next, want)
+		}
+	}
+}
@@ -0,0 +1,435 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ghana-police/ticketing-backend/internal/domain/models"
+	"github.com/ghana-police/ticketing-backend/internal/ports/repositories"
+	"github.com/ghana-police/ticketing-backend/pkg/pagination"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type ledgerRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewLedgerRepo(db *pgxpool.Pool) repositories.LedgerRepository {
+	return &ledgerRepo{db: db}
+}
+
+func (r *ledgerRepo) ListAccounts(ctx context.Context) ([]models.LedgerAccount, error) {
+	rows, err := conn(ctx, r.db).Query(ctx,
+		`SELECT code, name, type, normal_balance, description FROM ledger_accounts
+		 ORDER BY CASE type WHEN 'asset' THEN 1 WHEN 'revenue' THEN 2 ELSE 3 END, code`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var accounts []models.LedgerAccount
+	for rows.Next() {
+		var a models.LedgerAccount
+		if err := rows.Scan(&a.Code, &a.Name, &a.Type, &a.NormalBalance, &a.Description); err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, a)
+	}
+	return accounts, rows.Err()
+}
+
+// ---------------------------------------------------------------------------
+// Posting
+// ---------------------------------------------------------------------------
+
+func (r *ledgerRepo) Post(ctx context.Context, j *models.LedgerJournal) error {
+	tx, err := conn(ctx, r.db).Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	period, err := openPeriod(ctx, tx, j.OccurredAt.UTC().Format("2006-01"))
+	if err != nil {
+		return err
+	}
+	if period == "" {
+		// Late events in a closed period go to the current month, which
+		// cannot be closed before it ends
+		if period, err = openPeriod(ctx, tx, time.Now().UTC().Format("2006-01")); err != nil {
+			return err
+		}
+		if period == "" {
+			return fmt.Errorf("ledger period %s is closed", time.Now().UTC().Format("2006-01"))
+		}
+	}
+	j.Period = period
+
+	err = tx.QueryRow(ctx,
+		`INSERT INTO ledger_journals (kind, period, occurred_at, ticket_id, payment_id, station_id, region_id, memo, posted_by_id)
+		 SELECT $1, $2, $3, t.id, $4, t.station_id, t.region_id, $5, $6 FROM tickets t WHERE t.id = $7
+		 RETURNING id, posted_at, station_id, region_id`,
+		j.Kind, j.Period, j.OccurredAt, j.PaymentID, j.Memo, j.PostedByID, j.TicketID,
+	).Scan(&j.ID, &j.PostedAt, &j.StationID, &j.RegionID)
+	if err != nil {
+		return err
+	}
+
+	for _, e := range j.Entries {
+		_, err = tx.Exec(ctx,
+			`INSERT INTO ledger_entries (journal_id, account_code, debit, credit) VALUES ($1, $2, $3, $4)`,
+			j.ID, e.AccountCode, e.Debit, e.Credit)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+// openPeriod makes sure the period has a row and holds a share lock on it
+// until the transaction ends, so it cannot be closed under a journal being
+// posted. It returns "" when the period is closed.
+func openPeriod(ctx context.Context, tx pgx.Tx, period string) (string, error) {
+	_, err := tx.Exec(ctx,
+		`INSERT INTO ledger_periods (period, status) VALUES ($1, 'open') ON CONFLICT (period) DO NOTHING`, period)
+	if err != nil {
+		return "", err
+	}
+	var status string
+	err = tx.QueryRow(ctx,
+		`SELECT status FROM ledger_periods WHERE period = $1 FOR SHARE`, period).Scan(&status)
+	if err != nil {
+		return "", err
+	}
+	if status == models.PeriodClosed {
+		return "", nil
+	}
+	return period, nil
+}
+
+// ---------------------------------------------------------------------------
+// Journals
+// ---------------------------------------------------------------------------
+
+var journalSelect = `SELECT j.id, j.kind, j.period, j.occurred_at, j.posted_at, j.ticket_id, t.ticket_number,
+	j.payment_id, j.station_id, j.region_id, j.memo, j.posted_by_id
+	FROM ledger_journals j
+	JOIN tickets t ON t.id = j.ticket_id`
+
+func scanJournal(scanner interface{ Scan(dest ...any) error }) (*models.LedgerJournal, error) {
+	var j models.LedgerJournal
+	err := scanner.Scan(&j.ID, &j.Kind, &j.Period, &j.OccurredAt, &j.PostedAt, &j.TicketID, &j.TicketNumber,
+		&j.PaymentID, &j.StationID, &j.RegionID, &j.Memo, &j.PostedByID)
+	if err != nil {
+		return nil, err
+	}
+	return &j, nil
+}
+
+// loadEntries fills in the entries of the journals.
+func (r *ledgerRepo) loadEntries(ctx context.Context, journals []models.LedgerJournal) error {
+	if len(journals) == 0 {
+		return nil
+	}
+	ids := make([]uuid.UUID, len(journals))
+	index := make(map[uuid.UUID]int, len(journals))
+	for i := range journals {
+		ids[i] = journals[i].ID
+		index[journals[i].ID] = i
+		journals[i].Entries = []models.LedgerEntry{}
+	}
+
+	rows, err := conn(ctx, r.db).Query(ctx,
+		`SELECT e.journal_id, e.account_code, e.debit, e.credit FROM ledger_entries e
+		 WHERE e.journal_id = ANY($1) ORDER BY e.journal_id, e.debit DESC, e.account_code`, ids)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var journalID uuid.UUID
+		var e models.LedgerEntry
+		if err := rows.Scan(&journalID, &e.AccountCode, &e.Debit, &e.Credit); err != nil {
+			return err
+		}
+		i := index[journalID]
+		journals[i].Entries = append(journals[i].Entries, e)
+	}
+	return rows.Err()
+}
+
+func (r *ledgerRepo) GetJournal(ctx context.Context, id uuid.UUID) (*models.LedgerJournal, error) {
+	j, err := scanJournal(conn(ctx, r.db).QueryRow(ctx, journalSelect+` WHERE j.id = $1`, id))
+	if err != nil {
+		return nil, err
+	}
+	journals := []models.LedgerJournal{*j}
+	if err := r.loadEntries(ctx, journals); err != nil {
+		return nil, err
+	}
+	return &journals[0], nil
+}
+
+// journalConditions builds the WHERE conditions of a journal filter.
+func journalConditions(filter models.LedgerJournalFilter, argIdx int) ([]string, []any, int) {
+	var conditions []string
+	var args []any
+
+	if filter.Kind != nil {
+		conditions = append(conditions, fmt.Sprintf("j.kind = $%d", argIdx))
+		args = append(args, *filter.Kind)
+		argIdx++
+	}
+	if filter.Period != nil {
+		conditions = append(conditions, fmt.Sprintf("j.period = $%d", argIdx))
+		args = append(args, *filter.Period)
+		argIdx++
+	}
+	if filter.TicketID != nil {
+		conditions = append(conditions, fmt.Sprintf("j.ticket_id = $%d", argIdx))
+		args = append(args, *filter.TicketID)
+		argIdx++
+	}
+	if filter.PaymentID != nil {
+		conditions = append(conditions, fmt.Sprintf("j.payment_id = $%d", argIdx))
+		args = append(args, *filter.PaymentID)
+		argIdx++
+	}
+	if filter.StationID != nil {
+		conditions = append(conditions, fmt.Sprintf("j.station_id = $%d", argIdx))
+		args = append(args, *filter.StationID)
+		argIdx++
+	}
+	if filter.RegionID != nil {
+		conditions = append(conditions, fmt.Sprintf("j.region_id = $%d", argIdx))
+		args = append(args, *filter.RegionID)
+		argIdx++
+	}
+	if filter.Account != nil {
+		conditions = append(conditions, fmt.Sprintf(
+			"EXISTS (SELECT 1 FROM ledger_entries e WHERE e.journal_id = j.id AND e.account_code = $%d)", argIdx))
+		args = append(args, *filter.Account)
+		argIdx++
+	}
+	if filter.DateFrom != nil {
+		conditions = append(conditions, fmt.Sprintf("j.occurred_at >= $%d", argIdx))
+		args = append(args, *filter.DateFrom)
+		argIdx++
+	}
+	if filter.DateTo != nil {
+		conditions = append(conditions, fmt.Sprintf("j.occurred_at <= $%d", argIdx))
+		args = append(args, *filter.DateTo)
+		argIdx++
+	}
+	return appendJurisdiction(conditions, args, argIdx, filter.Scope, "j.station_id", "j.region_id")
+}
+
+var journalSortColumns = map[string]string{
+	"postedAt":   "j.posted_at",
+	"occurredAt": "j.occurred_at",
+}
+
+func (r *ledgerRepo) ListJournals(ctx context.Context, filter models.LedgerJournalFilter, p pagination.Params) ([]models.LedgerJournal, int, error) {
+	conditions, args, argIdx := journalConditions(filter, 1)
+	where := ""
+	if len(conditions) > 0 {
+		where = " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var total int
+	if err := conn(ctx, r.db).QueryRow(ctx, "SELECT COUNT(*) FROM ledger_journals j"+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	orderCol := journalSortColumns[p.SortBy]
+	if orderCol == "" {
+		orderCol = "j.posted_at"
+	}
+
+	query := fmt.Sprintf("%s%s ORDER BY %s %s, j.id LIMIT $%d OFFSET $%d",
+		journalSelect, where, orderCol, p.SortOrder, argIdx, argIdx+1)
+	args = append(args, p.Limit, p.Offset())
+
+	rows, err := conn(ctx, r.db).Query(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var items []models.LedgerJournal
+	for rows.Next() {
+		j, err := scanJournal(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		items = append(items, *j)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+	rows.Close()
+
+	if err := r.loadEntries(ctx, items); err != nil {
+		return nil, 0, err
+	}
+	return items, total, nil
+}
+
+// ---------------------------------------------------------------------------
+// Periods
+// ---------------------------------------------------------------------------
+
+var periodSelect = `SELECT period, status, journal_count, total_debits, total_credits,
+	closed_by_id, closed_at, reopened_by_id, reopened_at, reopen_reason
+	FROM ledger_periods`
+
+func scanPeriod(scanner interface{ Scan(dest ...any) error }) (*models.LedgerPeriod, error) {
+	var lp models.LedgerPeriod
+	err := scanner.Scan(&lp.Period, &lp.Status, &lp.JournalCount, &lp.TotalDebits, &lp.TotalCredits,
+		&lp.ClosedByID, &lp.ClosedAt, &lp.ReopenedByID, &lp.ReopenedAt, &lp.ReopenReason)
+	if err != nil {
+		return nil, err
+	}
+	return &lp, nil
+}
+
+func (r *ledgerRepo) ListPeriods(ctx context.Context) ([]models.LedgerPeriod, error) {
+	rows, err := conn(ctx, r.db).Query(ctx, periodSelect+` ORDER BY period DESC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var periods []models.LedgerPeriod
+	for rows.Next() {
+		lp, err := scanPeriod(rows)
+		if err != nil {
+			return nil, err
+		}
+		periods = append(periods, *lp)
+	}
+	return periods, rows.Err()
+}
+
+func (r *ledgerRepo) GetPeriod(ctx context.Context, period string) (*models.LedgerPeriod, error) {
+	return scanPeriod(conn(ctx, r.db).QueryRow(ctx, periodSelect+` WHERE period = $1`, period))
+}
+
+func (r *ledgerRepo) ClosePeriod(ctx context.Context, period string, closedByID uuid.UUID) error {
+	tx, err := conn(ctx, r.db).Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx,
+		`INSERT INTO ledger_periods (period, status) VALUES ($1, 'open') ON CONFLICT (period) DO NOTHING`, period)
+	if err != nil {
+		return err
+	}
+	// Waits for journals holding the period's share lock
+	if _, err = tx.Exec(ctx, `SELECT 1 FROM ledger_periods WHERE period = $1 FOR UPDATE`, period); err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx,
+		`UPDATE ledger_periods lp SET status = 'closed',
+			journal_count = totals.n, total_debits = totals.debits, total_credits = totals.credits,
+			closed_by_id = $2, closed_at = NOW(), updated_at = NOW()
+		 FROM (
+			SELECT COUNT(DISTINCT j.id) AS n, COALESCE(SUM(e.debit), 0) AS debits, COALESCE(SUM(e.credit), 0) AS credits
+			FROM ledger_journals j JOIN ledger_entries e ON e.journal_id = j.id
+			WHERE j.period = $1
+		 ) totals
+		 WHERE lp.period = $1`,
+		period, closedByID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *ledgerRepo) ReopenPeriod(ctx context.Context, period string, reopenedByID uuid.UUID, reason string) error {
+	var reopened string
+	return conn(ctx, r.db).QueryRow(ctx,
+		`UPDATE ledger_periods SET status = 'open', reopened_by_id = $2, reopened_at = NOW(),
+		 reopen_reason = $3, updated_at = NOW()
+		 WHERE period = $1 AND status = 'closed' RETURNING period`,
+		period, reopenedByID, reason).Scan(&reopened)
+}
+
+// ---------------------------------------------------------------------------
+// Trial balance
+// ---------------------------------------------------------------------------
+
+func (r *ledgerRepo) TrialBalance(ctx context.Context, period string, filter models.LedgerJournalFilter, groupBy string) ([]models.TrialBalanceLine, error) {
+	filter.Period = nil
+	conditions, args, _ := journalConditions(filter, 2)
+	args = append([]any{period}, args...)
+	joinCond := ""
+	if len(conditions) > 0 {
+		joinCond = " AND " + strings.Join(conditions, " AND ")
+	}
+
+	dimCols, dimJoin, dimGroup := "NULL::uuid, NULL::text, NULL::uuid, NULL::text", "", ""
+	switch groupBy {
+	case "station":
+		dimCols = "s.id, s.name, s.region_id, rg.name"
+		dimJoin = " LEFT JOIN stations s ON s.id = j.station_id LEFT JOIN regions rg ON rg.id = s.region_id"
+		dimGroup = ", s.id, s.name, s.region_id, rg.name"
+	case "region":
+		dimCols = "NULL::uuid, NULL::text, rg.id, rg.name"
+		dimJoin = " LEFT JOIN regions rg ON rg.id = j.region_id"
+		dimGroup = ", rg.id, rg.name"
+	}
+
+	rows, err := conn(ctx, r.db).Query(ctx,
+		`SELECT a.code, a.name, a.type, a.normal_balance, `+dimCols+`,
+			COALESCE(SUM(e.debit - e.credit) FILTER (WHERE j.period < $1), 0),
+			COALESCE(SUM(e.debit) FILTER (WHERE j.period = $1), 0),
+			COALESCE(SUM(e.credit) FILTER (WHERE j.period = $1), 0)
+		FROM ledger_accounts a
+		LEFT JOIN (ledger_entries e JOIN ledger_journals j ON j.id = e.journal_id AND j.period <= $1`+joinCond+`)
+			ON e.account_code = a.code`+dimJoin+`
+		GROUP BY a.code, a.name, a.type, a.normal_balance`+dimGroup+`
+		ORDER BY CASE a.type WHEN 'asset' THEN 1 WHEN 'revenue' THEN 2 ELSE 3 END, a.code`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var lines []models.TrialBalanceLine
+	for rows.Next() {
+		var l models.TrialBalanceLine
+		var normal string
+		var openingNet float64
+		if err := rows.Scan(&l.AccountCode, &l.AccountName, &l.AccountType, &normal,
+			&l.StationID, &l.StationName, &l.RegionID, &l.RegionName,
+			&openingNet, &l.Debits, &l.Credits); err != nil {
+			return nil, err
+		}
+		if groupBy != "" && openingNet == 0 && l.Debits == 0 && l.Credits == 0 {
+			// Accounts a station or region never used
+			continue
+		}
+		closingNet := openingNet + l.Debits - l.Credits
+		if closingNet > 0 {
+			l.ClosingDebit = closingNet
+		} else {
+			l.ClosingCredit = -closingNet
+		}
+		if normal == "credit" {
+			openingNet, closingNet = -openingNet, -closingNet
+		}
+		l.OpeningBalance = openingNet
+		l.ClosingBalance = closingNet
+		lines = append(lines, l)
+	}
+	return lines, rows.Err()
+}
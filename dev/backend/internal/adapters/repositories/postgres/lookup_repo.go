@@ -6,6 +6,7 @@ import (
 
 	"github.com/ghana-police/ticketing-backend/internal/domain/models"
 	"github.com/ghana-police/ticketing-backend/internal/ports/repositories"
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -17,7 +18,16 @@ func NewLookupRepo(db *pgxpool.Pool) repositories.LookupRepository {
 	return &lookupRepo{db: db}
 }
 
-func (r *lookupRepo) GetLookupData(ctx context.Context) (*models.LookupData, error) {
+// lookupCondition returns the WHERE clause and args for one entity: active rows for a full
+// fetch, or every row (active or not) changed after the entity's cursor for a delta fetch.
+func lookupCondition(alias string, since *time.Time) (string, []any) {
+	if since == nil {
+		return " WHERE " + alias + "is_active = true", nil
+	}
+	return " WHERE " + alias + "updated_at > $1", []any{*since}
+}
+
+func (r *lookupRepo) GetLookupData(ctx context.Context, since *models.LookupVersions) (*models.LookupData, error) {
 	data := &models.LookupData{
 		Offences:     []models.LookupOffence{},
 		Regions:      []models.LookupRegion{},
@@ -25,98 +35,118 @@ func (r *lookupRepo) GetLookupData(ctx context.Context) (*models.LookupData, err
 		VehicleTypes: []models.LookupVehicleType{},
 	}
 
-	var maxUpdated time.Time
+	var offenceSince, regionSince, stationSince, vehicleTypeSince *time.Time
+	if since != nil {
+		data.Delta = true
+		data.Removed = &models.LookupRemoved{
+			Offences:     []uuid.UUID{},
+			Regions:      []uuid.UUID{},
+			Stations:     []uuid.UUID{},
+			VehicleTypes: []uuid.UUID{},
+		}
+		offenceSince = &since.Offences
+		regionSince = &since.Regions
+		stationSince = &since.Stations
+		vehicleTypeSince = &since.VehicleTypes
+	}
 
 	// Offences
+	where, args := lookupCondition("", offenceSince)
 	rows, err := r.db.Query(ctx,
-		`SELECT id, code, name, category, default_fine, min_fine, max_fine, updated_at
-		 FROM offences WHERE is_active = true ORDER BY code`)
+		`SELECT id, code, name, category, default_fine, min_fine, max_fine, is_active
+		 FROM offences`+where+` ORDER BY code`, args...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 	for rows.Next() {
 		var o models.LookupOffence
-		var updatedAt time.Time
-		if err := rows.Scan(&o.ID, &o.Code, &o.Name, &o.Category, &o.DefaultFine, &o.MinFine, &o.MaxFine, &updatedAt); err != nil {
+		var isActive bool
+		if err := rows.Scan(&o.ID, &o.Code, &o.Name, &o.Category, &o.DefaultFine, &o.MinFine, &o.MaxFine, &isActive); err != nil {
 			return nil, err
 		}
-		data.Offences = append(data.Offences, o)
-		if updatedAt.After(maxUpdated) {
-			maxUpdated = updatedAt
+		if isActive {
+			data.Offences = append(data.Offences, o)
+		} else {
+			data.Removed.Offences = append(data.Removed.Offences, o.ID)
 		}
 	}
 
 	// Regions
+	where, args = lookupCondition("", regionSince)
 	rows2, err := r.db.Query(ctx,
-		`SELECT id, name, code, updated_at FROM regions WHERE is_active = true ORDER BY name`)
+		`SELECT id, name, code, is_active FROM regions`+where+` ORDER BY name`, args...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows2.Close()
 	for rows2.Next() {
 		var reg models.LookupRegion
-		var updatedAt time.Time
-		if err := rows2.Scan(&reg.ID, &reg.Name, &reg.Code, &updatedAt); err != nil {
+		var isActive bool
+		if err := rows2.Scan(&reg.ID, &reg.Name, &reg.Code, &isActive); err != nil {
 			return nil, err
 		}
-		data.Regions = append(data.Regions, reg)
-		if updatedAt.After(maxUpdated) {
-			maxUpdated = updatedAt
+		if isActive {
+			data.Regions = append(data.Regions, reg)
+		} else {
+			data.Removed.Regions = append(data.Removed.Regions, reg.ID)
 		}
 	}
 
 	// Stations
+	where, args = lookupCondition("s.", stationSince)
 	rows3, err := r.db.Query(ctx,
-		`SELECT s.id, s.name, s.code, s.region_id, s.updated_at
-		 FROM stations s WHERE s.is_active = true ORDER BY s.name`)
+		`SELECT s.id, s.name, s.code, s.region_id, s.is_active
+		 FROM stations s`+where+` ORDER BY s.name`, args...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows3.Close()
 	for rows3.Next() {
 		var st models.LookupStation
-		var updatedAt time.Time
-		if err := rows3.Scan(&st.ID, &st.Name, &st.Code, &st.RegionID, &updatedAt); err != nil {
+		var isActive bool
+		if err := rows3.Scan(&st.ID, &st.Name, &st.Code, &st.RegionID, &isActive); err != nil {
 			return nil, err
 		}
-		data.Stations = append(data.Stations, st)
-		if updatedAt.After(maxUpdated) {
-			maxUpdated = updatedAt
+		if isActive {
+			data.Stations = append(data.Stations, st)
+		} else {
+			data.Removed.Stations = append(data.Removed.Stations, st.ID)
 		}
 	}
 
-	// Vehicle types (no updated_at column — use created_at)
+	// Vehicle types
+	where, args = lookupCondition("", vehicleTypeSince)
 	rows4, err := r.db.Query(ctx,
-		`SELECT id, name, created_at FROM vehicle_types WHERE is_active = true ORDER BY name`)
+		`SELECT id, name, is_active FROM vehicle_types`+where+` ORDER BY name`, args...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows4.Close()
 	for rows4.Next() {
 		var vt models.LookupVehicleType
-		var updatedAt time.Time
-		if err := rows4.Scan(&vt.ID, &vt.Name, &updatedAt); err != nil {
+		var isActive bool
+		if err := rows4.Scan(&vt.ID, &vt.Name, &isActive); err != nil {
 			return nil, err
 		}
-		data.VehicleTypes = append(data.VehicleTypes, vt)
-		if updatedAt.After(maxUpdated) {
-			maxUpdated = updatedAt
+		if isActive {
+			data.VehicleTypes = append(data.VehicleTypes, vt)
+		} else {
+			data.Removed.VehicleTypes = append(data.Removed.VehicleTypes, vt.ID)
 		}
 	}
 
-	data.LastUpdated = maxUpdated
 	return data, nil
 }
 
-func (r *lookupRepo) GetLastUpdated(ctx context.Context) (time.Time, error) {
-	var t time.Time
+func (r *lookupRepo) GetVersions(ctx context.Context) (models.LookupVersions, error) {
+	var v models.LookupVersions
 	err := r.db.QueryRow(ctx,
-		`SELECT GREATEST(
-			(SELECT COALESCE(MAX(updated_at), '1970-01-01') FROM offences WHERE is_active = true),
-			(SELECT COALESCE(MAX(updated_at), '1970-01-01') FROM regions WHERE is_active = true),
-			(SELECT COALESCE(MAX(updated_at), '1970-01-01') FROM stations WHERE is_active = true),
-			(SELECT COALESCE(MAX(created_at), '1970-01-01') FROM vehicle_types WHERE is_active = true)
-		)`).Scan(&t)
-	return t, err
+		`SELECT
+			(SELECT COALESCE(MAX(updated_at), '1970-01-01') FROM offences),
+			(SELECT COALESCE(MAX(updated_at), '1970-01-01') FROM regions),
+			(SELECT COALESCE(MAX(updated_at), '1970-01-01') FROM stations),
+			(SELECT COALESCE(MAX(updated_at), '1970-01-01') FROM vehicle_types)`,
+	).Scan(&v.Offences, &v.Regions, &v.Stations, &v.VehicleTypes)
+	return v, err
 }
@@ -0,0 +1,194 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/ghana-police/ticketing-backend/internal/domain/models"
+	"github.com/ghana-police/ticketing-backend/internal/ports/repositories"
+)
+
+type mfaRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewMFARepo(db *pgxpool.Pool) repositories.MFARepository {
+	return &mfaRepo{db: db}
+}
+
+func (r *mfaRepo) Get(ctx context.Context, userID uuid.UUID) (*models.UserMFA, error) {
+	m := models.UserMFA{UserID: userID}
+	err := r.db.QueryRow(ctx, `
+		SELECT mfa_enabled, mfa_secret, mfa_pending_secret, mfa_enrolled_at, mfa_last_step
+		FROM users WHERE id = $1`, userID).
+		Scan(&m.Enabled, &m.Secret, &m.PendingSecret, &m.EnrolledAt, &m.LastStep)
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+func (r *mfaRepo) SetPendingSecret(ctx context.Context, userID uuid.UUID, sealed string) error {
+	_, err := r.db.Exec(ctx,
+		"UPDATE users SET mfa_pending_secret = $2, updated_at = NOW() WHERE id = $1",
+		userID, sealed)
+	return err
+}
+
+func (r *mfaRepo) Activate(ctx context.Context, userID uuid.UUID, step int64, codeHashes []string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx, `
+		UPDATE users SET
+			mfa_enabled = true,
+			mfa_secret = mfa_pending_secret,
+			mfa_pending_secret = NULL,
+			mfa_enrolled_at = NOW(),
+			mfa_last_step = $2,
+			updated_at = NOW()
+		WHERE id = $1 AND mfa_pending_secret IS NOT NULL`,
+		userID, step)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+
+	if err := replaceRecoveryCodes(ctx, tx, userID, codeHashes); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func (r *mfaRepo) AdvanceStep(ctx context.Context, userID uuid.UUID, step int64) (bool, error) {
+	tag, err := r.db.Exec(ctx,
+		"UPDATE users SET mfa_last_step = $2 WHERE id = $1 AND mfa_last_step < $2",
+		userID, step)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+func (r *mfaRepo) UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string) (bool, error) {
+	tag, err := r.db.Exec(ctx, `
+		UPDATE mfa_recovery_codes SET used_at = NOW()
+		WHERE id = (
+			SELECT id FROM mfa_recovery_codes
+			WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL
+			LIMIT 1
+		)`, userID, codeHash)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+func (r *mfaRepo) ReplaceRecoveryCodes(ctx context.Context, userID uuid.UUID, codeHashes []string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := replaceRecoveryCodes(ctx, tx, userID, codeHashes); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func replaceRecoveryCodes(ctx context.Context, tx pgx.Tx, userID uuid.UUID, codeHashes []string) error {
+	if _, err := tx.Exec(ctx, "DELETE FROM mfa_recovery_codes WHERE user_id = $1", userID); err != nil {
+		return err
+	}
+	for _, h := range codeHashes {
+		if _, err := tx.Exec(ctx,
+			"INSERT INTO mfa_recovery_codes (user_id, code_hash) VALUES ($1, $2)",
+			userID, h); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *mfaRepo) CountRecoveryCodes(ctx context.Context, userID uuid.UUID) (int, error) {
+	var n int
+	err := r.db.QueryRow(ctx,
+		"SELECT COUNT(*) FROM mfa_recovery_codes WHERE user_id = $1 AND used_at IS NULL",
+		userID).Scan(&n)
+	return n, err
+}
+
+func (r *mfaRepo) Disable(ctx context.Context, userID uuid.UUID) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `
+		UPDATE users SET
+			mfa_enabled = false,
+			mfa_secret = NULL,
+			mfa_pending_secret = NULL,
+			mfa_enrolled_at = NULL,
+			updated_at = NOW()
+		WHERE id = $1`, userID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, "DELETE FROM mfa_recovery_codes WHERE user_id = $1", userID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx,
+		"UPDATE mfa_challenges SET used_at = NOW() WHERE user_id = $1 AND used_at IS NULL",
+		userID); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func (r *mfaRepo) CreateChallenge(ctx context.Context, c *models.MFAChallenge) error {
+	return r.db.QueryRow(ctx, `
+		INSERT INTO mfa_challenges (id, user_id, token_hash, setup, device_id, device_info, device_ref, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING created_at`,
+		c.ID, c.UserID, c.TokenHash, c.Setup, c.DeviceID, c.DeviceInfo, c.DeviceRef, c.ExpiresAt).
+		Scan(&c.CreatedAt)
+}
+
+func (r *mfaRepo) FindChallenge(ctx context.Context, tokenHash string) (*models.MFAChallenge, error) {
+	var c models.MFAChallenge
+	err := r.db.QueryRow(ctx, `
+		SELECT id, user_id, token_hash, setup, device_id, device_info, device_ref,
+		       attempts, expires_at, used_at, created_at
+		FROM mfa_challenges WHERE token_hash = $1`, tokenHash).
+		Scan(&c.ID, &c.UserID, &c.TokenHash, &c.Setup, &c.DeviceID, &c.DeviceInfo, &c.DeviceRef,
+			&c.Attempts, &c.ExpiresAt, &c.UsedAt, &c.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (r *mfaRepo) RecordChallengeAttempt(ctx context.Context, id uuid.UUID) error {
+	_, err := r.db.Exec(ctx,
+		"UPDATE mfa_challenges SET attempts = attempts + 1 WHERE id = $1", id)
+	return err
+}
+
+func (r *mfaRepo) ConsumeChallenge(ctx context.Context, id uuid.UUID) (bool, error) {
+	tag, err := r.db.Exec(ctx,
+		"UPDATE mfa_challenges SET used_at = NOW() WHERE id = $1 AND used_at IS NULL", id)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
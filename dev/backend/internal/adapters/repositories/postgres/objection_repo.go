@@ -179,7 +179,7 @@ func (r *objectionRepo) List(ctx context.Context, filter models.ObjectionFilter,
 
 func (r *objectionRepo) Review(ctx context.Context, id uuid.UUID, status string, reviewedByID uuid.UUID, reviewNotes string, adjustedFine *float64) error {
 	now := time.Now()
-	_, err := r.db.Exec(ctx,
+	_, err := conn(ctx, r.db).Exec(ctx,
 		`UPDATE objections SET status = $1, reviewed_at = $2, reviewed_by_id = $3,
 		 review_notes = $4, adjusted_fine = $5, updated_at = $2 WHERE id = $6`,
 		status, now, reviewedByID, reviewNotes, adjustedFine, id)
@@ -326,5 +326,7 @@ func buildObjectionConditions(filter models.ObjectionFilter, search string) ([]s
 		argIdx++
 	}
 
+	conditions, args, argIdx = appendJurisdiction(conditions, args, argIdx, filter.Scope, "o.station_id", "o.region_id")
+
 	return conditions, args, argIdx
 }
@@ -100,6 +100,7 @@ func (r *officerRepo) List(ctx context.Context, filter models.OfficerFilter, sea
 		args = append(args, "%"+search+"%")
 		argIdx++
 	}
+	conditions, args, argIdx = appendJurisdiction(conditions, args, argIdx, filter.Scope, "o.station_id", "o.region_id")
 
 	where := ""
 	if len(conditions) > 0 {
@@ -153,10 +154,10 @@ func (r *officerRepo) Create(ctx context.Context, user *models.User, officer *mo
 
 	// Insert user
 	err = tx.QueryRow(ctx,
-		`INSERT INTO users (email, password_hash, first_name, last_name, phone, role)
-		 VALUES ($1, $2, $3, $4, $5, $6)
+		`INSERT INTO users (email, password_hash, first_name, last_name, phone, role, must_change_password)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7)
 		 RETURNING id, is_active, created_at, updated_at`,
-		user.Email, user.PasswordHash, user.FirstName, user.LastName, user.Phone, user.Role).
+		user.Email, user.PasswordHash, user.FirstName, user.LastName, user.Phone, user.Role, user.MustChangePassword).
 		Scan(&user.ID, &user.IsActive, &user.CreatedAt, &user.UpdatedAt)
 	if err != nil {
 		return err
@@ -10,6 +10,7 @@ import (
 	"github.com/ghana-police/ticketing-backend/internal/ports/repositories"
 	"github.com/ghana-police/ticketing-backend/pkg/pagination"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -22,21 +23,21 @@ func NewPaymentRepo(db *pgxpool.Pool) repositories.PaymentRepository {
 }
 
 func (r *paymentRepo) Create(ctx context.Context, p *models.Payment) error {
-	return r.db.QueryRow(ctx,
+	return conn(ctx, r.db).QueryRow(ctx,
 		`INSERT INTO payments (
 			payment_reference, ticket_id, ticket_number, amount, currency,
 			original_fine, late_fee, discount, method, phone_number,
 			network, status, status_message, payer_name, payer_phone,
 			payer_email, processed_by_id, station_id, expires_at,
-			processed_at, completed_at, receipt_number, transaction_id
+			processed_at, completed_at, receipt_number, transaction_id, shift_id
 		) VALUES (
-			$1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16,$17,$18,$19,$20,$21,$22,$23
+			$1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16,$17,$18,$19,$20,$21,$22,$23,$24
 		) RETURNING id, created_at, updated_at`,
 		p.PaymentReference, p.TicketID, p.TicketNumber, p.Amount, p.Currency,
 		p.OriginalFine, p.LateFee, p.Discount, p.Method, p.PhoneNumber,
 		p.Network, p.Status, p.StatusMessage, p.PayerName, p.PayerPhone,
 		p.PayerEmail, p.ProcessedByID, p.StationID, p.ExpiresAt,
-		p.ProcessedAt, p.CompletedAt, p.ReceiptNumber, p.TransactionID,
+		p.ProcessedAt, p.CompletedAt, p.ReceiptNumber, p.TransactionID, p.ShiftID,
 	).Scan(&p.ID, &p.CreatedAt, &p.UpdatedAt)
 }
 
@@ -45,7 +46,8 @@ var paymentScanCols = `p.id, p.payment_reference, p.ticket_id, p.ticket_number,
 	p.method, p.phone_number, p.network, p.transaction_id,
 	p.status, p.status_message, p.payer_name, p.payer_phone, p.payer_email,
 	p.receipt_number, p.processed_by_id, p.station_id, p.provider_response,
-	p.processed_at, p.completed_at, p.expires_at, p.created_at, p.updated_at`
+	p.processed_at, p.completed_at, p.expires_at, p.created_at, p.updated_at,
+	p.balance_after, p.shift_id`
 
 func scanPayment(scanner interface{ Scan(dest ...any) error }) (*models.Payment, error) {
 	var p models.Payment
@@ -56,17 +58,29 @@ func scanPayment(scanner interface{ Scan(dest ...any) error }) (*models.Payment,
 		&p.Status, &p.StatusMessage, &p.PayerName, &p.PayerPhone, &p.PayerEmail,
 		&p.ReceiptNumber, &p.ProcessedByID, &p.StationID, &p.ProviderResponse,
 		&p.ProcessedAt, &p.CompletedAt, &p.ExpiresAt, &p.CreatedAt, &p.UpdatedAt,
+		&p.BalanceAfter, &p.ShiftID,
 	)
 	return &p, err
 }
 
 func (r *paymentRepo) GetByID(ctx context.Context, id uuid.UUID) (*models.Payment, error) {
-	row := r.db.QueryRow(ctx, `SELECT `+paymentScanCols+` FROM payments p WHERE p.id = $1`, id)
+	row := conn(ctx, r.db).QueryRow(ctx, `SELECT `+paymentScanCols+` FROM payments p WHERE p.id = $1`, id)
+	return scanPayment(row)
+}
+
+func (r *paymentRepo) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*models.Payment, error) {
+	row := conn(ctx, r.db).QueryRow(ctx, `SELECT `+paymentScanCols+` FROM payments p WHERE p.id = $1 FOR UPDATE`, id)
 	return scanPayment(row)
 }
 
 func (r *paymentRepo) GetByReference(ctx context.Context, ref string) (*models.Payment, error) {
-	row := r.db.QueryRow(ctx, `SELECT `+paymentScanCols+` FROM payments p WHERE p.payment_reference = $1`, ref)
+	row := conn(ctx, r.db).QueryRow(ctx, `SELECT `+paymentScanCols+` FROM payments p WHERE p.payment_reference = $1`, ref)
+	return scanPayment(row)
+}
+
+func (r *paymentRepo) GetByTransactionID(ctx context.Context, transactionID string) (*models.Payment, error) {
+	row := conn(ctx, r.db).QueryRow(ctx, `SELECT `+paymentScanCols+` FROM payments p WHERE p.transaction_id = $1
+		ORDER BY p.created_at DESC LIMIT 1`, transactionID)
 	return scanPayment(row)
 }
 
@@ -91,7 +105,7 @@ func (r *paymentRepo) List(ctx context.Context, filter models.PaymentFilter, sea
 	}
 
 	var total int
-	if err := r.db.QueryRow(ctx, "SELECT COUNT(*) FROM payments p"+where, args...).Scan(&total); err != nil {
+	if err := conn(ctx, r.db).QueryRow(ctx, "SELECT COUNT(*) FROM payments p"+where, args...).Scan(&total); err != nil {
 		return nil, 0, err
 	}
 
@@ -104,7 +118,7 @@ func (r *paymentRepo) List(ctx context.Context, filter models.PaymentFilter, sea
 		paymentScanCols, where, orderCol, p.SortOrder, argIdx, argIdx+1)
 	args = append(args, p.Limit, p.Offset())
 
-	rows, err := r.db.Query(ctx, query, args...)
+	rows, err := conn(ctx, r.db).Query(ctx, query, args...)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -126,7 +140,7 @@ func (r *paymentRepo) List(ctx context.Context, filter models.PaymentFilter, sea
 // ---------------------------------------------------------------------------
 
 func (r *paymentRepo) UpdateStatus(ctx context.Context, id uuid.UUID, status string, transactionID, statusMessage, providerResponse *string) error {
-	_, err := r.db.Exec(ctx,
+	_, err := conn(ctx, r.db).Exec(ctx,
 		`UPDATE payments SET status = $1, transaction_id = COALESCE($2, transaction_id),
 		 status_message = COALESCE($3, status_message),
 		 provider_response = COALESCE($4::jsonb, provider_response),
@@ -135,35 +149,91 @@ func (r *paymentRepo) UpdateStatus(ctx context.Context, id uuid.UUID, status str
 	return err
 }
 
-func (r *paymentRepo) Complete(ctx context.Context, id uuid.UUID, transactionID *string, receiptNumber string) error {
-	tx, err := r.db.Begin(ctx)
+func (r *paymentRepo) SetProviderRef(ctx context.Context, id uuid.UUID, transactionID string, network *string, status string) error {
+	tag, err := conn(ctx, r.db).Exec(ctx,
+		`UPDATE payments SET transaction_id = $1, network = COALESCE($2, network), status = $3, updated_at = NOW()
+		 WHERE id = $4 AND status = 'pending' AND transaction_id IS NULL`,
+		transactionID, network, status, id)
 	if err != nil {
 		return err
 	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+func (r *paymentRepo) Complete(ctx context.Context, id uuid.UUID, transactionID *string, receiptNumber string) (float64, error) {
+	tx, err := conn(ctx, r.db).Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
 	defer tx.Rollback(ctx)
 
 	now := time.Now()
-	_, err = tx.Exec(ctx,
+	var ticketID uuid.UUID
+	var amount float64
+	var method string
+	err = tx.QueryRow(ctx,
 		`UPDATE payments SET status = 'completed', transaction_id = COALESCE($1, transaction_id),
-		 receipt_number = $2, completed_at = $3, updated_at = $3 WHERE id = $4`,
-		transactionID, receiptNumber, now, id)
+		 receipt_number = $2, completed_at = $3, updated_at = $3 WHERE id = $4
+		 RETURNING ticket_id, amount, method`,
+		transactionID, receiptNumber, now, id).Scan(&ticketID, &amount, &method)
+	if err != nil {
+		return 0, err
+	}
+
+	// Add the payment to what the ticket has been paid so far
+	var balance float64
+	err = tx.QueryRow(ctx,
+		`UPDATE tickets SET paid_amount = COALESCE(paid_amount, 0) + $1, updated_at = $2
+		 WHERE id = $3 RETURNING GREATEST(total_fine - paid_amount, 0)`,
+		amount, now, ticketID).Scan(&balance)
+	if err != nil {
+		return 0, err
+	}
+
+	// The ticket is paid once nothing is outstanding
+	if balance < 0.005 {
+		balance = 0
+		_, err = tx.Exec(ctx,
+			`UPDATE tickets SET status = 'paid', paid_at = $1, paid_method = $2, updated_at = $1 WHERE id = $3`,
+			now, method, ticketID)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	_, err = tx.Exec(ctx, `UPDATE payments SET balance_after = $1 WHERE id = $2`, balance, id)
+	if err != nil {
+		return 0, err
+	}
+
+	return balance, tx.Commit(ctx)
+}
+
+func (r *paymentRepo) Refund(ctx context.Context, id uuid.UUID, reason string, withdrawn float64) error {
+	tx, err := conn(ctx, r.db).Begin(ctx)
 	if err != nil {
 		return err
 	}
+	defer tx.Rollback(ctx)
 
-	// Update ticket status to paid
+	now := time.Now()
 	var ticketID uuid.UUID
 	var amount float64
-	var method string
-	err = tx.QueryRow(ctx, `SELECT ticket_id, amount, method FROM payments WHERE id = $1`, id).Scan(&ticketID, &amount, &method)
+	err = tx.QueryRow(ctx,
+		`UPDATE payments SET status = 'refunded', status_message = $1, updated_at = $2
+		 WHERE id = $3 AND status = 'completed' RETURNING ticket_id, amount`,
+		reason, now, id).Scan(&ticketID, &amount)
 	if err != nil {
 		return err
 	}
 
 	_, err = tx.Exec(ctx,
-		`UPDATE tickets SET status = 'paid', paid_at = $1, paid_amount = $2,
-		 paid_method = $3, updated_at = $1 WHERE id = $4`,
-		now, amount, method, ticketID)
+		`UPDATE tickets SET paid_amount = GREATEST(COALESCE(paid_amount, 0) - $1, 0),
+		 total_fine = GREATEST(total_fine - $2, 0), updated_at = $3 WHERE id = $4`,
+		amount, withdrawn, now, ticketID)
 	if err != nil {
 		return err
 	}
@@ -176,7 +246,7 @@ func (r *paymentRepo) Complete(ctx context.Context, id uuid.UUID, transactionID
 // ---------------------------------------------------------------------------
 
 func (r *paymentRepo) GetStats(ctx context.Context, filter models.PaymentFilter) (*models.PaymentStats, error) {
-	conditions, args, _ := buildPaymentConditions(filter, "")
+	conditions, args, argIdx := buildPaymentConditions(filter, "")
 	where := ""
 	if len(conditions) > 0 {
 		where = " WHERE " + strings.Join(conditions, " AND ")
@@ -188,7 +258,7 @@ func (r *paymentRepo) GetStats(ctx context.Context, filter models.PaymentFilter)
 	}
 
 	// Totals
-	err := r.db.QueryRow(ctx, fmt.Sprintf(
+	err := conn(ctx, r.db).QueryRow(ctx, fmt.Sprintf(
 		`SELECT COUNT(*), COALESCE(SUM(CASE WHEN status = 'completed' THEN amount ELSE 0 END), 0)
 		 FROM payments p%s`, where), args...).Scan(&stats.TotalPayments, &stats.TotalAmount)
 	if err != nil {
@@ -196,7 +266,7 @@ func (r *paymentRepo) GetStats(ctx context.Context, filter models.PaymentFilter)
 	}
 
 	// By status
-	rows, err := r.db.Query(ctx, fmt.Sprintf(
+	rows, err := conn(ctx, r.db).Query(ctx, fmt.Sprintf(
 		`SELECT status, COUNT(*) FROM payments p%s GROUP BY status`, where), args...)
 	if err != nil {
 		return nil, err
@@ -212,7 +282,7 @@ func (r *paymentRepo) GetStats(ctx context.Context, filter models.PaymentFilter)
 	}
 
 	// By method (completed only)
-	rows2, err := r.db.Query(ctx, fmt.Sprintf(
+	rows2, err := conn(ctx, r.db).Query(ctx, fmt.Sprintf(
 		`SELECT method, COUNT(*), COALESCE(SUM(amount), 0)
 		 FROM payments p%s AND status = 'completed' GROUP BY method`,
 		func() string {
@@ -240,13 +310,14 @@ func (r *paymentRepo) GetStats(ctx context.Context, filter models.PaymentFilter)
 	weekStart := todayStart.AddDate(0, 0, -int(now.Weekday()))
 	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
 
-	r.db.QueryRow(ctx,
+	periodConditions := append([]string{"status = 'completed'"}, conditions...)
+	conn(ctx, r.db).QueryRow(ctx, fmt.Sprintf(
 		`SELECT
-			COALESCE(SUM(CASE WHEN completed_at >= $1 THEN amount ELSE 0 END), 0),
-			COALESCE(SUM(CASE WHEN completed_at >= $2 THEN amount ELSE 0 END), 0),
-			COALESCE(SUM(CASE WHEN completed_at >= $3 THEN amount ELSE 0 END), 0)
-		 FROM payments WHERE status = 'completed'`,
-		todayStart, weekStart, monthStart,
+			COALESCE(SUM(CASE WHEN completed_at >= $%d THEN amount ELSE 0 END), 0),
+			COALESCE(SUM(CASE WHEN completed_at >= $%d THEN amount ELSE 0 END), 0),
+			COALESCE(SUM(CASE WHEN completed_at >= $%d THEN amount ELSE 0 END), 0)
+		 FROM payments p WHERE %s`, argIdx, argIdx+1, argIdx+2, strings.Join(periodConditions, " AND ")),
+		append(args, todayStart, weekStart, monthStart)...,
 	).Scan(&stats.TodayAmount, &stats.WeekAmount, &stats.MonthAmount)
 
 	return stats, nil
@@ -256,17 +327,62 @@ func (r *paymentRepo) GetStats(ctx context.Context, filter models.PaymentFilter)
 // Helpers
 // ---------------------------------------------------------------------------
 
-func (r *paymentRepo) HasPendingOrCompleted(ctx context.Context, ticketID uuid.UUID) (bool, error) {
+func (r *paymentRepo) CountByTicket(ctx context.Context, ticketID uuid.UUID) (int, error) {
+	var n int
+	err := conn(ctx, r.db).QueryRow(ctx, `SELECT COUNT(*) FROM payments WHERE ticket_id = $1`, ticketID).Scan(&n)
+	return n, err
+}
+
+func (r *paymentRepo) HasPending(ctx context.Context, ticketID uuid.UUID) (bool, error) {
 	var exists bool
-	err := r.db.QueryRow(ctx,
-		`SELECT EXISTS(SELECT 1 FROM payments WHERE ticket_id = $1 AND status IN ('pending', 'processing', 'completed'))`,
+	err := conn(ctx, r.db).QueryRow(ctx,
+		`SELECT EXISTS(SELECT 1 FROM payments WHERE ticket_id = $1 AND status IN ('pending', 'processing'))`,
 		ticketID).Scan(&exists)
 	return exists, err
 }
 
+func (r *paymentRepo) ClaimForPoll(ctx context.Context, limit int, backoffBase, backoffMax time.Duration) ([]models.Payment, error) {
+	// A payment is first due one backoff step after it was created
+	rows, err := conn(ctx, r.db).Query(ctx,
+		`UPDATE payments p SET
+			poll_attempts = p.poll_attempts + 1,
+			last_polled_at = NOW(),
+			next_poll_at = NOW() + LEAST($2::float8 * power(2, LEAST(p.poll_attempts, 20)), $3::float8) * INTERVAL '1 second'
+		WHERE p.id IN (
+			SELECT id FROM payments
+			WHERE status IN ('pending', 'processing')
+			  AND COALESCE(next_poll_at, created_at + $2::float8 * INTERVAL '1 second') <= NOW()
+			ORDER BY COALESCE(next_poll_at, created_at)
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING `+paymentScanCols,
+		limit, backoffBase.Seconds(), backoffMax.Seconds())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var payments []models.Payment
+	for rows.Next() {
+		p, err := scanPayment(rows)
+		if err != nil {
+			return nil, err
+		}
+		payments = append(payments, *p)
+	}
+	return payments, rows.Err()
+}
+
+func (r *paymentRepo) CountPending(ctx context.Context) (int, error) {
+	var n int
+	err := conn(ctx, r.db).QueryRow(ctx, `SELECT COUNT(*) FROM payments WHERE status IN ('pending', 'processing')`).Scan(&n)
+	return n, err
+}
+
 func (r *paymentRepo) NextReceiptNumber(ctx context.Context) (string, error) {
 	var seq int64
-	err := r.db.QueryRow(ctx, "SELECT nextval('receipt_number_seq')").Scan(&seq)
+	err := conn(ctx, r.db).QueryRow(ctx, "SELECT nextval('receipt_number_seq')").Scan(&seq)
 	if err != nil {
 		return "", err
 	}
@@ -275,9 +391,10 @@ func (r *paymentRepo) NextReceiptNumber(ctx context.Context) (string, error) {
 
 func (r *paymentRepo) GetReceipt(ctx context.Context, id uuid.UUID) (*models.PaymentReceipt, error) {
 	var receipt models.PaymentReceipt
-	err := r.db.QueryRow(ctx,
+	err := conn(ctx, r.db).QueryRow(ctx,
 		`SELECT p.receipt_number, p.ticket_number, t.vehicle_reg_number,
 		        p.payer_name, p.amount, p.method, p.transaction_id, p.completed_at,
+		        t.total_fine, COALESCE(p.balance_after, 0),
 		        COALESCE(u.first_name || ' ' || u.last_name, ''),
 		        COALESCE(s.name, '')
 		 FROM payments p
@@ -288,11 +405,13 @@ func (r *paymentRepo) GetReceipt(ctx context.Context, id uuid.UUID) (*models.Pay
 	).Scan(
 		&receipt.ReceiptNumber, &receipt.TicketNumber, &receipt.VehicleReg,
 		&receipt.PayerName, &receipt.Amount, &receipt.Method, &receipt.TransactionID,
-		&receipt.PaidAt, &receipt.ProcessedBy, &receipt.StationName,
+		&receipt.PaidAt, &receipt.TotalFine, &receipt.BalanceAfter,
+		&receipt.ProcessedBy, &receipt.StationName,
 	)
 	if err != nil {
 		return nil, err
 	}
+	receipt.BalanceBefore = receipt.BalanceAfter + receipt.Amount
 	return &receipt, nil
 }
 
@@ -345,6 +464,11 @@ func buildPaymentConditions(filter models.PaymentFilter, search string) ([]strin
 		args = append(args, *filter.MaxAmount)
 		argIdx++
 	}
+	if filter.TicketID != nil {
+		conditions = append(conditions, fmt.Sprintf("p.ticket_id = $%d", argIdx))
+		args = append(args, *filter.TicketID)
+		argIdx++
+	}
 	if filter.StationID != nil {
 		conditions = append(conditions, fmt.Sprintf("p.station_id = $%d", argIdx))
 		args = append(args, *filter.StationID)
@@ -363,5 +487,12 @@ func buildPaymentConditions(filter models.PaymentFilter, search string) ([]strin
 		argIdx++
 	}
 
+	if filter.Scope != nil && !filter.Scope.Unrestricted() {
+		cond, scopeArgs, next := jurisdictionCondition(*filter.Scope, "t.station_id", "t.region_id", argIdx)
+		conditions = append(conditions, "p.ticket_id IN (SELECT t.id FROM tickets t WHERE "+cond+")")
+		args = append(args, scopeArgs...)
+		argIdx = next
+	}
+
 	return conditions, args, argIdx
 }
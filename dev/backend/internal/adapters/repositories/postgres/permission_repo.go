@@ -0,0 +1,131 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/ghana-police/ticketing-backend/internal/domain/models"
+	"github.com/ghana-police/ticketing-backend/internal/ports/repositories"
+)
+
+type permissionRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewPermissionRepo(db *pgxpool.Pool) repositories.PermissionRepository {
+	return &permissionRepo{db: db}
+}
+
+func (r *permissionRepo) ListPermissions(ctx context.Context) ([]models.Permission, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT key, category, description
+		FROM permissions
+		ORDER BY category, key`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var perms []models.Permission
+	for rows.Next() {
+		var p models.Permission
+		if err := rows.Scan(&p.Key, &p.Category, &p.Description); err != nil {
+			return nil, err
+		}
+		perms = append(perms, p)
+	}
+	return perms, rows.Err()
+}
+
+func (r *permissionRepo) ListRolePermissions(ctx context.Context) (map[string][]string, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT role, permission
+		FROM role_permissions
+		ORDER BY role, permission`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	byRole := make(map[string][]string)
+	for rows.Next() {
+		var role, perm string
+		if err := rows.Scan(&role, &perm); err != nil {
+			return nil, err
+		}
+		byRole[role] = append(byRole[role], perm)
+	}
+	return byRole, rows.Err()
+}
+
+func (r *permissionRepo) ReplaceRolePermissions(ctx context.Context, role string, permissions []string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `DELETE FROM role_permissions WHERE role = $1`, role); err != nil {
+		return err
+	}
+	if len(permissions) > 0 {
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO role_permissions (role, permission)
+			SELECT $1, unnest($2::text[])`, role, permissions); err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+func (r *permissionRepo) ListUserOverrides(ctx context.Context, userID uuid.UUID) ([]models.UserPermission, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT user_id, permission, effect, reason, granted_by, created_at
+		FROM user_permissions
+		WHERE user_id = $1
+		ORDER BY permission`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	overrides := []models.UserPermission{}
+	for rows.Next() {
+		var p models.UserPermission
+		if err := rows.Scan(&p.UserID, &p.Permission, &p.Effect, &p.Reason, &p.GrantedBy, &p.CreatedAt); err != nil {
+			return nil, err
+		}
+		overrides = append(overrides, p)
+	}
+	return overrides, rows.Err()
+}
+
+func (r *permissionRepo) SetUserOverride(ctx context.Context, p *models.UserPermission) error {
+	return r.db.QueryRow(ctx, `
+		INSERT INTO user_permissions (user_id, permission, effect, reason, granted_by)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (user_id, permission) DO UPDATE
+		SET effect = EXCLUDED.effect,
+		    reason = EXCLUDED.reason,
+		    granted_by = EXCLUDED.granted_by,
+		    created_at = NOW()
+		RETURNING created_at`,
+		p.UserID, p.Permission, p.Effect, p.Reason, p.GrantedBy,
+	).Scan(&p.CreatedAt)
+}
+
+func (r *permissionRepo) DeleteUserOverride(ctx context.Context, userID uuid.UUID, permission string) error {
+	tag, err := r.db.Exec(ctx, `
+		DELETE FROM user_permissions
+		WHERE user_id = $1 AND permission = $2`, userID, permission)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
@@ -0,0 +1,196 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/ghana-police/ticketing-backend/internal/domain/models"
+	"github.com/ghana-police/ticketing-backend/internal/ports/repositories"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type serviceAccountRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewServiceAccountRepo(db *pgxpool.Pool) repositories.ServiceAccountRepository {
+	return &serviceAccountRepo{db: db}
+}
+
+// ---------------------------------------------------------------------------
+// Service accounts
+// ---------------------------------------------------------------------------
+
+func (r *serviceAccountRepo) Create(ctx context.Context, sa *models.ServiceAccount, email, passwordHash string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := tx.QueryRow(ctx,
+		`INSERT INTO users (email, password_hash, first_name, last_name, role, is_service_account)
+		 VALUES ($1, $2, $3, '', $4, true)
+		 RETURNING id, is_active`,
+		email, passwordHash, sa.Name, sa.Role,
+	).Scan(&sa.ID, &sa.IsActive); err != nil {
+		return err
+	}
+
+	if err := tx.QueryRow(ctx,
+		`INSERT INTO service_accounts (user_id, description, station_id, region_id, created_by)
+		 VALUES ($1, $2, $3, $4, $5)
+		 RETURNING created_at, updated_at`,
+		sa.ID, sa.Description, sa.StationID, sa.RegionID, sa.CreatedBy,
+	).Scan(&sa.CreatedAt, &sa.UpdatedAt); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+const serviceAccountCols = `u.id, u.first_name, sa.description, u.role, sa.station_id, sa.region_id, u.is_active,
+	(SELECT COUNT(*) FROM api_keys k WHERE k.service_account_id = sa.user_id
+		AND k.revoked_at IS NULL AND (k.expires_at IS NULL OR k.expires_at > NOW())),
+	sa.created_by, sa.created_at, sa.updated_at`
+
+const serviceAccountJoins = ` FROM service_accounts sa JOIN users u ON u.id = sa.user_id`
+
+func scanServiceAccount(scanner interface{ Scan(dest ...any) error }) (*models.ServiceAccount, error) {
+	var sa models.ServiceAccount
+	err := scanner.Scan(
+		&sa.ID, &sa.Name, &sa.Description, &sa.Role, &sa.StationID, &sa.RegionID, &sa.IsActive,
+		&sa.ActiveKeys, &sa.CreatedBy, &sa.CreatedAt, &sa.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &sa, nil
+}
+
+func (r *serviceAccountRepo) GetByID(ctx context.Context, id uuid.UUID) (*models.ServiceAccount, error) {
+	row := r.db.QueryRow(ctx, "SELECT "+serviceAccountCols+serviceAccountJoins+" WHERE sa.user_id = $1", id)
+	return scanServiceAccount(row)
+}
+
+func (r *serviceAccountRepo) List(ctx context.Context) ([]models.ServiceAccount, error) {
+	rows, err := r.db.Query(ctx, "SELECT "+serviceAccountCols+serviceAccountJoins+" ORDER BY sa.created_at DESC")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []models.ServiceAccount
+	for rows.Next() {
+		sa, err := scanServiceAccount(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, *sa)
+	}
+	return items, rows.Err()
+}
+
+func (r *serviceAccountRepo) Update(ctx context.Context, sa *models.ServiceAccount) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx,
+		"UPDATE users SET first_name = $2, is_active = $3, updated_at = NOW() WHERE id = $1",
+		sa.ID, sa.Name, sa.IsActive); err != nil {
+		return err
+	}
+	if err := tx.QueryRow(ctx,
+		"UPDATE service_accounts SET description = $2, updated_at = NOW() WHERE user_id = $1 RETURNING updated_at",
+		sa.ID, sa.Description,
+	).Scan(&sa.UpdatedAt); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// ---------------------------------------------------------------------------
+// API keys
+// ---------------------------------------------------------------------------
+
+func (r *serviceAccountRepo) CreateKey(ctx context.Context, k *models.APIKey) error {
+	return r.db.QueryRow(ctx,
+		`INSERT INTO api_keys (service_account_id, name, prefix, key_hash, scopes, allowed_ips, expires_at, created_by)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		 RETURNING id, created_at`,
+		k.ServiceAccountID, k.Name, k.Prefix, k.KeyHash, k.Scopes, k.AllowedIPs, k.ExpiresAt, k.CreatedBy,
+	).Scan(&k.ID, &k.CreatedAt)
+}
+
+const apiKeyCols = `k.id, k.service_account_id, k.name, k.prefix, k.key_hash, k.scopes, k.allowed_ips,
+	k.expires_at, k.last_used_at, k.last_used_ip, k.revoked_at, k.created_by, k.created_at`
+
+func scanAPIKey(k *models.APIKey) []any {
+	return []any{
+		&k.ID, &k.ServiceAccountID, &k.Name, &k.Prefix, &k.KeyHash, &k.Scopes, &k.AllowedIPs,
+		&k.ExpiresAt, &k.LastUsedAt, &k.LastUsedIP, &k.RevokedAt, &k.CreatedBy, &k.CreatedAt,
+	}
+}
+
+func (r *serviceAccountRepo) ListKeys(ctx context.Context, serviceAccountID uuid.UUID) ([]models.APIKey, error) {
+	rows, err := r.db.Query(ctx,
+		"SELECT "+apiKeyCols+" FROM api_keys k WHERE k.service_account_id = $1 ORDER BY k.created_at DESC",
+		serviceAccountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []models.APIKey
+	for rows.Next() {
+		var k models.APIKey
+		if err := rows.Scan(scanAPIKey(&k)...); err != nil {
+			return nil, err
+		}
+		items = append(items, k)
+	}
+	return items, rows.Err()
+}
+
+func (r *serviceAccountRepo) FindKey(ctx context.Context, prefix string) (*models.APIKey, *models.ServiceAccount, error) {
+	var k models.APIKey
+	var sa models.ServiceAccount
+	dest := append(scanAPIKey(&k),
+		&sa.ID, &sa.Name, &sa.Role, &sa.StationID, &sa.RegionID, &sa.IsActive)
+	err := r.db.QueryRow(ctx,
+		`SELECT `+apiKeyCols+`, u.id, u.first_name, u.role, sa.station_id, sa.region_id, u.is_active
+		 FROM api_keys k
+		 JOIN service_accounts sa ON sa.user_id = k.service_account_id
+		 JOIN users u ON u.id = sa.user_id
+		 WHERE k.prefix = $1`, prefix,
+	).Scan(dest...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &k, &sa, nil
+}
+
+func (r *serviceAccountRepo) RevokeKey(ctx context.Context, serviceAccountID, keyID uuid.UUID) error {
+	tag, err := r.db.Exec(ctx,
+		"UPDATE api_keys SET revoked_at = NOW() WHERE id = $1 AND service_account_id = $2 AND revoked_at IS NULL",
+		keyID, serviceAccountID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+func (r *serviceAccountRepo) TouchKey(ctx context.Context, keyID uuid.UUID, ip string) error {
+	_, err := r.db.Exec(ctx,
+		`UPDATE api_keys SET last_used_at = NOW(), last_used_ip = NULLIF($2, '')
+		 WHERE id = $1 AND (last_used_at IS NULL OR last_used_at < NOW() - INTERVAL '1 minute')`,
+		keyID, ip)
+	return err
+}
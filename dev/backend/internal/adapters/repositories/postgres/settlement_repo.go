@@ -0,0 +1,312 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/ghana-police/ticketing-backend/internal/domain/models"
+	"github.com/ghana-police/ticketing-backend/internal/ports/repositories"
+	"github.com/ghana-police/ticketing-backend/pkg/pagination"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type settlementRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewSettlementRepo(db *pgxpool.Pool) repositories.SettlementRepository {
+	return &settlementRepo{db: db}
+}
+
+// ---------------------------------------------------------------------------
+// Imports
+// ---------------------------------------------------------------------------
+
+var settlementImportCols = `id, provider, file_name, checksum, line_count, matched_count,
+	unmatched_count, mismatch_count, duplicate_count, total_amount, imported_by_id, created_at`
+
+func scanSettlementImport(scanner interface{ Scan(dest ...any) error }) (*models.SettlementImport, error) {
+	var imp models.SettlementImport
+	err := scanner.Scan(&imp.ID, &imp.Provider, &imp.FileName, &imp.Checksum, &imp.LineCount, &imp.MatchedCount,
+		&imp.UnmatchedCount, &imp.MismatchCount, &imp.DuplicateCount, &imp.TotalAmount, &imp.ImportedByID, &imp.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &imp, nil
+}
+
+func (r *settlementRepo) CreateImport(ctx context.Context, imp *models.SettlementImport, lines []models.SettlementLine) error {
+	tx, err := conn(ctx, r.db).Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	err = tx.QueryRow(ctx,
+		`INSERT INTO settlement_imports (
+			provider, file_name, checksum, line_count, matched_count,
+			unmatched_count, mismatch_count, duplicate_count, total_amount, imported_by_id
+		) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10) RETURNING id, created_at`,
+		imp.Provider, imp.FileName, imp.Checksum, imp.LineCount, imp.MatchedCount,
+		imp.UnmatchedCount, imp.MismatchCount, imp.DuplicateCount, imp.TotalAmount, imp.ImportedByID,
+	).Scan(&imp.ID, &imp.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	for i := range lines {
+		l := &lines[i]
+		l.ImportID = imp.ID
+		err = tx.QueryRow(ctx,
+			`INSERT INTO settlement_lines (
+				import_id, provider, line_number, transaction_id, reference, amount, fee,
+				currency, settled_at, payment_id, payment_amount, match_status, review_status
+			) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13) RETURNING id, created_at`,
+			l.ImportID, l.Provider, l.LineNumber, l.TransactionID, l.Reference, l.Amount, l.Fee,
+			l.Currency, l.SettledAt, l.PaymentID, l.PaymentAmount, l.MatchStatus, l.ReviewStatus,
+		).Scan(&l.ID, &l.CreatedAt)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *settlementRepo) GetImportByChecksum(ctx context.Context, provider, checksum string) (*models.SettlementImport, error) {
+	row := conn(ctx, r.db).QueryRow(ctx, `SELECT `+settlementImportCols+` FROM settlement_imports
+		WHERE provider = $1 AND checksum = $2`, provider, checksum)
+	return scanSettlementImport(row)
+}
+
+func (r *settlementRepo) GetImport(ctx context.Context, id uuid.UUID) (*models.SettlementImport, error) {
+	row := conn(ctx, r.db).QueryRow(ctx, `SELECT `+settlementImportCols+` FROM settlement_imports WHERE id = $1`, id)
+	return scanSettlementImport(row)
+}
+
+func (r *settlementRepo) ListImports(ctx context.Context, provider *string, p pagination.Params) ([]models.SettlementImport, int, error) {
+	var total int
+	if err := conn(ctx, r.db).QueryRow(ctx,
+		`SELECT COUNT(*) FROM settlement_imports WHERE ($1::text IS NULL OR provider = $1)`, provider,
+	).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := conn(ctx, r.db).Query(ctx,
+		`SELECT `+settlementImportCols+` FROM settlement_imports WHERE ($1::text IS NULL OR provider = $1)
+		 ORDER BY created_at DESC LIMIT $2 OFFSET $3`, provider, p.Limit, p.Offset())
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var items []models.SettlementImport
+	for rows.Next() {
+		imp, err := scanSettlementImport(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		items = append(items, *imp)
+	}
+	return items, total, rows.Err()
+}
+
+// ---------------------------------------------------------------------------
+// Lines
+// ---------------------------------------------------------------------------
+
+func (r *settlementRepo) IsPaymentSettled(ctx context.Context, paymentID uuid.UUID) (bool, error) {
+	var exists bool
+	err := conn(ctx, r.db).QueryRow(ctx,
+		`SELECT EXISTS(SELECT 1 FROM settlement_lines
+		 WHERE payment_id = $1 AND match_status IN ('matched', 'amount_mismatch'))`, paymentID).Scan(&exists)
+	return exists, err
+}
+
+var settlementLineCols = `s.id, s.import_id, s.provider, s.line_number, s.transaction_id, s.reference,
+	s.amount, s.fee, s.currency, s.settled_at, s.payment_id, p.payment_reference, s.payment_amount,
+	s.match_status, s.review_status, s.resolution, s.resolved_by_id, s.resolved_at, s.created_at`
+
+const settlementLineFrom = ` FROM settlement_lines s LEFT JOIN payments p ON p.id = s.payment_id`
+
+func scanSettlementLine(scanner interface{ Scan(dest ...any) error }) (*models.SettlementLine, error) {
+	var l models.SettlementLine
+	err := scanner.Scan(&l.ID, &l.ImportID, &l.Provider, &l.LineNumber, &l.TransactionID, &l.Reference,
+		&l.Amount, &l.Fee, &l.Currency, &l.SettledAt, &l.PaymentID, &l.PaymentReference, &l.PaymentAmount,
+		&l.MatchStatus, &l.ReviewStatus, &l.Resolution, &l.ResolvedByID, &l.ResolvedAt, &l.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+func (r *settlementRepo) GetLine(ctx context.Context, id uuid.UUID) (*models.SettlementLine, error) {
+	row := conn(ctx, r.db).QueryRow(ctx, `SELECT `+settlementLineCols+settlementLineFrom+` WHERE s.id = $1`, id)
+	return scanSettlementLine(row)
+}
+
+var settlementLineSortColumns = map[string]string{
+	"settledAt":  "s.settled_at",
+	"amount":     "s.amount",
+	"lineNumber": "s.line_number",
+	"createdAt":  "s.created_at",
+}
+
+func (r *settlementRepo) ListLines(ctx context.Context, filter models.SettlementLineFilter, p pagination.Params) ([]models.SettlementLine, int, error) {
+	var conditions []string
+	var args []any
+	argIdx := 1
+
+	if filter.Provider != nil {
+		conditions = append(conditions, fmt.Sprintf("s.provider = $%d", argIdx))
+		args = append(args, *filter.Provider)
+		argIdx++
+	}
+	if filter.ImportID != nil {
+		conditions = append(conditions, fmt.Sprintf("s.import_id = $%d", argIdx))
+		args = append(args, *filter.ImportID)
+		argIdx++
+	}
+	if filter.MatchStatus != nil {
+		conditions = append(conditions, fmt.Sprintf("s.match_status = ANY($%d)", argIdx))
+		args = append(args, strings.Split(*filter.MatchStatus, ","))
+		argIdx++
+	}
+	if filter.ReviewStatus != nil {
+		conditions = append(conditions, fmt.Sprintf("s.review_status = $%d", argIdx))
+		args = append(args, *filter.ReviewStatus)
+		argIdx++
+	}
+	if filter.DateFrom != nil {
+		conditions = append(conditions, fmt.Sprintf("s.settled_at >= $%d", argIdx))
+		args = append(args, *filter.DateFrom)
+		argIdx++
+	}
+	if filter.DateTo != nil {
+		conditions = append(conditions, fmt.Sprintf("s.settled_at <= $%d", argIdx))
+		args = append(args, *filter.DateTo)
+		argIdx++
+	}
+	if p.Search != "" {
+		conditions = append(conditions, fmt.Sprintf("(s.transaction_id ILIKE $%d OR s.reference ILIKE $%d OR p.payment_reference ILIKE $%d)", argIdx, argIdx, argIdx))
+		args = append(args, "%"+p.Search+"%")
+		argIdx++
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var total int
+	if err := conn(ctx, r.db).QueryRow(ctx, "SELECT COUNT(*)"+settlementLineFrom+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	orderCol := settlementLineSortColumns[p.SortBy]
+	if orderCol == "" {
+		orderCol = "s.settled_at"
+	}
+
+	query := fmt.Sprintf("SELECT %s%s%s ORDER BY %s %s, s.line_number LIMIT $%d OFFSET $%d",
+		settlementLineCols, settlementLineFrom, where, orderCol, p.SortOrder, argIdx, argIdx+1)
+	args = append(args, p.Limit, p.Offset())
+
+	rows, err := conn(ctx, r.db).Query(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var items []models.SettlementLine
+	for rows.Next() {
+		line, err := scanSettlementLine(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		items = append(items, *line)
+	}
+	return items, total, rows.Err()
+}
+
+func (r *settlementRepo) ResolveLine(ctx context.Context, id, resolvedBy uuid.UUID, resolution string) error {
+	var lineID uuid.UUID
+	return conn(ctx, r.db).QueryRow(ctx,
+		`UPDATE settlement_lines SET review_status = 'resolved', resolution = $1,
+		 resolved_by_id = $2, resolved_at = NOW()
+		 WHERE id = $3 AND review_status = 'open' RETURNING id`,
+		resolution, resolvedBy, id).Scan(&lineID)
+}
+
+// ---------------------------------------------------------------------------
+// Report
+// ---------------------------------------------------------------------------
+
+func (r *settlementRepo) DailyReport(ctx context.Context, provider string, methods []string, from, to time.Time) ([]models.ReconciliationDay, error) {
+	// Days are UTC days, which is Ghana time
+	rows, err := conn(ctx, r.db).Query(ctx,
+		`WITH days AS (
+			SELECT generate_series($2::date, $3::date, INTERVAL '1 day')::date AS day
+		), pay AS (
+			SELECT (p.completed_at AT TIME ZONE 'UTC')::date AS day,
+				COUNT(*) AS n, SUM(p.amount) AS amount,
+				COUNT(*) FILTER (WHERE NOT EXISTS (
+					SELECT 1 FROM settlement_lines s WHERE s.payment_id = p.id
+					AND s.match_status IN ('matched', 'amount_mismatch'))) AS unsettled_n,
+				COALESCE(SUM(p.amount) FILTER (WHERE NOT EXISTS (
+					SELECT 1 FROM settlement_lines s WHERE s.payment_id = p.id
+					AND s.match_status IN ('matched', 'amount_mismatch'))), 0) AS unsettled_amount
+			FROM payments p
+			WHERE p.status = 'completed' AND p.method = ANY($4)
+			  AND p.completed_at >= $2::date AT TIME ZONE 'UTC'
+			  AND p.completed_at < ($3::date + 1) AT TIME ZONE 'UTC'
+			GROUP BY 1
+		), settled AS (
+			SELECT (s.settled_at AT TIME ZONE 'UTC')::date AS day,
+				COUNT(*) AS n, SUM(s.amount) AS amount, SUM(s.fee) AS fee,
+				COUNT(*) FILTER (WHERE s.match_status = 'matched') AS matched,
+				COUNT(*) FILTER (WHERE s.match_status = 'unmatched') AS unmatched,
+				COUNT(*) FILTER (WHERE s.match_status = 'amount_mismatch') AS mismatch,
+				COUNT(*) FILTER (WHERE s.match_status = 'duplicate') AS duplicate,
+				COUNT(*) FILTER (WHERE s.review_status = 'open') AS open
+			FROM settlement_lines s
+			WHERE s.provider = $1
+			  AND s.settled_at >= $2::date AT TIME ZONE 'UTC'
+			  AND s.settled_at < ($3::date + 1) AT TIME ZONE 'UTC'
+			GROUP BY 1
+		)
+		SELECT to_char(d.day, 'YYYY-MM-DD'),
+			COALESCE(pay.n, 0), COALESCE(pay.amount, 0),
+			COALESCE(settled.n, 0), COALESCE(settled.amount, 0), COALESCE(settled.fee, 0),
+			COALESCE(settled.matched, 0), COALESCE(settled.unmatched, 0),
+			COALESCE(settled.mismatch, 0), COALESCE(settled.duplicate, 0),
+			COALESCE(pay.unsettled_n, 0), COALESCE(pay.unsettled_amount, 0),
+			COALESCE(settled.open, 0)
+		FROM days d
+		LEFT JOIN pay ON pay.day = d.day
+		LEFT JOIN settled ON settled.day = d.day
+		ORDER BY d.day`,
+		provider, from, to, methods)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var days []models.ReconciliationDay
+	for rows.Next() {
+		d := models.ReconciliationDay{Provider: provider}
+		if err := rows.Scan(&d.Date, &d.PaymentCount, &d.PaymentAmount,
+			&d.SettledCount, &d.SettledAmount, &d.FeeAmount,
+			&d.MatchedCount, &d.UnmatchedCount, &d.MismatchCount, &d.DuplicateCount,
+			&d.UnsettledCount, &d.UnsettledAmount, &d.OpenItems); err != nil {
+			return nil, err
+		}
+		d.Difference = math.Round((d.SettledAmount-d.PaymentAmount)*100) / 100
+		days = append(days, d)
+	}
+	return days, rows.Err()
+}
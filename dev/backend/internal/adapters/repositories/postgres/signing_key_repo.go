@@ -0,0 +1,94 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/ghana-police/ticketing-backend/internal/domain/models"
+	"github.com/ghana-police/ticketing-backend/internal/ports/repositories"
+)
+
+// signingKeyLock serialises key rotation across API instances.
+const signingKeyLock = 7310034
+
+// Retired keys are kept this long before being deleted.
+const signingKeyRetention = 24 * time.Hour
+
+type signingKeyRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewSigningKeyRepo(db *pgxpool.Pool) repositories.SigningKeyRepository {
+	return &signingKeyRepo{db: db}
+}
+
+func (r *signingKeyRepo) ListLive(ctx context.Context) ([]models.SigningKey, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT kid, algorithm, private_key, activates_at, retires_at, created_at
+		FROM signing_keys
+		WHERE retires_at IS NULL OR retires_at > NOW()
+		ORDER BY activates_at DESC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var keys []models.SigningKey
+	for rows.Next() {
+		var k models.SigningKey
+		if err := rows.Scan(&k.KID, &k.Algorithm, &k.PrivateKey, &k.ActivatesAt, &k.RetiresAt, &k.CreatedAt); err != nil {
+			return nil, err
+		}
+		keys = append(keys, k)
+	}
+	return keys, rows.Err()
+}
+
+func (r *signingKeyRepo) Rotate(ctx context.Context, key *models.SigningKey, dueBefore, retireAt time.Time) (bool, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, "SELECT pg_advisory_xact_lock($1)", signingKeyLock); err != nil {
+		return false, err
+	}
+
+	var rotated bool
+	if err := tx.QueryRow(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM signing_keys
+			WHERE algorithm = $1 AND activates_at > $2
+			  AND (retires_at IS NULL OR retires_at > NOW())
+		)`, key.Algorithm, dueBefore).Scan(&rotated); err != nil {
+		return false, err
+	}
+	if rotated {
+		return false, nil
+	}
+
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO signing_keys (kid, algorithm, private_key, activates_at)
+		VALUES ($1, $2, $3, $4)`,
+		key.KID, key.Algorithm, key.PrivateKey, key.ActivatesAt); err != nil {
+		return false, err
+	}
+
+	if _, err := tx.Exec(ctx, `
+		UPDATE signing_keys SET retires_at = $2
+		WHERE kid <> $1 AND (retires_at IS NULL OR retires_at > $2)`,
+		key.KID, retireAt); err != nil {
+		return false, err
+	}
+
+	if _, err := tx.Exec(ctx,
+		"DELETE FROM signing_keys WHERE retires_at < $1",
+		time.Now().Add(-signingKeyRetention)); err != nil {
+		return false, err
+	}
+
+	return true, tx.Commit(ctx)
+}
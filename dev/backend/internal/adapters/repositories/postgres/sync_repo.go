@@ -3,10 +3,12 @@ package postgres
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/ghana-police/ticketing-backend/internal/domain/models"
 	"github.com/ghana-police/ticketing-backend/internal/ports/repositories"
+	"github.com/ghana-police/ticketing-backend/pkg/pagination"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
@@ -21,10 +23,11 @@ func NewSyncRepo(db *pgxpool.Pool) repositories.SyncRepository {
 
 func (r *syncRepo) UpsertDeviceSync(ctx context.Context, userID uuid.UUID, deviceID string, syncTimestamp time.Time, itemsSynced int) error {
 	_, err := r.db.Exec(ctx,
-		`INSERT INTO device_syncs (user_id, device_id, last_sync_timestamp, items_synced)
-		 VALUES ($1, $2, $3, $4)
+		`INSERT INTO device_syncs (user_id, device_id, last_sync_timestamp, items_synced, last_success_at)
+		 VALUES ($1, $2, $3, $4, NOW())
 		 ON CONFLICT (user_id, device_id)
-		 DO UPDATE SET last_sync_timestamp = $3, items_synced = device_syncs.items_synced + $4, updated_at = NOW()`,
+		 DO UPDATE SET last_sync_timestamp = $3, items_synced = device_syncs.items_synced + $4,
+		 last_success_at = NOW(), updated_at = NOW()`,
 		userID, deviceID, syncTimestamp, itemsSynced)
 	return err
 }
@@ -32,11 +35,11 @@ func (r *syncRepo) UpsertDeviceSync(ctx context.Context, userID uuid.UUID, devic
 func (r *syncRepo) GetDeviceSync(ctx context.Context, userID uuid.UUID, deviceID string) (*models.DeviceSync, error) {
 	var ds models.DeviceSync
 	err := r.db.QueryRow(ctx,
-		`SELECT id, user_id, device_id, last_sync_timestamp, items_synced, created_at, updated_at
+		`SELECT id, user_id, device_id, last_sync_timestamp, items_synced, last_success_at, created_at, updated_at
 		 FROM device_syncs WHERE user_id = $1 AND device_id = $2`,
 		userID, deviceID).Scan(
 		&ds.ID, &ds.UserID, &ds.DeviceID, &ds.LastSyncTimestamp,
-		&ds.ItemsSynced, &ds.CreatedAt, &ds.UpdatedAt)
+		&ds.ItemsSynced, &ds.LastSuccessAt, &ds.CreatedAt, &ds.UpdatedAt)
 	if err != nil {
 		return nil, err
 	}
@@ -44,13 +47,13 @@ func (r *syncRepo) GetDeviceSync(ctx context.Context, userID uuid.UUID, deviceID
 }
 
 // ---------------------------------------------------------------------------
-// Server updates — tickets modified since a given timestamp
+// Server updates — keyset-paginated ticket change feed
 // ---------------------------------------------------------------------------
 
-func (r *syncRepo) GetTicketsUpdatedSince(ctx context.Context, since time.Time, stationID *uuid.UUID, regionID *uuid.UUID, limit int) ([]models.ServerTicketUpdate, error) {
-	conditions := "t.updated_at > $1"
-	args := []any{since}
-	argIdx := 2
+func (r *syncRepo) GetTicketChanges(ctx context.Context, after models.ChangeCursor, stationID *uuid.UUID, regionID *uuid.UUID, limit int) ([]models.ServerTicketUpdate, error) {
+	conditions := "(t.updated_at, t.id) > ($1, $2)"
+	args := []any{after.UpdatedAt, after.ID}
+	argIdx := 3
 
 	if stationID != nil {
 		conditions += fmt.Sprintf(" AND t.station_id = $%d", argIdx)
@@ -65,7 +68,7 @@ func (r *syncRepo) GetTicketsUpdatedSince(ctx context.Context, since time.Time,
 
 	query := fmt.Sprintf(
 		`SELECT t.id, t.status, t.total_fine, t.paid_at, t.paid_amount, t.paid_method, t.voided_at, t.void_reason, t.updated_at
-		 FROM tickets t WHERE %s ORDER BY t.updated_at ASC LIMIT $%d`,
+		 FROM tickets t WHERE %s ORDER BY t.updated_at ASC, t.id ASC LIMIT $%d`,
 		conditions, argIdx)
 	args = append(args, limit)
 
@@ -92,6 +95,25 @@ func (r *syncRepo) GetTicketsUpdatedSince(ctx context.Context, since time.Time,
 			return nil, err
 		}
 
+		// Tombstone — the device drops its local copy
+		if status == "cancelled" {
+			data := map[string]any{"status": status, "reason": "cancelled"}
+			if voidedAt != nil {
+				data["reason"] = "voided"
+				data["voidedAt"] = voidedAt
+			}
+			if voidReason != nil {
+				data["voidReason"] = voidReason
+			}
+			updates = append(updates, models.ServerTicketUpdate{
+				ID:        id,
+				Action:    "delete",
+				Data:      data,
+				UpdatedAt: updatedAt,
+			})
+			continue
+		}
+
 		data := map[string]any{
 			"status":    status,
 			"totalFine": totalFine,
@@ -106,22 +128,12 @@ func (r *syncRepo) GetTicketsUpdatedSince(ctx context.Context, since time.Time,
 		if paidMethod != nil {
 			data["paidMethod"] = paidMethod
 		}
-		if voidedAt != nil {
-			data["voidedAt"] = voidedAt
-		}
-		if voidReason != nil {
-			data["voidReason"] = voidReason
-		}
-
-		action := "update"
-		if status == "cancelled" {
-			action = "delete"
-		}
 
 		updates = append(updates, models.ServerTicketUpdate{
-			ID:     id,
-			Action: action,
-			Data:   data,
+			ID:        id,
+			Action:    "update",
+			Data:      data,
+			UpdatedAt: updatedAt,
 		})
 	}
 
@@ -151,3 +163,409 @@ func (r *syncRepo) CountTicketsUpdatedSince(ctx context.Context, since time.Time
 	err := r.db.QueryRow(ctx, fmt.Sprintf("SELECT COUNT(*) FROM tickets WHERE %s", conditions), args...).Scan(&count)
 	return count, err
 }
+
+// ---------------------------------------------------------------------------
+// Conflicts
+// ---------------------------------------------------------------------------
+
+func (r *syncRepo) CreateConflict(ctx context.Context, c *models.SyncConflict) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	err = tx.QueryRow(ctx,
+		`INSERT INTO sync_conflicts (ticket_id, user_id, officer_id, device_id, local_id, strategy, fields,
+		 client_data, server_data, client_timestamp, server_updated_at, status, resolution, resolved_at,
+		 station_id, region_id)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
+		 RETURNING id, created_at, updated_at`,
+		c.TicketID, c.UserID, c.OfficerID, c.DeviceID, c.LocalID, c.Strategy, c.Fields,
+		c.ClientData, c.ServerData, c.ClientTimestamp, c.ServerUpdatedAt, c.Status, c.Resolution, c.ResolvedAt,
+		c.StationID, c.RegionID,
+	).Scan(&c.ID, &c.CreatedAt, &c.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	if c.Status == models.SyncConflictStatusPending {
+		// Flag only — updated_at is left alone so the flag does not re-trigger device conflicts.
+		_, err = tx.Exec(ctx, `UPDATE tickets SET sync_status = 'conflict' WHERE id = $1`, c.TicketID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+const syncConflictCols = `c.id, c.ticket_id, t.ticket_number, c.user_id, c.officer_id, c.device_id, c.local_id,
+	c.strategy, c.fields, c.client_data, c.server_data, c.client_timestamp, c.server_updated_at,
+	c.status, c.resolution, c.resolved_by, c.resolved_at, c.resolution_notes, c.station_id, c.region_id,
+	c.created_at, c.updated_at`
+
+const syncConflictJoins = ` FROM sync_conflicts c JOIN tickets t ON t.id = c.ticket_id`
+
+func scanSyncConflict(scanner interface{ Scan(dest ...any) error }) (*models.SyncConflict, error) {
+	var c models.SyncConflict
+	err := scanner.Scan(
+		&c.ID, &c.TicketID, &c.TicketNumber, &c.UserID, &c.OfficerID, &c.DeviceID, &c.LocalID,
+		&c.Strategy, &c.Fields, &c.ClientData, &c.ServerData, &c.ClientTimestamp, &c.ServerUpdatedAt,
+		&c.Status, &c.Resolution, &c.ResolvedBy, &c.ResolvedAt, &c.ResolutionNotes, &c.StationID, &c.RegionID,
+		&c.CreatedAt, &c.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (r *syncRepo) GetConflictByID(ctx context.Context, id uuid.UUID) (*models.SyncConflict, error) {
+	row := r.db.QueryRow(ctx, "SELECT "+syncConflictCols+syncConflictJoins+" WHERE c.id = $1", id)
+	return scanSyncConflict(row)
+}
+
+var syncConflictSortColumns = map[string]string{
+	"createdAt":       "c.created_at",
+	"clientTimestamp": "c.client_timestamp",
+	"status":          "c.status",
+}
+
+func (r *syncRepo) ListConflicts(ctx context.Context, filter models.SyncConflictFilter, p pagination.Params) ([]models.SyncConflict, int, error) {
+	var conditions []string
+	var args []any
+	argIdx := 1
+
+	if filter.Status != nil {
+		conditions = append(conditions, fmt.Sprintf("c.status = $%d", argIdx))
+		args = append(args, *filter.Status)
+		argIdx++
+	}
+	if filter.TicketID != nil {
+		conditions = append(conditions, fmt.Sprintf("c.ticket_id = $%d", argIdx))
+		args = append(args, *filter.TicketID)
+		argIdx++
+	}
+	if filter.StationID != nil {
+		conditions = append(conditions, fmt.Sprintf("c.station_id = $%d", argIdx))
+		args = append(args, *filter.StationID)
+		argIdx++
+	}
+	if filter.RegionID != nil {
+		conditions = append(conditions, fmt.Sprintf("c.region_id = $%d", argIdx))
+		args = append(args, *filter.RegionID)
+		argIdx++
+	}
+	conditions, args, argIdx = appendJurisdiction(conditions, args, argIdx, filter.Scope, "c.station_id", "c.region_id")
+
+	where := ""
+	if len(conditions) > 0 {
+		where = " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var total int
+	if err := r.db.QueryRow(ctx, "SELECT COUNT(*) FROM sync_conflicts c"+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	orderCol := syncConflictSortColumns[p.SortBy]
+	if orderCol == "" {
+		orderCol = "c.created_at"
+	}
+
+	query := fmt.Sprintf("SELECT %s%s%s ORDER BY %s %s LIMIT $%d OFFSET $%d",
+		syncConflictCols, syncConflictJoins, where, orderCol, p.SortOrder, argIdx, argIdx+1)
+	args = append(args, p.Limit, p.Offset())
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	items := []models.SyncConflict{}
+	for rows.Next() {
+		c, err := scanSyncConflict(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		items = append(items, *c)
+	}
+	return items, total, rows.Err()
+}
+
+func (r *syncRepo) ResolveConflict(ctx context.Context, id uuid.UUID, resolution string, resolvedBy uuid.UUID, notes *string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var ticketID uuid.UUID
+	err = tx.QueryRow(ctx,
+		`UPDATE sync_conflicts SET status = 'resolved', resolution = $1, resolved_by = $2,
+		 resolved_at = NOW(), resolution_notes = $3, updated_at = NOW()
+		 WHERE id = $4 RETURNING ticket_id`,
+		resolution, resolvedBy, notes, id).Scan(&ticketID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx,
+		`UPDATE tickets SET sync_status = 'synced'
+		 WHERE id = $1 AND sync_status = 'conflict'
+		 AND NOT EXISTS (SELECT 1 FROM sync_conflicts WHERE ticket_id = $1 AND status = 'pending')`,
+		ticketID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// ---------------------------------------------------------------------------
+// Sync telemetry
+// ---------------------------------------------------------------------------
+
+func (r *syncRepo) RecordSession(ctx context.Context, s *models.SyncSession) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	err = tx.QueryRow(ctx,
+		`INSERT INTO sync_sessions (user_id, officer_id, device_id, device_ref, station_id, region_id,
+		 status, error_code, errors, tickets_received, tickets_succeeded, tickets_conflicted, tickets_failed,
+		 photos_received, photos_succeeded, photos_failed, updates_sent, request_bytes, response_bytes,
+		 duration_ms, app_version, battery_level, charging, network_type, pending_items, started_at)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19,
+		 $20, $21, $22, $23, $24, $25, $26)
+		 RETURNING id, created_at`,
+		s.UserID, s.OfficerID, s.DeviceID, s.DeviceRef, s.StationID, s.RegionID,
+		s.Status, s.ErrorCode, s.Errors, s.TicketsReceived, s.TicketsSucceeded, s.TicketsConflicted, s.TicketsFailed,
+		s.PhotosReceived, s.PhotosSucceeded, s.PhotosFailed, s.UpdatesSent, s.RequestBytes, s.ResponseBytes,
+		s.DurationMs, s.AppVersion, s.BatteryLevel, s.Charging, s.NetworkType, s.PendingItems, s.StartedAt,
+	).Scan(&s.ID, &s.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	var lastError *string
+	if len(s.Errors) > 0 {
+		lastError = &s.Errors[0]
+	}
+	succeeded := s.Status == models.SyncSessionCompleted || s.Status == models.SyncSessionPartial
+
+	// Rows created here for devices that have never synced successfully keep a
+	// NULL last_success_at, which GetDeviceSync callers treat as "never synced".
+	_, err = tx.Exec(ctx,
+		`INSERT INTO device_syncs (user_id, device_id, last_sync_timestamp, last_session_at, last_success_at,
+		 last_status, last_error, app_version, battery_level, charging, network_type, pending_items)
+		 VALUES ($1, $2, $3, $3, CASE WHEN $4 THEN $3::timestamptz END, $5, $6, $7, $8, $9, $10, $11)
+		 ON CONFLICT (user_id, device_id) DO UPDATE SET
+		   last_session_at = EXCLUDED.last_session_at,
+		   last_success_at = COALESCE(EXCLUDED.last_success_at, device_syncs.last_success_at),
+		   last_status = EXCLUDED.last_status,
+		   last_error = EXCLUDED.last_error,
+		   app_version = COALESCE(EXCLUDED.app_version, device_syncs.app_version),
+		   battery_level = EXCLUDED.battery_level,
+		   charging = EXCLUDED.charging,
+		   network_type = EXCLUDED.network_type,
+		   pending_items = EXCLUDED.pending_items,
+		   updated_at = NOW()`,
+		s.UserID, s.DeviceID, s.StartedAt, succeeded, s.Status, lastError,
+		s.AppVersion, s.BatteryLevel, s.Charging, s.NetworkType, s.PendingItems)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+const syncSessionCols = `id, user_id, officer_id, device_id, device_ref, station_id, region_id, status, error_code, errors,
+	tickets_received, tickets_succeeded, tickets_conflicted, tickets_failed, photos_received, photos_succeeded,
+	photos_failed, updates_sent, request_bytes, response_bytes, duration_ms, app_version, battery_level,
+	charging, network_type, pending_items, started_at, created_at`
+
+func (r *syncRepo) ListSessions(ctx context.Context, deviceID string, scope *models.Jurisdiction, p pagination.Params) ([]models.SyncSession, int, error) {
+	parts, args, argIdx := appendJurisdiction([]string{"device_id = $1"}, []any{deviceID}, 2, scope, "station_id", "region_id")
+	conditions := strings.Join(parts, " AND ")
+
+	var total int
+	if err := r.db.QueryRow(ctx, "SELECT COUNT(*) FROM sync_sessions WHERE "+conditions, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM sync_sessions WHERE %s ORDER BY started_at DESC LIMIT $%d OFFSET $%d",
+		syncSessionCols, conditions, argIdx, argIdx+1)
+	args = append(args, p.Limit, p.Offset())
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	items := []models.SyncSession{}
+	for rows.Next() {
+		var s models.SyncSession
+		if err := rows.Scan(
+			&s.ID, &s.UserID, &s.OfficerID, &s.DeviceID, &s.DeviceRef, &s.StationID, &s.RegionID, &s.Status,
+			&s.ErrorCode, &s.Errors, &s.TicketsReceived, &s.TicketsSucceeded, &s.TicketsConflicted, &s.TicketsFailed,
+			&s.PhotosReceived, &s.PhotosSucceeded, &s.PhotosFailed, &s.UpdatesSent, &s.RequestBytes, &s.ResponseBytes,
+			&s.DurationMs, &s.AppVersion, &s.BatteryLevel, &s.Charging, &s.NetworkType, &s.PendingItems,
+			&s.StartedAt, &s.CreatedAt); err != nil {
+			return nil, 0, err
+		}
+		items = append(items, s)
+	}
+	return items, total, rows.Err()
+}
+
+// deviceHealthQuery classifies the latest user+device summary of each handheld.
+// $1 stale-before, $2 statistics window start, $3 backlog threshold.
+const deviceHealthQuery = `SELECT ds.device_id, d.id AS device_ref, d.name AS device_name, ds.user_id,
+	o.id AS officer_id, NULLIF(TRIM(u.first_name || ' ' || u.last_name), '') AS officer_name,
+	s.id AS station_id, s.name AS station_name, s.region_id,
+	CASE
+		WHEN ds.last_session_at < $1 THEN 'stale'
+		WHEN ds.last_status <> 'completed' THEN 'failing'
+		WHEN ds.pending_items > $3 THEN 'backlog'
+		ELSE 'healthy'
+	END AS health,
+	ds.last_session_at, ds.last_success_at, ds.last_status, ds.last_error, ds.app_version, ds.battery_level,
+	ds.charging, ds.network_type, ds.pending_items,
+	COALESCE(w.sessions, 0) AS sessions, COALESCE(w.failed_sessions, 0) AS failed_sessions,
+	COALESCE(w.failed_items, 0) AS failed_items, COALESCE(w.avg_duration_ms, 0) AS avg_duration_ms
+	FROM (
+		SELECT DISTINCT ON (device_id) device_id, user_id,
+			COALESCE(last_session_at, updated_at) AS last_session_at, last_success_at,
+			COALESCE(last_status, 'completed') AS last_status, last_error, app_version, battery_level,
+			charging, network_type, pending_items
+		FROM device_syncs
+		ORDER BY device_id, COALESCE(last_session_at, updated_at) DESC
+	) ds
+	JOIN users u ON ds.user_id = u.id
+	LEFT JOIN devices d ON d.device_id = ds.device_id
+	LEFT JOIN officers o ON o.user_id = ds.user_id
+	LEFT JOIN stations s ON s.id = COALESCE(d.station_id, o.station_id)
+	LEFT JOIN LATERAL (
+		SELECT COUNT(*) AS sessions,
+			COUNT(*) FILTER (WHERE ss.status <> 'completed') AS failed_sessions,
+			SUM(ss.tickets_failed + ss.photos_failed) AS failed_items,
+			AVG(ss.duration_ms)::int AS avg_duration_ms
+		FROM sync_sessions ss
+		WHERE ss.device_id = ds.device_id AND ss.started_at >= $2
+	) w ON true`
+
+func deviceHealthConditions(filter models.DeviceHealthFilter, args []any) (string, []any) {
+	var conditions []string
+	argIdx := len(args) + 1
+
+	if filter.StationID != nil {
+		conditions = append(conditions, fmt.Sprintf("h.station_id = $%d", argIdx))
+		args = append(args, *filter.StationID)
+		argIdx++
+	}
+	if filter.RegionID != nil {
+		conditions = append(conditions, fmt.Sprintf("h.region_id = $%d", argIdx))
+		args = append(args, *filter.RegionID)
+		argIdx++
+	}
+	if filter.Health != nil {
+		conditions = append(conditions, fmt.Sprintf("h.health = $%d", argIdx))
+		args = append(args, *filter.Health)
+		argIdx++
+	}
+	conditions, args, _ = appendJurisdiction(conditions, args, argIdx, filter.Scope, "h.station_id", "h.region_id")
+
+	if len(conditions) == 0 {
+		return "", args
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+var deviceHealthSortColumns = map[string]string{
+	"lastSessionAt": "h.last_session_at",
+	"station":       "h.station_name",
+	"health":        "h.health",
+	"pendingItems":  "h.pending_items",
+}
+
+func (r *syncRepo) ListDeviceHealth(ctx context.Context, filter models.DeviceHealthFilter, t models.DeviceHealthThresholds, p pagination.Params) ([]models.DeviceHealth, int, error) {
+	from := " FROM (" + deviceHealthQuery + ") h"
+	where, args := deviceHealthConditions(filter, []any{t.StaleBefore, t.WindowStart, t.BacklogItems})
+
+	var total int
+	if err := r.db.QueryRow(ctx, "SELECT COUNT(*)"+from+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	orderCol := deviceHealthSortColumns[p.SortBy]
+	if orderCol == "" {
+		orderCol = "h.last_session_at"
+	}
+
+	argIdx := len(args) + 1
+	query := fmt.Sprintf("SELECT h.*%s%s ORDER BY %s %s NULLS LAST LIMIT $%d OFFSET $%d",
+		from, where, orderCol, p.SortOrder, argIdx, argIdx+1)
+	args = append(args, p.Limit, p.Offset())
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	items := []models.DeviceHealth{}
+	for rows.Next() {
+		var h models.DeviceHealth
+		if err := rows.Scan(
+			&h.DeviceID, &h.DeviceRef, &h.DeviceName, &h.UserID, &h.OfficerID, &h.OfficerName,
+			&h.StationID, &h.StationName, &h.RegionID, &h.Health,
+			&h.LastSessionAt, &h.LastSuccessAt, &h.LastStatus, &h.LastError, &h.AppVersion, &h.BatteryLevel,
+			&h.Charging, &h.NetworkType, &h.PendingItems,
+			&h.Sessions, &h.FailedSessions, &h.FailedItems, &h.AvgDurationMs); err != nil {
+			return nil, 0, err
+		}
+		items = append(items, h)
+	}
+	return items, total, rows.Err()
+}
+
+func (r *syncRepo) ListStationHealth(ctx context.Context, filter models.DeviceHealthFilter, t models.DeviceHealthThresholds) ([]models.StationSyncHealth, error) {
+	where, args := deviceHealthConditions(filter, []any{t.StaleBefore, t.WindowStart, t.BacklogItems})
+	if where == "" {
+		where = " WHERE h.station_id IS NOT NULL"
+	} else {
+		where += " AND h.station_id IS NOT NULL"
+	}
+
+	rows, err := r.db.Query(ctx,
+		`SELECT h.station_id, h.station_name, h.region_id, COUNT(*),
+			COUNT(*) FILTER (WHERE h.health = 'healthy'),
+			COUNT(*) FILTER (WHERE h.health = 'backlog'),
+			COUNT(*) FILTER (WHERE h.health = 'failing'),
+			COUNT(*) FILTER (WHERE h.health = 'stale'),
+			COALESCE(SUM(h.pending_items), 0),
+			MAX(h.last_session_at)
+		 FROM (`+deviceHealthQuery+`) h`+where+`
+		 GROUP BY h.station_id, h.station_name, h.region_id
+		 ORDER BY h.station_name`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []models.StationSyncHealth{}
+	for rows.Next() {
+		var sh models.StationSyncHealth
+		if err := rows.Scan(&sh.StationID, &sh.StationName, &sh.RegionID, &sh.Devices,
+			&sh.Healthy, &sh.Backlog, &sh.Failing, &sh.Stale, &sh.PendingItems, &sh.LastSessionAt); err != nil {
+			return nil, err
+		}
+		items = append(items, sh)
+	}
+	return items, rows.Err()
+}
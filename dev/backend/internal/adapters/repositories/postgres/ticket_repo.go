@@ -38,7 +38,7 @@ func (r *ticketRepo) NextTicketNumber(ctx context.Context, regionCode string) (s
 }
 
 func (r *ticketRepo) Create(ctx context.Context, ticket *models.Ticket, offences []repositories.TicketOffenceInput) (string, error) {
-	tx, err := r.db.Begin(ctx)
+	tx, err := conn(ctx, r.db).Begin(ctx)
 	if err != nil {
 		return "", err
 	}
@@ -98,7 +98,7 @@ func (r *ticketRepo) getTicket(ctx context.Context, where string, arg any) (*mod
 		t.driver_name, t.driver_license, t.driver_phone, t.driver_address,
 		t.location_description, t.location_latitude, t.location_longitude,
 		t.notes, t.officer_id, t.station_id, t.district_id, t.division_id, t.region_id,
-		t.payment_reference, t.paid_at, t.paid_amount, t.paid_method,
+		t.payment_reference, t.paid_at, t.paid_amount, t.paid_method, t.outstanding_balance,
 		t.sync_status, t.printed, t.printed_at,
 		t.voided_by, t.voided_at, t.void_reason,
 		t.created_at, t.updated_at,
@@ -123,7 +123,7 @@ func (r *ticketRepo) getTicket(ctx context.Context, where string, arg any) (*mod
 		&driverName, &driverLicense, &driverPhone, &driverAddress,
 		&locDesc, &locLat, &locLng,
 		&resp.Notes, &resp.OfficerID, &resp.StationID, &resp.DistrictID, &resp.DivisionID, &resp.RegionID,
-		&resp.PaymentReference, &resp.PaidAt, &resp.PaidAmount, &resp.PaymentMethod,
+		&resp.PaymentReference, &resp.PaidAt, &resp.PaidAmount, &resp.PaymentMethod, &resp.Outstanding,
 		&resp.SyncStatus, &resp.Printed, &resp.PrintedAt,
 		&resp.VoidedBy, &resp.VoidedAt, &resp.VoidReason,
 		&resp.CreatedAt, &resp.UpdatedAt,
@@ -363,13 +363,35 @@ func (r *ticketRepo) Search(ctx context.Context, query string, filter models.Tic
 // ---------------------------------------------------------------------------
 
 func (r *ticketRepo) UpdateStatus(ctx context.Context, ticketID uuid.UUID, status string) error {
-	_, err := r.db.Exec(ctx,
+	_, err := conn(ctx, r.db).Exec(ctx,
 		`UPDATE tickets SET status = $1, updated_at = NOW() WHERE id = $2`, status, ticketID)
 	return err
 }
 
+func (r *ticketRepo) LockForUpdate(ctx context.Context, ticketID uuid.UUID) (string, float64, error) {
+	var status string
+	var outstanding float64
+	err := conn(ctx, r.db).QueryRow(ctx,
+		`SELECT status, outstanding_balance FROM tickets WHERE id = $1 FOR UPDATE`, ticketID).Scan(&status, &outstanding)
+	return status, outstanding, err
+}
+
+func (r *ticketRepo) Receivable(ctx context.Context, ticketID uuid.UUID) (float64, error) {
+	var receivable float64
+	err := conn(ctx, r.db).QueryRow(ctx,
+		`SELECT outstanding_balance - GREATEST(COALESCE(paid_amount, 0) - total_fine, 0) FROM tickets WHERE id = $1`,
+		ticketID).Scan(&receivable)
+	return receivable, err
+}
+
+func (r *ticketRepo) SetTotalFine(ctx context.Context, ticketID uuid.UUID, totalFine float64) error {
+	_, err := conn(ctx, r.db).Exec(ctx,
+		`UPDATE tickets SET total_fine = $1, updated_at = NOW() WHERE id = $2`, totalFine, ticketID)
+	return err
+}
+
 func (r *ticketRepo) VoidTicket(ctx context.Context, ticketID uuid.UUID, voidedBy uuid.UUID, reason string) error {
-	_, err := r.db.Exec(ctx,
+	_, err := conn(ctx, r.db).Exec(ctx,
 		`UPDATE tickets SET status = 'cancelled', voided_by = $1, voided_at = NOW(),
 		 void_reason = $2, updated_at = NOW() WHERE id = $3`,
 		voidedBy, reason, ticketID)
@@ -377,7 +399,7 @@ func (r *ticketRepo) VoidTicket(ctx context.Context, ticketID uuid.UUID, voidedB
 }
 
 func (r *ticketRepo) ReplaceOffences(ctx context.Context, ticketID uuid.UUID, offences []repositories.TicketOffenceInput) (float64, error) {
-	tx, err := r.db.Begin(ctx)
+	tx, err := conn(ctx, r.db).Begin(ctx)
 	if err != nil {
 		return 0, err
 	}
@@ -435,8 +457,8 @@ func (r *ticketRepo) GetStats(ctx context.Context, filter models.TicketFilter) (
 		COUNT(*) FILTER (WHERE status = 'objection'),
 		COUNT(*) FILTER (WHERE status = 'cancelled'),
 		COALESCE(SUM(total_fine), 0),
-		COALESCE(SUM(CASE WHEN status = 'paid' THEN COALESCE(paid_amount, total_fine) ELSE 0 END), 0),
-		COALESCE(SUM(CASE WHEN status IN ('unpaid', 'overdue') THEN total_fine ELSE 0 END), 0)
+		COALESCE(SUM(CASE WHEN status = 'paid' THEN COALESCE(paid_amount, total_fine) ELSE COALESCE(paid_amount, 0) END), 0),
+		COALESCE(SUM(CASE WHEN status IN ('unpaid', 'overdue') THEN outstanding_balance ELSE 0 END), 0)
 	FROM tickets t%s`, where), args...).Scan(
 		&stats.Total, &stats.Paid, &stats.Unpaid, &stats.Overdue,
 		&stats.Objection, &stats.Cancelled,
@@ -535,5 +557,7 @@ func buildTicketConditions(filter models.TicketFilter, search string) ([]string,
 		argIdx++
 	}
 
+	conditions, args, argIdx = appendJurisdiction(conditions, args, argIdx, filter.Scope, "t.station_id", "t.region_id")
+
 	return conditions, args, argIdx
 }
@@ -22,8 +22,8 @@ const userWithOfficerQuery = `
 SELECT
     u.id, u.email, u.password_hash, u.first_name, u.last_name, u.phone,
     u.role, u.is_active, u.profile_photo_url, u.last_login_at,
-    u.password_changed_at, u.failed_login_attempts, u.locked_until,
-    u.created_at, u.updated_at,
+    u.password_changed_at, u.must_change_password, u.failed_login_attempts, u.locked_until,
+    u.mfa_enabled, u.is_service_account, u.created_at, u.updated_at,
     o.id, o.badge_number, o.rank, o.station_id, o.region_id, o.assigned_device_id,
     s.id, s.name, s.code
 FROM users u
@@ -42,8 +42,8 @@ func scanUserWithOfficer(row pgx.Row) (*models.User, error) {
 	err := row.Scan(
 		&u.ID, &u.Email, &u.PasswordHash, &u.FirstName, &u.LastName, &u.Phone,
 		&u.Role, &u.IsActive, &u.ProfilePhotoURL, &u.LastLoginAt,
-		&u.PasswordChangedAt, &u.FailedLoginAttempts, &u.LockedUntil,
-		&u.CreatedAt, &u.UpdatedAt,
+		&u.PasswordChangedAt, &u.MustChangePassword, &u.FailedLoginAttempts, &u.LockedUntil,
+		&u.MFAEnabled, &u.IsServiceAccount, &u.CreatedAt, &u.UpdatedAt,
 		&officerID, &badgeNumber, &rank, &stationID, &regionID, &assignedDeviceID,
 		&sID, &sName, &sCode,
 	)
@@ -116,11 +116,53 @@ func (r *UserRepo) UpdateProfile(ctx context.Context, id uuid.UUID, firstName, l
 	return r.FindByID(ctx, id)
 }
 
-func (r *UserRepo) UpdatePassword(ctx context.Context, id uuid.UUID, passwordHash string) error {
-	_, err := r.db.Exec(ctx,
-		"UPDATE users SET password_hash = $2, password_changed_at = NOW(), updated_at = NOW() WHERE id = $1",
-		id, passwordHash)
-	return err
+func (r *UserRepo) UpdatePassword(ctx context.Context, id uuid.UUID, passwordHash string, mustChange bool) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// Keep the replaced hash so it cannot be chosen again
+	if _, err := tx.Exec(ctx,
+		"INSERT INTO password_history (user_id, password_hash) SELECT id, password_hash FROM users WHERE id = $1",
+		id); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `
+		DELETE FROM password_history WHERE user_id = $1 AND id NOT IN (
+			SELECT id FROM password_history WHERE user_id = $1 ORDER BY created_at DESC LIMIT $2)`,
+		id, models.MaxPasswordHistory); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `
+		UPDATE users SET password_hash = $2, password_changed_at = NOW(), must_change_password = $3, updated_at = NOW()
+		WHERE id = $1`,
+		id, passwordHash, mustChange); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *UserRepo) ListPasswordHistory(ctx context.Context, id uuid.UUID, limit int) ([]string, error) {
+	rows, err := r.db.Query(ctx,
+		"SELECT password_hash FROM password_history WHERE user_id = $1 ORDER BY created_at DESC LIMIT $2",
+		id, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var hashes []string
+	for rows.Next() {
+		var h string
+		if err := rows.Scan(&h); err != nil {
+			return nil, err
+		}
+		hashes = append(hashes, h)
+	}
+	return hashes, rows.Err()
 }
 
 func (r *UserRepo) IncrementFailedLogins(ctx context.Context, id uuid.UUID, lockUntil *string) error {
@@ -143,25 +185,64 @@ func (r *UserRepo) ResetFailedLogins(ctx context.Context, id uuid.UUID) error {
 
 func (r *UserRepo) SaveRefreshToken(ctx context.Context, token *models.RefreshToken) error {
 	_, err := r.db.Exec(ctx, `
-		INSERT INTO refresh_tokens (id, user_id, token_hash, device_id, device_info, expires_at)
-		VALUES ($1, $2, $3, $4, $5, $6)`,
-		token.ID, token.UserID, token.TokenHash, token.DeviceID, token.DeviceInfo, token.ExpiresAt)
+		INSERT INTO refresh_tokens (id, user_id, token_hash, device_id, device_info, device_ref, family_id, ip_address, user_agent, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+		token.ID, token.UserID, token.TokenHash, token.DeviceID, token.DeviceInfo, token.DeviceRef, token.FamilyID,
+		token.IPAddress, token.UserAgent, token.ExpiresAt)
 	return err
 }
 
 func (r *UserRepo) FindRefreshTokenByHash(ctx context.Context, tokenHash string) (*models.RefreshToken, error) {
 	var t models.RefreshToken
 	err := r.db.QueryRow(ctx, `
-		SELECT id, user_id, token_hash, device_id, device_info, expires_at, revoked_at, created_at
+		SELECT id, user_id, token_hash, device_id, device_info, device_ref, family_id, replaced_by,
+		       ip_address, user_agent, expires_at, revoked_at, last_used_at, created_at
 		FROM refresh_tokens WHERE token_hash = $1`, tokenHash).
-		Scan(&t.ID, &t.UserID, &t.TokenHash, &t.DeviceID, &t.DeviceInfo,
-			&t.ExpiresAt, &t.RevokedAt, &t.CreatedAt)
+		Scan(&t.ID, &t.UserID, &t.TokenHash, &t.DeviceID, &t.DeviceInfo, &t.DeviceRef, &t.FamilyID, &t.ReplacedBy,
+			&t.IPAddress, &t.UserAgent, &t.ExpiresAt, &t.RevokedAt, &t.LastUsedAt, &t.CreatedAt)
 	if err != nil {
 		return nil, err
 	}
 	return &t, nil
 }
 
+func (r *UserRepo) RotateRefreshToken(ctx context.Context, currentID uuid.UUID, next *models.RefreshToken) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// Insert the successor first so replaced_by can reference it
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO refresh_tokens (id, user_id, token_hash, device_id, device_info, device_ref, family_id, ip_address, user_agent, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+		next.ID, next.UserID, next.TokenHash, next.DeviceID, next.DeviceInfo, next.DeviceRef, next.FamilyID,
+		next.IPAddress, next.UserAgent, next.ExpiresAt); err != nil {
+		return err
+	}
+
+	tag, err := tx.Exec(ctx, `
+		UPDATE refresh_tokens SET revoked_at = NOW(), last_used_at = NOW(), replaced_by = $2
+		WHERE id = $1 AND revoked_at IS NULL`,
+		currentID, next.ID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *UserRepo) RevokeTokenFamily(ctx context.Context, familyID uuid.UUID) error {
+	_, err := r.db.Exec(ctx,
+		"UPDATE refresh_tokens SET revoked_at = $2 WHERE family_id = $1 AND revoked_at IS NULL",
+		familyID, time.Now())
+	return err
+}
+
 func (r *UserRepo) RevokeRefreshToken(ctx context.Context, tokenHash string) error {
 	_, err := r.db.Exec(ctx,
 		"UPDATE refresh_tokens SET revoked_at = $2 WHERE token_hash = $1",
@@ -175,3 +256,43 @@ func (r *UserRepo) RevokeAllUserTokens(ctx context.Context, userID uuid.UUID) er
 		userID, time.Now())
 	return err
 }
+
+// ListSessions returns the head of each live token family. The head was issued
+// at the family's last login or refresh; the family began with its oldest token.
+func (r *UserRepo) ListSessions(ctx context.Context, userID uuid.UUID) ([]models.Session, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT t.family_id, t.user_id, t.device_id, t.device_info, t.device_ref, t.ip_address, t.user_agent,
+		       t.created_at, t.expires_at,
+		       (SELECT MIN(f.created_at) FROM refresh_tokens f WHERE f.family_id = t.family_id)
+		FROM refresh_tokens t
+		WHERE t.user_id = $1 AND t.revoked_at IS NULL AND t.expires_at > NOW()
+		ORDER BY t.created_at DESC`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sessions := []models.Session{}
+	for rows.Next() {
+		var s models.Session
+		if err := rows.Scan(&s.ID, &s.UserID, &s.DeviceID, &s.DeviceInfo, &s.DeviceRef, &s.IPAddress, &s.UserAgent,
+			&s.LastUsedAt, &s.ExpiresAt, &s.CreatedAt); err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, s)
+	}
+	return sessions, rows.Err()
+}
+
+func (r *UserRepo) RevokeSession(ctx context.Context, userID, familyID uuid.UUID) error {
+	tag, err := r.db.Exec(ctx,
+		"UPDATE refresh_tokens SET revoked_at = $3 WHERE user_id = $1 AND family_id = $2 AND revoked_at IS NULL",
+		userID, familyID, time.Now())
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
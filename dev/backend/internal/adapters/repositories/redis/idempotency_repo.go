@@ -0,0 +1,64 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/ghana-police/ticketing-backend/internal/domain/models"
+	"github.com/ghana-police/ticketing-backend/internal/ports/repositories"
+)
+
+const idempotencyKeyPrefix = "idempotency:"
+
+type idempotencyRepo struct {
+	rdb *goredis.Client
+}
+
+func NewIdempotencyRepo(rdb *goredis.Client) repositories.IdempotencyRepository {
+	return &idempotencyRepo{rdb: rdb}
+}
+
+func (r *idempotencyRepo) Reserve(ctx context.Context, key string, record *models.IdempotencyRecord, ttl time.Duration) (*models.IdempotencyRecord, bool, error) {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return nil, false, err
+	}
+	ok, err := r.rdb.SetNX(ctx, idempotencyKeyPrefix+key, data, ttl).Result()
+	if err != nil {
+		return nil, false, err
+	}
+	if ok {
+		return nil, true, nil
+	}
+
+	raw, err := r.rdb.Get(ctx, idempotencyKeyPrefix+key).Bytes()
+	if errors.Is(err, goredis.Nil) {
+		// Expired or released between the two calls; try once more
+		ok, err = r.rdb.SetNX(ctx, idempotencyKeyPrefix+key, data, ttl).Result()
+		return nil, ok, err
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	var existing models.IdempotencyRecord
+	if err := json.Unmarshal(raw, &existing); err != nil {
+		return nil, false, err
+	}
+	return &existing, false, nil
+}
+
+func (r *idempotencyRepo) Save(ctx context.Context, key string, record *models.IdempotencyRecord, ttl time.Duration) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	return r.rdb.Set(ctx, idempotencyKeyPrefix+key, data, ttl).Err()
+}
+
+func (r *idempotencyRepo) Delete(ctx context.Context, key string) error {
+	return r.rdb.Del(ctx, idempotencyKeyPrefix+key).Err()
+}
@@ -0,0 +1,58 @@
+package redis
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/ghana-police/ticketing-backend/internal/ports/repositories"
+)
+
+const rateLimitKeyPrefix = "ratelimit:"
+
+// slidingWindowScript keeps one sorted-set member per admitted request, scored
+// by its time in milliseconds. Expired members are trimmed before counting so
+// the window slides with every call; the whole check is atomic.
+var slidingWindowScript = goredis.NewScript(`
+local key = KEYS[1]
+local now = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local limit = tonumber(ARGV[3])
+
+redis.call('ZREMRANGEBYSCORE', key, '-inf', now - window)
+local count = redis.call('ZCARD', key)
+local allowed = 0
+if count < limit then
+	redis.call('ZADD', key, now, ARGV[4])
+	count = count + 1
+	allowed = 1
+end
+redis.call('PEXPIRE', key, window)
+
+local oldest = now
+local first = redis.call('ZRANGE', key, 0, 0, 'WITHSCORES')
+if first[2] then
+	oldest = tonumber(first[2])
+end
+return {allowed, count, oldest}
+`)
+
+type rateLimitRepo struct {
+	rdb *goredis.Client
+}
+
+func NewRateLimitRepo(rdb *goredis.Client) repositories.RateLimitRepository {
+	return &rateLimitRepo{rdb: rdb}
+}
+
+func (r *rateLimitRepo) Hit(ctx context.Context, key string, limit int, window time.Duration) (bool, int, time.Time, error) {
+	now := time.Now()
+	res, err := slidingWindowScript.Run(ctx, r.rdb, []string{rateLimitKeyPrefix + key},
+		now.UnixMilli(), window.Milliseconds(), limit, uuid.NewString()).Int64Slice()
+	if err != nil {
+		return false, 0, time.Time{}, err
+	}
+	return res[0] == 1, int(res[1]), time.UnixMilli(res[2]), nil
+}
@@ -0,0 +1,34 @@
+package redis
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestIssuedBeforeRevocation(t *testing.T) {
+	revokedAt := time.Date(2026, 3, 1, 9, 30, 15, 400*int(time.Millisecond), time.UTC)
+	stored := strconv.FormatInt(revokedAt.UnixMicro(), 10)
+
+	tests := []struct {
+		name     string
+		issuedAt time.Time
+		want     bool
+	}{
+		{"earlier second", revokedAt.Add(-time.Second), true},
+		{"same second, before", revokedAt.Add(-300 * time.Millisecond), true},
+		{"same instant", revokedAt, true},
+		{"same second, after", revokedAt.Add(2 * time.Millisecond), false},
+		{"later second", revokedAt.Add(time.Second), false},
+	}
+
+	for _, tt := range tests {
+		got, err := issuedBeforeRevocation(stored, tt.issuedAt)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if got != tt.want {
+			t.Errorf("%s: denied = %t, want %t", tt.name, got, tt.want)
+		}
+	}
+}
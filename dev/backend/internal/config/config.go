@@ -2,12 +2,15 @@ package config
 
 import (
 	"fmt"
+	"net/url"
 	"os"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
+
+	jwtpkg "github.com/ghana-police/ticketing-backend/pkg/jwt"
 )
 
 type Config struct {
@@ -39,6 +42,10 @@ type Config struct {
 	JWTSecret             string
 	JWTAccessTokenExpiry  time.Duration
 	JWTRefreshTokenExpiry time.Duration
+	JWTSigningAlg         string        // HS256 (shared secret), RS256 or EdDSA
+	JWTKeyRotation        time.Duration // lifetime of an asymmetric signing key
+	JWTAcceptHS256        bool          // keep accepting HS256 tokens after switching algorithm
+	JWTRevocationFailOpen bool          // accept access tokens when the revocation denylist cannot be read
 
 	// CORS
 	CORSAllowedOrigins []string
@@ -49,6 +56,18 @@ type Config struct {
 	StorageDriver    string
 	StorageLocalPath string
 
+	// Rate Limiting (defaults; overridden by security.rateLimits settings)
+	RateLimitAuth   int
+	RateLimitLogin  int
+	RateLimitWrite  int
+	RateLimitRead   int
+	RateLimitUpload int
+	RateLimitSync   int
+	RateLimitWindow time.Duration
+
+	// Idempotency
+	IdempotencyTTL time.Duration // how long a response is replayed for its Idempotency-Key
+
 	// Logging
 	LogLevel  string
 	LogFormat string
@@ -61,6 +80,42 @@ type Config struct {
 	MaxPhotoSizeMB       int
 	SyncBatchSize        int
 	SyncMaxRetries       int
+	SyncStaleMultiplier  int // device is stale after autoSyncIntervalSeconds × this
+
+	// Device registry
+	DeviceEnrollmentRequired bool // refuse officer login and sync from unregistered devices
+
+	// Multi-factor authentication
+	MFAEncryptionKey string // seals TOTP secrets at rest; defaults to JWT_SECRET outside production
+	MFAIssuer        string // shown in authenticator apps
+
+	// Password policy
+	BreachedPasswordsFile string // newline-separated passwords or SHA-1 hashes refused as new passwords
+
+	// Mobile money
+	MomoProvider          string // "mock" (in-process) or "http" (MoMo collection API)
+	MomoBaseURL           string
+	MomoAPIUser           string
+	MomoAPIKey            string
+	MomoSubscriptionKey   string
+	MomoTargetEnvironment string
+	MomoTimeout           time.Duration
+
+	// Card and bank payments (hosted checkout)
+	CheckoutProvider  string // "none" (card and bank disabled) or "http"
+	CheckoutBaseURL   string
+	CheckoutSecretKey string
+	CheckoutReturnURL string // public URL of GET /api/payments/return
+	CheckoutResultURL string // page the payer is redirected to afterwards; empty returns JSON
+	CheckoutTimeout   time.Duration
+
+	// Payment poller
+	PaymentPollerEnabled   bool
+	PaymentPollInterval    time.Duration
+	PaymentPollBatchSize   int
+	PaymentPollBackoffBase time.Duration // wait before the first provider check, doubled after each
+	PaymentPollBackoffMax  time.Duration
+	PaymentPollGiveUpAfter time.Duration // fail payments the provider cannot confirm this long after expiry
 }
 
 func Load() (*Config, error) {
@@ -97,16 +152,33 @@ func Load() (*Config, error) {
 		JWTSecret:             getEnv("JWT_SECRET", "change-this-to-a-long-random-secret-in-production"),
 		JWTAccessTokenExpiry:  getEnvDuration("JWT_ACCESS_TOKEN_EXPIRY", 15*time.Minute),
 		JWTRefreshTokenExpiry: getEnvDuration("JWT_REFRESH_TOKEN_EXPIRY", 168*time.Hour),
+		JWTSigningAlg:         getEnv("JWT_SIGNING_ALG", "HS256"),
+		JWTKeyRotation:        getEnvDuration("JWT_KEY_ROTATION_INTERVAL", 720*time.Hour),
+		JWTAcceptHS256:        getEnvBool("JWT_ACCEPT_HS256", true),
+		JWTRevocationFailOpen: getEnvBool("JWT_REVOCATION_FAIL_OPEN", false),
 
 		// CORS
 		CORSAllowedOrigins: getEnvSlice("CORS_ALLOWED_ORIGINS", []string{"http://localhost:5173", "http://localhost:3000"}),
 		CORSAllowedMethods: getEnvSlice("CORS_ALLOWED_METHODS", []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"}),
-		CORSAllowedHeaders: getEnvSlice("CORS_ALLOWED_HEADERS", []string{"Authorization", "Content-Type", "X-Device-ID", "Accept"}),
+		CORSAllowedHeaders: getEnvSlice("CORS_ALLOWED_HEADERS", []string{"Authorization", "Content-Type", "X-Device-ID", "X-Device-Key", "Accept", "If-None-Match",
+			"X-App-Version", "X-Battery-Level", "X-Battery-Charging", "X-Network-Type", "X-Pending-Items", "Idempotency-Key"}),
 
 		// Storage
 		StorageDriver:    getEnv("STORAGE_DRIVER", "local"),
 		StorageLocalPath: getEnv("STORAGE_LOCAL_PATH", "./uploads"),
 
+		// Rate Limiting
+		RateLimitAuth:   getEnvInt("RATE_LIMIT_AUTH", 10),
+		RateLimitLogin:  getEnvInt("RATE_LIMIT_LOGIN", 100),
+		RateLimitWrite:  getEnvInt("RATE_LIMIT_WRITE", 60),
+		RateLimitRead:   getEnvInt("RATE_LIMIT_READ", 120),
+		RateLimitUpload: getEnvInt("RATE_LIMIT_UPLOAD", 20),
+		RateLimitSync:   getEnvInt("RATE_LIMIT_SYNC", 10),
+		RateLimitWindow: getEnvDuration("RATE_LIMIT_WINDOW", 60*time.Second),
+
+		// Idempotency
+		IdempotencyTTL: getEnvDuration("IDEMPOTENCY_TTL", 24*time.Hour),
+
 		// Logging
 		LogLevel:  getEnv("LOG_LEVEL", "debug"),
 		LogFormat: getEnv("LOG_FORMAT", "json"),
@@ -119,10 +191,84 @@ func Load() (*Config, error) {
 		MaxPhotoSizeMB:       getEnvInt("MAX_PHOTO_SIZE_MB", 5),
 		SyncBatchSize:        getEnvInt("SYNC_BATCH_SIZE", 50),
 		SyncMaxRetries:       getEnvInt("SYNC_MAX_RETRIES", 5),
+		SyncStaleMultiplier:  getEnvInt("SYNC_STALE_MULTIPLIER", 3),
+
+		// Device registry
+		DeviceEnrollmentRequired: getEnvBool("DEVICE_ENROLLMENT_REQUIRED", true),
+
+		// Multi-factor authentication
+		MFAEncryptionKey: getEnv("MFA_ENCRYPTION_KEY", ""),
+		MFAIssuer:        getEnv("MFA_ISSUER", "Ghana Police Service"),
+
+		// Password policy
+		BreachedPasswordsFile: getEnv("BREACHED_PASSWORDS_FILE", ""),
+
+		// Mobile money
+		MomoProvider:          getEnv("MOMO_PROVIDER", "mock"),
+		MomoBaseURL:           getEnv("MOMO_BASE_URL", "http://localhost:8090"),
+		MomoAPIUser:           getEnv("MOMO_API_USER", ""),
+		MomoAPIKey:            getEnv("MOMO_API_KEY", ""),
+		MomoSubscriptionKey:   getEnv("MOMO_SUBSCRIPTION_KEY", ""),
+		MomoTargetEnvironment: getEnv("MOMO_TARGET_ENVIRONMENT", "sandbox"),
+		MomoTimeout:           getEnvDuration("MOMO_TIMEOUT", 10*time.Second),
+
+		// Card and bank payments
+		CheckoutProvider:  getEnv("CHECKOUT_PROVIDER", "none"),
+		CheckoutBaseURL:   getEnv("CHECKOUT_BASE_URL", "http://localhost:8091"),
+		CheckoutSecretKey: getEnv("CHECKOUT_SECRET_KEY", ""),
+		CheckoutReturnURL: getEnv("CHECKOUT_RETURN_URL", "http://localhost:8000/api/payments/return"),
+		CheckoutResultURL: getEnv("CHECKOUT_RESULT_URL", ""),
+		CheckoutTimeout:   getEnvDuration("CHECKOUT_TIMEOUT", 10*time.Second),
+
+		// Payment poller
+		PaymentPollerEnabled:   getEnvBool("PAYMENT_POLLER_ENABLED", true),
+		PaymentPollInterval:    getEnvDuration("PAYMENT_POLL_INTERVAL", 15*time.Second),
+		PaymentPollBatchSize:   getEnvInt("PAYMENT_POLL_BATCH_SIZE", 50),
+		PaymentPollBackoffBase: getEnvDuration("PAYMENT_POLL_BACKOFF_BASE", 10*time.Second),
+		PaymentPollBackoffMax:  getEnvDuration("PAYMENT_POLL_BACKOFF_MAX", 5*time.Minute),
+		PaymentPollGiveUpAfter: getEnvDuration("PAYMENT_POLL_GIVE_UP_AFTER", 24*time.Hour),
+	}
+
+	if cfg.AppEnv == "production" {
+		// JWT_SECRET also backs other secrets, so it is checked whatever the signing algorithm
+		if cfg.JWTSecret == "change-this-to-a-long-random-secret-in-production" {
+			return nil, fmt.Errorf("JWT_SECRET must be set in production")
+		}
+		if cfg.MFAEncryptionKey == "" {
+			return nil, fmt.Errorf("MFA_ENCRYPTION_KEY must be set in production")
+		}
+	}
+	if cfg.MFAEncryptionKey == "" {
+		cfg.MFAEncryptionKey = cfg.JWTSecret
+	}
+
+	if !jwtpkg.ValidAlgorithm(cfg.JWTSigningAlg) {
+		return nil, fmt.Errorf("JWT_SIGNING_ALG must be HS256, RS256 or EdDSA")
+	}
+
+	switch cfg.MomoProvider {
+	case "mock":
+	case "http":
+		if cfg.MomoAPIUser == "" || cfg.MomoAPIKey == "" || cfg.MomoSubscriptionKey == "" {
+			return nil, fmt.Errorf("MOMO_API_USER, MOMO_API_KEY and MOMO_SUBSCRIPTION_KEY are required when MOMO_PROVIDER=http")
+		}
+	default:
+		return nil, fmt.Errorf("MOMO_PROVIDER must be mock or http")
 	}
 
-	if cfg.JWTSecret == "change-this-to-a-long-random-secret-in-production" && cfg.AppEnv == "production" {
-		return nil, fmt.Errorf("JWT_SECRET must be set in production")
+	switch cfg.CheckoutProvider {
+	case "none":
+	case "http":
+		if cfg.CheckoutSecretKey == "" {
+			return nil, fmt.Errorf("CHECKOUT_SECRET_KEY is required when CHECKOUT_PROVIDER=http")
+		}
+	default:
+		return nil, fmt.Errorf("CHECKOUT_PROVIDER must be none or http")
+	}
+	if cfg.CheckoutResultURL != "" {
+		if u, err := url.Parse(cfg.CheckoutResultURL); err != nil || !u.IsAbs() {
+			return nil, fmt.Errorf("CHECKOUT_RESULT_URL must be an absolute URL")
+		}
 	}
 
 	return cfg, nil
@@ -151,6 +297,15 @@ func getEnvInt(key string, fallback int) int {
 	return fallback
 }
 
+func getEnvBool(key string, fallback bool) bool {
+	if v := os.Getenv(key); v != "" {
+		if b, err := strconv.ParseBool(v); err == nil {
+			return b
+		}
+	}
+	return fallback
+}
+
 func getEnvDuration(key string, fallback time.Duration) time.Duration {
 	if v := os.Getenv(key); v != "" {
 		if d, err := time.ParseDuration(v); err == nil {
@@ -0,0 +1,66 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLoadProductionSecrets(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		wantErr string
+	}{
+		{
+			name:    "default JWT secret with asymmetric signing",
+			env:     map[string]string{"JWT_SIGNING_ALG": "EdDSA", "MFA_ENCRYPTION_KEY": "mfa-key"},
+			wantErr: "JWT_SECRET",
+		},
+		{
+			name:    "missing MFA key",
+			env:     map[string]string{"JWT_SECRET": "a-real-secret"},
+			wantErr: "MFA_ENCRYPTION_KEY",
+		},
+		{
+			name: "both set",
+			env:  map[string]string{"JWT_SECRET": "a-real-secret", "MFA_ENCRYPTION_KEY": "mfa-key"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("APP_ENV", "production")
+			t.Setenv("JWT_SECRET", "")
+			t.Setenv("JWT_SIGNING_ALG", "")
+			t.Setenv("MFA_ENCRYPTION_KEY", "")
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+
+			_, err := Load()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("err = %v, want mention of %s", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadDevelopmentMFAKeyDefault(t *testing.T) {
+	t.Setenv("APP_ENV", "development")
+	t.Setenv("JWT_SECRET", "dev-secret")
+	t.Setenv("MFA_ENCRYPTION_KEY", "")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.MFAEncryptionKey != "dev-secret" {
+		t.Errorf("MFAEncryptionKey = %q, want JWT_SECRET", cfg.MFAEncryptionKey)
+	}
+}
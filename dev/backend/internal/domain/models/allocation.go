@@ -0,0 +1,177 @@
+package models
+
+import (
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RevenueBeneficiary is a body that receives a share of fine revenue.
+type RevenueBeneficiary struct {
+	ID          uuid.UUID `json:"id"`
+	Code        string    `json:"code"`
+	Name        string    `json:"name"`
+	BankName    *string   `json:"bankName,omitempty"`
+	BankAccount *string   `json:"bankAccount,omitempty"`
+	IsActive    bool      `json:"isActive"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// AllocationRule splits payments between beneficiaries. A key left nil
+// matches any value; the rule with every key nil is the default.
+type AllocationRule struct {
+	ID              uuid.UUID         `json:"id"`
+	Name            string            `json:"name"`
+	OffenceCategory *string           `json:"offenceCategory,omitempty"`
+	RegionID        *uuid.UUID        `json:"regionId,omitempty"`
+	RegionName      *string           `json:"regionName,omitempty"`
+	PaymentMethod   *string           `json:"paymentMethod,omitempty"`
+	IsActive        bool              `json:"isActive"`
+	Shares          []AllocationShare `json:"shares"`
+	CreatedByID     *uuid.UUID        `json:"createdById,omitempty"`
+	UpdatedByID     *uuid.UUID        `json:"updatedById,omitempty"`
+	CreatedAt       time.Time         `json:"createdAt"`
+	UpdatedAt       time.Time         `json:"updatedAt"`
+}
+
+// AllocationShare is one beneficiary's percentage under a rule.
+type AllocationShare struct {
+	BeneficiaryID   uuid.UUID `json:"beneficiaryId"`
+	BeneficiaryName string    `json:"beneficiaryName,omitempty"`
+	Percentage      float64   `json:"percentage"`
+}
+
+// IsDefault reports whether the rule matches every payment.
+func (r *AllocationRule) IsDefault() bool {
+	return r.OffenceCategory == nil && r.RegionID == nil && r.PaymentMethod == nil
+}
+
+// Matches reports whether the rule applies to a payment by method for an
+// offence category on a ticket issued in the region.
+func (r *AllocationRule) Matches(category string, regionID uuid.UUID, method string) bool {
+	return (r.OffenceCategory == nil || *r.OffenceCategory == category) &&
+		(r.RegionID == nil || *r.RegionID == regionID) &&
+		(r.PaymentMethod == nil || *r.PaymentMethod == method)
+}
+
+// specificity ranks matching rules: a category match outweighs a region
+// match, which outweighs a payment method match.
+func (r *AllocationRule) specificity() int {
+	n := 0
+	if r.OffenceCategory != nil {
+		n += 4
+	}
+	if r.RegionID != nil {
+		n += 2
+	}
+	if r.PaymentMethod != nil {
+		n++
+	}
+	return n
+}
+
+// MatchRule returns the most specific active rule that applies, or nil.
+// Active rules never share all three keys, so there is no tie.
+func MatchRule(rules []AllocationRule, category string, regionID uuid.UUID, method string) *AllocationRule {
+	var best *AllocationRule
+	for i := range rules {
+		r := &rules[i]
+		if !r.IsActive || !r.Matches(category, regionID, method) {
+			continue
+		}
+		if best == nil || r.specificity() > best.specificity() {
+			best = r
+		}
+	}
+	return best
+}
+
+// Apportion splits an amount in proportion to weights, or equally when the
+// weights are all zero. It works in pesewas so the parts add up exactly; the
+// last part takes what rounding leaves.
+func Apportion(amount float64, weights []float64) []float64 {
+	parts := make([]float64, len(weights))
+	if len(weights) == 0 {
+		return parts
+	}
+	var total float64
+	for _, w := range weights {
+		total += w
+	}
+	if total <= 0 {
+		weights = make([]float64, len(weights))
+		for i := range weights {
+			weights[i] = 1
+		}
+		total = float64(len(weights))
+	}
+
+	amountMinor := toMinor(amount)
+	var given int64
+	for i, w := range weights {
+		part := int64(math.Round(float64(amountMinor) * w / total))
+		if i == len(weights)-1 {
+			part = amountMinor - given
+		}
+		given += part
+		parts[i] = float64(part) / 100
+	}
+	return parts
+}
+
+// PaymentAllocation is the share of one payment, for one offence category,
+// that goes to one beneficiary. A refund reverses it with a negative amount.
+type PaymentAllocation struct {
+	ID               uuid.UUID  `json:"id"`
+	PaymentID        uuid.UUID  `json:"paymentId"`
+	PaymentReference string     `json:"paymentReference"`
+	PaymentMethod    string     `json:"paymentMethod"`
+	BeneficiaryID    uuid.UUID  `json:"beneficiaryId"`
+	BeneficiaryName  string     `json:"beneficiaryName"`
+	RuleID           uuid.UUID  `json:"ruleId"`
+	OffenceCategory  *string    `json:"offenceCategory,omitempty"`
+	Percentage       float64    `json:"percentage"`
+	Amount           float64    `json:"amount"`
+	Reversal         bool       `json:"reversal"`
+	Period           string     `json:"period"` // YYYY-MM
+	StationID        *uuid.UUID `json:"stationId,omitempty"`
+	RegionID         *uuid.UUID `json:"regionId,omitempty"`
+	CreatedAt        time.Time  `json:"createdAt"`
+}
+
+// PaymentAllocationFilter holds query parameters for allocation listing.
+type PaymentAllocationFilter struct {
+	PaymentID     *uuid.UUID
+	BeneficiaryID *uuid.UUID
+	Period        *string
+	StationID     *uuid.UUID
+	RegionID      *uuid.UUID
+	Scope         *Jurisdiction
+}
+
+// DisbursementReport is the transfer schedule for a period: what each
+// beneficiary is owed from the payments completed and refunded in it.
+type DisbursementReport struct {
+	Period    string             `json:"period"`
+	Lines     []DisbursementLine `json:"lines"`
+	Allocated float64            `json:"allocated"`
+	Reversed  float64            `json:"reversed"`
+	Due       float64            `json:"due"`
+}
+
+// DisbursementLine is one beneficiary's transfer, optionally for one region.
+type DisbursementLine struct {
+	BeneficiaryID   uuid.UUID  `json:"beneficiaryId"`
+	BeneficiaryCode string     `json:"beneficiaryCode"`
+	BeneficiaryName string     `json:"beneficiaryName"`
+	BankName        *string    `json:"bankName,omitempty"`
+	BankAccount     *string    `json:"bankAccount,omitempty"`
+	RegionID        *uuid.UUID `json:"regionId,omitempty"`
+	RegionName      *string    `json:"regionName,omitempty"`
+	PaymentCount    int        `json:"paymentCount"`
+	Allocated       float64    `json:"allocated"` // from payments completed in the period
+	Reversed        float64    `json:"reversed"`  // taken back for payments refunded in the period
+	Due             float64    `json:"due"`       // allocated - reversed: the amount to transfer
+}
@@ -25,6 +25,7 @@ type AuditLog struct {
 	IPAddress       *string     `json:"ipAddress,omitempty"`
 	UserAgent       *string     `json:"userAgent,omitempty"`
 	SessionID       *string     `json:"sessionId,omitempty"`
+	APIKeyID        *uuid.UUID  `json:"apiKeyId,omitempty"` // set when a service account acted through an API key
 	StationID       *uuid.UUID  `json:"stationId,omitempty"`
 	StationName     *string     `json:"stationName,omitempty"`
 	RegionID        *uuid.UUID  `json:"regionId,omitempty"`
@@ -40,6 +41,7 @@ type AuditFilter struct {
 	Action     *string
 	EntityType *string
 	UserID     *uuid.UUID
+	APIKeyID   *uuid.UUID
 	Severity   *string
 	DateFrom   *time.Time
 	DateTo     *time.Time
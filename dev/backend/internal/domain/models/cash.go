@@ -0,0 +1,128 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Cashier shift statuses.
+const (
+	ShiftOpen   = "open"
+	ShiftClosed = "closed"
+)
+
+// Cash remittance statuses.
+const (
+	RemittancePending  = "pending"
+	RemittanceApproved = "approved"
+	RemittanceRejected = "rejected"
+)
+
+// CashierShift is the period one cashier takes cash at a station, from the
+// opening float to the cash-up.
+type CashierShift struct {
+	ID            uuid.UUID  `json:"id"`
+	StationID     uuid.UUID  `json:"stationId"`
+	StationName   string     `json:"stationName"`
+	CashierID     uuid.UUID  `json:"cashierId"`
+	CashierName   string     `json:"cashierName"`
+	Status        string     `json:"status"`
+	OpeningFloat  float64    `json:"openingFloat"`
+	PaymentCount  int        `json:"paymentCount"`  // cash payments taken in the shift
+	CashCollected float64    `json:"cashCollected"` // their total
+	OpenedAt      time.Time  `json:"openedAt"`
+	ClosedAt      *time.Time `json:"closedAt,omitempty"`
+	ClosedByID    *uuid.UUID `json:"closedById,omitempty"`
+	ExpectedCash  *float64   `json:"expectedCash,omitempty"` // opening float + cash collected, at cash-up
+	CountedCash   *float64   `json:"countedCash,omitempty"`
+	Variance      *float64   `json:"variance,omitempty"` // counted - expected
+	CloseNotes    *string    `json:"closeNotes,omitempty"`
+	RemittanceID  *uuid.UUID `json:"remittanceId,omitempty"`
+	CreatedAt     time.Time  `json:"createdAt"`
+	UpdatedAt     time.Time  `json:"updatedAt"`
+}
+
+// Banked is the cash a closed shift hands over for deposit: what was counted,
+// less the float that stays in the drawer.
+func (s *CashierShift) Banked() float64 {
+	if s.CountedCash == nil {
+		return 0
+	}
+	return *s.CountedCash - s.OpeningFloat
+}
+
+// CashRemittance is a bank deposit of the cash from one or more closed shifts
+// of a station.
+type CashRemittance struct {
+	ID                uuid.UUID      `json:"id"`
+	StationID         uuid.UUID      `json:"stationId"`
+	StationName       string         `json:"stationName"`
+	DepositSlipNumber string         `json:"depositSlipNumber"`
+	BankName          string         `json:"bankName"`
+	BankAccount       *string        `json:"bankAccount,omitempty"`
+	DepositedAt       time.Time      `json:"depositedAt"`
+	ExpectedAmount    float64        `json:"expectedAmount"`
+	DepositedAmount   float64        `json:"depositedAmount"`
+	Variance          float64        `json:"variance"` // deposited - expected
+	Status            string         `json:"status"`
+	Notes             *string        `json:"notes,omitempty"`
+	SubmittedByID     uuid.UUID      `json:"submittedById"`
+	SubmittedByName   string         `json:"submittedByName"`
+	ReviewedByID      *uuid.UUID     `json:"reviewedById,omitempty"`
+	ReviewedByName    *string        `json:"reviewedByName,omitempty"`
+	ReviewNotes       *string        `json:"reviewNotes,omitempty"`
+	ReviewedAt        *time.Time     `json:"reviewedAt,omitempty"`
+	CreatedAt         time.Time      `json:"createdAt"`
+	UpdatedAt         time.Time      `json:"updatedAt"`
+	Shifts            []CashierShift `json:"shifts,omitempty"`
+}
+
+// CashierShiftFilter holds query parameters for shift listing.
+type CashierShiftFilter struct {
+	StationID  *uuid.UUID
+	CashierID  *uuid.UUID
+	Status     *string
+	Unremitted *bool // closed shifts not on a pending or approved remittance
+	DateFrom   *time.Time
+	DateTo     *time.Time
+	Scope      *Jurisdiction
+}
+
+// CashRemittanceFilter holds query parameters for remittance listing.
+type CashRemittanceFilter struct {
+	StationID *uuid.UUID
+	Status    *string
+	DateFrom  *time.Time
+	DateTo    *time.Time
+	Scope     *Jurisdiction
+}
+
+// TreasuryReport follows station cash from collection to the bank.
+type TreasuryReport struct {
+	Days     []TreasuryDay     `json:"days"`
+	Stations []StationCashHeld `json:"stations"`
+}
+
+// TreasuryDay is one station's cash on one day.
+type TreasuryDay struct {
+	Date            string    `json:"date"` // YYYY-MM-DD
+	StationID       uuid.UUID `json:"stationId"`
+	StationName     string    `json:"stationName"`
+	PaymentCount    int       `json:"paymentCount"`
+	CashCollected   float64   `json:"cashCollected"`
+	ShiftsClosed    int       `json:"shiftsClosed"`
+	CashUpVariance  float64   `json:"cashUpVariance"`  // over (+) or short (-) at cash-up
+	Deposited       float64   `json:"deposited"`       // signed-off remittances deposited that day
+	AwaitingSignOff float64   `json:"awaitingSignOff"` // remittances deposited that day, not yet signed off
+}
+
+// StationCashHeld is the cash a station holds now that has not been banked.
+type StationCashHeld struct {
+	StationID       uuid.UUID `json:"stationId"`
+	StationName     string    `json:"stationName"`
+	OpenShifts      int       `json:"openShifts"`
+	InOpenShifts    float64   `json:"inOpenShifts"`    // collected in shifts still open
+	AwaitingDeposit float64   `json:"awaitingDeposit"` // counted at cash-up, not yet on a remittance
+	AwaitingSignOff float64   `json:"awaitingSignOff"` // deposited, remittance not yet signed off
+}
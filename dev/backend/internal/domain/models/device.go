@@ -0,0 +1,79 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Device statuses
+const (
+	DeviceStatusPending = "pending" // registered by an admin, awaiting enrollment
+	DeviceStatusActive  = "active"
+	DeviceStatusLost    = "lost"
+	DeviceStatusRetired = "retired"
+)
+
+// Device commands
+const (
+	DeviceCommandLock   = "lock"   // lock the app until the officer signs in again
+	DeviceCommandWipe   = "wipe"   // erase local tickets, photos and credentials
+	DeviceCommandResync = "resync" // drop local caches and perform a full sync
+)
+
+// Device command statuses
+const (
+	DeviceCommandStatusPending      = "pending"
+	DeviceCommandStatusDelivered    = "delivered"
+	DeviceCommandStatusAcknowledged = "acknowledged"
+	DeviceCommandStatusCancelled    = "cancelled"
+)
+
+// Device is a handheld enrolled in the managed registry.
+type Device struct {
+	ID                  uuid.UUID  `json:"id"`
+	DeviceID            *string    `json:"deviceId,omitempty"` // hardware identifier sent as X-Device-ID
+	Name                string     `json:"name"`
+	Platform            *string    `json:"platform,omitempty"`
+	Model               *string    `json:"model,omitempty"`
+	AppVersion          *string    `json:"appVersion,omitempty"`
+	Status              string     `json:"status"`
+	StatusReason        *string    `json:"statusReason,omitempty"`
+	StationID           *uuid.UUID `json:"stationId,omitempty"`
+	OfficerID           *uuid.UUID `json:"officerId,omitempty"`
+	EnrollmentCodeHash  *string    `json:"-"`
+	EnrollmentExpiresAt *time.Time `json:"enrollmentExpiresAt,omitempty"`
+	KeyHash             *string    `json:"-"`
+	RegisteredBy        uuid.UUID  `json:"registeredBy"`
+	EnrolledAt          *time.Time `json:"enrolledAt,omitempty"`
+	LastSeenAt          *time.Time `json:"lastSeenAt,omitempty"`
+	CreatedAt           time.Time  `json:"createdAt"`
+	UpdatedAt           time.Time  `json:"updatedAt"`
+
+	// Joined fields
+	StationName *string    `json:"stationName,omitempty"`
+	RegionID    *uuid.UUID `json:"regionId,omitempty"`
+	OfficerName *string    `json:"officerName,omitempty"`
+}
+
+// DeviceFilter holds query parameters for device listing.
+type DeviceFilter struct {
+	Status    *string
+	StationID *uuid.UUID
+	RegionID  *uuid.UUID
+	OfficerID *uuid.UUID
+	Scope     *Jurisdiction // caller's jurisdiction, applied on top of the filters above
+}
+
+// DeviceCommand is a remote command queued for a device.
+type DeviceCommand struct {
+	ID             uuid.UUID  `json:"id"`
+	DeviceID       uuid.UUID  `json:"deviceId"`
+	Command        string     `json:"command"`
+	Status         string     `json:"status"`
+	Reason         *string    `json:"reason,omitempty"`
+	IssuedBy       uuid.UUID  `json:"issuedBy"`
+	DeliveredAt    *time.Time `json:"deliveredAt,omitempty"`
+	AcknowledgedAt *time.Time `json:"acknowledgedAt,omitempty"`
+	CreatedAt      time.Time  `json:"createdAt"`
+}
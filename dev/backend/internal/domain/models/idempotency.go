@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// IdempotencyRecord is what is kept under an Idempotency-Key: the fingerprint
+// of the request that first used the key and, once it has finished, the
+// response it got. StatusCode is 0 while the first request is still running.
+type IdempotencyRecord struct {
+	Fingerprint string    `json:"fingerprint"`
+	StatusCode  int       `json:"statusCode,omitempty"`
+	ContentType string    `json:"contentType,omitempty"`
+	Body        []byte    `json:"body,omitempty"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+// Completed reports whether the response has been stored.
+func (r *IdempotencyRecord) Completed() bool {
+	return r.StatusCode != 0
+}
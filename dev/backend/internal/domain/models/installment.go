@@ -0,0 +1,87 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Installment plan statuses.
+const (
+	InstallmentPlanActive    = "active"
+	InstallmentPlanCompleted = "completed"
+	InstallmentPlanCancelled = "cancelled"
+)
+
+// Installment statuses, derived from what has been paid and the due date.
+const (
+	InstallmentPending       = "pending"
+	InstallmentPartiallyPaid = "partially_paid"
+	InstallmentPaid          = "paid"
+	InstallmentOverdue       = "overdue"
+)
+
+// InstallmentPlan spreads a ticket's outstanding balance over a schedule of
+// installments. Payments on the ticket pay off the installments in order.
+type InstallmentPlan struct {
+	ID            uuid.UUID     `json:"id"`
+	TicketID      uuid.UUID     `json:"ticketId"`
+	TicketNumber  string        `json:"ticketNumber"`
+	TotalAmount   float64       `json:"totalAmount"`
+	PaidAmount    float64       `json:"paidAmount"`
+	Status        string        `json:"status"`
+	Notes         *string       `json:"notes,omitempty"`
+	CreatedByID   *uuid.UUID    `json:"createdById,omitempty"`
+	CancelledByID *uuid.UUID    `json:"cancelledById,omitempty"`
+	CancelReason  *string       `json:"cancelReason,omitempty"`
+	CancelledAt   *time.Time    `json:"cancelledAt,omitempty"`
+	CompletedAt   *time.Time    `json:"completedAt,omitempty"`
+	CreatedAt     time.Time     `json:"createdAt"`
+	UpdatedAt     time.Time     `json:"updatedAt"`
+	Installments  []Installment `json:"installments"`
+}
+
+// Installment is one scheduled part of an installment plan.
+type Installment struct {
+	ID         uuid.UUID  `json:"id"`
+	Sequence   int        `json:"sequence"`
+	DueDate    time.Time  `json:"dueDate"`
+	Amount     float64    `json:"amount"`
+	PaidAmount float64    `json:"paidAmount"`
+	Status     string     `json:"status"`
+	PaidAt     *time.Time `json:"paidAt,omitempty"`
+}
+
+// Remaining is what is still owed on the installment.
+func (i *Installment) Remaining() float64 {
+	if r := i.Amount - i.PaidAmount; r > 0.005 {
+		return r
+	}
+	return 0
+}
+
+// SetStatus derives the installment's status as of now.
+func (i *Installment) SetStatus(now time.Time) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	switch {
+	case i.Remaining() == 0:
+		i.Status = InstallmentPaid
+	case i.DueDate.Before(today):
+		i.Status = InstallmentOverdue
+	case i.PaidAmount > 0:
+		i.Status = InstallmentPartiallyPaid
+	default:
+		i.Status = InstallmentPending
+	}
+}
+
+// NextDue returns the first installment not fully paid, or nil when the plan
+// is paid off.
+func (p *InstallmentPlan) NextDue() *Installment {
+	for i := range p.Installments {
+		if p.Installments[i].Remaining() > 0 {
+			return &p.Installments[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package models
+
+import "github.com/google/uuid"
+
+// Jurisdiction levels, narrowest first.
+const (
+	ScopeNone     = "none" // the caller's assignment is missing; nothing is visible
+	ScopeStation  = "station"
+	ScopeDistrict = "district" // every station in the district of the caller's station
+	ScopeRegion   = "region"
+	ScopeNational = "national"
+)
+
+// JurisdictionPolicy is the part of the hierarchy each role reads and acts
+// within. Roles not listed see nothing.
+var JurisdictionPolicy = map[string]string{
+	"officer":     ScopeStation,
+	"supervisor":  ScopeDistrict,
+	"admin":       ScopeRegion,
+	"accountant":  ScopeRegion,
+	"super_admin": ScopeNational,
+}
+
+// Entities whose rows are scoped by jurisdiction.
+const (
+	EntityTicket         = "ticket"
+	EntityPayment        = "payment"
+	EntityObjection      = "objection"
+	EntityOfficer        = "officer"
+	EntityDevice         = "device"
+	EntitySyncConflict   = "sync_conflict"
+	EntityStation        = "station"
+	EntityCashierShift   = "cashier_shift"
+	EntityCashRemittance = "cash_remittance"
+	EntityLedgerJournal  = "ledger_journal"
+)
+
+// Jurisdiction is the scope of one caller. A district scope is that of the
+// caller's station.
+type Jurisdiction struct {
+	Level     string
+	StationID *uuid.UUID
+	RegionID  *uuid.UUID
+}
+
+// JurisdictionFor applies JurisdictionPolicy to a caller. A caller whose
+// station or region is needed but unknown gets ScopeNone.
+func JurisdictionFor(role string, stationID, regionID *uuid.UUID) Jurisdiction {
+	level, ok := JurisdictionPolicy[role]
+	if !ok {
+		return Jurisdiction{Level: ScopeNone}
+	}
+
+	j := Jurisdiction{Level: level, StationID: stationID, RegionID: regionID}
+	switch level {
+	case ScopeStation, ScopeDistrict:
+		if stationID == nil {
+			j.Level = ScopeNone
+		}
+	case ScopeRegion:
+		if regionID == nil {
+			j.Level = ScopeNone
+		}
+	}
+	return j
+}
+
+// Unrestricted reports whether the jurisdiction covers the whole country.
+func (j Jurisdiction) Unrestricted() bool {
+	return j.Level == ScopeNational
+}
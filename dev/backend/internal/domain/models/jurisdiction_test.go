@@ -0,0 +1,41 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestJurisdictionFor(t *testing.T) {
+	station, region := uuid.New(), uuid.New()
+
+	tests := []struct {
+		role      string
+		stationID *uuid.UUID
+		regionID  *uuid.UUID
+		want      string
+	}{
+		{"officer", &station, &region, ScopeStation},
+		{"officer", nil, &region, ScopeNone},
+		{"supervisor", &station, &region, ScopeDistrict},
+		{"supervisor", nil, &region, ScopeNone},
+		{"admin", nil, &region, ScopeRegion},
+		{"admin", &station, nil, ScopeNone},
+		{"accountant", nil, &region, ScopeRegion},
+		{"accountant", nil, nil, ScopeNone},
+		{"super_admin", nil, nil, ScopeNational},
+		{"", &station, &region, ScopeNone},
+		{"unknown", &station, &region, ScopeNone},
+	}
+
+	for _, tt := range tests {
+		got := JurisdictionFor(tt.role, tt.stationID, tt.regionID)
+		if got.Level != tt.want {
+			t.Errorf("JurisdictionFor(%q, station=%t, region=%t) = %s, want %s",
+				tt.role, tt.stationID != nil, tt.regionID != nil, got.Level, tt.want)
+		}
+		if got.Unrestricted() != (tt.want == ScopeNational) {
+			t.Errorf("JurisdictionFor(%q).Unrestricted() = %t", tt.role, got.Unrestricted())
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package models
+
+import (
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Ledger account codes.
+const (
+	AccountFinesReceivable = "fines_receivable"
+	AccountCollections     = "collections"
+	AccountFineRevenue     = "fine_revenue"
+	AccountLateFeeRevenue  = "late_fee_revenue"
+	AccountWaivers         = "waivers"
+	AccountRefunds         = "refunds"
+)
+
+// Journal kinds: the financial event a journal records.
+const (
+	JournalOpeningBalance  = "opening_balance"
+	JournalTicketIssued    = "ticket_issued"
+	JournalFineAdjusted    = "fine_adjusted"
+	JournalTicketWaived    = "ticket_waived"
+	JournalPaymentReceived = "payment_received"
+	JournalPaymentRefunded = "payment_refunded"
+)
+
+// Ledger period statuses.
+const (
+	PeriodOpen   = "open"
+	PeriodClosed = "closed"
+)
+
+// LedgerAccount is an account of the revenue ledger's chart of accounts.
+type LedgerAccount struct {
+	Code          string `json:"code"`
+	Name          string `json:"name"`
+	Type          string `json:"type"`          // asset, revenue or contra_revenue
+	NormalBalance string `json:"normalBalance"` // debit or credit
+	Description   string `json:"description"`
+}
+
+// LedgerJournal is one financial event, posted as entries whose debits and
+// credits balance. Journals are never changed once posted.
+type LedgerJournal struct {
+	ID           uuid.UUID     `json:"id"`
+	Kind         string        `json:"kind"`
+	Period       string        `json:"period"` // YYYY-MM
+	OccurredAt   time.Time     `json:"occurredAt"`
+	PostedAt     time.Time     `json:"postedAt"`
+	TicketID     uuid.UUID     `json:"ticketId"`
+	TicketNumber string        `json:"ticketNumber,omitempty"`
+	PaymentID    *uuid.UUID    `json:"paymentId,omitempty"`
+	StationID    *uuid.UUID    `json:"stationId,omitempty"`
+	RegionID     *uuid.UUID    `json:"regionId,omitempty"`
+	Memo         string        `json:"memo"`
+	PostedByID   *uuid.UUID    `json:"postedById,omitempty"`
+	Entries      []LedgerEntry `json:"entries"`
+}
+
+// LedgerEntry is one side of a journal on one account.
+type LedgerEntry struct {
+	AccountCode string  `json:"accountCode"`
+	Debit       float64 `json:"debit"`
+	Credit      float64 `json:"credit"`
+}
+
+// Balanced reports whether the journal's debits equal its credits.
+func (j *LedgerJournal) Balanced() bool {
+	var debits, credits int64
+	for _, e := range j.Entries {
+		debits += toMinor(e.Debit)
+		credits += toMinor(e.Credit)
+	}
+	return debits == credits && debits > 0
+}
+
+// toMinor converts an amount in cedis to pesewas.
+func toMinor(amount float64) int64 {
+	return int64(math.Round(amount * 100))
+}
+
+// LedgerJournalFilter holds query parameters for journal listing.
+type LedgerJournalFilter struct {
+	Kind      *string
+	Period    *string
+	TicketID  *uuid.UUID
+	PaymentID *uuid.UUID
+	StationID *uuid.UUID
+	RegionID  *uuid.UUID
+	Account   *string
+	DateFrom  *time.Time
+	DateTo    *time.Time
+	Scope     *Jurisdiction
+}
+
+// LedgerPeriod is a calendar month of the ledger. A month gets its row when
+// the first journal is posted to it or when it is closed.
+type LedgerPeriod struct {
+	Period       string     `json:"period"`
+	Status       string     `json:"status"`
+	JournalCount int        `json:"journalCount"`
+	TotalDebits  float64    `json:"totalDebits"`
+	TotalCredits float64    `json:"totalCredits"`
+	ClosedByID   *uuid.UUID `json:"closedById,omitempty"`
+	ClosedAt     *time.Time `json:"closedAt,omitempty"`
+	ReopenedByID *uuid.UUID `json:"reopenedById,omitempty"`
+	ReopenedAt   *time.Time `json:"reopenedAt,omitempty"`
+	ReopenReason *string    `json:"reopenReason,omitempty"`
+}
+
+// TrialBalance lists every account's balance for a period. Closing debit and
+// credit balances add up to the same total when the ledger balances.
+type TrialBalance struct {
+	Period        string             `json:"period"`
+	Status        string             `json:"status"`
+	Lines         []TrialBalanceLine `json:"lines"`
+	PeriodDebits  float64            `json:"periodDebits"`
+	PeriodCredits float64            `json:"periodCredits"`
+	ClosingDebit  float64            `json:"closingDebit"`
+	ClosingCredit float64            `json:"closingCredit"`
+	Balanced      bool               `json:"balanced"`
+}
+
+// TrialBalanceLine is one account, optionally for one station or region.
+// Balances are signed in the account's normal direction.
+type TrialBalanceLine struct {
+	AccountCode    string     `json:"accountCode"`
+	AccountName    string     `json:"accountName"`
+	AccountType    string     `json:"accountType"`
+	StationID      *uuid.UUID `json:"stationId,omitempty"`
+	StationName    *string    `json:"stationName,omitempty"`
+	RegionID       *uuid.UUID `json:"regionId,omitempty"`
+	RegionName     *string    `json:"regionName,omitempty"`
+	OpeningBalance float64    `json:"openingBalance"`
+	Debits         float64    `json:"debits"`
+	Credits        float64    `json:"credits"`
+	ClosingBalance float64    `json:"closingBalance"`
+	ClosingDebit   float64    `json:"closingDebit"`
+	ClosingCredit  float64    `json:"closingCredit"`
+}
@@ -0,0 +1,43 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MFARoles are the roles that may enrol in TOTP multi-factor authentication.
+var MFARoles = []string{"admin", "accountant", "super_admin"}
+
+// UserMFA is a user's stored MFA state. Secrets are sealed.
+type UserMFA struct {
+	UserID        uuid.UUID
+	Enabled       bool
+	Secret        *string
+	PendingSecret *string
+	EnrolledAt    *time.Time
+	LastStep      int64
+}
+
+// MFAStatus is the API view of a user's MFA state.
+type MFAStatus struct {
+	Enabled                bool       `json:"enabled"`
+	Required               bool       `json:"required"`
+	EnrolledAt             *time.Time `json:"enrolledAt,omitempty"`
+	RecoveryCodesRemaining int        `json:"recoveryCodesRemaining"`
+}
+
+// MFAChallenge is the pending second step of a login.
+type MFAChallenge struct {
+	ID         uuid.UUID  `json:"id"`
+	UserID     uuid.UUID  `json:"userId"`
+	TokenHash  string     `json:"-"`
+	Setup      bool       `json:"setup"`
+	DeviceID   *string    `json:"deviceId,omitempty"`
+	DeviceInfo any        `json:"deviceInfo,omitempty"`
+	DeviceRef  *uuid.UUID `json:"deviceRef,omitempty"`
+	Attempts   int        `json:"attempts"`
+	ExpiresAt  time.Time  `json:"expiresAt"`
+	UsedAt     *time.Time `json:"usedAt,omitempty"`
+	CreatedAt  time.Time  `json:"createdAt"`
+}
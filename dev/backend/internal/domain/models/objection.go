@@ -93,6 +93,7 @@ type ObjectionFilter struct {
 	RegionID  *uuid.UUID
 	MinAmount *float64
 	MaxAmount *float64
+	Scope     *Jurisdiction // caller's jurisdiction, applied on top of the filters above
 }
 
 // ObjectionStats for the stats endpoint.
@@ -0,0 +1,60 @@
+package models
+
+import "time"
+
+// Password limits that hold whatever the settings say.
+const (
+	MinPasswordLength  = 6
+	MaxPasswordBytes   = 72 // bcrypt ignores anything longer
+	MaxPasswordHistory = 24
+)
+
+// PasswordPolicy is the password rules, stored as flat keys in the security
+// settings section.
+type PasswordPolicy struct {
+	MinLength        int  `json:"passwordMinLength"`
+	RequireUppercase bool `json:"passwordRequireUppercase"`
+	RequireLowercase bool `json:"passwordRequireLowercase"`
+	RequireDigit     bool `json:"passwordRequireDigit"`
+	RequireSymbol    bool `json:"passwordRequireSymbol"`
+	// HistoryCount is how many recent passwords, the current one included,
+	// cannot be chosen again.
+	HistoryCount int `json:"passwordHistoryCount"`
+	// MaxAgeDays forces a change once a password is this old; 0 disables it.
+	MaxAgeDays int `json:"passwordMaxAgeDays"`
+	// RequireChange makes passwords set by an administrator (new accounts and
+	// resets) temporary: they must be changed at first login.
+	RequireChange bool `json:"requirePasswordChange"`
+}
+
+// DefaultPasswordPolicy applies when the security settings cannot be read.
+func DefaultPasswordPolicy() PasswordPolicy {
+	return PasswordPolicy{
+		MinLength:        8,
+		RequireUppercase: true,
+		RequireLowercase: true,
+		RequireDigit:     true,
+		HistoryCount:     5,
+		MaxAgeDays:       90,
+		RequireChange:    true,
+	}
+}
+
+// Clamp brings out-of-range settings back within the fixed limits.
+func (p PasswordPolicy) Clamp() PasswordPolicy {
+	p.MinLength = max(p.MinLength, MinPasswordLength)
+	p.MinLength = min(p.MinLength, MaxPasswordBytes)
+	p.HistoryCount = max(p.HistoryCount, 1)
+	p.HistoryCount = min(p.HistoryCount, MaxPasswordHistory)
+	p.MaxAgeDays = max(p.MaxAgeDays, 0)
+	return p
+}
+
+// Expired reports whether a password last changed at changedAt is past the
+// maximum age.
+func (p PasswordPolicy) Expired(changedAt, now time.Time) bool {
+	if p.MaxAgeDays == 0 {
+		return false
+	}
+	return now.After(changedAt.AddDate(0, 0, p.MaxAgeDays))
+}
@@ -30,10 +30,12 @@ type Payment struct {
 	ProcessedByID    *uuid.UUID `json:"processedById,omitempty"`
 	ProcessedByName  *string    `json:"processedByName,omitempty"`
 	StationID        *uuid.UUID `json:"stationId,omitempty"`
+	ShiftID          *uuid.UUID `json:"shiftId,omitempty"` // cashier shift that took a cash payment
 	ProviderResponse *string    `json:"providerResponse,omitempty"`
 	ProcessedAt      *time.Time `json:"processedAt,omitempty"`
 	CompletedAt      *time.Time `json:"completedAt,omitempty"`
 	ExpiresAt        *time.Time `json:"expiresAt,omitempty"`
+	BalanceAfter     *float64   `json:"balanceAfter,omitempty"` // ticket balance once this payment completed
 	CreatedAt        time.Time  `json:"createdAt"`
 	UpdatedAt        time.Time  `json:"updatedAt"`
 }
@@ -46,8 +48,10 @@ type PaymentFilter struct {
 	DateTo        *time.Time
 	MinAmount     *float64
 	MaxAmount     *float64
+	TicketID      *uuid.UUID
 	StationID     *uuid.UUID
 	ProcessedByID *uuid.UUID
+	Scope         *Jurisdiction // caller's jurisdiction, applied through the paid ticket
 }
 
 // PaymentStats holds aggregate payment statistics.
@@ -77,6 +81,9 @@ type PaymentReceipt struct {
 	Method        string     `json:"method"`
 	TransactionID *string    `json:"transactionId,omitempty"`
 	PaidAt        *time.Time `json:"paidAt"`
+	TotalFine     float64    `json:"totalFine"`
+	BalanceBefore float64    `json:"balanceBefore"` // outstanding on the ticket before this payment
+	BalanceAfter  float64    `json:"balanceAfter"`  // still outstanding after it
 	ProcessedBy   *string    `json:"processedBy,omitempty"`
 	StationName   string     `json:"stationName"`
 }
@@ -86,3 +93,14 @@ var PaymentMethods = []string{"momo", "vodacash", "airteltigo", "bank", "card",
 
 // Valid payment statuses.
 var PaymentStatuses = []string{"pending", "processing", "completed", "failed", "refunded"}
+
+// PaymentPollResult counts what one pass of the payment poller did.
+type PaymentPollResult struct {
+	Polled    int // payments checked with their provider
+	Completed int
+	Failed    int // declined or rejected by the provider
+	Expired   int // still unpaid when the payment session ran out
+	Pending   int // checked and still waiting for the payer
+	Errors    int // provider or database errors; retried after backoff
+	Remaining int // payments awaiting the provider after the pass
+}
@@ -0,0 +1,87 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Permission keys. The catalogue itself lives in the permissions table; these
+// are the keys the router checks.
+const (
+	PermRegionManage         = "region.manage"
+	PermStationManage        = "station.manage"
+	PermStationDelete        = "station.delete"
+	PermOfficerManage        = "officer.manage"
+	PermUserMFAReset         = "user.mfa.reset"
+	PermUserSessionManage    = "user.session.manage"
+	PermTicketUpdate         = "ticket.update"
+	PermTicketVoid           = "ticket.void"
+	PermPaymentInitiate      = "payment.initiate"
+	PermPaymentCashRecord    = "payment.cash.record"
+	PermPaymentInstallment   = "payment.installment.manage"
+	PermPaymentReconcile     = "payment.reconcile"
+	PermPaymentRefund        = "payment.refund"
+	PermObjectionRead        = "objection.read"
+	PermObjectionReview      = "objection.review"
+	PermAuditRead            = "audit.read"
+	PermSyncConflictManage   = "sync.conflict.manage"
+	PermSyncHealthRead       = "sync.health.read"
+	PermDeviceManage         = "device.manage"
+	PermAnalyticsRead        = "analytics.read"
+	PermSettingsRead         = "settings.read"
+	PermSettingsWrite        = "settings.write"
+	PermOffenceManage        = "offence.manage"
+	PermOffenceDelete        = "offence.delete"
+	PermRoleManage           = "role.manage"
+	PermServiceAccountManage = "service_account.manage"
+	PermMetricsRead          = "metrics.read"
+	PermRemittanceApprove    = "cash.remittance.approve"
+	PermCashReportRead       = "cash.report.read"
+	PermLedgerRead           = "ledger.read"
+	PermLedgerPeriodClose    = "ledger.period.close"
+	PermLedgerPeriodReopen   = "ledger.period.reopen"
+	PermAllocationManage     = "allocation.manage"
+	PermDisbursementRead     = "disbursement.read"
+)
+
+// Roles are the user roles a permission set can be attached to.
+var Roles = []string{"officer", "supervisor", "admin", "accountant", "super_admin"}
+
+// Permission effects for per-user overrides.
+const (
+	PermissionGrant = "grant"
+	PermissionDeny  = "deny"
+)
+
+type Permission struct {
+	Key         string `json:"key"`
+	Category    string `json:"category"`
+	Description string `json:"description"`
+}
+
+// RolePermissions is the permission set held by a role.
+type RolePermissions struct {
+	Role        string   `json:"role"`
+	Permissions []string `json:"permissions"`
+}
+
+// UserPermission grants or denies one permission to a user regardless of
+// their role. A deny wins over the role and any grant.
+type UserPermission struct {
+	UserID     uuid.UUID  `json:"userId"`
+	Permission string     `json:"permission"`
+	Effect     string     `json:"effect"`
+	Reason     *string    `json:"reason,omitempty"`
+	GrantedBy  *uuid.UUID `json:"grantedBy,omitempty"`
+	CreatedAt  time.Time  `json:"createdAt"`
+}
+
+// UserPermissions is a user's effective permission set with the overrides
+// that produced it.
+type UserPermissions struct {
+	UserID      uuid.UUID        `json:"userId"`
+	Role        string           `json:"role"`
+	Permissions []string         `json:"permissions"`
+	Overrides   []UserPermission `json:"overrides"`
+}
@@ -0,0 +1,72 @@
+package models
+
+import "time"
+
+// Route classes used for rate limiting.
+const (
+	RateClassAuth   = "auth"
+	RateClassLogin  = "login" // sign-in attempts per IP, across accounts
+	RateClassWrite  = "write"
+	RateClassRead   = "read"
+	RateClassUpload = "upload"
+	RateClassSync   = "sync"
+)
+
+// RateLimits is the per-class request budget within one sliding window. It is
+// stored under "rateLimits" in the security settings section.
+type RateLimits struct {
+	WindowSeconds int `json:"windowSeconds"`
+	Auth          int `json:"auth"`
+	Login         int `json:"login"`
+	Write         int `json:"write"`
+	Read          int `json:"read"`
+	Upload        int `json:"upload"`
+	Sync          int `json:"sync"`
+}
+
+// For returns the limit for a route class (0 when the class is unknown).
+func (l RateLimits) For(class string) int {
+	switch class {
+	case RateClassAuth:
+		return l.Auth
+	case RateClassLogin:
+		return l.Login
+	case RateClassWrite:
+		return l.Write
+	case RateClassRead:
+		return l.Read
+	case RateClassUpload:
+		return l.Upload
+	case RateClassSync:
+		return l.Sync
+	}
+	return 0
+}
+
+// Merge returns l with every non-positive value taken from fallback.
+func (l RateLimits) Merge(fallback RateLimits) RateLimits {
+	pick := func(v, f int) int {
+		if v > 0 {
+			return v
+		}
+		return f
+	}
+	return RateLimits{
+		WindowSeconds: pick(l.WindowSeconds, fallback.WindowSeconds),
+		Auth:          pick(l.Auth, fallback.Auth),
+		Login:         pick(l.Login, fallback.Login),
+		Write:         pick(l.Write, fallback.Write),
+		Read:          pick(l.Read, fallback.Read),
+		Upload:        pick(l.Upload, fallback.Upload),
+		Sync:          pick(l.Sync, fallback.Sync),
+	}
+}
+
+// RateLimitResult is the outcome of counting one request.
+type RateLimitResult struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	Window    time.Duration
+	Reset     time.Duration // until the oldest request in the window expires
+}
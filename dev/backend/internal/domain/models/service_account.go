@@ -0,0 +1,60 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// APIKeyPrefix starts every API key, so the auth middleware can tell one from
+// a JWT in the Authorization header.
+const APIKeyPrefix = "gpk_"
+
+// ServiceAccountRoles are the roles a service account can take. Officers are
+// left out: their access depends on an officer record.
+var ServiceAccountRoles = []string{"supervisor", "admin", "accountant", "super_admin"}
+
+// ServiceAccount is a non-interactive identity for a machine integration. Its
+// role and station or region decide what it may do, like a user's; each API
+// key narrows that further to its scopes.
+type ServiceAccount struct {
+	ID          uuid.UUID  `json:"id"` // also the ID of the backing users row
+	Name        string     `json:"name"`
+	Description *string    `json:"description,omitempty"`
+	Role        string     `json:"role"`
+	StationID   *uuid.UUID `json:"stationId,omitempty"`
+	RegionID    *uuid.UUID `json:"regionId,omitempty"`
+	IsActive    bool       `json:"isActive"`
+	ActiveKeys  int        `json:"activeKeys"`
+	CreatedBy   *uuid.UUID `json:"createdBy,omitempty"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	UpdatedAt   time.Time  `json:"updatedAt"`
+}
+
+// APIKey is a credential of a service account. Only its hash is stored.
+type APIKey struct {
+	ID               uuid.UUID  `json:"id"`
+	ServiceAccountID uuid.UUID  `json:"serviceAccountId"`
+	Name             string     `json:"name"`
+	Prefix           string     `json:"prefix"` // identifies the key; not secret
+	KeyHash          string     `json:"-"`
+	Scopes           []string   `json:"scopes"`     // permission keys
+	AllowedIPs       []string   `json:"allowedIps"` // CIDR blocks; empty allows any address
+	ExpiresAt        *time.Time `json:"expiresAt,omitempty"`
+	LastUsedAt       *time.Time `json:"lastUsedAt,omitempty"`
+	LastUsedIP       *string    `json:"lastUsedIp,omitempty"`
+	RevokedAt        *time.Time `json:"revokedAt,omitempty"`
+	CreatedBy        *uuid.UUID `json:"createdBy,omitempty"`
+	CreatedAt        time.Time  `json:"createdAt"`
+}
+
+// APIKeyPrincipal is the caller of a request authenticated by an API key.
+type APIKeyPrincipal struct {
+	KeyID            uuid.UUID
+	ServiceAccountID uuid.UUID
+	Name             string
+	Role             string
+	StationID        *uuid.UUID
+	RegionID         *uuid.UUID
+	Scopes           []string
+}
@@ -0,0 +1,23 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Session is one signed-in device: a refresh token family that is still live.
+// Its ID is the family ID, carried as the sid claim of its access tokens.
+type Session struct {
+	ID         uuid.UUID  `json:"id"`
+	UserID     uuid.UUID  `json:"userId"`
+	DeviceID   *string    `json:"deviceId,omitempty"`
+	DeviceInfo any        `json:"deviceInfo,omitempty"`
+	DeviceRef  *uuid.UUID `json:"deviceRef,omitempty"`
+	IPAddress  *string    `json:"ipAddress,omitempty"`
+	UserAgent  *string    `json:"userAgent,omitempty"`
+	Current    bool       `json:"current"`    // the session making the request
+	LastUsedAt time.Time  `json:"lastUsedAt"` // last login or refresh
+	ExpiresAt  time.Time  `json:"expiresAt"`
+	CreatedAt  time.Time  `json:"createdAt"`
+}
@@ -44,7 +44,7 @@ func DefaultSettings() map[string]json.RawMessage {
 		"system":        json.RawMessage(`{"organizationName":"Ghana Police Service","timezone":"Africa/Accra","dateFormat":"DD/MM/YYYY","currency":"GHS","maintenanceMode":false}`),
 		"ticket":        json.RawMessage(`{"prefix":"GPS","paymentGraceDays":14,"objectionDeadlineDays":7,"maxPhotos":4,"maxPhotoSizeMB":5,"autoOverdueEnabled":true}`),
 		"notifications": json.RawMessage(`{"smsEnabled":true,"emailEnabled":true,"overdueReminderDays":[7,14],"paymentConfirmation":true}`),
-		"security":      json.RawMessage(`{"maxLoginAttempts":5,"lockoutDurationMinutes":30,"accessTokenExpiryMinutes":15,"refreshTokenExpiryDays":7,"passwordMinLength":8,"requirePasswordChange":true}`),
+		"security":      json.RawMessage(`{"maxLoginAttempts":5,"lockoutDurationMinutes":30,"accessTokenExpiryMinutes":15,"refreshTokenExpiryDays":7,"passwordMinLength":8,"passwordRequireUppercase":true,"passwordRequireLowercase":true,"passwordRequireDigit":true,"passwordRequireSymbol":false,"passwordHistoryCount":5,"passwordMaxAgeDays":90,"requirePasswordChange":true,"rateLimits":{"windowSeconds":60,"auth":10,"login":100,"write":60,"read":120,"upload":20,"sync":10},"mfaRequiredRoles":[]}`),
 		"data":          json.RawMessage(`{"syncBatchSize":50,"maxSyncRetries":5,"conflictResolution":"server-wins","dataRetentionDays":365}`),
 		"device":        json.RawMessage(`{"gpsRequired":true,"cameraRequired":false,"offlineEnabled":true,"autoSyncIntervalSeconds":300}`),
 	}
@@ -55,12 +55,52 @@ func DefaultSettings() map[string]json.RawMessage {
 // ---------------------------------------------------------------------------
 
 // LookupData is the combined reference data for offline caching.
+// For a delta request the lists hold only rows changed since the cursor and
+// Removed holds rows deactivated since then.
 type LookupData struct {
 	Offences     []LookupOffence     `json:"offences"`
 	Regions      []LookupRegion      `json:"regions"`
 	Stations     []LookupStation     `json:"stations"`
 	VehicleTypes []LookupVehicleType `json:"vehicleTypes"`
+	Removed      *LookupRemoved      `json:"removed,omitempty"`
+	Delta        bool                `json:"delta"`
+	Versions     LookupVersions      `json:"versions"`
+	Cursor       string              `json:"cursor"`
 	LastUpdated  time.Time           `json:"lastUpdated"`
+	ETag         string              `json:"-"`
+}
+
+// LookupVersions is the per-entity version (latest updated_at) of the reference data.
+type LookupVersions struct {
+	Offences     time.Time `json:"offences"`
+	Regions      time.Time `json:"regions"`
+	Stations     time.Time `json:"stations"`
+	VehicleTypes time.Time `json:"vehicleTypes"`
+}
+
+// Latest returns the most recent version across all entities.
+func (v LookupVersions) Latest() time.Time {
+	latest := v.Offences
+	for _, t := range []time.Time{v.Regions, v.Stations, v.VehicleTypes} {
+		if t.After(latest) {
+			latest = t
+		}
+	}
+	return latest
+}
+
+// Covers reports whether v is at least as new as other for every entity.
+func (v LookupVersions) Covers(other LookupVersions) bool {
+	return !other.Offences.After(v.Offences) && !other.Regions.After(v.Regions) &&
+		!other.Stations.After(v.Stations) && !other.VehicleTypes.After(v.VehicleTypes)
+}
+
+// LookupRemoved lists reference rows deactivated since the client's cursor.
+type LookupRemoved struct {
+	Offences     []uuid.UUID `json:"offences"`
+	Regions      []uuid.UUID `json:"regions"`
+	Stations     []uuid.UUID `json:"stations"`
+	VehicleTypes []uuid.UUID `json:"vehicleTypes"`
 }
 
 // LookupOffence is a minimal offence for the lookup endpoint.
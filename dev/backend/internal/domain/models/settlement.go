@@ -0,0 +1,91 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Settlement line match statuses.
+const (
+	SettlementMatched        = "matched"
+	SettlementUnmatched      = "unmatched"       // no payment of ours has this transaction ID or reference
+	SettlementAmountMismatch = "amount_mismatch" // the provider settled a different amount than we recorded
+	SettlementDuplicate      = "duplicate"       // the payment was already settled by another line
+)
+
+// Reconciliation worklist statuses of lines that did not match cleanly.
+const (
+	ReviewOpen     = "open"
+	ReviewResolved = "resolved"
+)
+
+// SettlementImport is one settlement statement received from a provider.
+type SettlementImport struct {
+	ID             uuid.UUID  `json:"id"`
+	Provider       string     `json:"provider"`
+	FileName       string     `json:"fileName"`
+	Checksum       string     `json:"checksum"`
+	LineCount      int        `json:"lineCount"`
+	MatchedCount   int        `json:"matchedCount"`
+	UnmatchedCount int        `json:"unmatchedCount"`
+	MismatchCount  int        `json:"mismatchCount"`
+	DuplicateCount int        `json:"duplicateCount"`
+	TotalAmount    float64    `json:"totalAmount"`
+	ImportedByID   *uuid.UUID `json:"importedById,omitempty"`
+	CreatedAt      time.Time  `json:"createdAt"`
+}
+
+// SettlementLine is one transaction on a settlement statement and what it
+// matched among our payments.
+type SettlementLine struct {
+	ID               uuid.UUID  `json:"id"`
+	ImportID         uuid.UUID  `json:"importId"`
+	Provider         string     `json:"provider"`
+	LineNumber       int        `json:"lineNumber"`
+	TransactionID    *string    `json:"transactionId,omitempty"`
+	Reference        *string    `json:"reference,omitempty"`
+	Amount           float64    `json:"amount"`
+	Fee              float64    `json:"fee"`
+	Currency         string     `json:"currency"`
+	SettledAt        time.Time  `json:"settledAt"`
+	PaymentID        *uuid.UUID `json:"paymentId,omitempty"`
+	PaymentReference *string    `json:"paymentReference,omitempty"`
+	PaymentAmount    *float64   `json:"paymentAmount,omitempty"`
+	MatchStatus      string     `json:"matchStatus"`
+	ReviewStatus     *string    `json:"reviewStatus,omitempty"`
+	Resolution       *string    `json:"resolution,omitempty"`
+	ResolvedByID     *uuid.UUID `json:"resolvedById,omitempty"`
+	ResolvedAt       *time.Time `json:"resolvedAt,omitempty"`
+	CreatedAt        time.Time  `json:"createdAt"`
+}
+
+// SettlementLineFilter holds query parameters for the reconciliation worklist.
+type SettlementLineFilter struct {
+	Provider     *string
+	ImportID     *uuid.UUID
+	MatchStatus  *string
+	ReviewStatus *string
+	DateFrom     *time.Time
+	DateTo       *time.Time
+}
+
+// ReconciliationDay compares one provider's completed payments with what it
+// settled on one day.
+type ReconciliationDay struct {
+	Date            string  `json:"date"` // YYYY-MM-DD
+	Provider        string  `json:"provider"`
+	PaymentCount    int     `json:"paymentCount"` // completed payments of ours
+	PaymentAmount   float64 `json:"paymentAmount"`
+	SettledCount    int     `json:"settledCount"` // settlement lines on the statements
+	SettledAmount   float64 `json:"settledAmount"`
+	FeeAmount       float64 `json:"feeAmount"`
+	MatchedCount    int     `json:"matchedCount"`
+	UnmatchedCount  int     `json:"unmatchedCount"`
+	MismatchCount   int     `json:"mismatchCount"`
+	DuplicateCount  int     `json:"duplicateCount"`
+	UnsettledCount  int     `json:"unsettledCount"` // completed payments no statement has settled
+	UnsettledAmount float64 `json:"unsettledAmount"`
+	OpenItems       int     `json:"openItems"`  // worklist lines still to resolve
+	Difference      float64 `json:"difference"` // settledAmount - paymentAmount
+}
@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// SigningKey is a stored asymmetric JWT signing key (PKCS#8 PEM).
+type SigningKey struct {
+	KID         string     `json:"kid"`
+	Algorithm   string     `json:"algorithm"`
+	PrivateKey  string     `json:"-"`
+	ActivatesAt time.Time  `json:"activatesAt"`
+	RetiresAt   *time.Time `json:"retiresAt,omitempty"`
+	CreatedAt   time.Time  `json:"createdAt"`
+}
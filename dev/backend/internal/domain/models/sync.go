@@ -16,6 +16,14 @@ type SyncRequest struct {
 	LastSyncTimestamp time.Time       `json:"lastSyncTimestamp"`
 	Tickets          []SyncTicketItem `json:"tickets"`
 	Photos           []SyncPhotoItem  `json:"photos"`
+	// ServerCursor continues the server change feed from a previous response's
+	// nextCursor. When omitted the feed starts at LastSyncTimestamp.
+	ServerCursor *string `json:"serverCursor,omitempty"`
+	// LookupCursor is the reference-data cursor from the last sync or /lookup
+	// response. Empty requests the full catalogue; omit to skip reference data.
+	LookupCursor *string `json:"lookupCursor,omitempty"`
+	// AckCommands lists device commands the device has carried out.
+	AckCommands []uuid.UUID `json:"ackCommands,omitempty"`
 }
 
 // SyncTicketItem is a single ticket to sync from the device.
@@ -58,9 +66,10 @@ type SyncTicketData struct {
 
 // SyncTicketUpdateData is the parsed ticket data from an update action.
 type SyncTicketUpdateData struct {
-	ID     uuid.UUID `json:"id"` // server-assigned ticket ID
-	Status *string   `json:"status,omitempty"`
-	Notes  *string   `json:"notes,omitempty"`
+	ID         uuid.UUID `json:"id"` // server-assigned ticket ID
+	Status     *string   `json:"status,omitempty"`
+	BaseStatus *string   `json:"baseStatus,omitempty"` // status the device last saw, used by field-merge
+	Notes      *string   `json:"notes,omitempty"`
 }
 
 // ---------------------------------------------------------------------------
@@ -72,6 +81,9 @@ type SyncResponse struct {
 	SyncTimestamp time.Time       `json:"syncTimestamp"`
 	Results       SyncResults     `json:"results"`
 	ServerUpdates ServerUpdates   `json:"serverUpdates"`
+	// Commands are outstanding remote commands for the device; they are
+	// re-sent on every sync until acknowledged via ackCommands.
+	Commands []DeviceCommand `json:"commands,omitempty"`
 }
 
 // SyncResults holds the results for each submitted item.
@@ -82,10 +94,11 @@ type SyncResults struct {
 
 // SyncTicketResult is the outcome of syncing a single ticket.
 type SyncTicketResult struct {
-	LocalID  string  `json:"localId"`
-	ServerID string  `json:"serverId"`
-	Status   string  `json:"status"` // success, conflict, error
-	Error    *string `json:"error,omitempty"`
+	LocalID    string     `json:"localId"`
+	ServerID   string     `json:"serverId"`
+	Status     string     `json:"status"` // success, conflict, error
+	Error      *string    `json:"error,omitempty"`
+	ConflictID *uuid.UUID `json:"conflictId,omitempty"`
 }
 
 // SyncPhotoResult is the outcome of syncing a single photo.
@@ -97,15 +110,28 @@ type SyncPhotoResult struct {
 }
 
 // ServerUpdates holds server-side changes since the last sync.
+// Tickets is one page of the change feed ordered by (updatedAt, id); while
+// HasMore is true the device should keep requesting with NextCursor.
 type ServerUpdates struct {
-	Tickets []ServerTicketUpdate `json:"tickets"`
+	Tickets    []ServerTicketUpdate `json:"tickets"`
+	HasMore    bool                 `json:"hasMore"`
+	NextCursor string               `json:"nextCursor"`
+	Lookup     *LookupData          `json:"lookup,omitempty"` // reference-data delta, omitted when current
 }
 
 // ServerTicketUpdate is a server-side change to push to the device.
+// Deleted and voided tickets are sent as tombstones (action "delete").
 type ServerTicketUpdate struct {
-	ID     uuid.UUID              `json:"id"`
-	Action string                 `json:"action"` // update, delete
-	Data   map[string]any `json:"data,omitempty"`
+	ID        uuid.UUID      `json:"id"`
+	Action    string         `json:"action"` // update, delete
+	Data      map[string]any `json:"data,omitempty"`
+	UpdatedAt time.Time      `json:"updatedAt"`
+}
+
+// ChangeCursor is a keyset position in the ticket change feed.
+type ChangeCursor struct {
+	UpdatedAt time.Time
+	ID        uuid.UUID
 }
 
 // ---------------------------------------------------------------------------
@@ -130,6 +156,207 @@ type DeviceSync struct {
 	DeviceID          string     `json:"deviceId"`
 	LastSyncTimestamp  time.Time  `json:"lastSyncTimestamp"`
 	ItemsSynced       int        `json:"itemsSynced"`
+	LastSuccessAt     *time.Time `json:"lastSuccessAt,omitempty"` // nil until the device first syncs successfully
 	CreatedAt         time.Time  `json:"createdAt"`
 	UpdatedAt         time.Time  `json:"updatedAt"`
 }
+
+// ---------------------------------------------------------------------------
+// Conflict resolution
+// ---------------------------------------------------------------------------
+
+// Conflict resolution strategies (data.conflictResolution setting).
+const (
+	ConflictStrategyServerWins = "server-wins"
+	ConflictStrategyClientWins = "client-wins"
+	ConflictStrategyFieldMerge = "field-merge"
+	ConflictStrategyManual     = "manual"
+)
+
+// ConflictStrategies is the list of valid conflict resolution strategies.
+var ConflictStrategies = []string{
+	ConflictStrategyServerWins, ConflictStrategyClientWins, ConflictStrategyFieldMerge, ConflictStrategyManual,
+}
+
+// Sync conflict statuses
+const (
+	SyncConflictStatusPending      = "pending"
+	SyncConflictStatusAutoResolved = "auto_resolved"
+	SyncConflictStatusResolved     = "resolved"
+)
+
+// Sync conflict resolutions (which version was kept)
+const (
+	SyncConflictResolutionServer = "server"
+	SyncConflictResolutionClient = "client"
+	SyncConflictResolutionMerged = "merged"
+)
+
+// SyncConflict is a device update that collided with a newer server version.
+// Both versions are kept so a supervisor can review or override the outcome.
+type SyncConflict struct {
+	ID              uuid.UUID       `json:"id"`
+	TicketID        uuid.UUID       `json:"ticketId"`
+	TicketNumber    string          `json:"ticketNumber"`
+	UserID          uuid.UUID       `json:"userId"`
+	OfficerID       *uuid.UUID      `json:"officerId,omitempty"`
+	DeviceID        string          `json:"deviceId"`
+	LocalID         string          `json:"localId"`
+	Strategy        string          `json:"strategy"`
+	Fields          []string        `json:"fields"`
+	ClientData      json.RawMessage `json:"clientData"`
+	ServerData      json.RawMessage `json:"serverData"`
+	ClientTimestamp time.Time       `json:"clientTimestamp"`
+	ServerUpdatedAt time.Time       `json:"serverUpdatedAt"`
+	Status          string          `json:"status"`
+	Resolution      *string         `json:"resolution,omitempty"`
+	ResolvedBy      *uuid.UUID      `json:"resolvedBy,omitempty"`
+	ResolvedAt      *time.Time      `json:"resolvedAt,omitempty"`
+	ResolutionNotes *string         `json:"resolutionNotes,omitempty"`
+	StationID       uuid.UUID       `json:"stationId"`
+	RegionID        uuid.UUID       `json:"regionId"`
+	CreatedAt       time.Time       `json:"createdAt"`
+	UpdatedAt       time.Time       `json:"updatedAt"`
+}
+
+// SyncConflictFilter holds query parameters for the conflict queue.
+type SyncConflictFilter struct {
+	Status    *string
+	TicketID  *uuid.UUID
+	StationID *uuid.UUID
+	RegionID  *uuid.UUID
+	Scope     *Jurisdiction // caller's jurisdiction, applied on top of the filters above
+}
+
+// ---------------------------------------------------------------------------
+// Sync telemetry
+// ---------------------------------------------------------------------------
+
+// SyncClientInfo identifies the syncing device and carries the telemetry hints
+// it sends as headers (X-App-Version, X-Battery-Level, X-Battery-Charging,
+// X-Network-Type, X-Pending-Items).
+type SyncClientInfo struct {
+	DeviceID     string
+	DeviceKey    string
+	AppVersion   *string
+	BatteryLevel *int    // percent
+	Charging     *bool
+	NetworkType  *string // e.g. wifi, 4g, 3g, 2g
+	PendingItems *int    // items still queued on the device after this batch
+	RequestBytes int64
+}
+
+// Sync session statuses
+const (
+	SyncSessionCompleted = "completed" // every item succeeded or was held as a conflict
+	SyncSessionPartial   = "partial"   // some items failed
+	SyncSessionRejected  = "rejected"  // refused before processing (validation, device checks)
+	SyncSessionFailed    = "failed"    // server error
+)
+
+// SyncSession records a single BatchSync call.
+type SyncSession struct {
+	ID                uuid.UUID  `json:"id"`
+	UserID            uuid.UUID  `json:"userId"`
+	OfficerID         *uuid.UUID `json:"officerId,omitempty"`
+	DeviceID          string     `json:"deviceId"`
+	DeviceRef         *uuid.UUID `json:"deviceRef,omitempty"`
+	StationID         *uuid.UUID `json:"stationId,omitempty"`
+	RegionID          *uuid.UUID `json:"regionId,omitempty"`
+	Status            string     `json:"status"`
+	ErrorCode         *string    `json:"errorCode,omitempty"`
+	Errors            []string   `json:"errors"`
+	TicketsReceived   int        `json:"ticketsReceived"`
+	TicketsSucceeded  int        `json:"ticketsSucceeded"`
+	TicketsConflicted int        `json:"ticketsConflicted"`
+	TicketsFailed     int        `json:"ticketsFailed"`
+	PhotosReceived    int        `json:"photosReceived"`
+	PhotosSucceeded   int        `json:"photosSucceeded"`
+	PhotosFailed      int        `json:"photosFailed"`
+	UpdatesSent       int        `json:"updatesSent"`
+	RequestBytes      int64      `json:"requestBytes"`
+	ResponseBytes     int64      `json:"responseBytes"`
+	DurationMs        int        `json:"durationMs"`
+	AppVersion        *string    `json:"appVersion,omitempty"`
+	BatteryLevel      *int       `json:"batteryLevel,omitempty"`
+	Charging          *bool      `json:"charging,omitempty"`
+	NetworkType       *string    `json:"networkType,omitempty"`
+	PendingItems      *int       `json:"pendingItems,omitempty"`
+	StartedAt         time.Time  `json:"startedAt"`
+	CreatedAt         time.Time  `json:"createdAt"`
+}
+
+// Device health states, in order of severity.
+const (
+	DeviceHealthHealthy = "healthy"
+	DeviceHealthBacklog = "backlog" // device reports more pending items than one sync batch
+	DeviceHealthFailing = "failing" // last session was partial, rejected or failed
+	DeviceHealthStale   = "stale"   // no sync within the expected interval
+)
+
+// DeviceHealth is the sync health of a single handheld (by hardware ID).
+type DeviceHealth struct {
+	DeviceID       string     `json:"deviceId"`
+	DeviceRef      *uuid.UUID `json:"deviceRef,omitempty"`
+	DeviceName     *string    `json:"deviceName,omitempty"`
+	UserID         uuid.UUID  `json:"userId"`
+	OfficerID      *uuid.UUID `json:"officerId,omitempty"`
+	OfficerName    *string    `json:"officerName,omitempty"`
+	StationID      *uuid.UUID `json:"stationId,omitempty"`
+	StationName    *string    `json:"stationName,omitempty"`
+	RegionID       *uuid.UUID `json:"regionId,omitempty"`
+	Health         string     `json:"health"`
+	LastSessionAt  time.Time  `json:"lastSessionAt"`
+	LastSuccessAt  *time.Time `json:"lastSuccessAt,omitempty"`
+	LastStatus     string     `json:"lastStatus"`
+	LastError      *string    `json:"lastError,omitempty"`
+	AppVersion     *string    `json:"appVersion,omitempty"`
+	BatteryLevel   *int       `json:"batteryLevel,omitempty"`
+	Charging       *bool      `json:"charging,omitempty"`
+	NetworkType    *string    `json:"networkType,omitempty"`
+	PendingItems   *int       `json:"pendingItems,omitempty"`
+	Sessions       int        `json:"sessions"`       // within the health window
+	FailedSessions int        `json:"failedSessions"` // within the health window
+	FailedItems    int        `json:"failedItems"`    // within the health window
+	AvgDurationMs  int        `json:"avgDurationMs"`
+	OverdueSeconds int64      `json:"overdueSeconds,omitempty"` // stale devices only
+}
+
+// DeviceHealthFilter holds query parameters for the device health views.
+type DeviceHealthFilter struct {
+	StationID *uuid.UUID
+	RegionID  *uuid.UUID
+	Health    *string
+	Scope     *Jurisdiction // caller's jurisdiction, applied on top of the filters above
+}
+
+// DeviceHealthThresholds decide how device health is classified.
+type DeviceHealthThresholds struct {
+	StaleBefore  time.Time // last session older than this → stale
+	WindowStart  time.Time // start of the session statistics window
+	BacklogItems int       // pending items above this → backlog
+}
+
+// StationSyncHealth summarises device health for one station.
+type StationSyncHealth struct {
+	StationID     uuid.UUID  `json:"stationId"`
+	StationName   string     `json:"stationName"`
+	RegionID      uuid.UUID  `json:"regionId"`
+	Devices       int        `json:"devices"`
+	Healthy       int        `json:"healthy"`
+	Backlog       int        `json:"backlog"`
+	Failing       int        `json:"failing"`
+	Stale         int        `json:"stale"`
+	PendingItems  int        `json:"pendingItems"`
+	LastSessionAt *time.Time `json:"lastSessionAt,omitempty"`
+}
+
+// SyncHealthAlerts lists devices that have not synced within the expected
+// interval, longest overdue first. Truncated is set when more than the listed
+// devices are overdue.
+type SyncHealthAlerts struct {
+	ThresholdSeconds int            `json:"thresholdSeconds"` // autoSyncIntervalSeconds × multiplier
+	Total            int            `json:"total"`            // overdue devices, listed or not
+	Truncated        bool           `json:"truncated"`
+	Devices          []DeviceHealth `json:"devices"`
+}
@@ -76,6 +76,7 @@ type TicketResponse struct {
 	PaidAt           *time.Time      `json:"paidAt,omitempty"`
 	PaidAmount       *float64        `json:"paidAmount,omitempty"`
 	PaymentMethod    *string         `json:"paymentMethod,omitempty"`
+	Outstanding      float64         `json:"outstandingBalance"`
 	ObjectionFiled   bool            `json:"objectionFiled"`
 	SyncStatus       string          `json:"syncStatus"`
 	Printed          bool            `json:"printed"`
@@ -179,6 +180,7 @@ type TicketFilter struct {
 	MinAmount *float64
 	MaxAmount *float64
 	Category  *string
+	Scope     *Jurisdiction // caller's jurisdiction, applied on top of the filters above
 }
 
 // TicketStats holds aggregate ticket statistics.
@@ -18,8 +18,11 @@ type User struct {
 	ProfilePhotoURL     *string    `json:"profilePhoto,omitempty"`
 	LastLoginAt         *time.Time `json:"lastLogin,omitempty"`
 	PasswordChangedAt   *time.Time `json:"-"`
+	MustChangePassword  bool       `json:"mustChangePassword"`
 	FailedLoginAttempts int        `json:"-"`
 	LockedUntil         *time.Time `json:"-"`
+	MFAEnabled          bool       `json:"mfaEnabled"`
+	IsServiceAccount    bool       `json:"-"` // authenticates with API keys only
 	CreatedAt           time.Time  `json:"createdAt"`
 	UpdatedAt           time.Time  `json:"-"`
 
@@ -34,34 +37,38 @@ func (u *User) FullName() string {
 
 // UserResponse is the API response shape for a user.
 type UserResponse struct {
-	ID           uuid.UUID    `json:"id"`
-	Email        string       `json:"email"`
-	FirstName    string       `json:"firstName"`
-	LastName     string       `json:"lastName"`
-	FullName     string       `json:"fullName"`
-	Phone        *string      `json:"phone,omitempty"`
-	Role         string       `json:"role"`
-	IsActive     bool         `json:"isActive"`
-	CreatedAt    time.Time    `json:"createdAt"`
-	LastLogin    *time.Time   `json:"lastLogin,omitempty"`
-	ProfilePhoto *string      `json:"profilePhoto,omitempty"`
-	Officer      *OfficerInfo `json:"officer,omitempty"`
+	ID                 uuid.UUID    `json:"id"`
+	Email              string       `json:"email"`
+	FirstName          string       `json:"firstName"`
+	LastName           string       `json:"lastName"`
+	FullName           string       `json:"fullName"`
+	Phone              *string      `json:"phone,omitempty"`
+	Role               string       `json:"role"`
+	IsActive           bool         `json:"isActive"`
+	MFAEnabled         bool         `json:"mfaEnabled"`
+	MustChangePassword bool         `json:"mustChangePassword"` // only change-password is allowed until it is done
+	CreatedAt          time.Time    `json:"createdAt"`
+	LastLogin          *time.Time   `json:"lastLogin,omitempty"`
+	ProfilePhoto       *string      `json:"profilePhoto,omitempty"`
+	Officer            *OfficerInfo `json:"officer,omitempty"`
 }
 
 func (u *User) ToResponse() *UserResponse {
 	return &UserResponse{
-		ID:           u.ID,
-		Email:        u.Email,
-		FirstName:    u.FirstName,
-		LastName:     u.LastName,
-		FullName:     u.FullName(),
-		Phone:        u.Phone,
-		Role:         u.Role,
-		IsActive:     u.IsActive,
-		CreatedAt:    u.CreatedAt,
-		LastLogin:    u.LastLoginAt,
-		ProfilePhoto: u.ProfilePhotoURL,
-		Officer:      u.Officer,
+		ID:                 u.ID,
+		Email:              u.Email,
+		FirstName:          u.FirstName,
+		LastName:           u.LastName,
+		FullName:           u.FullName(),
+		Phone:              u.Phone,
+		Role:               u.Role,
+		IsActive:           u.IsActive,
+		MFAEnabled:         u.MFAEnabled,
+		MustChangePassword: u.MustChangePassword,
+		CreatedAt:          u.CreatedAt,
+		LastLogin:          u.LastLoginAt,
+		ProfilePhoto:       u.ProfilePhotoURL,
+		Officer:            u.Officer,
 	}
 }
 
@@ -100,8 +107,14 @@ type RefreshToken struct {
 	TokenHash  string     `json:"-"`
 	DeviceID   *string    `json:"deviceId,omitempty"`
 	DeviceInfo any        `json:"deviceInfo,omitempty"`
+	DeviceRef  *uuid.UUID `json:"deviceRef,omitempty"` // registry device the token is bound to
+	FamilyID   uuid.UUID  `json:"familyId"`             // rotation chain started at login
+	ReplacedBy *uuid.UUID `json:"replacedBy,omitempty"` // successor once rotated
+	IPAddress  *string    `json:"ipAddress,omitempty"`
+	UserAgent  *string    `json:"userAgent,omitempty"`
 	ExpiresAt  time.Time  `json:"expiresAt"`
 	RevokedAt  *time.Time `json:"revokedAt,omitempty"`
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty"`
 	CreatedAt  time.Time  `json:"createdAt"`
 }
 
@@ -134,6 +147,7 @@ type OfficerFilter struct {
 	Rank      *string
 	Role      *string
 	IsActive  *bool
+	Scope     *Jurisdiction // caller's jurisdiction, applied on top of the filters above
 }
 
 // OfficerStats holds officer performance statistics.
@@ -105,6 +105,10 @@ func buildAuditEntry(r *http.Request, statusCode int) *portservices.AuditEntry {
 	if userID.String() != "00000000-0000-0000-0000-000000000000" {
 		entry.UserID = &userID
 	}
+	if key := GetAPIKey(r.Context()); key != nil {
+		entry.UserName = key.Name
+		entry.APIKeyID = &key.KeyID
+	}
 
 	if badge := r.Context().Value(BadgeNumberKey); badge != nil {
 		if b, ok := badge.(string); ok {
@@ -135,19 +139,32 @@ func resolveActionAndEntity(method, path string) (string, string) {
 
 	// Map resource to entity type
 	entityMap := map[string]string{
-		"tickets":    "ticket",
-		"payments":   "payment",
-		"objections": "objection",
-		"officers":   "officer",
-		"regions":    "region",
-		"divisions":  "division",
-		"districts":  "district",
-		"stations":   "station",
-		"offences":   "offence",
-		"auth":       "user",
+		"tickets":          "ticket",
+		"payments":         "payment",
+		"objections":       "objection",
+		"officers":         "officer",
+		"regions":          "region",
+		"divisions":        "division",
+		"districts":        "district",
+		"stations":         "station",
+		"offences":         "offence",
+		"devices":          "device",
+		"auth":             "user",
+		"service-accounts": "service_account",
+		"reconciliation":   "settlement",
+		"cash/shifts":      "cashier_shift",
+		"cash/remittances": "cash_remittance",
+		"ledger/periods":   "ledger_period",
+
+		"allocations/beneficiaries": "revenue_beneficiary",
+		"allocations/rules":         "allocation_rule",
 	}
 
 	resource := parts[0]
+	// Cash, ledger and allocation routes name their entity one level down
+	if (resource == "cash" || resource == "ledger" || resource == "allocations") && len(parts) >= 2 {
+		resource += "/" + parts[1]
+	}
 	entityType := entityMap[resource]
 	if entityType == "" {
 		return "", ""
@@ -172,11 +189,11 @@ func resolveActionAndEntity(method, path string) (string, string) {
 			action = "update"
 		case "photos":
 			action = "create"
-		case "toggle":
+		case "toggle", "status", "cancel", "resolve", "close", "reopen", "refund":
 			action = "change_status"
 		case "reset-password":
 			action = "reset_password"
-		case "change-password":
+		case "change-password", "re-enroll":
 			action = "update"
 		case "refresh", "forgot-password":
 			return "", "" // skip non-auditable auth actions
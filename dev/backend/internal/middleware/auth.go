@@ -2,10 +2,14 @@ package middleware
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"strings"
+	"time"
 
 	apperrors "github.com/ghana-police/ticketing-backend/internal/domain/errors"
+	"github.com/ghana-police/ticketing-backend/internal/domain/models"
+	portservices "github.com/ghana-police/ticketing-backend/internal/ports/services"
 	jwtpkg "github.com/ghana-police/ticketing-backend/pkg/jwt"
 	"github.com/ghana-police/ticketing-backend/pkg/response"
 	"github.com/google/uuid"
@@ -14,16 +18,24 @@ import (
 type contextKey string
 
 const (
-	UserIDKey      contextKey = "user_id"
-	UserRoleKey    contextKey = "user_role"
-	OfficerIDKey   contextKey = "officer_id"
-	StationIDKey   contextKey = "station_id"
-	RegionIDKey    contextKey = "region_id"
-	BadgeNumberKey contextKey = "badge_number"
+	UserIDKey         contextKey = "user_id"
+	UserRoleKey       contextKey = "user_role"
+	OfficerIDKey      contextKey = "officer_id"
+	StationIDKey      contextKey = "station_id"
+	RegionIDKey       contextKey = "region_id"
+	BadgeNumberKey    contextKey = "badge_number"
+	TokenIDKey        contextKey = "token_id"
+	TokenExpiryKey    contextKey = "token_expiry"
+	SessionIDKey      contextKey = "session_id"
+	PasswordChangeKey contextKey = "password_change"
+	APIKeyKey         contextKey = "api_key"
 )
 
-// Auth validates the JWT token and injects user claims into context.
-func Auth(jwtManager *jwtpkg.Manager) func(http.Handler) http.Handler {
+// Auth validates the JWT token, rejects tokens revoked through the denylist
+// and injects user claims into context. When apiKeys is set, service account
+// API keys are accepted in place of a JWT on the routes in scoped; on any
+// other route a key is refused.
+func Auth(jwtManager *jwtpkg.Manager, revocations portservices.TokenRevocationService, apiKeys portservices.ServiceAccountService, scoped *ScopedRoutes) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			authHeader := r.Header.Get("Authorization")
@@ -38,6 +50,20 @@ func Auth(jwtManager *jwtpkg.Manager) func(http.Handler) http.Handler {
 				return
 			}
 
+			if apiKeys != nil && strings.HasPrefix(parts[1], models.APIKeyPrefix) {
+				key, err := apiKeys.Authenticate(r.Context(), parts[1], ClientIP(r))
+				if err != nil {
+					response.Error(w, toAppError(err))
+					return
+				}
+				if !scoped.Allows(r) {
+					response.Error(w, apperrors.NewForbidden("API keys are not accepted on this endpoint"))
+					return
+				}
+				next.ServeHTTP(w, r.WithContext(withAPIKey(r.Context(), key)))
+				return
+			}
+
 			claims, err := jwtManager.ValidateToken(parts[1])
 			if err != nil {
 				if strings.Contains(err.Error(), "expired") {
@@ -48,6 +74,24 @@ func Auth(jwtManager *jwtpkg.Manager) func(http.Handler) http.Handler {
 				return
 			}
 
+			var issuedAt time.Time
+			if claims.IssuedAt != nil {
+				issuedAt = claims.IssuedAt.Time
+			}
+			revoked, err := revocations.IsRevoked(r.Context(), claims.ID, claims.UserID, claims.SessionID, issuedAt)
+			if err != nil {
+				response.Error(w, toAppError(err))
+				return
+			}
+			if revoked {
+				response.Error(w, &apperrors.AppError{
+					Code:       "TOKEN_REVOKED",
+					Message:    "Access token has been revoked",
+					HTTPStatus: http.StatusUnauthorized,
+				})
+				return
+			}
+
 			// Inject claims into context
 			ctx := r.Context()
 			ctx = context.WithValue(ctx, UserIDKey, claims.UserID)
@@ -64,12 +108,59 @@ func Auth(jwtManager *jwtpkg.Manager) func(http.Handler) http.Handler {
 			if claims.BadgeNumber != nil {
 				ctx = context.WithValue(ctx, BadgeNumberKey, *claims.BadgeNumber)
 			}
+			ctx = context.WithValue(ctx, TokenIDKey, claims.ID)
+			if claims.ExpiresAt != nil {
+				ctx = context.WithValue(ctx, TokenExpiryKey, claims.ExpiresAt.Time)
+			}
+			if claims.SessionID != nil {
+				ctx = context.WithValue(ctx, SessionIDKey, *claims.SessionID)
+			}
+			ctx = context.WithValue(ctx, PasswordChangeKey, claims.MustChangePassword)
 
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
 }
 
+// withAPIKey injects a service account caller. It stands in for a user of
+// its role, with the account's station or region as its jurisdiction.
+func withAPIKey(ctx context.Context, key *models.APIKeyPrincipal) context.Context {
+	ctx = context.WithValue(ctx, UserIDKey, key.ServiceAccountID)
+	ctx = context.WithValue(ctx, UserRoleKey, key.Role)
+	if key.StationID != nil {
+		ctx = context.WithValue(ctx, StationIDKey, *key.StationID)
+	}
+	if key.RegionID != nil {
+		ctx = context.WithValue(ctx, RegionIDKey, *key.RegionID)
+	}
+	return context.WithValue(ctx, APIKeyKey, key)
+}
+
+// toAppError passes application errors through and hides anything else.
+func toAppError(err error) *apperrors.AppError {
+	var appErr *apperrors.AppError
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+	return apperrors.NewInternal(err)
+}
+
+// RequirePasswordCurrent rejects requests from users who must change their
+// password first. Routes that let them do so are mounted without it.
+func RequirePasswordCurrent(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if MustChangePassword(r.Context()) {
+			response.Error(w, &apperrors.AppError{
+				Code:       "PASSWORD_CHANGE_REQUIRED",
+				Message:    "Password must be changed before continuing",
+				HTTPStatus: http.StatusForbidden,
+			})
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
 // Helper functions to extract values from context
 
 func GetUserID(ctx context.Context) uuid.UUID {
@@ -106,3 +197,44 @@ func GetRegionID(ctx context.Context) *uuid.UUID {
 	}
 	return nil
 }
+
+// GetTokenID returns the jti of the access token used for the request.
+func GetTokenID(ctx context.Context) string {
+	if v, ok := ctx.Value(TokenIDKey).(string); ok {
+		return v
+	}
+	return ""
+}
+
+// GetSessionID returns the session (refresh token family) the access token
+// was issued to, or nil for tokens issued without one.
+func GetSessionID(ctx context.Context) *uuid.UUID {
+	if v, ok := ctx.Value(SessionIDKey).(uuid.UUID); ok {
+		return &v
+	}
+	return nil
+}
+
+// GetAPIKey returns the API key that authenticated the request, or nil when
+// the caller signed in with a JWT.
+func GetAPIKey(ctx context.Context) *models.APIKeyPrincipal {
+	if v, ok := ctx.Value(APIKeyKey).(*models.APIKeyPrincipal); ok {
+		return v
+	}
+	return nil
+}
+
+// MustChangePassword reports whether the access token only allows changing
+// the password.
+func MustChangePassword(ctx context.Context) bool {
+	v, _ := ctx.Value(PasswordChangeKey).(bool)
+	return v
+}
+
+// GetTokenExpiry returns the expiry of the access token used for the request.
+func GetTokenExpiry(ctx context.Context) time.Time {
+	if v, ok := ctx.Value(TokenExpiryKey).(time.Time); ok {
+		return v
+	}
+	return time.Time{}
+}
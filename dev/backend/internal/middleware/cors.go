@@ -12,6 +12,7 @@ func CORS(cfg *config.Config) func(http.Handler) http.Handler {
 		AllowedOrigins:   cfg.CORSAllowedOrigins,
 		AllowedMethods:   cfg.CORSAllowedMethods,
 		AllowedHeaders:   cfg.CORSAllowedHeaders,
+		ExposedHeaders:   []string{"ETag", "Last-Modified", "RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "RateLimit-Policy", "Retry-After", "Idempotent-Replayed"},
 		AllowCredentials: true,
 		MaxAge:           300,
 	})
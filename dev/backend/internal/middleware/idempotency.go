@@ -0,0 +1,177 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	apperrors "github.com/ghana-police/ticketing-backend/internal/domain/errors"
+	"github.com/ghana-police/ticketing-backend/internal/domain/models"
+	portservices "github.com/ghana-police/ticketing-backend/internal/ports/services"
+	"github.com/ghana-police/ticketing-backend/pkg/response"
+)
+
+const (
+	idempotencyHeader = "Idempotency-Key"
+	replayedHeader    = "Idempotent-Replayed"
+
+	// maxIdempotencyKeyLength bounds the client's key; UUIDs are the expected form.
+	maxIdempotencyKeyLength = 255
+	// maxStoredResponseSize skips storing responses larger than this; such a
+	// request is released and a retry runs again.
+	maxStoredResponseSize = 1 << 20
+	// maxIdempotentBodySize bounds the body read to fingerprint a request.
+	// The idempotent endpoints take small JSON documents, and the handler
+	// reads the same capped body.
+	maxIdempotentBodySize = 1 << 20
+)
+
+// Idempotency makes a create request safe to retry. A client sends the same
+// Idempotency-Key header with each attempt: the first attempt runs and its
+// response is stored, later attempts with the same body get that response
+// back with Idempotent-Replayed: true, and the same key with a different
+// body is refused with 409. Keys are scoped to the authenticated user.
+// Requests without the header run as usual.
+func Idempotency(svc portservices.IdempotencyService) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(idempotencyHeader)
+			if key == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if !validIdempotencyKey(key) {
+				response.Error(w, apperrors.NewValidationError("Invalid Idempotency-Key header",
+					map[string][]string{idempotencyHeader: {"must be 1 to 255 printable ASCII characters"}}))
+				return
+			}
+
+			body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxIdempotentBodySize))
+			r.Body.Close()
+			if err != nil {
+				var tooLarge *http.MaxBytesError
+				if errors.As(err, &tooLarge) {
+					response.Error(w, apperrors.NewValidationError("Request body too large", nil))
+					return
+				}
+				response.Error(w, apperrors.NewValidationError("Could not read request body", nil))
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			scoped := GetUserID(r.Context()).String() + ":" + key
+			fingerprint := requestFingerprint(r, body)
+
+			stored, err := svc.Begin(r.Context(), scoped, fingerprint)
+			if err != nil {
+				response.Error(w, toAppError(err))
+				return
+			}
+			if stored != nil {
+				if stored.ContentType != "" {
+					w.Header().Set("Content-Type", stored.ContentType)
+				}
+				w.Header().Set(replayedHeader, "true")
+				w.WriteHeader(stored.StatusCode)
+				_, _ = w.Write(stored.Body)
+				return
+			}
+
+			rec := &recordingWriter{ResponseWriter: w}
+			// Release the key if the handler panics, then let Recovery answer
+			defer func() {
+				if p := recover(); p != nil {
+					svc.Release(context.WithoutCancel(r.Context()), scoped)
+					panic(p)
+				}
+			}()
+			next.ServeHTTP(rec, r)
+
+			// The outcome is stored even if the client has gone, since that
+			// client is the one about to retry
+			ctx := context.WithoutCancel(r.Context())
+			if rec.status == 0 {
+				rec.status = http.StatusOK
+			}
+			if !storableStatus(rec.status) || rec.overflow {
+				svc.Release(ctx, scoped)
+				return
+			}
+			svc.Complete(ctx, scoped, &models.IdempotencyRecord{
+				Fingerprint: fingerprint,
+				StatusCode:  rec.status,
+				ContentType: rec.Header().Get("Content-Type"),
+				Body:        rec.body.Bytes(),
+				CreatedAt:   time.Now().UTC(),
+			})
+		})
+	}
+}
+
+func validIdempotencyKey(key string) bool {
+	if len(key) > maxIdempotencyKeyLength {
+		return false
+	}
+	for i := 0; i < len(key); i++ {
+		if key[i] < 0x20 || key[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+// requestFingerprint identifies a request by method, path and body, so a key
+// replayed against another endpoint counts as a different request.
+func requestFingerprint(r *http.Request, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(r.Method + " " + r.URL.Path + "\n"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// storableStatus reports whether a response is final for its request. Server
+// errors, conflicts and rate limiting may go away, so those are not replayed.
+func storableStatus(status int) bool {
+	switch {
+	case status >= 500:
+		return false
+	case status == http.StatusConflict, status == http.StatusTooManyRequests:
+		return false
+	}
+	return true
+}
+
+// recordingWriter passes the response through while keeping a copy of it.
+type recordingWriter struct {
+	http.ResponseWriter
+	status   int
+	body     bytes.Buffer
+	overflow bool
+}
+
+func (w *recordingWriter) WriteHeader(code int) {
+	if w.status == 0 {
+		w.status = code
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *recordingWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	if !w.overflow {
+		if w.body.Len()+len(b) > maxStoredResponseSize {
+			w.overflow = true
+			w.body.Reset()
+		} else {
+			w.body.Write(b)
+		}
+	}
+	return w.ResponseWriter.Write(b)
+}
@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ghana-police/ticketing-backend/internal/domain/models"
+)
+
+// memIdempotency keeps claimed keys in memory.
+type memIdempotency struct {
+	records map[string]*models.IdempotencyRecord
+}
+
+func (m *memIdempotency) Begin(_ context.Context, key, _ string) (*models.IdempotencyRecord, error) {
+	rec := m.records[key]
+	if rec == nil {
+		m.records[key] = &models.IdempotencyRecord{}
+	}
+	return rec, nil
+}
+
+func (m *memIdempotency) Complete(_ context.Context, key string, record *models.IdempotencyRecord) {
+	m.records[key] = record
+}
+
+func (m *memIdempotency) Release(_ context.Context, key string) {
+	delete(m.records, key)
+}
+
+func TestIdempotencyBodyLimit(t *testing.T) {
+	svc := &memIdempotency{records: map[string]*models.IdempotencyRecord{}}
+	var handled []byte
+	h := Idempotency(svc)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handled, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusCreated)
+	}))
+
+	tests := []struct {
+		name     string
+		size     int
+		wantCode int
+	}{
+		{name: "at the limit", size: maxIdempotentBodySize, wantCode: http.StatusCreated},
+		{name: "over the limit", size: maxIdempotentBodySize + 1, wantCode: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handled = nil
+			body := bytes.Repeat([]byte("a"), tt.size)
+			req := httptest.NewRequest(http.MethodPost, "/api/tickets", bytes.NewReader(body))
+			req.Header.Set(idempotencyHeader, tt.name)
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantCode {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantCode)
+			}
+			if tt.wantCode == http.StatusCreated && len(handled) != tt.size {
+				t.Errorf("handler read %d bytes, want %d", len(handled), tt.size)
+			}
+			if tt.wantCode != http.StatusCreated && handled != nil {
+				t.Error("handler ran for an oversized body")
+			}
+		})
+	}
+}
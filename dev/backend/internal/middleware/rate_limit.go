@@ -0,0 +1,160 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	apperrors "github.com/ghana-police/ticketing-backend/internal/domain/errors"
+	"github.com/ghana-police/ticketing-backend/internal/domain/models"
+	portservices "github.com/ghana-police/ticketing-backend/internal/ports/services"
+	"github.com/ghana-police/ticketing-backend/pkg/response"
+	"github.com/google/uuid"
+)
+
+// RateLimit limits requests in a fixed route class. Requests are keyed by the
+// authenticated user when there is one, otherwise by client IP. When the
+// limiter is unavailable requests are let through.
+func RateLimit(svc portservices.RateLimitService, class string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !allowRequest(w, r, svc, class, requestKey(r)) {
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RateLimitLogin limits sign-in attempts twice: in the auth class per client
+// IP and account, so officers behind one station NAT do not share that budget,
+// and in the higher login class per client IP, so one address cannot try
+// account after account. The account is the email or badge number in the JSON
+// body; the body is put back for the handler.
+func RateLimitLogin(svc portservices.RateLimitService) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, err := io.ReadAll(io.LimitReader(r.Body, maxLoginBodySize))
+			r.Body.Close()
+			if err != nil {
+				response.Error(w, apperrors.NewValidationError("Could not read request body", nil))
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			if !allowRequest(w, r, svc, models.RateClassLogin, "ip:"+ClientIP(r)) ||
+				!allowRequest(w, r, svc, models.RateClassAuth, loginKey(r, body)) {
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// maxLoginBodySize bounds how much of a sign-in body is read; real ones are
+// a few hundred bytes and the handler rejects a truncated one.
+const maxLoginBodySize = 64 << 10
+
+// loginKey returns the rate limit key of a sign-in attempt: the client IP and
+// the lower-cased email or badge number (empty when the body has neither).
+func loginKey(r *http.Request, body []byte) string {
+	var creds struct {
+		Email       *string `json:"email"`
+		BadgeNumber *string `json:"badgeNumber"`
+	}
+	_ = json.Unmarshal(body, &creds)
+
+	account := ""
+	switch {
+	case creds.Email != nil:
+		account = "email:" + strings.ToLower(strings.TrimSpace(*creds.Email))
+	case creds.BadgeNumber != nil:
+		account = "badge:" + strings.ToLower(strings.TrimSpace(*creds.BadgeNumber))
+	}
+	return "ip:" + ClientIP(r) + ":" + account
+}
+
+// RateLimitByRoute limits authenticated requests in the class derived from
+// the route: sync uploads, photo uploads, and otherwise reads or writes by
+// method. The sync status and change feed are reads, since a device pages
+// through the feed many requests at a time.
+func RateLimitByRoute(svc portservices.RateLimitService) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !allowRequest(w, r, svc, routeClass(r), requestKey(r)) {
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func routeClass(r *http.Request) string {
+	path := strings.TrimSuffix(r.URL.Path, "/")
+	switch {
+	case r.Method == http.MethodPost && path == "/api/sync":
+		return models.RateClassSync
+	case r.Method == http.MethodPost && (strings.HasSuffix(path, "/photos") || path == "/api/reconciliation/imports"):
+		return models.RateClassUpload
+	case r.Method == http.MethodGet || r.Method == http.MethodHead:
+		return models.RateClassRead
+	default:
+		return models.RateClassWrite
+	}
+}
+
+// requestKey keys a request by the authenticated user when there is one,
+// otherwise by client IP.
+func requestKey(r *http.Request) string {
+	if userID := GetUserID(r.Context()); userID != uuid.Nil {
+		return "user:" + userID.String()
+	}
+	return "ip:" + ClientIP(r)
+}
+
+// allowRequest counts the request under key, sets the RateLimit-* headers and
+// writes a 429 when the limit is exceeded. It reports whether the request may
+// proceed.
+func allowRequest(w http.ResponseWriter, r *http.Request, svc portservices.RateLimitService, class, key string) bool {
+	if r.Method == http.MethodOptions {
+		return true
+	}
+
+	result, err := svc.Allow(r.Context(), class, key)
+	if err != nil || result == nil {
+		return true
+	}
+
+	reset := seconds(result.Reset)
+	h := w.Header()
+	h.Set("RateLimit-Limit", strconv.Itoa(result.Limit))
+	h.Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+	h.Set("RateLimit-Reset", strconv.Itoa(reset))
+	h.Set("RateLimit-Policy", strconv.Itoa(result.Limit)+";w="+strconv.Itoa(seconds(result.Window)))
+
+	if !result.Allowed {
+		h.Set("Retry-After", strconv.Itoa(reset))
+		response.Error(w, apperrors.NewRateLimited())
+		return false
+	}
+	return true
+}
+
+// ClientIP returns the host part of RemoteAddr (already resolved from proxy
+// headers by chi's RealIP middleware).
+func ClientIP(r *http.Request) string {
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		return host
+	}
+	return r.RemoteAddr
+}
+
+// seconds rounds d up to whole seconds.
+func seconds(d time.Duration) int {
+	return int((d + time.Second - 1) / time.Second)
+}
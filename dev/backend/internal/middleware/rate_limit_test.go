@@ -0,0 +1,119 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ghana-police/ticketing-backend/internal/domain/models"
+	portservices "github.com/ghana-police/ticketing-backend/internal/ports/services"
+)
+
+// countingLimiter allows limits[class] requests per class and key.
+type countingLimiter struct {
+	limits map[string]int
+	seen   map[string]int
+}
+
+func (l *countingLimiter) Allow(_ context.Context, class, key string) (*models.RateLimitResult, error) {
+	l.seen[class+" "+key]++
+	n, limit := l.seen[class+" "+key], l.limits[class]
+	return &models.RateLimitResult{Allowed: n <= limit, Limit: limit, Remaining: max(limit-n, 0)}, nil
+}
+
+var _ portservices.RateLimitService = (*countingLimiter)(nil)
+
+func TestRouteClass(t *testing.T) {
+	tests := []struct {
+		method, path string
+		want         string
+	}{
+		{http.MethodPost, "/api/sync", models.RateClassSync},
+		{http.MethodGet, "/api/sync/changes", models.RateClassRead},
+		{http.MethodGet, "/api/sync/status", models.RateClassRead},
+		{http.MethodPost, "/api/tickets/123/photos", models.RateClassUpload},
+		{http.MethodPost, "/api/tickets", models.RateClassWrite},
+		{http.MethodGet, "/api/tickets", models.RateClassRead},
+	}
+	for _, tt := range tests {
+		if got := routeClass(httptest.NewRequest(tt.method, tt.path, nil)); got != tt.want {
+			t.Errorf("%s %s: class = %s, want %s", tt.method, tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestRateLimitLogin(t *testing.T) {
+	limiter := &countingLimiter{
+		limits: map[string]int{models.RateClassAuth: 2, models.RateClassLogin: 10},
+		seen:   map[string]int{},
+	}
+	var gotBody string
+	h := RateLimitLogin(limiter)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		gotBody = string(b)
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	login := func(ip, body string) int {
+		req := httptest.NewRequest(http.MethodPost, "/api/auth/login", strings.NewReader(body))
+		req.RemoteAddr = ip + ":5000"
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	ama := `{"badgeNumber":"GPS-1001","password":"x"}`
+	for i := 0; i < 2; i++ {
+		if code := login("10.0.0.1", ama); code != http.StatusOK {
+			t.Fatalf("attempt %d: status = %d, want 200", i+1, code)
+		}
+	}
+	if gotBody != ama {
+		t.Errorf("handler got body %q, want %q", gotBody, ama)
+	}
+	if code := login("10.0.0.1", `{"badgeNumber":"gps-1001 ","password":"x"}`); code != http.StatusTooManyRequests {
+		t.Errorf("third attempt on the account: status = %d, want 429", code)
+	}
+
+	// Another officer behind the same station NAT has a budget of their own
+	if code := login("10.0.0.1", `{"badgeNumber":"GPS-1002","password":"x"}`); code != http.StatusOK {
+		t.Errorf("other account from the same IP: status = %d, want 200", code)
+	}
+	if code := login("10.0.0.2", ama); code != http.StatusOK {
+		t.Errorf("same account from another IP: status = %d, want 200", code)
+	}
+}
+
+func TestRateLimitLoginAcrossAccounts(t *testing.T) {
+	limiter := &countingLimiter{
+		limits: map[string]int{models.RateClassAuth: 2, models.RateClassLogin: 10},
+		seen:   map[string]int{},
+	}
+	h := RateLimitLogin(limiter)(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	// One address trying a new account each time is stopped by the per-IP limit
+	codes := make([]int, 0, 15)
+	for i := 0; i < 15; i++ {
+		body := fmt.Sprintf(`{"badgeNumber":"GPS-%04d","password":"x"}`, i)
+		req := httptest.NewRequest(http.MethodPost, "/api/auth/login", strings.NewReader(body))
+		req.RemoteAddr = "10.0.0.1:5000"
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		codes = append(codes, rec.Code)
+	}
+	for i, code := range codes {
+		want := http.StatusOK
+		if i >= 10 {
+			want = http.StatusTooManyRequests
+		}
+		if code != want {
+			t.Errorf("attempt %d on a new account: status = %d, want %d", i+1, code, want)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/ghana-police/ticketing-backend/internal/domain/models"
+	portservices "github.com/ghana-police/ticketing-backend/internal/ports/services"
+)
+
+type fakeAPIKeys struct {
+	portservices.ServiceAccountService
+	scopes []string
+}
+
+func (f fakeAPIKeys) Authenticate(context.Context, string, string) (*models.APIKeyPrincipal, error) {
+	return &models.APIKeyPrincipal{KeyID: uuid.New(), ServiceAccountID: uuid.New(), Role: "admin", Scopes: f.scopes}, nil
+}
+
+// allowAll grants every permission to every caller, so only key scopes decide.
+type allowAll struct {
+	portservices.PermissionService
+}
+
+func (allowAll) Has(context.Context, uuid.UUID, string, string) (bool, error) {
+	return true, nil
+}
+
+// apiKeyRouter mirrors the shape of the real router: routes open to any
+// authenticated user next to routes guarded by a permission, both inline and
+// through a group.
+func apiKeyRouter(scopes []string) http.Handler {
+	ok := func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusOK) }
+	perms := allowAll{}
+	scoped := NewScopedRoutes()
+
+	r := chi.NewRouter()
+	r.Route("/api", func(r chi.Router) {
+		r.Group(func(r chi.Router) {
+			r.Use(Auth(nil, nil, fakeAPIKeys{scopes: scopes}, scoped))
+
+			r.Route("/tickets", func(r chi.Router) {
+				r.Get("/", ok)
+				r.Post("/", ok)
+				r.Post("/{id}/photos", ok)
+				r.Get("/{id}/installment-plan", ok)
+				r.With(RequirePermission(perms, models.PermTicketVoid)).Post("/{id}/void", ok)
+			})
+			r.Route("/payments", func(r chi.Router) {
+				r.Get("/", ok)
+				r.Get("/{id}", ok)
+				r.Post("/verify", ok)
+				r.With(RequirePermission(perms, models.PermPaymentRefund)).Post("/{id}/refund", ok)
+			})
+			r.Post("/objections", ok)
+			r.Route("/sync", func(r chi.Router) {
+				r.Post("/", ok)
+				r.Get("/status", ok)
+				r.Get("/changes", ok)
+			})
+			r.Get("/officers", ok)
+			r.Get("/regions", ok)
+			r.Get("/stations", ok)
+			r.Route("/ledger", func(r chi.Router) {
+				r.Group(func(r chi.Router) {
+					r.Use(RequirePermission(perms, models.PermLedgerRead))
+					r.Get("/", ok)
+					r.Get("/journals/{id}", ok)
+				})
+			})
+		})
+	})
+	if err := scoped.Load(r); err != nil {
+		panic(err)
+	}
+	return r
+}
+
+func TestAPIKeyScopes(t *testing.T) {
+	narrow := []string{models.PermLedgerRead}
+
+	tests := []struct {
+		method, path string
+		scopes       []string
+		want         int
+	}{
+		// Routes without a permission refuse keys whatever their scopes
+		{"GET", "/api/tickets", narrow, http.StatusForbidden},
+		{"POST", "/api/tickets", narrow, http.StatusForbidden},
+		{"POST", "/api/tickets/" + uuid.NewString() + "/photos", narrow, http.StatusForbidden},
+		{"GET", "/api/tickets/" + uuid.NewString() + "/installment-plan", narrow, http.StatusForbidden},
+		{"GET", "/api/payments", narrow, http.StatusForbidden},
+		{"GET", "/api/payments/" + uuid.NewString(), narrow, http.StatusForbidden},
+		{"POST", "/api/payments/verify", narrow, http.StatusForbidden},
+		{"POST", "/api/objections", narrow, http.StatusForbidden},
+		{"POST", "/api/sync", narrow, http.StatusForbidden},
+		{"GET", "/api/sync/status", narrow, http.StatusForbidden},
+		{"GET", "/api/sync/changes", narrow, http.StatusForbidden},
+		{"GET", "/api/officers", narrow, http.StatusForbidden},
+		{"GET", "/api/regions", narrow, http.StatusForbidden},
+		{"GET", "/api/stations", narrow, http.StatusForbidden},
+
+		// Guarded routes accept a key holding the permission
+		{"GET", "/api/ledger", narrow, http.StatusOK},
+		{"GET", "/api/ledger/journals/" + uuid.NewString(), narrow, http.StatusOK},
+		{"POST", "/api/payments/" + uuid.NewString() + "/refund", []string{models.PermPaymentRefund}, http.StatusOK},
+
+		// ... and refuse one without it
+		{"POST", "/api/tickets/" + uuid.NewString() + "/void", narrow, http.StatusForbidden},
+		{"POST", "/api/payments/" + uuid.NewString() + "/refund", narrow, http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			req.Header.Set("Authorization", "Bearer "+models.APIKeyPrefix+"test_secret")
+			rec := httptest.NewRecorder()
+			apiKeyRouter(tt.scopes).ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d (%s)", rec.Code, tt.want, rec.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/ghana-police/ticketing-backend/internal/domain/models"
+	"github.com/ghana-police/ticketing-backend/pkg/pagination"
+	"github.com/google/uuid"
+)
+
+type AllocationRepository interface {
+	ListBeneficiaries(ctx context.Context, isActive *bool) ([]models.RevenueBeneficiary, error)
+	GetBeneficiary(ctx context.Context, id uuid.UUID) (*models.RevenueBeneficiary, error)
+	BeneficiaryCodeExists(ctx context.Context, code string, excludeID *uuid.UUID) (bool, error)
+	CreateBeneficiary(ctx context.Context, b *models.RevenueBeneficiary) error
+	UpdateBeneficiary(ctx context.Context, b *models.RevenueBeneficiary) error
+
+	// BeneficiaryInActiveRules reports whether an active rule gives the
+	// beneficiary a share.
+	BeneficiaryInActiveRules(ctx context.Context, id uuid.UUID) (bool, error)
+
+	// ListRules returns rules with their shares, most specific first.
+	ListRules(ctx context.Context, isActive *bool) ([]models.AllocationRule, error)
+	GetRule(ctx context.Context, id uuid.UUID) (*models.AllocationRule, error)
+
+	// RuleKeysTaken reports whether another active rule has the same keys.
+	RuleKeysTaken(ctx context.Context, category *string, regionID *uuid.UUID, method *string, excludeID *uuid.UUID) (bool, error)
+
+	// CreateRule and UpdateRule write the rule and replace its shares.
+	CreateRule(ctx context.Context, rule *models.AllocationRule) error
+	UpdateRule(ctx context.Context, rule *models.AllocationRule) error
+
+	// CreateAllocations appends a payment's allocations. Inside a unit of
+	// work it runs in that transaction.
+	CreateAllocations(ctx context.Context, allocations []models.PaymentAllocation) error
+
+	// ReverseForPayment appends a negative allocation in the period for each
+	// of the payment's allocations not yet reversed.
+	ReverseForPayment(ctx context.Context, paymentID uuid.UUID, period string) error
+
+	ListAllocations(ctx context.Context, filter models.PaymentAllocationFilter, p pagination.Params) ([]models.PaymentAllocation, int, error)
+
+	// DisbursementReport totals the period's allocations per beneficiary, and
+	// per region when groupBy is "region".
+	DisbursementReport(ctx context.Context, period string, filter models.PaymentAllocationFilter, groupBy string) ([]models.DisbursementLine, error)
+}
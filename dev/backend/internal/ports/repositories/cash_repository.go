@@ -0,0 +1,52 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/ghana-police/ticketing-backend/internal/domain/models"
+	"github.com/ghana-police/ticketing-backend/pkg/pagination"
+	"github.com/google/uuid"
+)
+
+type CashRepository interface {
+	CreateShift(ctx context.Context, shift *models.CashierShift) error
+
+	GetShift(ctx context.Context, id uuid.UUID) (*models.CashierShift, error)
+
+	// GetShiftForUpdate locks the shift until the transaction ends.
+	GetShiftForUpdate(ctx context.Context, id uuid.UUID) (*models.CashierShift, error)
+
+	// GetOpenShift returns the cashier's open shift.
+	GetOpenShift(ctx context.Context, cashierID uuid.UUID) (*models.CashierShift, error)
+
+	// GetOpenShiftForUpdate locks the cashier's open shift, so it cannot be
+	// closed while a payment is added to it.
+	GetOpenShiftForUpdate(ctx context.Context, cashierID uuid.UUID) (*models.CashierShift, error)
+
+	// CloseShift records the cash-up of a shift.
+	CloseShift(ctx context.Context, shift *models.CashierShift) error
+
+	ListShifts(ctx context.Context, filter models.CashierShiftFilter, p pagination.Params) ([]models.CashierShift, int, error)
+
+	// CreateRemittance inserts a remittance and puts the shifts on it.
+	CreateRemittance(ctx context.Context, remittance *models.CashRemittance, shiftIDs []uuid.UUID) error
+
+	// SlipExists reports whether the bank's deposit slip is already on a
+	// remittance.
+	SlipExists(ctx context.Context, bankName, slipNumber string) (bool, error)
+
+	// GetRemittance returns a remittance with its shifts.
+	GetRemittance(ctx context.Context, id uuid.UUID) (*models.CashRemittance, error)
+
+	// ReviewRemittance signs off or rejects a pending remittance; a rejected
+	// remittance releases its shifts to be remitted again. Returns
+	// pgx.ErrNoRows if the remittance is not pending.
+	ReviewRemittance(ctx context.Context, id uuid.UUID, status string, reviewerID uuid.UUID, notes *string) error
+
+	ListRemittances(ctx context.Context, filter models.CashRemittanceFilter, p pagination.Params) ([]models.CashRemittance, int, error)
+
+	// TreasuryReport follows cash per station and day from collection to the
+	// bank, and totals what each station holds unbanked now.
+	TreasuryReport(ctx context.Context, scope models.Jurisdiction, stationID *uuid.UUID, from, to time.Time) (*models.TreasuryReport, error)
+}
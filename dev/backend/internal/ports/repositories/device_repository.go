@@ -0,0 +1,60 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/ghana-police/ticketing-backend/internal/domain/models"
+	"github.com/ghana-police/ticketing-backend/pkg/pagination"
+	"github.com/google/uuid"
+)
+
+type DeviceRepository interface {
+	// Create registers a pending device with its hashed enrollment code.
+	Create(ctx context.Context, device *models.Device) error
+
+	// GetByID returns a device with joined station/officer data.
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Device, error)
+
+	// GetByDeviceID returns a device by its hardware identifier (X-Device-ID).
+	GetByDeviceID(ctx context.Context, deviceID string) (*models.Device, error)
+
+	// GetByEnrollmentCode returns a pending device by its hashed enrollment code.
+	GetByEnrollmentCode(ctx context.Context, codeHash string) (*models.Device, error)
+
+	// List returns a paginated list of devices.
+	List(ctx context.Context, filter models.DeviceFilter, search string, p pagination.Params) ([]models.Device, int, error)
+
+	// Enroll activates a pending device, binding its hardware ID and key and consuming the code.
+	// Also records the hardware ID on the assigned officer, if any. Returns pgx.ErrNoRows
+	// when the device is no longer pending with the given code hash.
+	Enroll(ctx context.Context, id uuid.UUID, codeHash, deviceID string, platform, model, appVersion *string, keyHash string) error
+
+	// ResetEnrollment returns a device to pending with a fresh enrollment code and no key.
+	ResetEnrollment(ctx context.Context, id uuid.UUID, codeHash string, expiresAt time.Time) error
+
+	// UpdateStatus changes the device status.
+	UpdateStatus(ctx context.Context, id uuid.UUID, status string, reason *string) error
+
+	// TouchLastSeen records device contact and the reported app version.
+	TouchLastSeen(ctx context.Context, id uuid.UUID, appVersion *string) error
+
+	// RevokeTokens revokes all refresh tokens bound to the device.
+	RevokeTokens(ctx context.Context, id uuid.UUID) error
+
+	// CreateCommand queues a command for the device.
+	CreateCommand(ctx context.Context, cmd *models.DeviceCommand) error
+
+	// ListCommands returns the command history for a device, newest first.
+	ListCommands(ctx context.Context, deviceRef uuid.UUID) ([]models.DeviceCommand, error)
+
+	// TakeOutstandingCommands returns pending and delivered-but-unacknowledged commands,
+	// marking pending ones delivered.
+	TakeOutstandingCommands(ctx context.Context, deviceRef uuid.UUID) ([]models.DeviceCommand, error)
+
+	// AcknowledgeCommands marks the given commands of the device acknowledged.
+	AcknowledgeCommands(ctx context.Context, deviceRef uuid.UUID, ids []uuid.UUID) error
+
+	// CancelCommand cancels a command that has not been acknowledged yet.
+	CancelCommand(ctx context.Context, deviceRef, commandID uuid.UUID) error
+}
@@ -0,0 +1,21 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/ghana-police/ticketing-backend/internal/domain/models"
+)
+
+type IdempotencyRepository interface {
+	// Reserve stores record under key for ttl unless the key is already
+	// taken. When it is, the record already stored is returned instead and
+	// reserved is false.
+	Reserve(ctx context.Context, key string, record *models.IdempotencyRecord, ttl time.Duration) (existing *models.IdempotencyRecord, reserved bool, err error)
+
+	// Save replaces the record under key, keeping it for ttl.
+	Save(ctx context.Context, key string, record *models.IdempotencyRecord, ttl time.Duration) error
+
+	// Delete frees the key so it can be used again.
+	Delete(ctx context.Context, key string) error
+}
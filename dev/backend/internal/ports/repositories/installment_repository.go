@@ -0,0 +1,34 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/ghana-police/ticketing-backend/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+type InstallmentRepository interface {
+	// Create inserts a plan with its installments.
+	Create(ctx context.Context, plan *models.InstallmentPlan) error
+
+	// GetActiveByTicket returns the ticket's running plan with its installments.
+	GetActiveByTicket(ctx context.Context, ticketID uuid.UUID) (*models.InstallmentPlan, error)
+
+	// GetLatestByTicket returns the ticket's most recent plan, whatever its status.
+	GetLatestByTicket(ctx context.Context, ticketID uuid.UUID) (*models.InstallmentPlan, error)
+
+	// Cancel stops an active plan; payments no longer count against it.
+	Cancel(ctx context.Context, planID uuid.UUID, cancelledBy uuid.UUID, reason *string) error
+
+	// ApplyPayment pays amount off the installments of the ticket's active
+	// plan, earliest first, and completes the plan once every installment is
+	// paid. It does nothing when the ticket has no active plan.
+	ApplyPayment(ctx context.Context, ticketID uuid.UUID, amount float64) error
+
+	// ReversePayment takes amount back off the installments of the ticket's
+	// latest plan, latest installment first, when that plan is active or
+	// completed and was created before paidAt (so the payment counted against
+	// it). A completed plan becomes active again. It does nothing otherwise.
+	ReversePayment(ctx context.Context, ticketID uuid.UUID, paidAt time.Time, amount float64) error
+}
@@ -0,0 +1,14 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/ghana-police/ticketing-backend/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+type JurisdictionRepository interface {
+	// Contains reports whether the entity (one of the models.Entity*
+	// constants) with the given id lies within j.
+	Contains(ctx context.Context, entity string, id uuid.UUID, j models.Jurisdiction) (bool, error)
+}
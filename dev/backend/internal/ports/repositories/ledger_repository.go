@@ -0,0 +1,39 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/ghana-police/ticketing-backend/internal/domain/models"
+	"github.com/ghana-police/ticketing-backend/pkg/pagination"
+	"github.com/google/uuid"
+)
+
+type LedgerRepository interface {
+	ListAccounts(ctx context.Context) ([]models.LedgerAccount, error)
+
+	// Post appends a journal and its entries, tagged with the ticket's station
+	// and region. The journal goes to the period it occurred in, or to the
+	// current month when that period is closed. Inside a unit of work it runs
+	// in that transaction.
+	Post(ctx context.Context, journal *models.LedgerJournal) error
+
+	GetJournal(ctx context.Context, id uuid.UUID) (*models.LedgerJournal, error)
+	ListJournals(ctx context.Context, filter models.LedgerJournalFilter, p pagination.Params) ([]models.LedgerJournal, int, error)
+
+	// ListPeriods returns every period with journals, newest first.
+	ListPeriods(ctx context.Context) ([]models.LedgerPeriod, error)
+
+	// GetPeriod returns pgx.ErrNoRows for a period without journals.
+	GetPeriod(ctx context.Context, period string) (*models.LedgerPeriod, error)
+
+	// ClosePeriod locks the period against further journals and records its
+	// totals. It waits for journals being posted to the period to commit.
+	ClosePeriod(ctx context.Context, period string, closedByID uuid.UUID) error
+
+	// ReopenPeriod returns pgx.ErrNoRows if the period is not closed.
+	ReopenPeriod(ctx context.Context, period string, reopenedByID uuid.UUID, reason string) error
+
+	// TrialBalance returns each account's balances for the period, per
+	// station or region when groupBy is set.
+	TrialBalance(ctx context.Context, period string, filter models.LedgerJournalFilter, groupBy string) ([]models.TrialBalanceLine, error)
+}
@@ -2,15 +2,16 @@ package repositories
 
 import (
 	"context"
-	"time"
 
 	"github.com/ghana-police/ticketing-backend/internal/domain/models"
 )
 
 type LookupRepository interface {
-	// GetLookupData returns all active reference data for offline caching.
-	GetLookupData(ctx context.Context) (*models.LookupData, error)
+	// GetLookupData returns reference data for offline caching. With a nil cursor it returns
+	// all active rows; otherwise only rows changed since each entity's version, with
+	// deactivated rows listed in Removed.
+	GetLookupData(ctx context.Context, since *models.LookupVersions) (*models.LookupData, error)
 
-	// GetLastUpdated returns the most recent updated_at across all lookup entities.
-	GetLastUpdated(ctx context.Context) (time.Time, error)
+	// GetVersions returns the most recent updated_at per lookup entity, including inactive rows.
+	GetVersions(ctx context.Context) (models.LookupVersions, error)
 }
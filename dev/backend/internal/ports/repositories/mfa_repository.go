@@ -0,0 +1,49 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/ghana-police/ticketing-backend/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+type MFARepository interface {
+	// Get returns the user's MFA state.
+	Get(ctx context.Context, userID uuid.UUID) (*models.UserMFA, error)
+
+	// SetPendingSecret stores a sealed secret awaiting confirmation.
+	SetPendingSecret(ctx context.Context, userID uuid.UUID, sealed string) error
+
+	// Activate promotes the pending secret, records the confirming step and
+	// replaces the recovery codes.
+	Activate(ctx context.Context, userID uuid.UUID, step int64, codeHashes []string) error
+
+	// AdvanceStep records an accepted TOTP step. Returns false if a step at
+	// or after it was already used.
+	AdvanceStep(ctx context.Context, userID uuid.UUID, step int64) (bool, error)
+
+	// UseRecoveryCode marks an unused recovery code as used. Returns false
+	// if no such code exists.
+	UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string) (bool, error)
+
+	// ReplaceRecoveryCodes discards the user's recovery codes and stores new ones.
+	ReplaceRecoveryCodes(ctx context.Context, userID uuid.UUID, codeHashes []string) error
+
+	// CountRecoveryCodes returns the number of unused recovery codes.
+	CountRecoveryCodes(ctx context.Context, userID uuid.UUID) (int, error)
+
+	// Disable clears secrets and recovery codes.
+	Disable(ctx context.Context, userID uuid.UUID) error
+
+	// CreateChallenge stores a login challenge.
+	CreateChallenge(ctx context.Context, c *models.MFAChallenge) error
+
+	// FindChallenge finds a challenge by token hash.
+	FindChallenge(ctx context.Context, tokenHash string) (*models.MFAChallenge, error)
+
+	// RecordChallengeAttempt increments the failed attempt counter.
+	RecordChallengeAttempt(ctx context.Context, id uuid.UUID) error
+
+	// ConsumeChallenge marks a challenge used. Returns false if it already was.
+	ConsumeChallenge(ctx context.Context, id uuid.UUID) (bool, error)
+}
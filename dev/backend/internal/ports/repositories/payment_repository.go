@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"time"
 
 	"github.com/ghana-police/ticketing-backend/internal/domain/models"
 	"github.com/ghana-police/ticketing-backend/pkg/pagination"
@@ -15,17 +16,39 @@ type PaymentRepository interface {
 	// GetByID returns a payment by its ID.
 	GetByID(ctx context.Context, id uuid.UUID) (*models.Payment, error)
 
+	// GetByIDForUpdate returns a payment and locks its row until the unit of
+	// work in ctx ends.
+	GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*models.Payment, error)
+
 	// GetByReference returns a payment by payment reference.
 	GetByReference(ctx context.Context, ref string) (*models.Payment, error)
 
+	// GetByTransactionID returns the payment a provider knows by this reference.
+	GetByTransactionID(ctx context.Context, transactionID string) (*models.Payment, error)
+
 	// List returns paginated payments with filters.
 	List(ctx context.Context, filter models.PaymentFilter, search string, p pagination.Params) ([]models.Payment, int, error)
 
 	// UpdateStatus updates payment status and related fields.
 	UpdateStatus(ctx context.Context, id uuid.UUID, status string, transactionID, statusMessage, providerResponse *string) error
 
-	// Complete marks a payment as completed and updates the ticket.
-	Complete(ctx context.Context, id uuid.UUID, transactionID *string, receiptNumber string) error
+	// SetProviderRef records the provider's reference, network and status on a
+	// pending payment that has none yet. Returns pgx.ErrNoRows otherwise.
+	SetProviderRef(ctx context.Context, id uuid.UUID, transactionID string, network *string, status string) error
+
+	// Complete marks a payment as completed and adds it to the ticket's paid
+	// amount. The ticket becomes paid when nothing is left outstanding. It
+	// returns the ticket's balance after the payment. Inside a unit of work it
+	// runs in that transaction.
+	Complete(ctx context.Context, id uuid.UUID, transactionID *string, receiptNumber string) (float64, error)
+
+	// Refund marks a completed payment refunded, with the reason as its status
+	// message, and takes it off the ticket's paid amount. The ticket's fine is
+	// lowered by withdrawn, the part of the refund that gives up the fine
+	// rather than returning an overpayment. It returns pgx.ErrNoRows if the
+	// payment is not completed. Inside a unit of work it runs in that
+	// transaction.
+	Refund(ctx context.Context, id uuid.UUID, reason string, withdrawn float64) error
 
 	// GetStats returns aggregate payment statistics.
 	GetStats(ctx context.Context, filter models.PaymentFilter) (*models.PaymentStats, error)
@@ -33,8 +56,19 @@ type PaymentRepository interface {
 	// GetReceipt returns receipt data for a completed payment.
 	GetReceipt(ctx context.Context, id uuid.UUID) (*models.PaymentReceipt, error)
 
-	// HasPendingOrCompleted checks if a ticket already has a pending or completed payment.
-	HasPendingOrCompleted(ctx context.Context, ticketID uuid.UUID) (bool, error)
+	// CountByTicket returns the number of payments made or attempted on a ticket.
+	CountByTicket(ctx context.Context, ticketID uuid.UUID) (int, error)
+
+	// HasPending checks if a ticket has a payment still awaiting its provider.
+	HasPending(ctx context.Context, ticketID uuid.UUID) (bool, error)
+
+	// ClaimForPoll returns up to limit pending payments that are due for a
+	// provider check and pushes their next check back exponentially from
+	// backoffBase up to backoffMax. Rows claimed by another instance are skipped.
+	ClaimForPoll(ctx context.Context, limit int, backoffBase, backoffMax time.Duration) ([]models.Payment, error)
+
+	// CountPending returns the number of payments awaiting the provider.
+	CountPending(ctx context.Context) (int, error)
 
 	// NextReceiptNumber generates the next receipt number.
 	NextReceiptNumber(ctx context.Context) (string, error)
@@ -0,0 +1,29 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/ghana-police/ticketing-backend/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+type PermissionRepository interface {
+	// ListPermissions returns the permission catalogue.
+	ListPermissions(ctx context.Context) ([]models.Permission, error)
+
+	// ListRolePermissions returns the permission keys held by each role.
+	ListRolePermissions(ctx context.Context) (map[string][]string, error)
+
+	// ReplaceRolePermissions sets the complete permission set of a role.
+	ReplaceRolePermissions(ctx context.Context, role string, permissions []string) error
+
+	// ListUserOverrides returns a user's grants and denies.
+	ListUserOverrides(ctx context.Context, userID uuid.UUID) ([]models.UserPermission, error)
+
+	// SetUserOverride creates or replaces a user's grant or deny.
+	SetUserOverride(ctx context.Context, p *models.UserPermission) error
+
+	// DeleteUserOverride removes a grant or deny. Returns pgx.ErrNoRows if
+	// there was none.
+	DeleteUserOverride(ctx context.Context, userID uuid.UUID, permission string) error
+}
@@ -0,0 +1,14 @@
+package repositories
+
+import (
+	"context"
+	"time"
+)
+
+type RateLimitRepository interface {
+	// Hit records a request against key in a sliding window of the given
+	// length, unless the window already holds limit requests. It returns
+	// whether the request was admitted, the number of requests now in the
+	// window and the time the oldest of them was made.
+	Hit(ctx context.Context, key string, limit int, window time.Duration) (allowed bool, count int, oldest time.Time, err error)
+}
@@ -0,0 +1,41 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/ghana-police/ticketing-backend/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+type ServiceAccountRepository interface {
+	// Create inserts a service account together with its backing users row,
+	// which gets passwordHash (an unusable value) and email.
+	Create(ctx context.Context, sa *models.ServiceAccount, email, passwordHash string) error
+
+	// GetByID returns a service account. Returns pgx.ErrNoRows if not found.
+	GetByID(ctx context.Context, id uuid.UUID) (*models.ServiceAccount, error)
+
+	// List returns every service account, newest first.
+	List(ctx context.Context) ([]models.ServiceAccount, error)
+
+	// Update changes the name, description and active flag.
+	Update(ctx context.Context, sa *models.ServiceAccount) error
+
+	// CreateKey stores a new API key.
+	CreateKey(ctx context.Context, key *models.APIKey) error
+
+	// ListKeys returns the keys of a service account, newest first.
+	ListKeys(ctx context.Context, serviceAccountID uuid.UUID) ([]models.APIKey, error)
+
+	// FindKey returns the key with the given prefix and its service account.
+	// Returns pgx.ErrNoRows if there is none.
+	FindKey(ctx context.Context, prefix string) (*models.APIKey, *models.ServiceAccount, error)
+
+	// RevokeKey revokes a live key of the service account. Returns
+	// pgx.ErrNoRows if there was none.
+	RevokeKey(ctx context.Context, serviceAccountID, keyID uuid.UUID) error
+
+	// TouchKey records use of a key from ip. Writes are skipped while the
+	// recorded use is under a minute old.
+	TouchKey(ctx context.Context, keyID uuid.UUID, ip string) error
+}
@@ -0,0 +1,37 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/ghana-police/ticketing-backend/internal/domain/models"
+	"github.com/ghana-police/ticketing-backend/pkg/pagination"
+	"github.com/google/uuid"
+)
+
+type SettlementRepository interface {
+	// CreateImport inserts a statement with its lines.
+	CreateImport(ctx context.Context, imp *models.SettlementImport, lines []models.SettlementLine) error
+
+	// GetImportByChecksum finds an earlier import of the same statement.
+	GetImportByChecksum(ctx context.Context, provider, checksum string) (*models.SettlementImport, error)
+
+	GetImport(ctx context.Context, id uuid.UUID) (*models.SettlementImport, error)
+
+	ListImports(ctx context.Context, provider *string, p pagination.Params) ([]models.SettlementImport, int, error)
+
+	// IsPaymentSettled reports whether a line already settled the payment.
+	IsPaymentSettled(ctx context.Context, paymentID uuid.UUID) (bool, error)
+
+	GetLine(ctx context.Context, id uuid.UUID) (*models.SettlementLine, error)
+
+	ListLines(ctx context.Context, filter models.SettlementLineFilter, p pagination.Params) ([]models.SettlementLine, int, error)
+
+	// ResolveLine closes an open worklist line. Returns pgx.ErrNoRows if the
+	// line is not open.
+	ResolveLine(ctx context.Context, id, resolvedBy uuid.UUID, resolution string) error
+
+	// DailyReport compares, for each day from..to, the completed payments made
+	// with the provider's methods against the provider's settlement lines.
+	DailyReport(ctx context.Context, provider string, methods []string, from, to time.Time) ([]models.ReconciliationDay, error)
+}
@@ -0,0 +1,19 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/ghana-police/ticketing-backend/internal/domain/models"
+)
+
+type SigningKeyRepository interface {
+	// ListLive returns keys that have not retired, newest activation first.
+	ListLive(ctx context.Context) ([]models.SigningKey, error)
+
+	// Rotate stores key and schedules every other live key to retire at
+	// retireAt. It does nothing and returns false when a key for the same
+	// algorithm activating after dueBefore already exists (another instance
+	// rotated first).
+	Rotate(ctx context.Context, key *models.SigningKey, dueBefore, retireAt time.Time) (bool, error)
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/ghana-police/ticketing-backend/internal/domain/models"
+	"github.com/ghana-police/ticketing-backend/pkg/pagination"
 	"github.com/google/uuid"
 )
 
@@ -15,9 +16,34 @@ type SyncRepository interface {
 	// GetDeviceSync returns the sync record for a user+device pair.
 	GetDeviceSync(ctx context.Context, userID uuid.UUID, deviceID string) (*models.DeviceSync, error)
 
-	// GetTicketsUpdatedSince returns tickets (for the user's jurisdiction) modified since the given timestamp.
-	GetTicketsUpdatedSince(ctx context.Context, since time.Time, stationID *uuid.UUID, regionID *uuid.UUID, limit int) ([]models.ServerTicketUpdate, error)
+	// GetTicketChanges returns up to limit tickets (for the user's jurisdiction) positioned after
+	// the cursor, ordered by (updated_at, id). Cancelled tickets are returned as tombstones.
+	GetTicketChanges(ctx context.Context, after models.ChangeCursor, stationID *uuid.UUID, regionID *uuid.UUID, limit int) ([]models.ServerTicketUpdate, error)
 
 	// CountTicketsUpdatedSince returns the count of tickets modified since the given timestamp.
 	CountTicketsUpdatedSince(ctx context.Context, since time.Time, stationID *uuid.UUID, regionID *uuid.UUID) (int, error)
+
+	// CreateConflict records a conflicting device update. Pending conflicts also flag the ticket's sync_status.
+	CreateConflict(ctx context.Context, c *models.SyncConflict) error
+
+	// GetConflictByID returns a single conflict with the joined ticket number.
+	GetConflictByID(ctx context.Context, id uuid.UUID) (*models.SyncConflict, error)
+
+	// ListConflicts returns a paginated list of conflicts.
+	ListConflicts(ctx context.Context, filter models.SyncConflictFilter, p pagination.Params) ([]models.SyncConflict, int, error)
+
+	// ResolveConflict marks a conflict resolved and clears the ticket's conflict flag when none remain pending.
+	ResolveConflict(ctx context.Context, id uuid.UUID, resolution string, resolvedBy uuid.UUID, notes *string) error
+
+	// RecordSession stores a sync session and refreshes the user+device telemetry summary.
+	RecordSession(ctx context.Context, session *models.SyncSession) error
+
+	// ListSessions returns a paginated list of sessions for a device (within the caller's jurisdiction), newest first.
+	ListSessions(ctx context.Context, deviceID string, scope *models.Jurisdiction, p pagination.Params) ([]models.SyncSession, int, error)
+
+	// ListDeviceHealth returns a paginated list of devices with their classified sync health.
+	ListDeviceHealth(ctx context.Context, filter models.DeviceHealthFilter, t models.DeviceHealthThresholds, p pagination.Params) ([]models.DeviceHealth, int, error)
+
+	// ListStationHealth returns device health counts per station.
+	ListStationHealth(ctx context.Context, filter models.DeviceHealthFilter, t models.DeviceHealthThresholds) ([]models.StationSyncHealth, error)
 }
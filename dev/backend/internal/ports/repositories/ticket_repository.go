@@ -27,6 +27,20 @@ type TicketRepository interface {
 	// UpdateStatus updates the ticket status.
 	UpdateStatus(ctx context.Context, ticketID uuid.UUID, status string) error
 
+	// LockForUpdate locks the ticket row until the unit of work in ctx ends
+	// and returns the ticket's current status and outstanding balance.
+	// Payment changes take this lock first, so they apply to one ticket at a
+	// time.
+	LockForUpdate(ctx context.Context, ticketID uuid.UUID) (status string, outstanding float64, err error)
+
+	// Receivable returns what the ticket's holder still owes, less anything
+	// paid over the fine. It is the ticket's fines receivable balance in the
+	// revenue ledger.
+	Receivable(ctx context.Context, ticketID uuid.UUID) (float64, error)
+
+	// SetTotalFine replaces the ticket's fine without touching its offences.
+	SetTotalFine(ctx context.Context, ticketID uuid.UUID, totalFine float64) error
+
 	// VoidTicket sets status=cancelled with void metadata.
 	VoidTicket(ctx context.Context, ticketID uuid.UUID, voidedBy uuid.UUID, reason string) error
 
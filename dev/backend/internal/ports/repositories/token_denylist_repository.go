@@ -0,0 +1,25 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TokenDenylistRepository interface {
+	// DenyToken denies a single access token (by jti) for ttl.
+	DenyToken(ctx context.Context, jti string, ttl time.Duration) error
+
+	// DenyUser denies every access token issued to the user at or before
+	// revokedAt, to the microsecond. The entry is kept for ttl.
+	DenyUser(ctx context.Context, userID uuid.UUID, revokedAt time.Time, ttl time.Duration) error
+
+	// DenySession denies every access token carrying the session ID. The
+	// entry is kept for ttl.
+	DenySession(ctx context.Context, sessionID uuid.UUID, ttl time.Duration) error
+
+	// IsDenied reports whether the token, its session or its user has been
+	// denied. sessionID is nil for tokens issued without one.
+	IsDenied(ctx context.Context, jti string, userID uuid.UUID, sessionID *uuid.UUID, issuedAt time.Time) (bool, error)
+}
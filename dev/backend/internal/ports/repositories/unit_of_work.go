@@ -0,0 +1,12 @@
+package repositories
+
+import "context"
+
+// UnitOfWork groups repository calls into one database transaction.
+type UnitOfWork interface {
+	// Do runs fn in a transaction. Repository calls made with the context
+	// passed to fn take part in it, and row locks taken there are held until
+	// it ends. The transaction commits when fn returns nil and rolls back
+	// otherwise; the error from fn is returned unchanged.
+	Do(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -23,8 +23,12 @@ type UserRepository interface {
 	// UpdateProfile updates user profile fields.
 	UpdateProfile(ctx context.Context, id uuid.UUID, firstName, lastName string, phone, email *string, profilePhoto *string) (*models.User, error)
 
-	// UpdatePassword changes the user's password hash.
-	UpdatePassword(ctx context.Context, id uuid.UUID, passwordHash string) error
+	// UpdatePassword changes the user's password hash, moving the old one to
+	// the password history. mustChange marks the new password as temporary.
+	UpdatePassword(ctx context.Context, id uuid.UUID, passwordHash string, mustChange bool) error
+
+	// ListPasswordHistory returns up to limit replaced password hashes, newest first.
+	ListPasswordHistory(ctx context.Context, id uuid.UUID, limit int) ([]string, error)
 
 	// IncrementFailedLogins increments failed_login_attempts and optionally locks the account.
 	IncrementFailedLogins(ctx context.Context, id uuid.UUID, lockUntil *string) error
@@ -38,9 +42,23 @@ type UserRepository interface {
 	// FindRefreshToken finds a refresh token by its hash.
 	FindRefreshTokenByHash(ctx context.Context, tokenHash string) (*models.RefreshToken, error)
 
+	// RotateRefreshToken stores next and marks the current token as replaced by
+	// it. Returns pgx.ErrNoRows if the current token was already revoked.
+	RotateRefreshToken(ctx context.Context, currentID uuid.UUID, next *models.RefreshToken) error
+
+	// RevokeTokenFamily revokes every live token in a rotation family.
+	RevokeTokenFamily(ctx context.Context, familyID uuid.UUID) error
+
 	// RevokeRefreshToken marks a refresh token as revoked.
 	RevokeRefreshToken(ctx context.Context, tokenHash string) error
 
 	// RevokeAllUserTokens revokes all refresh tokens for a user.
 	RevokeAllUserTokens(ctx context.Context, userID uuid.UUID) error
+
+	// ListSessions returns the user's live sessions, most recently used first.
+	ListSessions(ctx context.Context, userID uuid.UUID) ([]models.Session, error)
+
+	// RevokeSession revokes the user's token family familyID. Returns
+	// pgx.ErrNoRows if the user has no live session with that ID.
+	RevokeSession(ctx context.Context, userID, familyID uuid.UUID) error
 }
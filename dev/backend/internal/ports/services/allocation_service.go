@@ -0,0 +1,67 @@
+package services
+
+import (
+	"context"
+
+	"github.com/ghana-police/ticketing-backend/internal/domain/models"
+	"github.com/ghana-police/ticketing-backend/pkg/pagination"
+	"github.com/google/uuid"
+)
+
+type AllocationService interface {
+	ListBeneficiaries(ctx context.Context, isActive *bool) ([]models.RevenueBeneficiary, error)
+	GetBeneficiary(ctx context.Context, id uuid.UUID) (*models.RevenueBeneficiary, error)
+	CreateBeneficiary(ctx context.Context, req *CreateBeneficiaryRequest) (*models.RevenueBeneficiary, error)
+
+	// UpdateBeneficiary refuses to deactivate a beneficiary that still has a
+	// share in an active rule.
+	UpdateBeneficiary(ctx context.Context, id uuid.UUID, req *UpdateBeneficiaryRequest) (*models.RevenueBeneficiary, error)
+
+	ListRules(ctx context.Context, isActive *bool) ([]models.AllocationRule, error)
+	GetRule(ctx context.Context, id uuid.UUID) (*models.AllocationRule, error)
+	CreateRule(ctx context.Context, req *AllocationRuleRequest) (*models.AllocationRule, error)
+
+	// UpdateRule replaces the rule's keys and shares. The default rule keeps
+	// matching everything and stays active. Payments already allocated keep
+	// the shares they were allocated under.
+	UpdateRule(ctx context.Context, id uuid.UUID, req *AllocationRuleRequest) (*models.AllocationRule, error)
+
+	ListAllocations(ctx context.Context, filter models.PaymentAllocationFilter, p pagination.Params) ([]models.PaymentAllocation, int, error)
+
+	// DisbursementReport returns what each beneficiary is owed for a period
+	// (YYYY-MM, default the current month), optionally per region.
+	DisbursementReport(ctx context.Context, req *DisbursementReportRequest) (*models.DisbursementReport, error)
+}
+
+type CreateBeneficiaryRequest struct {
+	Code        string  `json:"code"`
+	Name        string  `json:"name"`
+	BankName    *string `json:"bankName"`
+	BankAccount *string `json:"bankAccount"`
+}
+
+type UpdateBeneficiaryRequest struct {
+	Code        *string `json:"code"`
+	Name        *string `json:"name"`
+	BankName    *string `json:"bankName"`
+	BankAccount *string `json:"bankAccount"`
+	IsActive    *bool   `json:"isActive"`
+}
+
+// AllocationRuleRequest describes a whole rule. A key left out matches any
+// value.
+type AllocationRuleRequest struct {
+	Name            string                   `json:"name"`
+	OffenceCategory *string                  `json:"offenceCategory"`
+	RegionID        *uuid.UUID               `json:"regionId"`
+	PaymentMethod   *string                  `json:"paymentMethod"`
+	IsActive        *bool                    `json:"isActive"` // default true
+	Shares          []models.AllocationShare `json:"shares"`
+}
+
+type DisbursementReportRequest struct {
+	Period    string
+	StationID *uuid.UUID
+	RegionID  *uuid.UUID
+	GroupBy   string // region or empty for the whole country
+}
@@ -32,6 +32,7 @@ type AuditEntry struct {
 	ErrorMsg    string
 	IPAddress   string
 	UserAgent   string
+	APIKeyID    *uuid.UUID // filled from the request context when unset
 	StationID   *uuid.UUID
 	StationName string
 	RegionID    *uuid.UUID
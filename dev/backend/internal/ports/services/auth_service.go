@@ -12,19 +12,51 @@ type LoginRequest struct {
 	BadgeNumber *string `json:"badgeNumber"`
 	Password    string  `json:"password"`
 	DeviceID    *string `json:"deviceId"`
+	DeviceKey   *string `json:"deviceKey"` // issued at enrollment; also accepted as X-Device-Key
 	DeviceInfo  any     `json:"deviceInfo"`
+	IPAddress   string  `json:"-"`
+	UserAgent   string  `json:"-"`
 }
 
+// LoginResult carries either a session or, when a second factor is needed,
+// only MFA (the challenge to complete with VerifyMFA).
 type LoginResult struct {
 	User         *models.UserResponse `json:"user"`
 	AccessToken  string               `json:"accessToken"`
 	RefreshToken string               `json:"refreshToken"`
 	ExpiresIn    int                  `json:"expiresIn"`
+
+	MFA           *MFAChallengeResult `json:"-"`
+	RecoveryCodes []string            `json:"-"` // set when MFA was activated at login
+}
+
+type MFAChallengeResult struct {
+	ChallengeToken string `json:"challengeToken"`
+	SetupRequired  bool   `json:"setupRequired"`
+	ExpiresIn      int    `json:"expiresIn"`
+}
+
+type VerifyMFARequest struct {
+	ChallengeToken string `json:"challengeToken"`
+	Code           string `json:"code"`
+	IPAddress      string `json:"-"`
+	UserAgent      string `json:"-"`
+}
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refreshToken"`
+	DeviceID     string `json:"deviceId"`  // also accepted as X-Device-ID
+	DeviceKey    string `json:"deviceKey"` // also accepted as X-Device-Key
+	IPAddress    string `json:"-"`
+	UserAgent    string `json:"-"`
 }
 
+// RefreshResult carries the rotated refresh token; the presented one is no
+// longer valid once this is returned.
 type RefreshResult struct {
-	AccessToken string `json:"accessToken"`
-	ExpiresIn   int    `json:"expiresIn"`
+	AccessToken  string `json:"accessToken"`
+	RefreshToken string `json:"refreshToken"`
+	ExpiresIn    int    `json:"expiresIn"`
 }
 
 type UpdateProfileRequest struct {
@@ -42,8 +74,14 @@ type ChangePasswordRequest struct {
 
 type AuthService interface {
 	Login(ctx context.Context, req *LoginRequest) (*LoginResult, error)
+	VerifyMFA(ctx context.Context, req *VerifyMFARequest) (*LoginResult, error)
+	// EnrollMFAChallenge starts TOTP enrolment for a user who must set up
+	// MFA before their first session is issued.
+	EnrollMFAChallenge(ctx context.Context, challengeToken string) (*MFAEnrollment, error)
+	// Logout ends the session of the given refresh token, or else the session
+	// of the access token used to call it.
 	Logout(ctx context.Context, userID uuid.UUID, refreshToken *string) error
-	RefreshToken(ctx context.Context, refreshToken string) (*RefreshResult, error)
+	RefreshToken(ctx context.Context, req *RefreshRequest) (*RefreshResult, error)
 	GetProfile(ctx context.Context, userID uuid.UUID) (*models.UserResponse, error)
 	UpdateProfile(ctx context.Context, userID uuid.UUID, req *UpdateProfileRequest) (*models.UserResponse, error)
 	ChangePassword(ctx context.Context, userID uuid.UUID, req *ChangePasswordRequest) error
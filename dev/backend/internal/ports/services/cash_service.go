@@ -0,0 +1,63 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/ghana-police/ticketing-backend/internal/domain/models"
+	"github.com/ghana-police/ticketing-backend/pkg/pagination"
+	"github.com/google/uuid"
+)
+
+type CashService interface {
+	// OpenShift starts the caller's cashier shift with an opening float.
+	OpenShift(ctx context.Context, req *OpenShiftRequest) (*models.CashierShift, error)
+
+	// CurrentShift returns the caller's open shift.
+	CurrentShift(ctx context.Context) (*models.CashierShift, error)
+
+	GetShift(ctx context.Context, id uuid.UUID) (*models.CashierShift, error)
+	ListShifts(ctx context.Context, filter models.CashierShiftFilter, p pagination.Params) ([]models.CashierShift, int, error)
+
+	// CloseShift cashes up a shift, comparing the counted cash with the float
+	// and the cash payments taken.
+	CloseShift(ctx context.Context, id uuid.UUID, req *CloseShiftRequest) (*models.CashierShift, error)
+
+	// SubmitRemittance records the bank deposit of closed shifts' cash.
+	SubmitRemittance(ctx context.Context, req *SubmitRemittanceRequest) (*models.CashRemittance, error)
+
+	GetRemittance(ctx context.Context, id uuid.UUID) (*models.CashRemittance, error)
+	ListRemittances(ctx context.Context, filter models.CashRemittanceFilter, p pagination.Params) ([]models.CashRemittance, int, error)
+
+	// ReviewRemittance signs off or rejects a remittance. Nobody reviews
+	// their own.
+	ReviewRemittance(ctx context.Context, id uuid.UUID, req *ReviewRemittanceRequest) (*models.CashRemittance, error)
+
+	// TreasuryReport follows station cash from collection to the bank.
+	TreasuryReport(ctx context.Context, stationID *uuid.UUID, from, to time.Time) (*models.TreasuryReport, error)
+}
+
+type OpenShiftRequest struct {
+	StationID    *uuid.UUID `json:"stationId,omitempty"` // default: the caller's station
+	OpeningFloat float64    `json:"openingFloat"`
+}
+
+type CloseShiftRequest struct {
+	CountedCash float64 `json:"countedCash"` // everything in the drawer, float included
+	Notes       *string `json:"notes,omitempty"`
+}
+
+type SubmitRemittanceRequest struct {
+	ShiftIDs          []uuid.UUID `json:"shiftIds"`
+	DepositSlipNumber string      `json:"depositSlipNumber"`
+	BankName          string      `json:"bankName"`
+	BankAccount       *string     `json:"bankAccount,omitempty"`
+	DepositedAt       time.Time   `json:"depositedAt"`
+	DepositedAmount   float64     `json:"depositedAmount"`
+	Notes             *string     `json:"notes,omitempty"`
+}
+
+type ReviewRemittanceRequest struct {
+	Decision string  `json:"decision"` // approved or rejected
+	Notes    *string `json:"notes,omitempty"`
+}
@@ -0,0 +1,77 @@
+package services
+
+import (
+	"context"
+
+	"github.com/ghana-police/ticketing-backend/internal/domain/models"
+	"github.com/ghana-police/ticketing-backend/pkg/pagination"
+	"github.com/google/uuid"
+)
+
+type DeviceService interface {
+	// Registry administration
+	List(ctx context.Context, filter models.DeviceFilter, search string, p pagination.Params) ([]models.Device, int, error)
+	Get(ctx context.Context, id uuid.UUID) (*models.Device, error)
+	Register(ctx context.Context, req *RegisterDeviceRequest) (*DeviceEnrollmentResult, error)
+	ReEnroll(ctx context.Context, id uuid.UUID) (*DeviceEnrollmentResult, error)
+	UpdateStatus(ctx context.Context, id uuid.UUID, req *UpdateDeviceStatusRequest) (*models.Device, error)
+	ListCommands(ctx context.Context, id uuid.UUID) ([]models.DeviceCommand, error)
+	IssueCommand(ctx context.Context, id uuid.UUID, req *IssueDeviceCommandRequest) (*models.DeviceCommand, error)
+	CancelCommand(ctx context.Context, id, commandID uuid.UUID) error
+
+	// Enroll exchanges a one-time enrollment code for a device key (public).
+	Enroll(ctx context.Context, req *EnrollDeviceRequest) (*EnrollDeviceResult, error)
+
+	// Authorize checks the hardware ID and device key presented by a client.
+	// When mandatory is set (and enrollment is enforced) unknown devices are
+	// refused; otherwise they are allowed through with a nil device. Known
+	// devices must always be active and present their key.
+	Authorize(ctx context.Context, hardwareID, deviceKey string, mandatory bool) (*models.Device, error)
+
+	// VerifyBinding checks that a refresh token bound to a device is being used
+	// by that device and that the device is still active.
+	VerifyBinding(ctx context.Context, deviceRef uuid.UUID, deviceKey string) error
+
+	// DeliverCommands acknowledges the given commands and returns those still
+	// outstanding for the device.
+	DeliverCommands(ctx context.Context, deviceRef uuid.UUID, acked []uuid.UUID) ([]models.DeviceCommand, error)
+
+	// Touch records device contact.
+	Touch(ctx context.Context, deviceRef uuid.UUID, appVersion *string)
+}
+
+type RegisterDeviceRequest struct {
+	Name      string     `json:"name"`
+	StationID *uuid.UUID `json:"stationId"`
+	OfficerID *uuid.UUID `json:"officerId"`
+}
+
+type DeviceEnrollmentResult struct {
+	Device         *models.Device `json:"device"`
+	EnrollmentCode string         `json:"enrollmentCode"`
+}
+
+type UpdateDeviceStatusRequest struct {
+	Status string  `json:"status"`
+	Reason *string `json:"reason"`
+}
+
+type IssueDeviceCommandRequest struct {
+	Command string  `json:"command"`
+	Reason  *string `json:"reason"`
+}
+
+type EnrollDeviceRequest struct {
+	EnrollmentCode string  `json:"enrollmentCode"`
+	DeviceID       string  `json:"deviceId"`
+	BadgeNumber    string  `json:"badgeNumber"` // required when the device is issued to an officer
+	Password       string  `json:"password"`
+	Platform       *string `json:"platform"`
+	Model          *string `json:"model"`
+	AppVersion     *string `json:"appVersion"`
+}
+
+type EnrollDeviceResult struct {
+	Device    *models.Device `json:"device"`
+	DeviceKey string         `json:"deviceKey"`
+}
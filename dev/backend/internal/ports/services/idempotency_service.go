@@ -0,0 +1,22 @@
+package services
+
+import (
+	"context"
+
+	"github.com/ghana-police/ticketing-backend/internal/domain/models"
+)
+
+type IdempotencyService interface {
+	// Begin claims key for a request with the given fingerprint. When the key
+	// was already used by the same request and that request has finished, the
+	// stored record is returned so its response can be replayed; a nil record
+	// means the request should run. A key reused for a different request, or
+	// whose first request is still running, is a conflict.
+	Begin(ctx context.Context, key, fingerprint string) (*models.IdempotencyRecord, error)
+
+	// Complete stores the response of the request that claimed key.
+	Complete(ctx context.Context, key string, record *models.IdempotencyRecord)
+
+	// Release frees key without storing a response, so a retry runs again.
+	Release(ctx context.Context, key string)
+}
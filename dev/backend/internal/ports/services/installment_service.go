@@ -0,0 +1,39 @@
+package services
+
+import (
+	"context"
+
+	"github.com/ghana-police/ticketing-backend/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+type InstallmentService interface {
+	// CreatePlan spreads the ticket's outstanding balance over a schedule.
+	CreatePlan(ctx context.Context, ticketID uuid.UUID, req *CreateInstallmentPlanRequest) (*models.InstallmentPlan, error)
+
+	// GetPlan returns the ticket's most recent plan.
+	GetPlan(ctx context.Context, ticketID uuid.UUID) (*models.InstallmentPlan, error)
+
+	// CancelPlan stops the ticket's active plan. Payments already made stay
+	// on the ticket.
+	CancelPlan(ctx context.Context, ticketID uuid.UUID, req *CancelInstallmentPlanRequest) (*models.InstallmentPlan, error)
+}
+
+// CreateInstallmentPlanRequest gives either an explicit schedule, which must
+// add up to the outstanding balance, or a number of equal installments.
+type CreateInstallmentPlanRequest struct {
+	Installments []InstallmentInput `json:"installments,omitempty"`
+	Count        int                `json:"count,omitempty"`
+	IntervalDays int                `json:"intervalDays,omitempty"` // between equal installments; default 30
+	FirstDueDate *string            `json:"firstDueDate,omitempty"` // YYYY-MM-DD; default one interval from today
+	Notes        *string            `json:"notes,omitempty"`
+}
+
+type InstallmentInput struct {
+	DueDate string  `json:"dueDate"` // YYYY-MM-DD
+	Amount  float64 `json:"amount"`
+}
+
+type CancelInstallmentPlanRequest struct {
+	Reason *string `json:"reason,omitempty"`
+}
@@ -0,0 +1,39 @@
+package services
+
+import (
+	"context"
+
+	"github.com/ghana-police/ticketing-backend/internal/domain/models"
+	"github.com/ghana-police/ticketing-backend/pkg/pagination"
+	"github.com/google/uuid"
+)
+
+type LedgerService interface {
+	ListAccounts(ctx context.Context) ([]models.LedgerAccount, error)
+
+	GetJournal(ctx context.Context, id uuid.UUID) (*models.LedgerJournal, error)
+	ListJournals(ctx context.Context, filter models.LedgerJournalFilter, p pagination.Params) ([]models.LedgerJournal, int, error)
+
+	// TrialBalance returns every account's balances for a period (YYYY-MM,
+	// default the current month), optionally per station or region.
+	TrialBalance(ctx context.Context, req *TrialBalanceRequest) (*models.TrialBalance, error)
+
+	ListPeriods(ctx context.Context) ([]models.LedgerPeriod, error)
+
+	// ClosePeriod locks a month that has ended. Months close in order.
+	ClosePeriod(ctx context.Context, period string) (*models.LedgerPeriod, error)
+
+	// ReopenPeriod unlocks the latest closed month.
+	ReopenPeriod(ctx context.Context, period string, req *ReopenPeriodRequest) (*models.LedgerPeriod, error)
+}
+
+type TrialBalanceRequest struct {
+	Period    string
+	StationID *uuid.UUID
+	RegionID  *uuid.UUID
+	GroupBy   string // station, region or empty for the whole ledger
+}
+
+type ReopenPeriodRequest struct {
+	Reason string `json:"reason"`
+}
@@ -8,6 +8,13 @@ import (
 )
 
 type LookupService interface {
-	// GetLookupData returns all active reference data. Returns nil if not modified since ifModifiedSince.
-	GetLookupData(ctx context.Context, ifModifiedSince *time.Time) (*models.LookupData, bool, error)
+	// GetLookupData returns the full catalogue, or only changes since req.Cursor.
+	// Returns false (with only ETag/LastUpdated set) when the client's copy is current.
+	GetLookupData(ctx context.Context, req *LookupRequest) (*models.LookupData, bool, error)
+}
+
+type LookupRequest struct {
+	Cursor          string // opaque cursor from a previous response; empty for the full catalogue
+	IfNoneMatch     string
+	IfModifiedSince *time.Time
 }
@@ -0,0 +1,45 @@
+package services
+
+import (
+	"context"
+
+	"github.com/ghana-police/ticketing-backend/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+type MFAService interface {
+	// Self-service for the authenticated user
+	Status(ctx context.Context, userID uuid.UUID) (*models.MFAStatus, error)
+	Enroll(ctx context.Context, userID uuid.UUID) (*MFAEnrollment, error)
+	Activate(ctx context.Context, userID uuid.UUID, code string) (*MFARecoveryCodes, error)
+	Disable(ctx context.Context, userID uuid.UUID, code string) error
+	RegenerateRecoveryCodes(ctx context.Context, userID uuid.UUID, code string) (*MFARecoveryCodes, error)
+
+	// Reset removes another user's MFA enrolment and signs them out (super_admin).
+	Reset(ctx context.Context, userID uuid.UUID) error
+
+	// Required reports whether the security settings make MFA mandatory for role.
+	// It reports true for an MFA-eligible role when the settings cannot be read.
+	Required(ctx context.Context, role string) bool
+
+	// Verify checks a TOTP code or unused recovery code for an enrolled user.
+	Verify(ctx context.Context, userID uuid.UUID, code string) error
+}
+
+// MFAEnrollment is a new TOTP secret awaiting confirmation. ProvisioningURI
+// is rendered as a QR code for authenticator apps.
+type MFAEnrollment struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioningUri"`
+	Issuer          string `json:"issuer"`
+	Account         string `json:"account"`
+}
+
+// MFARecoveryCodes are shown once; only their hashes are stored.
+type MFARecoveryCodes struct {
+	RecoveryCodes []string `json:"recoveryCodes"`
+}
+
+type MFACodeRequest struct {
+	Code string `json:"code"`
+}
@@ -0,0 +1,21 @@
+package services
+
+import (
+	"context"
+
+	"github.com/ghana-police/ticketing-backend/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+type PasswordPolicyService interface {
+	// Policy returns the password rules from the security settings.
+	Policy(ctx context.Context) models.PasswordPolicy
+
+	// Validate checks password against the policy. When userID is set the
+	// user's current and recent passwords are refused as well.
+	Validate(ctx context.Context, userID *uuid.UUID, password string) error
+
+	// MustChange reports whether user may only change their password: it was
+	// set by an administrator or is past the maximum age.
+	MustChange(ctx context.Context, user *models.User) bool
+}
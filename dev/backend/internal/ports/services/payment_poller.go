@@ -0,0 +1,9 @@
+package services
+
+import "context"
+
+// PaymentPoller settles pending digital payments in the background.
+type PaymentPoller interface {
+	// Run polls until ctx is cancelled.
+	Run(ctx context.Context)
+}
@@ -20,6 +20,7 @@ type PaymentProvider interface {
 
 // ProviderInitiateRequest is the data passed to a provider to start a payment.
 type ProviderInitiateRequest struct {
+	Method           string // payment method the provider was picked for
 	Amount           float64
 	Currency         string
 	PaymentReference string
@@ -35,13 +36,16 @@ type ProviderInitiateResult struct {
 	Status       string // "completed" for cash, "pending" for digital
 	RedirectURL  string // For card/bank payments
 	USSDCode     string // For mobile money
+	Network      string // Mobile money network of the payer, if known
 	Instructions string // Human-readable instructions
 }
 
 // ProviderVerifyResult is returned when verifying a payment with the provider.
 type ProviderVerifyResult struct {
-	Status        string // "completed", "pending", "failed"
-	TransactionID string // Provider's transaction ID
+	Status        string  // "completed", "pending", "failed"
+	TransactionID string  // Provider's transaction ID
+	Amount        float64 // Amount the provider collected; 0 if it does not say
+	Currency      string
 	StatusMessage string
 	RawResponse   string // JSON string of provider response for audit
 }
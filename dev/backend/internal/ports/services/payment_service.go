@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"time"
 
 	"github.com/ghana-police/ticketing-backend/internal/domain/models"
 	"github.com/ghana-police/ticketing-backend/pkg/pagination"
@@ -12,15 +13,22 @@ type PaymentService interface {
 	InitiateDigital(ctx context.Context, req *InitiatePaymentRequest) (*InitiatePaymentResult, error)
 	RecordCash(ctx context.Context, req *RecordCashRequest) (*models.Payment, error)
 	Verify(ctx context.Context, req *VerifyPaymentRequest) (*VerifyPaymentResult, error)
+	HandleReturn(ctx context.Context, providerRef string) (*CheckoutReturnResult, error)
+	PollPending(ctx context.Context, req *PollPaymentsRequest) (*models.PaymentPollResult, error)
 	GetByID(ctx context.Context, id uuid.UUID) (*models.Payment, error)
 	List(ctx context.Context, filter models.PaymentFilter, search string, p pagination.Params) ([]models.Payment, int, error)
 	Stats(ctx context.Context, filter models.PaymentFilter) (*models.PaymentStats, error)
 	Receipt(ctx context.Context, id uuid.UUID) (*models.PaymentReceipt, error)
+
+	// Refund returns a completed payment to the payer. Money paid over the
+	// fine goes back first; the rest gives up that much of the fine.
+	Refund(ctx context.Context, id uuid.UUID, req *RefundPaymentRequest) (*models.Payment, error)
 }
 
 type InitiatePaymentRequest struct {
 	TicketID    uuid.UUID `json:"ticketId"`
 	Method      string    `json:"method"`
+	Amount      *float64  `json:"amount,omitempty"` // defaults to the next installment, else the outstanding balance
 	PhoneNumber *string   `json:"phoneNumber,omitempty"`
 	PayerName   *string   `json:"payerName,omitempty"`
 	PayerEmail  *string   `json:"payerEmail,omitempty"`
@@ -45,6 +53,10 @@ type RecordCashRequest struct {
 	Notes     *string   `json:"notes,omitempty"`
 }
 
+type RefundPaymentRequest struct {
+	Reason string `json:"reason"`
+}
+
 type VerifyPaymentRequest struct {
 	PaymentReference string  `json:"paymentReference"`
 	TransactionID    *string `json:"transactionId,omitempty"`
@@ -59,4 +71,25 @@ type VerifyTicketInfo struct {
 	ID           uuid.UUID `json:"id"`
 	TicketNumber string    `json:"ticketNumber"`
 	Status       string    `json:"status"`
+	Outstanding  float64   `json:"outstandingBalance"`
+}
+
+// CheckoutReturnResult is shown to a payer returning from a hosted checkout.
+// It leaves out payer details: the caller is not authenticated.
+type CheckoutReturnResult struct {
+	PaymentReference string  `json:"paymentReference"`
+	TicketNumber     string  `json:"ticketNumber"`
+	Amount           float64 `json:"amount"`
+	Currency         string  `json:"currency"`
+	Status           string  `json:"status"`
+	StatusMessage    *string `json:"statusMessage,omitempty"`
+	ReceiptNumber    *string `json:"receiptNumber,omitempty"`
+}
+
+// PollPaymentsRequest tunes one pass of the payment poller.
+type PollPaymentsRequest struct {
+	Limit       int           // payments claimed per pass
+	BackoffBase time.Duration // wait before the first check, doubled after each
+	BackoffMax  time.Duration
+	GiveUpAfter time.Duration // fail a payment the provider cannot confirm this long after it expired
 }
@@ -0,0 +1,35 @@
+package services
+
+import (
+	"context"
+
+	"github.com/ghana-police/ticketing-backend/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+type PermissionService interface {
+	// Has reports whether a user holds a permission through their role or a
+	// grant and has not been denied it.
+	Has(ctx context.Context, userID uuid.UUID, role, permission string) (bool, error)
+
+	// Catalogue and role administration
+	ListPermissions(ctx context.Context) ([]models.Permission, error)
+	ListRoles(ctx context.Context) ([]models.RolePermissions, error)
+	GetRole(ctx context.Context, role string) (*models.RolePermissions, error)
+	UpdateRole(ctx context.Context, role string, req *UpdateRolePermissionsRequest) (*models.RolePermissions, error)
+
+	// Per-user grants and denies
+	GetUserPermissions(ctx context.Context, userID uuid.UUID) (*models.UserPermissions, error)
+	SetUserPermission(ctx context.Context, userID uuid.UUID, req *SetUserPermissionRequest) (*models.UserPermission, error)
+	RemoveUserPermission(ctx context.Context, userID uuid.UUID, permission string) error
+}
+
+type UpdateRolePermissionsRequest struct {
+	Permissions []string `json:"permissions"`
+}
+
+type SetUserPermissionRequest struct {
+	Permission string  `json:"permission"`
+	Effect     string  `json:"effect"` // grant | deny
+	Reason     *string `json:"reason"`
+}
@@ -0,0 +1,13 @@
+package services
+
+import (
+	"context"
+
+	"github.com/ghana-police/ticketing-backend/internal/domain/models"
+)
+
+type RateLimitService interface {
+	// Allow counts a request made by key (an IP or user) against the limit of
+	// its route class. A nil result means the class is not limited.
+	Allow(ctx context.Context, class, key string) (*models.RateLimitResult, error)
+}
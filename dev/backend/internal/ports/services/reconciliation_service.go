@@ -0,0 +1,35 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/ghana-police/ticketing-backend/internal/domain/models"
+	"github.com/ghana-police/ticketing-backend/pkg/pagination"
+	"github.com/google/uuid"
+)
+
+type ReconciliationService interface {
+	// Import reads a provider's settlement statement and matches each line
+	// against our payments. Lines that do not match cleanly go on the worklist.
+	Import(ctx context.Context, provider, fileName string, data []byte) (*ImportSettlementResult, error)
+
+	GetImport(ctx context.Context, id uuid.UUID) (*models.SettlementImport, error)
+	ListImports(ctx context.Context, provider *string, p pagination.Params) ([]models.SettlementImport, int, error)
+
+	// ListItems returns settlement lines; the worklist is the open ones.
+	ListItems(ctx context.Context, filter models.SettlementLineFilter, p pagination.Params) ([]models.SettlementLine, int, error)
+	ResolveItem(ctx context.Context, id uuid.UUID, req *ResolveReconciliationItemRequest) (*models.SettlementLine, error)
+
+	// Report compares payments with settlements per provider and day.
+	Report(ctx context.Context, provider *string, from, to time.Time) ([]models.ReconciliationDay, error)
+}
+
+type ImportSettlementResult struct {
+	Import *models.SettlementImport `json:"import"`
+	Items  []models.SettlementLine  `json:"items"` // lines put on the worklist
+}
+
+type ResolveReconciliationItemRequest struct {
+	Resolution string `json:"resolution"` // what was done about the line
+}
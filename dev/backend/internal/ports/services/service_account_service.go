@@ -0,0 +1,53 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/ghana-police/ticketing-backend/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+type ServiceAccountService interface {
+	// Account administration
+	List(ctx context.Context) ([]models.ServiceAccount, error)
+	Get(ctx context.Context, id uuid.UUID) (*models.ServiceAccount, error)
+	Create(ctx context.Context, req *CreateServiceAccountRequest) (*models.ServiceAccount, error)
+	Update(ctx context.Context, id uuid.UUID, req *UpdateServiceAccountRequest) (*models.ServiceAccount, error)
+
+	// API keys
+	ListKeys(ctx context.Context, id uuid.UUID) ([]models.APIKey, error)
+	CreateKey(ctx context.Context, id uuid.UUID, req *CreateAPIKeyRequest) (*CreateAPIKeyResult, error)
+	RevokeKey(ctx context.Context, id, keyID uuid.UUID) error
+
+	// Authenticate resolves an API key presented from clientIP. The key must
+	// be live, allowed from that address and belong to an active account.
+	Authenticate(ctx context.Context, apiKey, clientIP string) (*models.APIKeyPrincipal, error)
+}
+
+type CreateServiceAccountRequest struct {
+	Name        string     `json:"name"`
+	Description *string    `json:"description"`
+	Role        string     `json:"role"`
+	StationID   *uuid.UUID `json:"stationId"`
+	RegionID    *uuid.UUID `json:"regionId"`
+}
+
+type UpdateServiceAccountRequest struct {
+	Name        *string `json:"name"`
+	Description *string `json:"description"`
+	IsActive    *bool   `json:"isActive"`
+}
+
+type CreateAPIKeyRequest struct {
+	Name       string     `json:"name"`
+	Scopes     []string   `json:"scopes"`
+	AllowedIPs []string   `json:"allowedIps"`
+	ExpiresAt  *time.Time `json:"expiresAt"`
+}
+
+// CreateAPIKeyResult carries the only copy of the key that is ever returned.
+type CreateAPIKeyResult struct {
+	Key    *models.APIKey `json:"key"`
+	APIKey string         `json:"apiKey"`
+}
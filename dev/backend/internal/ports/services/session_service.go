@@ -0,0 +1,20 @@
+package services
+
+import (
+	"context"
+
+	"github.com/ghana-police/ticketing-backend/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+// SessionService lists and signs out the sessions (refresh token families)
+// of a user. Callers other than the user themselves only reach users within
+// their jurisdiction.
+type SessionService interface {
+	// List returns the user's live sessions, flagging the one making the request.
+	List(ctx context.Context, userID uuid.UUID) ([]models.Session, error)
+
+	// Revoke signs out one session: its refresh tokens stop working and its
+	// access tokens are denied.
+	Revoke(ctx context.Context, userID, sessionID uuid.UUID) error
+}
@@ -0,0 +1,71 @@
+package services
+
+import (
+	"io"
+	"sort"
+	"time"
+)
+
+// SettlementParser reads one provider's settlement statement.
+// Adding a provider = implement this interface + register it in the SettlementParserRegistry.
+type SettlementParser interface {
+	// Provider returns the provider identifier the statements come from (e.g. "momo").
+	Provider() string
+
+	// Methods returns the payment methods the provider's statements settle.
+	Methods() []string
+
+	// Parse reads the statement. Lines the provider did not settle (failed or
+	// reversed transactions) are left out.
+	Parse(r io.Reader) ([]SettlementRecord, error)
+}
+
+// SettlementRecord is one settled transaction read from a statement.
+type SettlementRecord struct {
+	LineNumber    int // line in the file, for pointing at problems
+	TransactionID string
+	Reference     string // our payment reference, or the reference we gave the provider
+	Amount        float64
+	Fee           float64
+	Currency      string
+	SettledAt     time.Time
+}
+
+// SettlementParseError points at the line of a statement that could not be read.
+type SettlementParseError struct {
+	Line    int
+	Message string
+}
+
+func (e *SettlementParseError) Error() string {
+	return e.Message
+}
+
+// SettlementParserRegistry manages settlement parsers keyed by provider.
+type SettlementParserRegistry struct {
+	parsers map[string]SettlementParser
+}
+
+func NewSettlementParserRegistry() *SettlementParserRegistry {
+	return &SettlementParserRegistry{parsers: make(map[string]SettlementParser)}
+}
+
+// Register adds a parser for its provider.
+func (r *SettlementParserRegistry) Register(parser SettlementParser) {
+	r.parsers[parser.Provider()] = parser
+}
+
+// Get returns the parser for the given provider, or nil if not found.
+func (r *SettlementParserRegistry) Get(provider string) SettlementParser {
+	return r.parsers[provider]
+}
+
+// Providers returns the registered providers in name order.
+func (r *SettlementParserRegistry) Providers() []string {
+	names := make([]string, 0, len(r.parsers))
+	for name := range r.parsers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,17 @@
+package services
+
+import (
+	"context"
+
+	jwtpkg "github.com/ghana-police/ticketing-backend/pkg/jwt"
+)
+
+// SigningKeyService manages the asymmetric JWT signing keys. It also acts as
+// the jwt.KeyProvider for the token manager.
+type SigningKeyService interface {
+	jwtpkg.KeyProvider
+
+	// JWKS returns the public keys of every live key, including keys that
+	// are published ahead of their activation.
+	JWKS(ctx context.Context) (*jwtpkg.JWKS, error)
+}
@@ -2,14 +2,52 @@ package services
 
 import (
 	"context"
+	"encoding/json"
+	"time"
 
 	"github.com/ghana-police/ticketing-backend/internal/domain/models"
+	"github.com/ghana-police/ticketing-backend/pkg/pagination"
+	"github.com/google/uuid"
 )
 
 type SyncService interface {
-	// BatchSync processes a batch of offline-created/updated tickets and photos.
-	BatchSync(ctx context.Context, req *models.SyncRequest, deviceID string) (*models.SyncResponse, error)
+	// BatchSync processes a batch of offline-created/updated tickets and photos
+	// and returns the JSON-encoded models.SyncResponse, ready to send. Every
+	// call is recorded as a sync session with the client's telemetry and the
+	// size of that response.
+	BatchSync(ctx context.Context, req *models.SyncRequest, client models.SyncClientInfo) (json.RawMessage, error)
+
+	// GetChanges returns one page of the ticket change feed, continuing from cursor or
+	// starting after since.
+	GetChanges(ctx context.Context, cursor string, since *time.Time, limit int) (*models.ServerUpdates, error)
 
 	// GetStatus returns the sync status for a user's device.
 	GetStatus(ctx context.Context, deviceID string) (*models.SyncStatus, error)
+
+	// ListConflicts returns the conflict queue for the caller's jurisdiction.
+	ListConflicts(ctx context.Context, filter models.SyncConflictFilter, p pagination.Params) ([]models.SyncConflict, int, error)
+
+	// GetConflict returns a single conflict with both stored versions.
+	GetConflict(ctx context.Context, id uuid.UUID) (*models.SyncConflict, error)
+
+	// ResolveConflict settles a pending conflict by keeping the server or the device version.
+	ResolveConflict(ctx context.Context, id uuid.UUID, req *ResolveConflictRequest) (*models.SyncConflict, error)
+
+	// ListDeviceHealth returns per-device sync health for the caller's jurisdiction.
+	ListDeviceHealth(ctx context.Context, filter models.DeviceHealthFilter, p pagination.Params) ([]models.DeviceHealth, int, error)
+
+	// ListStationHealth returns device health counts per station for the caller's jurisdiction.
+	ListStationHealth(ctx context.Context, filter models.DeviceHealthFilter) ([]models.StationSyncHealth, error)
+
+	// GetHealthAlerts returns devices that have not synced within
+	// autoSyncIntervalSeconds × the configured multiplier.
+	GetHealthAlerts(ctx context.Context, filter models.DeviceHealthFilter) (*models.SyncHealthAlerts, error)
+
+	// ListSessions returns the recorded sync sessions of a device, newest first.
+	ListSessions(ctx context.Context, deviceID string, p pagination.Params) ([]models.SyncSession, int, error)
+}
+
+type ResolveConflictRequest struct {
+	Resolution string  `json:"resolution"` // server or client
+	Notes      *string `json:"notes,omitempty"`
 }
@@ -0,0 +1,26 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TokenRevocationService interface {
+	// RevokeToken denies one access token until it expires.
+	RevokeToken(ctx context.Context, jti string, expiresAt time.Time) error
+
+	// RevokeUser denies every access token issued to the user so far and
+	// revokes their refresh tokens, signing them out everywhere.
+	RevokeUser(ctx context.Context, userID uuid.UUID, reason string) error
+
+	// RevokeSession revokes one of the user's sessions (a refresh token
+	// family) and denies the access tokens issued to it.
+	RevokeSession(ctx context.Context, userID, sessionID uuid.UUID, reason string) error
+
+	// IsRevoked reports whether an access token has been revoked. When the
+	// denylist store cannot be read it returns a ServiceUnavailable error, or,
+	// if configured to fail open, logs the error and treats the token as valid.
+	IsRevoked(ctx context.Context, jti string, userID uuid.UUID, sessionID *uuid.UUID, issuedAt time.Time) (bool, error)
+}
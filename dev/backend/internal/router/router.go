@@ -1,6 +1,9 @@
 package router
 
 import (
+	"context"
+	"time"
+
 	"github.com/go-chi/chi/v5"
 	chimw "github.com/go-chi/chi/v5/middleware"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -10,12 +13,17 @@ import (
 	"github.com/ghana-police/ticketing-backend/internal/adapters/handlers"
 	"github.com/ghana-police/ticketing-backend/internal/adapters/payment_providers"
 	"github.com/ghana-police/ticketing-backend/internal/adapters/repositories/postgres"
+	redisrepo "github.com/ghana-police/ticketing-backend/internal/adapters/repositories/redis"
 	"github.com/ghana-police/ticketing-backend/internal/adapters/storage"
 	"github.com/ghana-police/ticketing-backend/internal/config"
+	"github.com/ghana-police/ticketing-backend/internal/domain/models"
 	"github.com/ghana-police/ticketing-backend/internal/middleware"
 	portservices "github.com/ghana-police/ticketing-backend/internal/ports/services"
 	"github.com/ghana-police/ticketing-backend/internal/services"
 	jwtpkg "github.com/ghana-police/ticketing-backend/pkg/jwt"
+	"github.com/ghana-police/ticketing-backend/pkg/metrics"
+	"github.com/ghana-police/ticketing-backend/pkg/passwordlist"
+	"github.com/ghana-police/ticketing-backend/pkg/secretbox"
 )
 
 func New(cfg *config.Config, logger *zap.Logger, db *pgxpool.Pool, rdb *redis.Client) *chi.Mux {
@@ -28,8 +36,12 @@ func New(cfg *config.Config, logger *zap.Logger, db *pgxpool.Pool, rdb *redis.Cl
 	r.Use(chimw.RealIP)
 	r.Use(chimw.RequestID)
 
-	// JWT manager
+	// JWT manager (asymmetric keys are loaded and rotated by the signing key service)
+	signingKeyService := services.NewSigningKeyService(postgres.NewSigningKeyRepo(db), cfg.JWTSigningAlg, cfg.JWTKeyRotation, cfg.JWTAccessTokenExpiry, logger)
 	jwtManager := jwtpkg.NewManager(cfg.JWTSecret, cfg.JWTAccessTokenExpiry, cfg.JWTRefreshTokenExpiry)
+	if cfg.JWTSigningAlg != jwtpkg.AlgHS256 {
+		jwtManager.UseKeys(signingKeyService, cfg.JWTAcceptHS256)
+	}
 
 	// Repositories
 	userRepo := postgres.NewUserRepo(db)
@@ -38,12 +50,26 @@ func New(cfg *config.Config, logger *zap.Logger, db *pgxpool.Pool, rdb *redis.Cl
 	officerRepo := postgres.NewOfficerRepo(db)
 	ticketRepo := postgres.NewTicketRepo(db)
 	paymentRepo := postgres.NewPaymentRepo(db)
+	installmentRepo := postgres.NewInstallmentRepo(db)
+	cashRepo := postgres.NewCashRepo(db)
+	ledgerRepo := postgres.NewLedgerRepo(db)
+	allocationRepo := postgres.NewAllocationRepo(db)
+	settlementRepo := postgres.NewSettlementRepo(db)
+	unitOfWork := postgres.NewUnitOfWork(db)
 	objectionRepo := postgres.NewObjectionRepo(db)
 	auditRepo := postgres.NewAuditRepo(db)
 	syncRepo := postgres.NewSyncRepo(db)
 	analyticsRepo := postgres.NewAnalyticsRepo(db)
 	settingsRepo := postgres.NewSettingsRepo(db)
 	lookupRepo := postgres.NewLookupRepo(db)
+	deviceRepo := postgres.NewDeviceRepo(db)
+	mfaRepo := postgres.NewMFARepo(db)
+	permissionRepo := postgres.NewPermissionRepo(db)
+	jurisdictionRepo := postgres.NewJurisdictionRepo(db)
+	serviceAccountRepo := postgres.NewServiceAccountRepo(db)
+	rateLimitRepo := redisrepo.NewRateLimitRepo(rdb)
+	denylistRepo := redisrepo.NewTokenDenylistRepo(rdb)
+	idempotencyRepo := redisrepo.NewIdempotencyRepo(rdb)
 
 	// Storage
 	storageService := storage.NewLocalStorage(cfg.StorageLocalPath, "/uploads")
@@ -51,36 +77,123 @@ func New(cfg *config.Config, logger *zap.Logger, db *pgxpool.Pool, rdb *redis.Cl
 	// Payment providers
 	providerRegistry := portservices.NewProviderRegistry()
 	providerRegistry.Register(payment_providers.NewCashProvider())
-	providerRegistry.Register(payment_providers.NewMomoMockProvider())
+	if cfg.MomoProvider == "http" {
+		providerRegistry.Register(payment_providers.NewMomoProvider(payment_providers.MomoConfig{
+			BaseURL:           cfg.MomoBaseURL,
+			APIUser:           cfg.MomoAPIUser,
+			APIKey:            cfg.MomoAPIKey,
+			SubscriptionKey:   cfg.MomoSubscriptionKey,
+			TargetEnvironment: cfg.MomoTargetEnvironment,
+			Timeout:           cfg.MomoTimeout,
+		}, logger))
+	} else {
+		if cfg.AppEnv == "production" {
+			logger.Warn("mobile money payments use the in-memory mock provider; set MOMO_PROVIDER=http")
+		}
+		providerRegistry.Register(payment_providers.NewMomoMockProvider())
+	}
+	if cfg.CheckoutProvider == "http" {
+		providerRegistry.Register(payment_providers.NewCheckoutProvider(payment_providers.CheckoutConfig{
+			BaseURL:   cfg.CheckoutBaseURL,
+			SecretKey: cfg.CheckoutSecretKey,
+			ReturnURL: cfg.CheckoutReturnURL,
+			Timeout:   cfg.CheckoutTimeout,
+		}, logger))
+	}
+
+	// Settlement statement parsers, one per provider
+	settlementParsers := portservices.NewSettlementParserRegistry()
+	settlementParsers.Register(payment_providers.NewCSVSettlementParser(payment_providers.MomoSettlementFormat))
+	settlementParsers.Register(payment_providers.NewCSVSettlementParser(payment_providers.CheckoutSettlementFormat))
 
 	// Services
-	authService := services.NewAuthService(userRepo, jwtManager, logger)
+	auditService := services.NewAuditService(auditRepo, logger)
+	permissionService := services.NewPermissionService(permissionRepo, userRepo, auditService, logger)
+	revocationService := services.NewTokenRevocationService(denylistRepo, userRepo, cfg.JWTAccessTokenExpiry, cfg.JWTRevocationFailOpen, logger)
+	deviceService := services.NewDeviceService(deviceRepo, officerRepo, userRepo, hierarchyRepo, jurisdictionRepo, cfg.DeviceEnrollmentRequired, logger)
+	mfaBox, err := secretbox.New(cfg.MFAEncryptionKey)
+	if err != nil {
+		logger.Fatal("failed to initialise MFA secret encryption", zap.Error(err))
+	}
+	mfaService := services.NewMFAService(mfaRepo, userRepo, settingsRepo, revocationService, auditService, mfaBox, cfg.MFAIssuer, logger)
+	breachedPasswords, err := passwordlist.Load(cfg.BreachedPasswordsFile)
+	if err != nil {
+		logger.Fatal("failed to load breached password list", zap.Error(err))
+	}
+	passwordPolicyService := services.NewPasswordPolicyService(userRepo, settingsRepo, breachedPasswords, logger)
+	authService := services.NewAuthService(userRepo, mfaRepo, deviceService, mfaService, auditService, revocationService, passwordPolicyService, jwtManager, logger)
+	serviceAccountService := services.NewServiceAccountService(serviceAccountRepo, hierarchyRepo, permissionService, logger)
+	sessionService := services.NewSessionService(userRepo, jurisdictionRepo, revocationService, auditService, logger)
 	hierarchyService := services.NewHierarchyService(hierarchyRepo, logger)
 	offenceService := services.NewOffenceService(offenceRepo, logger)
-	officerService := services.NewOfficerService(officerRepo, hierarchyRepo, userRepo, logger)
-	ticketService := services.NewTicketService(ticketRepo, offenceRepo, hierarchyRepo, storageService, logger)
-	paymentService := services.NewPaymentService(paymentRepo, ticketRepo, providerRegistry, logger)
-	objectionService := services.NewObjectionService(objectionRepo, ticketRepo, logger)
-	auditService := services.NewAuditService(auditRepo, logger)
-	syncService := services.NewSyncService(syncRepo, ticketRepo, offenceRepo, hierarchyRepo, storageService, logger)
+	officerService := services.NewOfficerService(officerRepo, hierarchyRepo, userRepo, jurisdictionRepo, revocationService, passwordPolicyService, logger)
+	ticketService := services.NewTicketService(unitOfWork, ticketRepo, offenceRepo, hierarchyRepo, jurisdictionRepo, ledgerRepo, storageService, logger)
+	paymentService := services.NewPaymentService(unitOfWork, paymentRepo, ticketRepo, installmentRepo, cashRepo, ledgerRepo, allocationRepo, jurisdictionRepo, providerRegistry, logger)
+	installmentService := services.NewInstallmentService(unitOfWork, installmentRepo, ticketRepo, jurisdictionRepo, logger)
+	reconciliationService := services.NewReconciliationService(unitOfWork, settlementRepo, paymentRepo, settlementParsers, logger)
+	cashService := services.NewCashService(unitOfWork, cashRepo, jurisdictionRepo, permissionService, logger)
+	ledgerService := services.NewLedgerService(ledgerRepo, jurisdictionRepo, logger)
+	allocationService := services.NewAllocationService(allocationRepo, hierarchyRepo, logger)
+
+	// Background workers (they run for the life of the process)
+	metricsRegistry := metrics.NewRegistry()
+	if cfg.PaymentPollerEnabled {
+		paymentPoller := services.NewPaymentPoller(paymentService, cfg.PaymentPollInterval, portservices.PollPaymentsRequest{
+			Limit:       cfg.PaymentPollBatchSize,
+			BackoffBase: cfg.PaymentPollBackoffBase,
+			BackoffMax:  cfg.PaymentPollBackoffMax,
+			GiveUpAfter: cfg.PaymentPollGiveUpAfter,
+		}, metricsRegistry, logger)
+		go paymentPoller.Run(context.Background())
+	}
+	objectionService := services.NewObjectionService(unitOfWork, objectionRepo, ticketRepo, jurisdictionRepo, ledgerRepo, logger)
+	syncService := services.NewSyncService(unitOfWork, syncRepo, ticketRepo, offenceRepo, hierarchyRepo, settingsRepo, lookupRepo, jurisdictionRepo, ledgerRepo, ticketService, permissionService, deviceService, storageService, cfg.SyncStaleMultiplier, logger)
 	analyticsService := services.NewAnalyticsService(analyticsRepo, logger)
 	settingsService := services.NewSettingsService(settingsRepo, logger)
 	lookupService := services.NewLookupService(lookupRepo, logger)
+	rateLimitService := services.NewRateLimitService(rateLimitRepo, settingsRepo, models.RateLimits{
+		WindowSeconds: int(cfg.RateLimitWindow / time.Second),
+		Auth:          cfg.RateLimitAuth,
+		Login:         cfg.RateLimitLogin,
+		Write:         cfg.RateLimitWrite,
+		Read:          cfg.RateLimitRead,
+		Upload:        cfg.RateLimitUpload,
+		Sync:          cfg.RateLimitSync,
+	}, logger)
+	idempotencyService := services.NewIdempotencyService(idempotencyRepo, cfg.IdempotencyTTL, logger)
+	idempotent := middleware.Idempotency(idempotencyService)
 
 	// Handlers
 	healthHandler := handlers.NewHealthHandler(db, rdb)
+	jwksHandler := handlers.NewJWKSHandler(signingKeyService)
 	authHandler := handlers.NewAuthHandler(authService)
+	mfaHandler := handlers.NewMFAHandler(mfaService)
+	sessionHandler := handlers.NewSessionHandler(sessionService)
+	serviceAccountHandler := handlers.NewServiceAccountHandler(serviceAccountService)
+	permissionHandler := handlers.NewPermissionHandler(permissionService)
 	hierarchyHandler := handlers.NewHierarchyHandler(hierarchyService)
 	offenceHandler := handlers.NewOffenceHandler(offenceService)
 	officerHandler := handlers.NewOfficerHandler(officerService)
 	ticketHandler := handlers.NewTicketHandler(ticketService)
-	paymentHandler := handlers.NewPaymentHandler(paymentService)
+	paymentHandler := handlers.NewPaymentHandler(paymentService, cfg.CheckoutResultURL)
+	installmentHandler := handlers.NewInstallmentHandler(installmentService)
+	reconciliationHandler := handlers.NewReconciliationHandler(reconciliationService)
+	cashHandler := handlers.NewCashHandler(cashService)
+	ledgerHandler := handlers.NewLedgerHandler(ledgerService)
+	allocationHandler := handlers.NewAllocationHandler(allocationService)
 	objectionHandler := handlers.NewObjectionHandler(objectionService)
 	auditHandler := handlers.NewAuditHandler(auditService)
 	syncHandler := handlers.NewSyncHandler(syncService)
 	analyticsHandler := handlers.NewAnalyticsHandler(analyticsService)
 	settingsHandler := handlers.NewSettingsHandler(settingsService)
 	lookupHandler := handlers.NewLookupHandler(lookupService)
+	deviceHandler := handlers.NewDeviceHandler(deviceService)
+
+	// Routes that check a permission, the only ones API keys may call
+	apiKeyRoutes := middleware.NewScopedRoutes()
+
+	// Public signing keys for verifying access tokens
+	r.Get("/.well-known/jwks.json", jwksHandler.Get)
 
 	r.Route("/api", func(r chi.Router) {
 		// Public endpoints
@@ -88,24 +201,53 @@ func New(cfg *config.Config, logger *zap.Logger, db *pgxpool.Pool, rdb *redis.Cl
 
 		// Auth routes (public)
 		r.Route("/auth", func(r chi.Router) {
-			r.Post("/login", authHandler.Login)
-			r.Post("/refresh", authHandler.Refresh)
-			r.Post("/forgot-password", authHandler.ForgotPassword)
-			r.Post("/reset-password", authHandler.ResetPassword)
+			r.With(middleware.RateLimitLogin(rateLimitService)).Post("/login", authHandler.Login)
+			r.Group(func(r chi.Router) {
+				r.Use(middleware.RateLimit(rateLimitService, models.RateClassAuth))
+				r.Post("/refresh", authHandler.Refresh)
+				r.Post("/forgot-password", authHandler.ForgotPassword)
+				r.Post("/reset-password", authHandler.ResetPassword)
+				r.Post("/mfa/verify", authHandler.VerifyMFA)
+				r.Post("/mfa/challenge/enroll", authHandler.EnrollMFAChallenge)
+			})
 
-			// Protected auth routes
+			// Protected auth routes (interactive sign-in only)
 			r.Group(func(r chi.Router) {
-				r.Use(middleware.Auth(jwtManager))
+				r.Use(middleware.Auth(jwtManager, revocationService, nil, nil))
+				r.Use(middleware.RateLimitByRoute(rateLimitService))
+
+				// Still open to users who must change their password
 				r.Post("/logout", authHandler.Logout)
-				r.Get("/profile", authHandler.GetProfile)
-				r.Put("/profile", authHandler.UpdateProfile)
 				r.Post("/change-password", authHandler.ChangePassword)
+
+				r.Group(func(r chi.Router) {
+					r.Use(middleware.RequirePasswordCurrent)
+					r.Get("/profile", authHandler.GetProfile)
+					r.Put("/profile", authHandler.UpdateProfile)
+
+					r.Get("/mfa", mfaHandler.Status)
+					r.Post("/mfa/enroll", mfaHandler.Enroll)
+					r.Post("/mfa/activate", mfaHandler.Activate)
+					r.Post("/mfa/disable", mfaHandler.Disable)
+					r.Post("/mfa/recovery-codes", mfaHandler.RegenerateRecoveryCodes)
+
+					r.Get("/sessions", sessionHandler.List)
+					r.Delete("/sessions/{sessionId}", sessionHandler.Revoke)
+				})
 			})
 		})
 
-		// Authenticated routes
+		// Device enrollment (public; the one-time code authenticates the device)
+		r.With(middleware.RateLimit(rateLimitService, models.RateClassAuth)).Post("/devices/enroll", deviceHandler.Enroll)
+
+		// Hosted checkout return (public; the payment is re-verified with the provider)
+		r.With(middleware.RateLimit(rateLimitService, models.RateClassAuth)).Get("/payments/return", paymentHandler.Return)
+
+		// Authenticated routes (user JWTs or service account API keys)
 		r.Group(func(r chi.Router) {
-			r.Use(middleware.Auth(jwtManager))
+			r.Use(middleware.Auth(jwtManager, revocationService, serviceAccountService, apiKeyRoutes))
+			r.Use(middleware.RateLimitByRoute(rateLimitService))
+			r.Use(middleware.RequirePasswordCurrent)
 			r.Use(middleware.Audit(auditService))
 
 			// Regions
@@ -113,7 +255,7 @@ func New(cfg *config.Config, logger *zap.Logger, db *pgxpool.Pool, rdb *redis.Cl
 				r.Get("/", hierarchyHandler.ListRegions)
 				r.Get("/{id}", hierarchyHandler.GetRegion)
 				r.Group(func(r chi.Router) {
-					r.Use(middleware.RequireRole("super_admin"))
+					r.Use(middleware.RequirePermission(permissionService, models.PermRegionManage))
 					r.Post("/", hierarchyHandler.CreateRegion)
 					r.Put("/{id}", hierarchyHandler.UpdateRegion)
 					r.Delete("/{id}", hierarchyHandler.DeleteRegion)
@@ -125,7 +267,7 @@ func New(cfg *config.Config, logger *zap.Logger, db *pgxpool.Pool, rdb *redis.Cl
 				r.Get("/", hierarchyHandler.ListDivisions)
 				r.Get("/{id}", hierarchyHandler.GetDivision)
 				r.Group(func(r chi.Router) {
-					r.Use(middleware.RequireRole("super_admin"))
+					r.Use(middleware.RequirePermission(permissionService, models.PermRegionManage))
 					r.Post("/", hierarchyHandler.CreateDivision)
 					r.Put("/{id}", hierarchyHandler.UpdateDivision)
 					r.Delete("/{id}", hierarchyHandler.DeleteDivision)
@@ -137,7 +279,7 @@ func New(cfg *config.Config, logger *zap.Logger, db *pgxpool.Pool, rdb *redis.Cl
 				r.Get("/", hierarchyHandler.ListDistricts)
 				r.Get("/{id}", hierarchyHandler.GetDistrict)
 				r.Group(func(r chi.Router) {
-					r.Use(middleware.RequireRole("super_admin"))
+					r.Use(middleware.RequirePermission(permissionService, models.PermRegionManage))
 					r.Post("/", hierarchyHandler.CreateDistrict)
 					r.Put("/{id}", hierarchyHandler.UpdateDistrict)
 					r.Delete("/{id}", hierarchyHandler.DeleteDistrict)
@@ -149,13 +291,13 @@ func New(cfg *config.Config, logger *zap.Logger, db *pgxpool.Pool, rdb *redis.Cl
 				r.Get("/", hierarchyHandler.ListStations)
 				r.Get("/{id}", hierarchyHandler.GetStation)
 				r.Group(func(r chi.Router) {
-					r.Use(middleware.RequireRole("admin", "super_admin"))
+					r.Use(middleware.RequirePermission(permissionService, models.PermStationManage))
 					r.Get("/stats", hierarchyHandler.GetStationStats)
 					r.Post("/", hierarchyHandler.CreateStation)
 					r.Put("/{id}", hierarchyHandler.UpdateStation)
 				})
 				r.Group(func(r chi.Router) {
-					r.Use(middleware.RequireRole("super_admin"))
+					r.Use(middleware.RequirePermission(permissionService, models.PermStationDelete))
 					r.Delete("/{id}", hierarchyHandler.DeleteStation)
 				})
 			})
@@ -166,7 +308,7 @@ func New(cfg *config.Config, logger *zap.Logger, db *pgxpool.Pool, rdb *redis.Cl
 				r.Get("/{id}", officerHandler.Get)
 				r.Get("/{id}/stats", officerHandler.GetStats)
 				r.Group(func(r chi.Router) {
-					r.Use(middleware.RequireRole("admin", "super_admin"))
+					r.Use(middleware.RequirePermission(permissionService, models.PermOfficerManage))
 					r.Post("/", officerHandler.Create)
 					r.Put("/{id}", officerHandler.Update)
 					r.Delete("/{id}", officerHandler.Delete)
@@ -174,6 +316,43 @@ func New(cfg *config.Config, logger *zap.Logger, db *pgxpool.Pool, rdb *redis.Cl
 				})
 			})
 
+			// User accounts
+			r.Route("/users", func(r chi.Router) {
+				r.With(middleware.RequirePermission(permissionService, models.PermUserMFAReset)).Post("/{id}/mfa/reset", mfaHandler.Reset)
+				r.Group(func(r chi.Router) {
+					r.Use(middleware.RequirePermission(permissionService, models.PermUserSessionManage))
+					r.Get("/{id}/sessions", sessionHandler.ListForUser)
+					r.Delete("/{id}/sessions/{sessionId}", sessionHandler.RevokeForUser)
+				})
+				r.Group(func(r chi.Router) {
+					r.Use(middleware.RequirePermission(permissionService, models.PermRoleManage))
+					r.Get("/{id}/permissions", permissionHandler.GetUserPermissions)
+					r.Put("/{id}/permissions", permissionHandler.SetUserPermission)
+					r.Delete("/{id}/permissions/{permission}", permissionHandler.RemoveUserPermission)
+				})
+			})
+
+			// Service accounts and their API keys
+			r.Route("/service-accounts", func(r chi.Router) {
+				r.Use(middleware.RequirePermission(permissionService, models.PermServiceAccountManage))
+				r.Get("/", serviceAccountHandler.List)
+				r.Post("/", serviceAccountHandler.Create)
+				r.Get("/{id}", serviceAccountHandler.Get)
+				r.Put("/{id}", serviceAccountHandler.Update)
+				r.Get("/{id}/keys", serviceAccountHandler.ListKeys)
+				r.Post("/{id}/keys", serviceAccountHandler.CreateKey)
+				r.Delete("/{id}/keys/{keyId}", serviceAccountHandler.RevokeKey)
+			})
+
+			// Permission catalogue and role permissions
+			r.Group(func(r chi.Router) {
+				r.Use(middleware.RequirePermission(permissionService, models.PermRoleManage))
+				r.Get("/permissions", permissionHandler.ListPermissions)
+				r.Get("/roles", permissionHandler.ListRoles)
+				r.Get("/roles/{role}", permissionHandler.GetRole)
+				r.Put("/roles/{role}/permissions", permissionHandler.UpdateRole)
+			})
+
 			// Tickets
 			r.Route("/tickets", func(r chi.Router) {
 				r.Get("/", ticketHandler.List)
@@ -181,15 +360,18 @@ func New(cfg *config.Config, logger *zap.Logger, db *pgxpool.Pool, rdb *redis.Cl
 				r.Get("/search", ticketHandler.Search)
 				r.Get("/number/{ticketNumber}", ticketHandler.GetByNumber)
 				r.Get("/{id}", ticketHandler.Get)
-				r.Post("/", ticketHandler.Create)
+				r.With(idempotent).Post("/", ticketHandler.Create)
 				r.Post("/{id}/photos", ticketHandler.UploadPhoto)
 				r.Group(func(r chi.Router) {
-					r.Use(middleware.RequireRole("admin", "super_admin"))
+					r.Use(middleware.RequirePermission(permissionService, models.PermTicketUpdate))
 					r.Patch("/{id}", ticketHandler.Update)
 				})
+				r.With(middleware.RequirePermission(permissionService, models.PermTicketVoid)).Post("/{id}/void", ticketHandler.Void)
+				r.Get("/{id}/installment-plan", installmentHandler.Get)
 				r.Group(func(r chi.Router) {
-					r.Use(middleware.RequireRole("supervisor", "admin", "super_admin"))
-					r.Post("/{id}/void", ticketHandler.Void)
+					r.Use(middleware.RequirePermission(permissionService, models.PermPaymentInstallment))
+					r.Post("/{id}/installment-plan", installmentHandler.Create)
+					r.Post("/{id}/installment-plan/cancel", installmentHandler.Cancel)
 				})
 			})
 
@@ -200,28 +382,91 @@ func New(cfg *config.Config, logger *zap.Logger, db *pgxpool.Pool, rdb *redis.Cl
 				r.Get("/{id}", paymentHandler.Get)
 				r.Get("/{id}/receipt", paymentHandler.Receipt)
 				r.Post("/verify", paymentHandler.Verify)
+				r.With(middleware.RequirePermission(permissionService, models.PermPaymentInitiate), idempotent).Post("/initiate", paymentHandler.Initiate)
+				r.With(middleware.RequirePermission(permissionService, models.PermPaymentCashRecord), idempotent).Post("/cash", paymentHandler.RecordCash)
+				r.With(middleware.RequirePermission(permissionService, models.PermPaymentRefund)).Post("/{id}/refund", paymentHandler.Refund)
+			})
+
+			// Settlement reconciliation
+			r.Route("/reconciliation", func(r chi.Router) {
+				r.Use(middleware.RequirePermission(permissionService, models.PermPaymentReconcile))
+				r.Get("/imports", reconciliationHandler.ListImports)
+				r.Get("/imports/{id}", reconciliationHandler.GetImport)
+				r.Post("/imports", reconciliationHandler.Import)
+				r.Get("/items", reconciliationHandler.ListItems)
+				r.Post("/items/{id}/resolve", reconciliationHandler.ResolveItem)
+				r.Get("/report", reconciliationHandler.Report)
+			})
+
+			// Station cash: shifts, remittances and treasury reporting
+			r.Route("/cash", func(r chi.Router) {
+				r.Group(func(r chi.Router) {
+					r.Use(middleware.RequirePermission(permissionService, models.PermPaymentCashRecord))
+					r.Post("/shifts", cashHandler.OpenShift)
+					r.Get("/shifts/current", cashHandler.CurrentShift)
+					r.Post("/shifts/{id}/close", cashHandler.CloseShift)
+					r.Post("/remittances", cashHandler.SubmitRemittance)
+				})
+				r.Group(func(r chi.Router) {
+					r.Use(middleware.RequirePermission(permissionService, models.PermCashReportRead))
+					r.Get("/shifts", cashHandler.ListShifts)
+					r.Get("/shifts/{id}", cashHandler.GetShift)
+					r.Get("/remittances", cashHandler.ListRemittances)
+					r.Get("/remittances/{id}", cashHandler.GetRemittance)
+					r.Get("/report", cashHandler.Report)
+				})
+				r.With(middleware.RequirePermission(permissionService, models.PermRemittanceApprove)).Post("/remittances/{id}/review", cashHandler.ReviewRemittance)
+			})
+
+			// Revenue ledger: journals, trial balance and period close
+			r.Route("/ledger", func(r chi.Router) {
+				r.Group(func(r chi.Router) {
+					r.Use(middleware.RequirePermission(permissionService, models.PermLedgerRead))
+					r.Get("/accounts", ledgerHandler.ListAccounts)
+					r.Get("/journals", ledgerHandler.ListJournals)
+					r.Get("/journals/{id}", ledgerHandler.GetJournal)
+					r.Get("/trial-balance", ledgerHandler.TrialBalance)
+					r.Get("/periods", ledgerHandler.ListPeriods)
+				})
+				r.With(middleware.RequirePermission(permissionService, models.PermLedgerPeriodClose)).Post("/periods/{period}/close", ledgerHandler.ClosePeriod)
+				r.With(middleware.RequirePermission(permissionService, models.PermLedgerPeriodReopen)).Post("/periods/{period}/reopen", ledgerHandler.ReopenPeriod)
+			})
+
+			// Revenue allocation: beneficiaries, rules and disbursements
+			r.Route("/allocations", func(r chi.Router) {
 				r.Group(func(r chi.Router) {
-					r.Use(middleware.RequireRole("admin", "super_admin", "accountant"))
-					r.Post("/initiate", paymentHandler.Initiate)
-					r.Post("/cash", paymentHandler.RecordCash)
+					r.Use(middleware.RequirePermission(permissionService, models.PermDisbursementRead))
+					r.Get("/", allocationHandler.ListAllocations)
+					r.Get("/disbursements", allocationHandler.DisbursementReport)
+					r.Get("/beneficiaries", allocationHandler.ListBeneficiaries)
+					r.Get("/beneficiaries/{id}", allocationHandler.GetBeneficiary)
+					r.Get("/rules", allocationHandler.ListRules)
+					r.Get("/rules/{id}", allocationHandler.GetRule)
+				})
+				r.Group(func(r chi.Router) {
+					r.Use(middleware.RequirePermission(permissionService, models.PermAllocationManage))
+					r.Post("/beneficiaries", allocationHandler.CreateBeneficiary)
+					r.Put("/beneficiaries/{id}", allocationHandler.UpdateBeneficiary)
+					r.Post("/rules", allocationHandler.CreateRule)
+					r.Put("/rules/{id}", allocationHandler.UpdateRule)
 				})
 			})
 
 			// Objections
 			r.Route("/objections", func(r chi.Router) {
-				r.Post("/", objectionHandler.File)
+				r.With(idempotent).Post("/", objectionHandler.File)
 				r.Group(func(r chi.Router) {
-					r.Use(middleware.RequireRole("admin", "super_admin"))
+					r.Use(middleware.RequirePermission(permissionService, models.PermObjectionRead))
 					r.Get("/", objectionHandler.List)
 					r.Get("/stats", objectionHandler.Stats)
 					r.Get("/{id}", objectionHandler.Get)
-					r.Post("/{id}/review", objectionHandler.Review)
 				})
+				r.With(middleware.RequirePermission(permissionService, models.PermObjectionReview)).Post("/{id}/review", objectionHandler.Review)
 			})
 
 			// Audit Logs (read-only)
 			r.Route("/audit", func(r chi.Router) {
-				r.Use(middleware.RequireRole("admin", "super_admin"))
+				r.Use(middleware.RequirePermission(permissionService, models.PermAuditRead))
 				r.Get("/logs", auditHandler.List)
 				r.Get("/logs/{id}", auditHandler.Get)
 				r.Get("/stats", auditHandler.Stats)
@@ -231,11 +476,38 @@ func New(cfg *config.Config, logger *zap.Logger, db *pgxpool.Pool, rdb *redis.Cl
 			r.Route("/sync", func(r chi.Router) {
 				r.Post("/", syncHandler.BatchSync)
 				r.Get("/status", syncHandler.GetStatus)
+				r.Get("/changes", syncHandler.GetChanges)
+				r.Group(func(r chi.Router) {
+					r.Use(middleware.RequirePermission(permissionService, models.PermSyncConflictManage))
+					r.Get("/conflicts", syncHandler.ListConflicts)
+					r.Get("/conflicts/{id}", syncHandler.GetConflict)
+					r.Post("/conflicts/{id}/resolve", syncHandler.ResolveConflict)
+				})
+				r.Group(func(r chi.Router) {
+					r.Use(middleware.RequirePermission(permissionService, models.PermSyncHealthRead))
+					r.Get("/health/devices", syncHandler.ListDeviceHealth)
+					r.Get("/health/devices/{deviceId}/sessions", syncHandler.ListDeviceSessions)
+					r.Get("/health/stations", syncHandler.ListStationHealth)
+					r.Get("/health/alerts", syncHandler.GetHealthAlerts)
+				})
+			})
+
+			// Device registry
+			r.Route("/devices", func(r chi.Router) {
+				r.Use(middleware.RequirePermission(permissionService, models.PermDeviceManage))
+				r.Get("/", deviceHandler.List)
+				r.Post("/", deviceHandler.Register)
+				r.Get("/{id}", deviceHandler.Get)
+				r.Patch("/{id}/status", deviceHandler.UpdateStatus)
+				r.Post("/{id}/re-enroll", deviceHandler.ReEnroll)
+				r.Get("/{id}/commands", deviceHandler.ListCommands)
+				r.Post("/{id}/commands", deviceHandler.IssueCommand)
+				r.Post("/{id}/commands/{commandId}/cancel", deviceHandler.CancelCommand)
 			})
 
-			// Analytics (admin/super_admin)
+			// Analytics
 			r.Route("/analytics", func(r chi.Router) {
-				r.Use(middleware.RequireRole("admin", "super_admin"))
+				r.Use(middleware.RequirePermission(permissionService, models.PermAnalyticsRead))
 				r.Get("/summary", analyticsHandler.Summary)
 				r.Get("/trends", analyticsHandler.Trends)
 				r.Get("/top-offences", analyticsHandler.TopOffences)
@@ -247,17 +519,20 @@ func New(cfg *config.Config, logger *zap.Logger, db *pgxpool.Pool, rdb *redis.Cl
 			// Settings
 			r.Route("/settings", func(r chi.Router) {
 				r.Group(func(r chi.Router) {
-					r.Use(middleware.RequireRole("admin", "super_admin"))
+					r.Use(middleware.RequirePermission(permissionService, models.PermSettingsRead))
 					r.Get("/", settingsHandler.GetAll)
 					r.Get("/{section}", settingsHandler.GetBySection)
 				})
 				r.Group(func(r chi.Router) {
-					r.Use(middleware.RequireRole("super_admin"))
+					r.Use(middleware.RequirePermission(permissionService, models.PermSettingsWrite))
 					r.Put("/", settingsHandler.UpdateAll)
 					r.Put("/{section}", settingsHandler.UpdateSection)
 				})
 			})
 
+			// Operational metrics (Prometheus text format)
+			r.With(middleware.RequirePermission(permissionService, models.PermMetricsRead)).Get("/metrics", metricsRegistry.Handler().ServeHTTP)
+
 			// Lookup (any authenticated user)
 			r.Get("/lookup", lookupHandler.GetLookupData)
 
@@ -266,18 +541,22 @@ func New(cfg *config.Config, logger *zap.Logger, db *pgxpool.Pool, rdb *redis.Cl
 				r.Get("/", offenceHandler.List)
 				r.Get("/{id}", offenceHandler.Get)
 				r.Group(func(r chi.Router) {
-					r.Use(middleware.RequireRole("admin", "super_admin"))
+					r.Use(middleware.RequirePermission(permissionService, models.PermOffenceManage))
 					r.Post("/", offenceHandler.Create)
 					r.Put("/{id}", offenceHandler.Update)
 					r.Patch("/{id}/toggle", offenceHandler.Toggle)
 				})
 				r.Group(func(r chi.Router) {
-					r.Use(middleware.RequireRole("super_admin"))
+					r.Use(middleware.RequirePermission(permissionService, models.PermOffenceDelete))
 					r.Delete("/{id}", offenceHandler.Delete)
 				})
 			})
 		})
 	})
 
+	if err := apiKeyRoutes.Load(r); err != nil {
+		logger.Fatal("failed to collect API key routes", zap.Error(err))
+	}
+
 	return r
 }
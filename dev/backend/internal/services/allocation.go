@@ -0,0 +1,88 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ghana-police/ticketing-backend/internal/domain/models"
+	"github.com/ghana-police/ticketing-backend/internal/ports/repositories"
+)
+
+// allocatePayment splits a completed payment between the revenue
+// beneficiaries. The amount is first shared across the ticket's offence
+// categories in proportion to their fines; each category's part then follows
+// the most specific active rule for that category, the ticket's region and
+// the payment method.
+func allocatePayment(
+	ctx context.Context,
+	allocations repositories.AllocationRepository,
+	tickets repositories.TicketRepository,
+	payment *models.Payment,
+) error {
+	ticket, err := tickets.GetByID(ctx, payment.TicketID)
+	if err != nil {
+		return err
+	}
+	active := true
+	rules, err := allocations.ListRules(ctx, &active)
+	if err != nil {
+		return err
+	}
+
+	// Offences of the same category are allocated together
+	var categories []string
+	var fines []float64
+	index := make(map[string]int)
+	for _, o := range ticket.Offences {
+		i, ok := index[o.Category]
+		if !ok {
+			i = len(categories)
+			index[o.Category] = i
+			categories = append(categories, o.Category)
+			fines = append(fines, 0)
+		}
+		fines[i] += o.Fine
+	}
+	if len(categories) == 0 {
+		categories, fines = []string{"other"}, []float64{1}
+	}
+
+	completedAt := time.Now()
+	if payment.CompletedAt != nil {
+		completedAt = *payment.CompletedAt
+	}
+	period := completedAt.UTC().Format(periodLayout)
+	stationID, regionID := ticket.StationID, ticket.RegionID
+
+	var rows []models.PaymentAllocation
+	for i, part := range models.Apportion(payment.Amount, fines) {
+		category := categories[i]
+		rule := models.MatchRule(rules, category, ticket.RegionID, payment.Method)
+		if rule == nil {
+			return fmt.Errorf("no allocation rule matches %s paid by %s in region %s", category, payment.Method, ticket.RegionID)
+		}
+
+		percentages := make([]float64, len(rule.Shares))
+		for j, share := range rule.Shares {
+			percentages[j] = share.Percentage
+		}
+		for j, amount := range models.Apportion(part, percentages) {
+			if amount == 0 {
+				continue
+			}
+			rows = append(rows, models.PaymentAllocation{
+				PaymentID:       payment.ID,
+				BeneficiaryID:   rule.Shares[j].BeneficiaryID,
+				RuleID:          rule.ID,
+				OffenceCategory: &category,
+				Percentage:      rule.Shares[j].Percentage,
+				Amount:          amount,
+				Period:          period,
+				StationID:       &stationID,
+				RegionID:        &regionID,
+			})
+		}
+	}
+	return allocations.CreateAllocations(ctx, rows)
+}
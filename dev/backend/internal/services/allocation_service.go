@@ -0,0 +1,352 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	apperrors "github.com/ghana-police/ticketing-backend/internal/domain/errors"
+	"github.com/ghana-police/ticketing-backend/internal/domain/models"
+	"github.com/ghana-police/ticketing-backend/internal/middleware"
+	"github.com/ghana-police/ticketing-backend/internal/ports/repositories"
+	portservices "github.com/ghana-police/ticketing-backend/internal/ports/services"
+	"github.com/ghana-police/ticketing-backend/pkg/pagination"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+type allocationService struct {
+	repo      repositories.AllocationRepository
+	hierarchy repositories.HierarchyRepository
+	logger    *zap.Logger
+}
+
+func NewAllocationService(
+	repo repositories.AllocationRepository,
+	hierarchy repositories.HierarchyRepository,
+	logger *zap.Logger,
+) portservices.AllocationService {
+	return &allocationService{
+		repo:      repo,
+		hierarchy: hierarchy,
+		logger:    logger,
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Beneficiaries
+// ---------------------------------------------------------------------------
+
+func (s *allocationService) ListBeneficiaries(ctx context.Context, isActive *bool) ([]models.RevenueBeneficiary, error) {
+	beneficiaries, err := s.repo.ListBeneficiaries(ctx, isActive)
+	if err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+	return beneficiaries, nil
+}
+
+func (s *allocationService) GetBeneficiary(ctx context.Context, id uuid.UUID) (*models.RevenueBeneficiary, error) {
+	b, err := s.repo.GetBeneficiary(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NewNotFound("Beneficiary")
+		}
+		return nil, apperrors.NewInternal(err)
+	}
+	return b, nil
+}
+
+func (s *allocationService) CreateBeneficiary(ctx context.Context, req *portservices.CreateBeneficiaryRequest) (*models.RevenueBeneficiary, error) {
+	b := &models.RevenueBeneficiary{
+		Code:        strings.TrimSpace(req.Code),
+		Name:        strings.TrimSpace(req.Name),
+		BankName:    req.BankName,
+		BankAccount: req.BankAccount,
+	}
+	if b.Code == "" || b.Name == "" {
+		return nil, apperrors.NewValidationError("Code and name are required", nil)
+	}
+
+	exists, err := s.repo.BeneficiaryCodeExists(ctx, b.Code, nil)
+	if err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+	if exists {
+		return nil, apperrors.NewConflict("Beneficiary code already exists")
+	}
+
+	if err := s.repo.CreateBeneficiary(ctx, b); err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+	return b, nil
+}
+
+func (s *allocationService) UpdateBeneficiary(ctx context.Context, id uuid.UUID, req *portservices.UpdateBeneficiaryRequest) (*models.RevenueBeneficiary, error) {
+	current, err := s.GetBeneficiary(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Code != nil && strings.TrimSpace(*req.Code) != current.Code {
+		code := strings.TrimSpace(*req.Code)
+		if code == "" {
+			return nil, apperrors.NewValidationError("Code cannot be empty", nil)
+		}
+		exists, err := s.repo.BeneficiaryCodeExists(ctx, code, &id)
+		if err != nil {
+			return nil, apperrors.NewInternal(err)
+		}
+		if exists {
+			return nil, apperrors.NewConflict("Beneficiary code already exists")
+		}
+		current.Code = code
+	}
+	if req.Name != nil {
+		if strings.TrimSpace(*req.Name) == "" {
+			return nil, apperrors.NewValidationError("Name cannot be empty", nil)
+		}
+		current.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.BankName != nil {
+		current.BankName = req.BankName
+	}
+	if req.BankAccount != nil {
+		current.BankAccount = req.BankAccount
+	}
+	if req.IsActive != nil && *req.IsActive != current.IsActive {
+		if !*req.IsActive {
+			inUse, err := s.repo.BeneficiaryInActiveRules(ctx, id)
+			if err != nil {
+				return nil, apperrors.NewInternal(err)
+			}
+			if inUse {
+				return nil, apperrors.NewConflict("Beneficiary has a share in an active allocation rule")
+			}
+		}
+		current.IsActive = *req.IsActive
+	}
+
+	if err := s.repo.UpdateBeneficiary(ctx, current); err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+	return current, nil
+}
+
+// ---------------------------------------------------------------------------
+// Rules
+// ---------------------------------------------------------------------------
+
+func (s *allocationService) ListRules(ctx context.Context, isActive *bool) ([]models.AllocationRule, error) {
+	rules, err := s.repo.ListRules(ctx, isActive)
+	if err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+	return rules, nil
+}
+
+func (s *allocationService) GetRule(ctx context.Context, id uuid.UUID) (*models.AllocationRule, error) {
+	rule, err := s.repo.GetRule(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NewNotFound("Allocation rule")
+		}
+		return nil, apperrors.NewInternal(err)
+	}
+	return rule, nil
+}
+
+func (s *allocationService) CreateRule(ctx context.Context, req *portservices.AllocationRuleRequest) (*models.AllocationRule, error) {
+	rule := &models.AllocationRule{}
+	if err := s.applyRule(ctx, rule, req); err != nil {
+		return nil, err
+	}
+	userID := middleware.GetUserID(ctx)
+	rule.CreatedByID = &userID
+
+	if err := s.repo.CreateRule(ctx, rule); err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+	s.logger.Info("allocation rule created", zap.String("rule_id", rule.ID.String()), zap.String("name", rule.Name))
+	return s.GetRule(ctx, rule.ID)
+}
+
+func (s *allocationService) UpdateRule(ctx context.Context, id uuid.UUID, req *portservices.AllocationRuleRequest) (*models.AllocationRule, error) {
+	rule, err := s.GetRule(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if rule.IsDefault() {
+		if req.OffenceCategory != nil || req.RegionID != nil || req.PaymentMethod != nil {
+			return nil, apperrors.NewValidationError("The default rule must match every payment", nil)
+		}
+		if req.IsActive != nil && !*req.IsActive {
+			return nil, apperrors.NewConflict("The default rule cannot be deactivated")
+		}
+	}
+
+	if err := s.applyRule(ctx, rule, req); err != nil {
+		return nil, err
+	}
+	userID := middleware.GetUserID(ctx)
+	rule.UpdatedByID = &userID
+
+	if err := s.repo.UpdateRule(ctx, rule); err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+	s.logger.Info("allocation rule updated", zap.String("rule_id", rule.ID.String()), zap.String("name", rule.Name))
+	return s.GetRule(ctx, rule.ID)
+}
+
+// applyRule validates a rule request and copies it onto rule.
+func (s *allocationService) applyRule(ctx context.Context, rule *models.AllocationRule, req *portservices.AllocationRuleRequest) error {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return apperrors.NewValidationError("Name is required", map[string][]string{"name": {"is required"}})
+	}
+	if req.OffenceCategory != nil && !slices.Contains(models.OffenceCategories, *req.OffenceCategory) {
+		return apperrors.NewValidationError("Invalid offence category",
+			map[string][]string{"offenceCategory": {"must be one of " + strings.Join(models.OffenceCategories, ", ")}})
+	}
+	if req.PaymentMethod != nil && !slices.Contains(models.PaymentMethods, *req.PaymentMethod) {
+		return apperrors.NewValidationError("Invalid payment method",
+			map[string][]string{"paymentMethod": {"must be one of " + strings.Join(models.PaymentMethods, ", ")}})
+	}
+	if req.RegionID != nil {
+		if _, err := s.hierarchy.GetRegionByID(ctx, *req.RegionID); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return apperrors.NewValidationError("Region not found", map[string][]string{"regionId": {"not found"}})
+			}
+			return apperrors.NewInternal(err)
+		}
+	}
+	if err := s.validateShares(ctx, req.Shares); err != nil {
+		return err
+	}
+
+	active := true
+	if req.IsActive != nil {
+		active = *req.IsActive
+	}
+	if active {
+		var excludeID *uuid.UUID
+		if rule.ID != uuid.Nil {
+			excludeID = &rule.ID
+		}
+		taken, err := s.repo.RuleKeysTaken(ctx, req.OffenceCategory, req.RegionID, req.PaymentMethod, excludeID)
+		if err != nil {
+			return apperrors.NewInternal(err)
+		}
+		if taken {
+			return apperrors.NewConflict("An active rule already covers this offence category, region and payment method")
+		}
+	}
+
+	rule.Name = name
+	rule.OffenceCategory = req.OffenceCategory
+	rule.RegionID = req.RegionID
+	rule.PaymentMethod = req.PaymentMethod
+	rule.IsActive = active
+	rule.Shares = req.Shares
+	return nil
+}
+
+// validateShares requires shares of distinct active beneficiaries, in
+// hundredths of a percent, that add up to exactly 100%.
+func (s *allocationService) validateShares(ctx context.Context, shares []models.AllocationShare) error {
+	if len(shares) == 0 {
+		return apperrors.NewValidationError("At least one share is required",
+			map[string][]string{"shares": {"is required"}})
+	}
+
+	seen := make(map[uuid.UUID]bool, len(shares))
+	var total int64
+	for i, share := range shares {
+		field := fmt.Sprintf("shares[%d]", i)
+		hundredths := math.Round(share.Percentage * 100)
+		if share.Percentage <= 0 || share.Percentage > 100 || math.Abs(hundredths-share.Percentage*100) > 1e-6 {
+			return apperrors.NewValidationError("Share percentages must be above 0 and at most 100, to two decimal places",
+				map[string][]string{field + ".percentage": {"must be above 0 and at most 100, to two decimal places"}})
+		}
+		if seen[share.BeneficiaryID] {
+			return apperrors.NewValidationError("A beneficiary can only have one share in a rule",
+				map[string][]string{field + ".beneficiaryId": {"is repeated"}})
+		}
+		seen[share.BeneficiaryID] = true
+
+		b, err := s.repo.GetBeneficiary(ctx, share.BeneficiaryID)
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return apperrors.NewInternal(err)
+		}
+		if err != nil || !b.IsActive {
+			return apperrors.NewValidationError("Shares must go to active beneficiaries",
+				map[string][]string{field + ".beneficiaryId": {"not found or inactive"}})
+		}
+		total += int64(hundredths)
+	}
+	if total != 10000 {
+		return apperrors.NewValidationError(fmt.Sprintf("Shares must add up to 100%% (got %.2f%%)", float64(total)/100),
+			map[string][]string{"shares": {"must add up to 100"}})
+	}
+	return nil
+}
+
+// ---------------------------------------------------------------------------
+// Allocations and disbursements
+// ---------------------------------------------------------------------------
+
+func (s *allocationService) ListAllocations(ctx context.Context, filter models.PaymentAllocationFilter, p pagination.Params) ([]models.PaymentAllocation, int, error) {
+	if filter.Period != nil {
+		if _, err := parsePeriod(*filter.Period); err != nil {
+			return nil, 0, err
+		}
+	}
+	filter.Scope = callerScope(ctx)
+	items, total, err := s.repo.ListAllocations(ctx, filter, p)
+	if err != nil {
+		return nil, 0, apperrors.NewInternal(err)
+	}
+	return items, total, nil
+}
+
+func (s *allocationService) DisbursementReport(ctx context.Context, req *portservices.DisbursementReportRequest) (*models.DisbursementReport, error) {
+	period := req.Period
+	if period == "" {
+		period = time.Now().UTC().Format(periodLayout)
+	}
+	if _, err := parsePeriod(period); err != nil {
+		return nil, err
+	}
+	if req.GroupBy != "" && req.GroupBy != "region" {
+		return nil, apperrors.NewValidationError("groupBy must be 'region'",
+			map[string][]string{"groupBy": {"must be region"}})
+	}
+
+	filter := models.PaymentAllocationFilter{
+		StationID: req.StationID,
+		RegionID:  req.RegionID,
+		Scope:     callerScope(ctx),
+	}
+	lines, err := s.repo.DisbursementReport(ctx, period, filter, req.GroupBy)
+	if err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+
+	report := &models.DisbursementReport{Period: period, Lines: lines}
+	if report.Lines == nil {
+		report.Lines = []models.DisbursementLine{}
+	}
+	for _, l := range report.Lines {
+		report.Allocated += l.Allocated
+		report.Reversed += l.Reversed
+		report.Due += l.Due
+	}
+	report.Allocated = math.Round(report.Allocated*100) / 100
+	report.Reversed = math.Round(report.Reversed*100) / 100
+	report.Due = math.Round(report.Due*100) / 100
+	return report, nil
+}
@@ -6,6 +6,7 @@ import (
 
 	apperrors "github.com/ghana-police/ticketing-backend/internal/domain/errors"
 	"github.com/ghana-police/ticketing-backend/internal/domain/models"
+	"github.com/ghana-police/ticketing-backend/internal/middleware"
 	"github.com/ghana-police/ticketing-backend/internal/ports/repositories"
 	portservices "github.com/ghana-police/ticketing-backend/internal/ports/services"
 	"github.com/ghana-police/ticketing-backend/pkg/pagination"
@@ -75,6 +76,12 @@ func (s *auditService) Log(ctx context.Context, entry *portservices.AuditEntry)
 	if entry.RegionName != "" {
 		log.RegionName = &entry.RegionName
 	}
+	log.APIKeyID = entry.APIKeyID
+	if log.APIKeyID == nil {
+		if key := middleware.GetAPIKey(ctx); key != nil {
+			log.APIKeyID = &key.KeyID
+		}
+	}
 
 	if err := s.repo.Create(ctx, log); err != nil {
 		s.logger.Error("failed to write audit log", zap.Error(err), zap.String("action", entry.Action))
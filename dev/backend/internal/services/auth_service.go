@@ -7,6 +7,7 @@ import (
 
 	apperrors "github.com/ghana-police/ticketing-backend/internal/domain/errors"
 	"github.com/ghana-police/ticketing-backend/internal/domain/models"
+	"github.com/ghana-police/ticketing-backend/internal/middleware"
 	"github.com/ghana-police/ticketing-backend/internal/ports/repositories"
 	portservices "github.com/ghana-police/ticketing-backend/internal/ports/services"
 	"github.com/ghana-police/ticketing-backend/pkg/hash"
@@ -19,17 +20,42 @@ import (
 const maxFailedLogins = 5
 const lockDuration = 30 * time.Minute
 
+const mfaChallengeTTL = 5 * time.Minute
+const maxMFAAttempts = 5
+
 type authService struct {
-	userRepo   repositories.UserRepository
-	jwtManager *jwtpkg.Manager
-	logger     *zap.Logger
+	userRepo    repositories.UserRepository
+	mfaRepo     repositories.MFARepository
+	devices     portservices.DeviceService
+	mfa         portservices.MFAService
+	audit       portservices.AuditService
+	revocations portservices.TokenRevocationService
+	passwords   portservices.PasswordPolicyService
+	jwtManager  *jwtpkg.Manager
+	logger      *zap.Logger
 }
 
-func NewAuthService(userRepo repositories.UserRepository, jwtManager *jwtpkg.Manager, logger *zap.Logger) portservices.AuthService {
+func NewAuthService(
+	userRepo repositories.UserRepository,
+	mfaRepo repositories.MFARepository,
+	devices portservices.DeviceService,
+	mfa portservices.MFAService,
+	audit portservices.AuditService,
+	revocations portservices.TokenRevocationService,
+	passwords portservices.PasswordPolicyService,
+	jwtManager *jwtpkg.Manager,
+	logger *zap.Logger,
+) portservices.AuthService {
 	return &authService{
-		userRepo:   userRepo,
-		jwtManager: jwtManager,
-		logger:     logger,
+		userRepo:    userRepo,
+		mfaRepo:     mfaRepo,
+		devices:     devices,
+		mfa:         mfa,
+		audit:       audit,
+		revocations: revocations,
+		passwords:   passwords,
+		jwtManager:  jwtManager,
+		logger:      logger,
 	}
 }
 
@@ -57,6 +83,10 @@ func (s *authService) Login(ctx context.Context, req *portservices.LoginRequest)
 		}
 		return nil, apperrors.NewInternal(err)
 	}
+	// Service accounts authenticate with API keys only
+	if user.IsServiceAccount {
+		return nil, apperrors.NewInvalidCredentials("Invalid credentials")
+	}
 
 	// Check if account is active
 	if !user.IsActive {
@@ -89,35 +119,167 @@ func (s *authService) Login(ctx context.Context, req *portservices.LoginRequest)
 		_ = s.userRepo.ResetFailedLogins(ctx, user.ID)
 	}
 
-	// Generate access token
-	claims := &jwtpkg.Claims{
-		UserID: user.ID,
-		Role:   user.Role,
+	// Field officers must sign in from an enrolled device; other roles are
+	// checked only when the device they present is in the registry.
+	var hardwareID, deviceKey string
+	if req.DeviceID != nil {
+		hardwareID = *req.DeviceID
 	}
-	if user.Officer != nil {
-		claims.OfficerID = &user.Officer.ID
-		claims.StationID = &user.Officer.StationID
-		claims.RegionID = &user.Officer.RegionID
-		claims.BadgeNumber = &user.Officer.BadgeNumber
+	if req.DeviceKey != nil {
+		deviceKey = *req.DeviceKey
+	}
+	device, err := s.devices.Authorize(ctx, hardwareID, deviceKey, user.Role == "officer")
+	if err != nil {
+		return nil, err
+	}
+
+	var deviceRef *uuid.UUID
+	if device != nil {
+		deviceRef = &device.ID
+	}
+
+	// Privileged roles complete a second step before any token is issued
+	if user.MFAEnabled || s.mfa.Required(ctx, user.Role) {
+		return s.startMFAChallenge(ctx, user, req.DeviceID, req.DeviceInfo, deviceRef)
+	}
+
+	return s.issueSession(ctx, user, req.DeviceID, req.DeviceInfo, deviceRef, req.IPAddress, req.UserAgent)
+}
+
+func (s *authService) VerifyMFA(ctx context.Context, req *portservices.VerifyMFARequest) (*portservices.LoginResult, error) {
+	if req.ChallengeToken == "" || req.Code == "" {
+		return nil, apperrors.NewValidationError("Challenge token and code are required", nil)
+	}
+
+	c, err := s.findChallenge(ctx, req.ChallengeToken)
+	if err != nil {
+		return nil, err
 	}
 
-	accessToken, err := s.jwtManager.GenerateAccessToken(claims)
+	user, err := s.userRepo.FindByID(ctx, c.UserID)
 	if err != nil {
 		return nil, apperrors.NewInternal(err)
 	}
+	if !user.IsActive {
+		return nil, apperrors.NewForbidden("Account is deactivated")
+	}
 
-	// Generate refresh token
-	rawRefresh := s.jwtManager.GenerateRefreshToken()
-	tokenHash := hash.HashToken(rawRefresh)
+	// A setup challenge is completed by confirming the new secret
+	var recoveryCodes []string
+	if c.Setup {
+		var codes *portservices.MFARecoveryCodes
+		codes, err = s.mfa.Activate(ctx, user.ID, req.Code)
+		if codes != nil {
+			recoveryCodes = codes.RecoveryCodes
+		}
+	} else {
+		err = s.mfa.Verify(ctx, user.ID, req.Code)
+	}
+	if err != nil {
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) && appErr.Code == codeInvalidMFA {
+			_ = s.mfaRepo.RecordChallengeAttempt(ctx, c.ID)
+		}
+		return nil, err
+	}
+
+	consumed, err := s.mfaRepo.ConsumeChallenge(ctx, c.ID)
+	if err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+	if !consumed {
+		return nil, errMFAChallengeExpired()
+	}
+
+	result, err := s.issueSession(ctx, user, c.DeviceID, c.DeviceInfo, c.DeviceRef, req.IPAddress, req.UserAgent)
+	if err != nil {
+		return nil, err
+	}
+	result.RecoveryCodes = recoveryCodes
+	return result, nil
+}
+
+func (s *authService) EnrollMFAChallenge(ctx context.Context, challengeToken string) (*portservices.MFAEnrollment, error) {
+	c, err := s.findChallenge(ctx, challengeToken)
+	if err != nil {
+		return nil, err
+	}
+	if !c.Setup {
+		return nil, apperrors.NewConflict("Multi-factor authentication is already enabled")
+	}
+	return s.mfa.Enroll(ctx, c.UserID)
+}
+
+// startMFAChallenge records a short-lived challenge carrying the device the
+// login came from; the session is issued once it is verified.
+func (s *authService) startMFAChallenge(ctx context.Context, user *models.User, deviceID *string, deviceInfo any, deviceRef *uuid.UUID) (*portservices.LoginResult, error) {
+	token := hash.GenerateSecret()
+	c := &models.MFAChallenge{
+		ID:         uuid.New(),
+		UserID:     user.ID,
+		TokenHash:  hash.HashToken(token),
+		Setup:      !user.MFAEnabled,
+		DeviceID:   deviceID,
+		DeviceInfo: deviceInfo,
+		DeviceRef:  deviceRef,
+		ExpiresAt:  time.Now().Add(mfaChallengeTTL),
+	}
+	if err := s.mfaRepo.CreateChallenge(ctx, c); err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+
+	return &portservices.LoginResult{
+		MFA: &portservices.MFAChallengeResult{
+			ChallengeToken: token,
+			SetupRequired:  c.Setup,
+			ExpiresIn:      int(mfaChallengeTTL.Seconds()),
+		},
+	}, nil
+}
+
+func (s *authService) findChallenge(ctx context.Context, token string) (*models.MFAChallenge, error) {
+	c, err := s.mfaRepo.FindChallenge(ctx, hash.HashToken(token))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NewUnauthorized("Invalid MFA challenge")
+		}
+		return nil, apperrors.NewInternal(err)
+	}
+	if c.UsedAt != nil || time.Now().After(c.ExpiresAt) || c.Attempts >= maxMFAAttempts {
+		return nil, errMFAChallengeExpired()
+	}
+	return c, nil
+}
 
+func errMFAChallengeExpired() *apperrors.AppError {
+	return &apperrors.AppError{
+		Code:       "MFA_CHALLENGE_EXPIRED",
+		Message:    "Verification has expired; please sign in again",
+		HTTPStatus: 401,
+	}
+}
+
+// issueSession generates an access token and the first refresh token of a
+// new rotation family, which is the session.
+func (s *authService) issueSession(ctx context.Context, user *models.User, deviceID *string, deviceInfo any, deviceRef *uuid.UUID, ipAddress, userAgent string) (*portservices.LoginResult, error) {
+	rawRefresh := s.jwtManager.GenerateRefreshToken()
 	rt := &models.RefreshToken{
 		ID:         uuid.New(),
 		UserID:     user.ID,
-		TokenHash:  tokenHash,
-		DeviceID:   req.DeviceID,
-		DeviceInfo: req.DeviceInfo,
+		TokenHash:  hash.HashToken(rawRefresh),
+		DeviceID:   deviceID,
+		DeviceInfo: deviceInfo,
+		DeviceRef:  deviceRef,
+		IPAddress:  strPtr(ipAddress),
+		UserAgent:  strPtr(userAgent),
 		ExpiresAt:  time.Now().Add(s.jwtManager.RefreshExpiry()),
 	}
+	rt.FamilyID = rt.ID
+
+	accessToken, err := s.jwtManager.GenerateAccessToken(s.accessClaims(ctx, user, rt.FamilyID))
+	if err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
 
 	if err := s.userRepo.SaveRefreshToken(ctx, rt); err != nil {
 		return nil, apperrors.NewInternal(err)
@@ -134,18 +296,49 @@ func (s *authService) Login(ctx context.Context, req *portservices.LoginRequest)
 	}, nil
 }
 
+// accessClaims builds the access token claims for user in session sessionID.
+// A user who must change their password gets a token that only allows that.
+func (s *authService) accessClaims(ctx context.Context, user *models.User, sessionID uuid.UUID) *jwtpkg.Claims {
+	user.MustChangePassword = s.passwords.MustChange(ctx, user)
+
+	claims := &jwtpkg.Claims{
+		UserID:             user.ID,
+		Role:               user.Role,
+		SessionID:          &sessionID,
+		MustChangePassword: user.MustChangePassword,
+	}
+	if user.Officer != nil {
+		claims.OfficerID = &user.Officer.ID
+		claims.StationID = &user.Officer.StationID
+		claims.RegionID = &user.Officer.RegionID
+		claims.BadgeNumber = &user.Officer.BadgeNumber
+	}
+	return claims
+}
+
 func (s *authService) Logout(ctx context.Context, userID uuid.UUID, refreshToken *string) error {
-	if refreshToken != nil && *refreshToken != "" {
+	// The access token used to log out stops working immediately
+	if jti := middleware.GetTokenID(ctx); jti != "" {
+		if err := s.revocations.RevokeToken(ctx, jti, middleware.GetTokenExpiry(ctx)); err != nil {
+			s.logger.Warn("failed to deny access token on logout", zap.Error(err))
+		}
+	}
+
+	switch sessionID := middleware.GetSessionID(ctx); {
+	case refreshToken != nil && *refreshToken != "":
 		tokenHash := hash.HashToken(*refreshToken)
 		_ = s.userRepo.RevokeRefreshToken(ctx, tokenHash)
-	} else {
+	case sessionID != nil:
+		_ = s.userRepo.RevokeSession(ctx, userID, *sessionID)
+	default:
+		// Tokens issued before sessions were tracked name no session
 		_ = s.userRepo.RevokeAllUserTokens(ctx, userID)
 	}
 	return nil
 }
 
-func (s *authService) RefreshToken(ctx context.Context, refreshToken string) (*portservices.RefreshResult, error) {
-	tokenHash := hash.HashToken(refreshToken)
+func (s *authService) RefreshToken(ctx context.Context, req *portservices.RefreshRequest) (*portservices.RefreshResult, error) {
+	tokenHash := hash.HashToken(req.RefreshToken)
 
 	rt, err := s.userRepo.FindRefreshTokenByHash(ctx, tokenHash)
 	if err != nil {
@@ -155,8 +348,13 @@ func (s *authService) RefreshToken(ctx context.Context, refreshToken string) (*p
 		return nil, apperrors.NewInternal(err)
 	}
 
-	// Check if revoked
+	// Check if revoked. A token that was rotated is only ever presented again
+	// if it was copied, so the whole family is treated as compromised.
 	if rt.RevokedAt != nil {
+		if rt.ReplacedBy != nil {
+			s.revokeFamily(ctx, rt, req, "critical", "Rotated refresh token was reused; all sessions in the token family revoked")
+			return nil, errRefreshTokenReused()
+		}
 		return nil, apperrors.NewUnauthorized("Refresh token has been revoked")
 	}
 
@@ -165,6 +363,35 @@ func (s *authService) RefreshToken(ctx context.Context, refreshToken string) (*p
 		return nil, apperrors.NewUnauthorized("Refresh token has expired")
 	}
 
+	// Tokens issued to a device are only honoured for that device ID. A
+	// request that names no device is refused without touching the family;
+	// only a different device ID is treated as theft.
+	if rt.DeviceID != nil && *rt.DeviceID != "" && req.DeviceID == "" {
+		return nil, apperrors.NewValidationError("deviceId is required to refresh a token issued to a device", nil)
+	}
+	if rt.DeviceID != nil && *rt.DeviceID != "" && req.DeviceID != *rt.DeviceID {
+		s.revokeFamily(ctx, rt, req, "critical", "Refresh token presented from a different device; token family revoked")
+		return nil, &apperrors.AppError{
+			Code:       "DEVICE_MISMATCH",
+			Message:    "Refresh token was issued to a different device",
+			HTTPStatus: 401,
+		}
+	}
+
+	// Device-bound tokens are only honoured for the device that holds the key
+	if rt.DeviceRef != nil && req.DeviceKey == "" {
+		return nil, apperrors.NewValidationError("deviceKey is required to refresh a token bound to a device", nil)
+	}
+	if rt.DeviceRef != nil {
+		if err := s.devices.VerifyBinding(ctx, *rt.DeviceRef, req.DeviceKey); err != nil {
+			var appErr *apperrors.AppError
+			if !errors.As(err, &appErr) || appErr.Code != apperrors.CodeInternal {
+				_ = s.userRepo.RevokeTokenFamily(ctx, rt.FamilyID)
+			}
+			return nil, err
+		}
+	}
+
 	// Get user for claims
 	user, err := s.userRepo.FindByID(ctx, rt.UserID)
 	if err != nil {
@@ -175,29 +402,76 @@ func (s *authService) RefreshToken(ctx context.Context, refreshToken string) (*p
 		return nil, apperrors.NewForbidden("Account is deactivated")
 	}
 
-	// Generate new access token
-	claims := &jwtpkg.Claims{
-		UserID: user.ID,
-		Role:   user.Role,
-	}
-	if user.Officer != nil {
-		claims.OfficerID = &user.Officer.ID
-		claims.StationID = &user.Officer.StationID
-		claims.RegionID = &user.Officer.RegionID
-		claims.BadgeNumber = &user.Officer.BadgeNumber
+	// Rotate: the successor keeps the family and its original expiry, so a
+	// session never outlives the refresh lifetime granted at login.
+	rawRefresh := s.jwtManager.GenerateRefreshToken()
+	next := &models.RefreshToken{
+		ID:         uuid.New(),
+		UserID:     rt.UserID,
+		TokenHash:  hash.HashToken(rawRefresh),
+		DeviceID:   rt.DeviceID,
+		DeviceInfo: rt.DeviceInfo,
+		DeviceRef:  rt.DeviceRef,
+		FamilyID:   rt.FamilyID,
+		IPAddress:  strPtr(req.IPAddress),
+		UserAgent:  strPtr(req.UserAgent),
+		ExpiresAt:  rt.ExpiresAt,
+	}
+	if err := s.userRepo.RotateRefreshToken(ctx, rt.ID, next); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			// Another request rotated the same token first
+			s.revokeFamily(ctx, rt, req, "critical", "Refresh token was presented twice concurrently; all sessions in the token family revoked")
+			return nil, errRefreshTokenReused()
+		}
+		return nil, apperrors.NewInternal(err)
 	}
 
-	accessToken, err := s.jwtManager.GenerateAccessToken(claims)
+	// Generate new access token
+	accessToken, err := s.jwtManager.GenerateAccessToken(s.accessClaims(ctx, user, rt.FamilyID))
 	if err != nil {
 		return nil, apperrors.NewInternal(err)
 	}
 
 	return &portservices.RefreshResult{
-		AccessToken: accessToken,
-		ExpiresIn:   int(s.jwtManager.AccessExpiry().Seconds()),
+		AccessToken:  accessToken,
+		RefreshToken: rawRefresh,
+		ExpiresIn:    int(s.jwtManager.AccessExpiry().Seconds()),
 	}, nil
 }
 
+// revokeFamily revokes every token in rt's rotation family and records an
+// audit entry against the token's owner.
+func (s *authService) revokeFamily(ctx context.Context, rt *models.RefreshToken, req *portservices.RefreshRequest, severity, description string) {
+	if err := s.userRepo.RevokeTokenFamily(ctx, rt.FamilyID); err != nil {
+		s.logger.Error("failed to revoke refresh token family", zap.String("familyId", rt.FamilyID.String()), zap.Error(err))
+	}
+	s.logger.Warn("refresh token family revoked",
+		zap.String("userId", rt.UserID.String()),
+		zap.String("familyId", rt.FamilyID.String()),
+		zap.String("reason", description))
+
+	userID := rt.UserID
+	s.audit.Log(ctx, &portservices.AuditEntry{
+		UserID:      &userID,
+		Action:      "token_reuse",
+		EntityType:  "user",
+		EntityID:    rt.UserID.String(),
+		Description: description,
+		Severity:    severity,
+		Success:     false,
+		IPAddress:   req.IPAddress,
+		UserAgent:   req.UserAgent,
+	})
+}
+
+func errRefreshTokenReused() *apperrors.AppError {
+	return &apperrors.AppError{
+		Code:       "REFRESH_TOKEN_REUSED",
+		Message:    "Refresh token has already been used; please sign in again",
+		HTTPStatus: 401,
+	}
+}
+
 func (s *authService) GetProfile(ctx context.Context, userID uuid.UUID) (*models.UserResponse, error) {
 	user, err := s.userRepo.FindByID(ctx, userID)
 	if err != nil {
@@ -206,6 +480,7 @@ func (s *authService) GetProfile(ctx context.Context, userID uuid.UUID) (*models
 		}
 		return nil, apperrors.NewInternal(err)
 	}
+	user.MustChangePassword = s.passwords.MustChange(ctx, user)
 	return user.ToResponse(), nil
 }
 
@@ -241,9 +516,6 @@ func (s *authService) ChangePassword(ctx context.Context, userID uuid.UUID, req
 	if req.CurrentPassword == "" || req.NewPassword == "" {
 		return apperrors.NewValidationError("Both current and new passwords are required", nil)
 	}
-	if len(req.NewPassword) < 8 {
-		return apperrors.NewValidationError("New password must be at least 8 characters", nil)
-	}
 
 	user, err := s.userRepo.FindByID(ctx, userID)
 	if err != nil {
@@ -258,10 +530,19 @@ func (s *authService) ChangePassword(ctx context.Context, userID uuid.UUID, req
 		}
 	}
 
+	if err := s.passwords.Validate(ctx, &userID, req.NewPassword); err != nil {
+		return err
+	}
+
 	newHash, err := hash.HashPassword(req.NewPassword)
 	if err != nil {
 		return apperrors.NewInternal(err)
 	}
 
-	return s.userRepo.UpdatePassword(ctx, userID, newHash)
+	if err := s.userRepo.UpdatePassword(ctx, userID, newHash, false); err != nil {
+		return apperrors.NewInternal(err)
+	}
+
+	// Sign out every session, including the one that made the change
+	return s.revocations.RevokeUser(ctx, userID, "password_changed")
 }
@@ -0,0 +1,91 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	apperrors "github.com/ghana-police/ticketing-backend/internal/domain/errors"
+	"github.com/ghana-police/ticketing-backend/internal/domain/models"
+	portservices "github.com/ghana-police/ticketing-backend/internal/ports/services"
+	"github.com/ghana-police/ticketing-backend/pkg/hash"
+	jwtpkg "github.com/ghana-police/ticketing-backend/pkg/jwt"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+func TestRefreshTokenFamilyRevocation(t *testing.T) {
+	user := &models.User{ID: uuid.New(), Role: "officer", IsActive: true}
+	deviceRef := uuid.New()
+	handset := "handset-1"
+	now := time.Now()
+
+	tests := []struct {
+		name       string
+		token      models.RefreshToken // ID, UserID, TokenHash, FamilyID and ExpiresAt are filled in
+		deviceID   string
+		deviceKey  string
+		wantErr    string
+		wantRevoke bool
+	}{
+		{name: "unbound token", token: models.RefreshToken{}},
+		{name: "device token from its device", token: models.RefreshToken{DeviceID: &handset}, deviceID: handset},
+		{name: "device token without device ID", token: models.RefreshToken{DeviceID: &handset},
+			wantErr: apperrors.CodeValidation},
+		{name: "device token from another device", token: models.RefreshToken{DeviceID: &handset}, deviceID: "handset-2",
+			wantErr: "DEVICE_MISMATCH", wantRevoke: true},
+		{name: "bound token with its key", token: models.RefreshToken{DeviceRef: &deviceRef}, deviceKey: "key-1"},
+		{name: "bound token without key", token: models.RefreshToken{DeviceRef: &deviceRef},
+			wantErr: apperrors.CodeValidation},
+		{name: "bound token with another key", token: models.RefreshToken{DeviceRef: &deviceRef}, deviceKey: "key-2",
+			wantErr: apperrors.CodeUnauthorized, wantRevoke: true},
+		{name: "rotated token presented again", token: models.RefreshToken{RevokedAt: &now, ReplacedBy: &deviceRef},
+			wantErr: "REFRESH_TOKEN_REUSED", wantRevoke: true},
+		{name: "revoked token", token: models.RefreshToken{RevokedAt: &now},
+			wantErr: apperrors.CodeUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw := "refresh-" + uuid.NewString()
+			rt := tt.token
+			rt.ID, rt.UserID, rt.TokenHash = uuid.New(), user.ID, hash.HashToken(raw)
+			rt.FamilyID, rt.ExpiresAt = uuid.New(), now.Add(time.Hour)
+
+			users := &fakeUserRepo{
+				users:         []*models.User{user},
+				refreshTokens: map[string]*models.RefreshToken{rt.TokenHash: &rt},
+			}
+			s := &authService{
+				userRepo:   users,
+				devices:    &fakeDevices{keys: map[uuid.UUID]string{deviceRef: "key-1"}},
+				audit:      &fakeAudit{},
+				passwords:  fakePasswords{},
+				jwtManager: jwtpkg.NewManager("test-secret", time.Minute, time.Hour),
+				logger:     zap.NewNop(),
+			}
+
+			result, err := s.RefreshToken(context.Background(), &portservices.RefreshRequest{
+				RefreshToken: raw, DeviceID: tt.deviceID, DeviceKey: tt.deviceKey,
+			})
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if result.RefreshToken == raw {
+					t.Error("refresh token was not rotated")
+				}
+			} else {
+				wantCode(t, err, tt.wantErr)
+			}
+
+			revoked := len(users.revokedFamilies) > 0
+			if revoked != tt.wantRevoke {
+				t.Errorf("family revoked = %t, want %t", revoked, tt.wantRevoke)
+			}
+			if revoked && users.revokedFamilies[0] != rt.FamilyID {
+				t.Errorf("revoked family %s, want %s", users.revokedFamilies[0], rt.FamilyID)
+			}
+		})
+	}
+}
@@ -0,0 +1,388 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	apperrors "github.com/ghana-police/ticketing-backend/internal/domain/errors"
+	"github.com/ghana-police/ticketing-backend/internal/domain/models"
+	"github.com/ghana-police/ticketing-backend/internal/middleware"
+	"github.com/ghana-police/ticketing-backend/internal/ports/repositories"
+	portservices "github.com/ghana-police/ticketing-backend/internal/ports/services"
+	"github.com/ghana-police/ticketing-backend/pkg/pagination"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+const (
+	maxRemittanceShifts = 100
+	maxTreasuryDays     = 92
+	defaultTreasuryDays = 7 // days shown when the report is asked for without dates
+)
+
+type cashService struct {
+	uow           repositories.UnitOfWork
+	repo          repositories.CashRepository
+	jurisdictions repositories.JurisdictionRepository
+	permissions   portservices.PermissionService
+	logger        *zap.Logger
+}
+
+func NewCashService(
+	uow repositories.UnitOfWork,
+	repo repositories.CashRepository,
+	jurisdictions repositories.JurisdictionRepository,
+	permissions portservices.PermissionService,
+	logger *zap.Logger,
+) portservices.CashService {
+	return &cashService{
+		uow:           uow,
+		repo:          repo,
+		jurisdictions: jurisdictions,
+		permissions:   permissions,
+		logger:        logger,
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Shifts
+// ---------------------------------------------------------------------------
+
+func (s *cashService) OpenShift(ctx context.Context, req *portservices.OpenShiftRequest) (*models.CashierShift, error) {
+	if req.OpeningFloat < 0 {
+		return nil, apperrors.NewValidationError("Opening float cannot be negative",
+			map[string][]string{"openingFloat": {"must be 0 or more"}})
+	}
+	stationID := req.StationID
+	if stationID == nil {
+		stationID = middleware.GetStationID(ctx)
+	}
+	if stationID == nil {
+		return nil, apperrors.NewValidationError("Station is required",
+			map[string][]string{"stationId": {"is required when you are not assigned to a station"}})
+	}
+	if err := requireInJurisdiction(ctx, s.jurisdictions, models.EntityStation, *stationID, "Station"); err != nil {
+		return nil, err
+	}
+
+	userID := middleware.GetUserID(ctx)
+	if _, err := s.repo.GetOpenShift(ctx, userID); err == nil {
+		return nil, apperrors.NewConflict("You already have an open shift; close it first")
+	} else if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, apperrors.NewInternal(err)
+	}
+
+	shift := &models.CashierShift{
+		StationID:    *stationID,
+		CashierID:    userID,
+		Status:       models.ShiftOpen,
+		OpeningFloat: math.Round(req.OpeningFloat*100) / 100,
+	}
+	if err := s.repo.CreateShift(ctx, shift); err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+
+	s.logger.Info("cashier shift opened",
+		zap.String("shift_id", shift.ID.String()),
+		zap.String("station_id", stationID.String()),
+		zap.Float64("opening_float", shift.OpeningFloat))
+	return s.getShift(ctx, shift.ID)
+}
+
+func (s *cashService) CurrentShift(ctx context.Context) (*models.CashierShift, error) {
+	shift, err := s.repo.GetOpenShift(ctx, middleware.GetUserID(ctx))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NewNotFound("Open shift")
+		}
+		return nil, apperrors.NewInternal(err)
+	}
+	return shift, nil
+}
+
+func (s *cashService) GetShift(ctx context.Context, id uuid.UUID) (*models.CashierShift, error) {
+	if err := requireInJurisdiction(ctx, s.jurisdictions, models.EntityCashierShift, id, "Shift"); err != nil {
+		return nil, err
+	}
+	return s.getShift(ctx, id)
+}
+
+func (s *cashService) getShift(ctx context.Context, id uuid.UUID) (*models.CashierShift, error) {
+	shift, err := s.repo.GetShift(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NewNotFound("Shift")
+		}
+		return nil, apperrors.NewInternal(err)
+	}
+	return shift, nil
+}
+
+func (s *cashService) ListShifts(ctx context.Context, filter models.CashierShiftFilter, p pagination.Params) ([]models.CashierShift, int, error) {
+	filter.Scope = callerScope(ctx)
+	items, total, err := s.repo.ListShifts(ctx, filter, p)
+	if err != nil {
+		return nil, 0, apperrors.NewInternal(err)
+	}
+	if items == nil {
+		items = []models.CashierShift{}
+	}
+	return items, total, nil
+}
+
+func (s *cashService) CloseShift(ctx context.Context, id uuid.UUID, req *portservices.CloseShiftRequest) (*models.CashierShift, error) {
+	if req.CountedCash < 0 {
+		return nil, apperrors.NewValidationError("Counted cash cannot be negative",
+			map[string][]string{"countedCash": {"must be 0 or more"}})
+	}
+	if err := requireInJurisdiction(ctx, s.jurisdictions, models.EntityCashierShift, id, "Shift"); err != nil {
+		return nil, err
+	}
+
+	userID := middleware.GetUserID(ctx)
+	var shift *models.CashierShift
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		// Cash payments lock the shift too, so none lands after the count
+		var err error
+		shift, err = s.repo.GetShiftForUpdate(ctx, id)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return apperrors.NewNotFound("Shift")
+			}
+			return err
+		}
+		if shift.Status != models.ShiftOpen {
+			return apperrors.NewConflict("Shift is already closed")
+		}
+		if shift.CashierID != userID {
+			ok, err := s.permissions.Has(ctx, userID, middleware.GetUserRole(ctx), models.PermRemittanceApprove)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return apperrors.NewForbidden("Only the cashier or a supervisor can close this shift")
+			}
+		}
+
+		now := time.Now()
+		counted := math.Round(req.CountedCash*100) / 100
+		expected := math.Round((shift.OpeningFloat+shift.CashCollected)*100) / 100
+		variance := math.Round((counted-expected)*100) / 100
+		shift.ClosedAt = &now
+		shift.ClosedByID = &userID
+		shift.ExpectedCash = &expected
+		shift.CountedCash = &counted
+		shift.Variance = &variance
+		shift.CloseNotes = req.Notes
+		return s.repo.CloseShift(ctx, shift)
+	})
+	if err != nil {
+		return nil, asAppError(err)
+	}
+
+	s.logger.Info("cashier shift closed",
+		zap.String("shift_id", id.String()),
+		zap.Float64("expected", *shift.ExpectedCash),
+		zap.Float64("counted", *shift.CountedCash),
+		zap.Float64("variance", *shift.Variance))
+	return s.getShift(ctx, id)
+}
+
+// ---------------------------------------------------------------------------
+// Remittances
+// ---------------------------------------------------------------------------
+
+func (s *cashService) SubmitRemittance(ctx context.Context, req *portservices.SubmitRemittanceRequest) (*models.CashRemittance, error) {
+	req.DepositSlipNumber = strings.TrimSpace(req.DepositSlipNumber)
+	req.BankName = strings.TrimSpace(req.BankName)
+	details := map[string][]string{}
+	if len(req.ShiftIDs) == 0 {
+		details["shiftIds"] = []string{"is required"}
+	} else if len(req.ShiftIDs) > maxRemittanceShifts {
+		details["shiftIds"] = []string{fmt.Sprintf("must have at most %d shifts", maxRemittanceShifts)}
+	}
+	if req.DepositSlipNumber == "" {
+		details["depositSlipNumber"] = []string{"is required"}
+	}
+	if req.BankName == "" {
+		details["bankName"] = []string{"is required"}
+	}
+	if req.DepositedAt.IsZero() {
+		details["depositedAt"] = []string{"is required"}
+	} else if req.DepositedAt.After(time.Now().Add(5 * time.Minute)) {
+		details["depositedAt"] = []string{"must not be in the future"}
+	}
+	if req.DepositedAmount <= 0 {
+		details["depositedAmount"] = []string{"must be greater than 0"}
+	}
+	if len(details) > 0 {
+		return nil, apperrors.NewValidationError("Invalid remittance", details)
+	}
+
+	// Lock shifts in one order so concurrent remittances cannot deadlock
+	shiftIDs := slices.Clone(req.ShiftIDs)
+	slices.SortFunc(shiftIDs, func(a, b uuid.UUID) int { return bytes.Compare(a[:], b[:]) })
+	shiftIDs = slices.Compact(shiftIDs)
+	for _, id := range shiftIDs {
+		if err := requireInJurisdiction(ctx, s.jurisdictions, models.EntityCashierShift, id, "Shift"); err != nil {
+			return nil, err
+		}
+	}
+
+	exists, err := s.repo.SlipExists(ctx, req.BankName, req.DepositSlipNumber)
+	if err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+	if exists {
+		return nil, apperrors.NewConflict("This deposit slip is already recorded")
+	}
+
+	remittance := &models.CashRemittance{
+		DepositSlipNumber: req.DepositSlipNumber,
+		BankName:          req.BankName,
+		BankAccount:       req.BankAccount,
+		DepositedAt:       req.DepositedAt,
+		DepositedAmount:   math.Round(req.DepositedAmount*100) / 100,
+		Status:            models.RemittancePending,
+		Notes:             req.Notes,
+		SubmittedByID:     middleware.GetUserID(ctx),
+	}
+	err = s.uow.Do(ctx, func(ctx context.Context) error {
+		var expected float64
+		for i, id := range shiftIDs {
+			shift, err := s.repo.GetShiftForUpdate(ctx, id)
+			if err != nil {
+				if errors.Is(err, pgx.ErrNoRows) {
+					return apperrors.NewNotFound("Shift")
+				}
+				return err
+			}
+			switch {
+			case shift.Status != models.ShiftClosed:
+				return apperrors.NewValidationError("Only closed shifts can be remitted",
+					map[string][]string{"shiftIds": {shift.ID.String() + " is still open"}})
+			case shift.RemittanceID != nil:
+				return apperrors.NewConflict("Shift " + shift.ID.String() + " is already on a remittance")
+			case i > 0 && shift.StationID != remittance.StationID:
+				return apperrors.NewValidationError("A remittance covers shifts of one station",
+					map[string][]string{"shiftIds": {"must all belong to the same station"}})
+			}
+			remittance.StationID = shift.StationID
+			expected += shift.Banked()
+		}
+		remittance.ExpectedAmount = math.Round(expected*100) / 100
+		remittance.Variance = math.Round((remittance.DepositedAmount-remittance.ExpectedAmount)*100) / 100
+		return s.repo.CreateRemittance(ctx, remittance, shiftIDs)
+	})
+	if err != nil {
+		return nil, asAppError(err)
+	}
+
+	s.logger.Info("cash remittance submitted",
+		zap.String("remittance_id", remittance.ID.String()),
+		zap.Int("shifts", len(shiftIDs)),
+		zap.Float64("expected", remittance.ExpectedAmount),
+		zap.Float64("deposited", remittance.DepositedAmount))
+	return s.getRemittance(ctx, remittance.ID)
+}
+
+func (s *cashService) GetRemittance(ctx context.Context, id uuid.UUID) (*models.CashRemittance, error) {
+	if err := requireInJurisdiction(ctx, s.jurisdictions, models.EntityCashRemittance, id, "Remittance"); err != nil {
+		return nil, err
+	}
+	return s.getRemittance(ctx, id)
+}
+
+func (s *cashService) getRemittance(ctx context.Context, id uuid.UUID) (*models.CashRemittance, error) {
+	remittance, err := s.repo.GetRemittance(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NewNotFound("Remittance")
+		}
+		return nil, apperrors.NewInternal(err)
+	}
+	return remittance, nil
+}
+
+func (s *cashService) ListRemittances(ctx context.Context, filter models.CashRemittanceFilter, p pagination.Params) ([]models.CashRemittance, int, error) {
+	filter.Scope = callerScope(ctx)
+	items, total, err := s.repo.ListRemittances(ctx, filter, p)
+	if err != nil {
+		return nil, 0, apperrors.NewInternal(err)
+	}
+	if items == nil {
+		items = []models.CashRemittance{}
+	}
+	return items, total, nil
+}
+
+func (s *cashService) ReviewRemittance(ctx context.Context, id uuid.UUID, req *portservices.ReviewRemittanceRequest) (*models.CashRemittance, error) {
+	if req.Decision != models.RemittanceApproved && req.Decision != models.RemittanceRejected {
+		return nil, apperrors.NewValidationError("Invalid decision",
+			map[string][]string{"decision": {"must be approved or rejected"}})
+	}
+	if req.Decision == models.RemittanceRejected && (req.Notes == nil || strings.TrimSpace(*req.Notes) == "") {
+		return nil, apperrors.NewValidationError("Give the reason for rejecting the remittance",
+			map[string][]string{"notes": {"is required when rejecting"}})
+	}
+
+	remittance, err := s.GetRemittance(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	userID := middleware.GetUserID(ctx)
+	if remittance.SubmittedByID == userID {
+		return nil, apperrors.NewForbidden("You cannot sign off a remittance you submitted")
+	}
+
+	if err := s.repo.ReviewRemittance(ctx, id, req.Decision, userID, req.Notes); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NewConflict("Remittance has already been reviewed")
+		}
+		return nil, apperrors.NewInternal(err)
+	}
+
+	s.logger.Info("cash remittance reviewed",
+		zap.String("remittance_id", id.String()),
+		zap.String("decision", req.Decision),
+		zap.Float64("variance", remittance.Variance))
+	return s.getRemittance(ctx, id)
+}
+
+// ---------------------------------------------------------------------------
+// Treasury report
+// ---------------------------------------------------------------------------
+
+func (s *cashService) TreasuryReport(ctx context.Context, stationID *uuid.UUID, from, to time.Time) (*models.TreasuryReport, error) {
+	if to.IsZero() {
+		now := time.Now().UTC()
+		to = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	}
+	if from.IsZero() {
+		from = to.AddDate(0, 0, -(defaultTreasuryDays - 1))
+	}
+	if to.Before(from) {
+		return nil, apperrors.NewValidationError("dateTo is before dateFrom", nil)
+	}
+	if to.Sub(from) >= maxTreasuryDays*24*time.Hour {
+		return nil, apperrors.NewValidationError(fmt.Sprintf("The report covers at most %d days", maxTreasuryDays), nil)
+	}
+	if stationID != nil {
+		if err := requireInJurisdiction(ctx, s.jurisdictions, models.EntityStation, *stationID, "Station"); err != nil {
+			return nil, err
+		}
+	}
+
+	report, err := s.repo.TreasuryReport(ctx, *callerScope(ctx), stationID, from, to)
+	if err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+	return report, nil
+}
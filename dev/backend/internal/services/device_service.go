@@ -0,0 +1,451 @@
+package services
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	apperrors "github.com/ghana-police/ticketing-backend/internal/domain/errors"
+	"github.com/ghana-police/ticketing-backend/internal/domain/models"
+	"github.com/ghana-police/ticketing-backend/internal/middleware"
+	"github.com/ghana-police/ticketing-backend/internal/ports/repositories"
+	portservices "github.com/ghana-police/ticketing-backend/internal/ports/services"
+	"github.com/ghana-police/ticketing-backend/pkg/hash"
+	"github.com/ghana-police/ticketing-backend/pkg/pagination"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+const enrollmentCodeTTL = 24 * time.Hour
+
+type deviceService struct {
+	deviceRepo        repositories.DeviceRepository
+	officerRepo       repositories.OfficerRepository
+	userRepo          repositories.UserRepository
+	hierarchyRepo     repositories.HierarchyRepository
+	jurisdictions     repositories.JurisdictionRepository
+	requireEnrollment bool
+	logger            *zap.Logger
+}
+
+func NewDeviceService(
+	deviceRepo repositories.DeviceRepository,
+	officerRepo repositories.OfficerRepository,
+	userRepo repositories.UserRepository,
+	hierarchyRepo repositories.HierarchyRepository,
+	jurisdictions repositories.JurisdictionRepository,
+	requireEnrollment bool,
+	logger *zap.Logger,
+) portservices.DeviceService {
+	return &deviceService{
+		deviceRepo:        deviceRepo,
+		officerRepo:       officerRepo,
+		userRepo:          userRepo,
+		hierarchyRepo:     hierarchyRepo,
+		jurisdictions:     jurisdictions,
+		requireEnrollment: requireEnrollment,
+		logger:            logger,
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Registry administration
+// ---------------------------------------------------------------------------
+
+func (s *deviceService) List(ctx context.Context, filter models.DeviceFilter, search string, p pagination.Params) ([]models.Device, int, error) {
+	filter.Scope = callerScope(ctx)
+	devices, total, err := s.deviceRepo.List(ctx, filter, search, p)
+	if err != nil {
+		return nil, 0, apperrors.NewInternal(err)
+	}
+	return devices, total, nil
+}
+
+func (s *deviceService) Get(ctx context.Context, id uuid.UUID) (*models.Device, error) {
+	device, err := s.deviceRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NewNotFound("Device")
+		}
+		return nil, apperrors.NewInternal(err)
+	}
+
+	// Devices outside the caller's jurisdiction are reported as not found
+	if err := requireInJurisdiction(ctx, s.jurisdictions, models.EntityDevice, id, "Device"); err != nil {
+		return nil, err
+	}
+	return device, nil
+}
+
+func (s *deviceService) Register(ctx context.Context, req *portservices.RegisterDeviceRequest) (*portservices.DeviceEnrollmentResult, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, apperrors.NewValidationError("name is required", nil)
+	}
+
+	// A device assigned to an officer belongs to the officer's station
+	stationID := req.StationID
+	if req.OfficerID != nil {
+		officer, err := s.officerRepo.GetByID(ctx, *req.OfficerID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, apperrors.NewValidationError("Officer not found", nil)
+			}
+			return nil, apperrors.NewInternal(err)
+		}
+		stationID = &officer.StationID
+	}
+	if stationID == nil {
+		return nil, apperrors.NewValidationError("stationId or officerId is required", nil)
+	}
+
+	station, err := s.hierarchyRepo.GetStationByID(ctx, *stationID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NewValidationError("Station not found", nil)
+		}
+		return nil, apperrors.NewInternal(err)
+	}
+	if regionID := middleware.GetRegionID(ctx); middleware.GetUserRole(ctx) == "admin" && regionID != nil && station.RegionID != *regionID {
+		return nil, apperrors.NewForbidden("Station is outside your region")
+	}
+
+	code := hash.GenerateEnrollmentCode()
+	codeHash := hash.HashToken(code)
+	expiresAt := time.Now().Add(enrollmentCodeTTL)
+
+	device := &models.Device{
+		Name:                name,
+		Status:              models.DeviceStatusPending,
+		StationID:           stationID,
+		OfficerID:           req.OfficerID,
+		EnrollmentCodeHash:  &codeHash,
+		EnrollmentExpiresAt: &expiresAt,
+		RegisteredBy:        middleware.GetUserID(ctx),
+	}
+	if err := s.deviceRepo.Create(ctx, device); err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+
+	created, err := s.deviceRepo.GetByID(ctx, device.ID)
+	if err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+
+	return &portservices.DeviceEnrollmentResult{Device: created, EnrollmentCode: code}, nil
+}
+
+func (s *deviceService) ReEnroll(ctx context.Context, id uuid.UUID) (*portservices.DeviceEnrollmentResult, error) {
+	device, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if device.Status == models.DeviceStatusRetired {
+		return nil, apperrors.NewConflict("Retired devices cannot be re-enrolled")
+	}
+
+	code := hash.GenerateEnrollmentCode()
+	if err := s.deviceRepo.ResetEnrollment(ctx, id, hash.HashToken(code), time.Now().Add(enrollmentCodeTTL)); err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+
+	// The old key no longer exists, so sessions bound to it must end
+	if err := s.deviceRepo.RevokeTokens(ctx, id); err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+
+	updated, err := s.deviceRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+	return &portservices.DeviceEnrollmentResult{Device: updated, EnrollmentCode: code}, nil
+}
+
+func (s *deviceService) UpdateStatus(ctx context.Context, id uuid.UUID, req *portservices.UpdateDeviceStatusRequest) (*models.Device, error) {
+	device, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	switch req.Status {
+	case models.DeviceStatusActive:
+		if device.KeyHash == nil {
+			return nil, apperrors.NewConflict("Device has not completed enrollment")
+		}
+		if device.Status == models.DeviceStatusRetired {
+			return nil, apperrors.NewConflict("Retired devices cannot be reactivated")
+		}
+	case models.DeviceStatusLost, models.DeviceStatusRetired:
+	default:
+		return nil, apperrors.NewValidationError("status must be one of: active, lost, retired", nil)
+	}
+
+	if err := s.deviceRepo.UpdateStatus(ctx, id, req.Status, req.Reason); err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+
+	// A lost or retired device must not keep a usable session
+	if req.Status != models.DeviceStatusActive {
+		if err := s.deviceRepo.RevokeTokens(ctx, id); err != nil {
+			return nil, apperrors.NewInternal(err)
+		}
+	}
+
+	updated, err := s.deviceRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+	return updated, nil
+}
+
+// ---------------------------------------------------------------------------
+// Commands
+// ---------------------------------------------------------------------------
+
+var deviceCommands = []string{models.DeviceCommandLock, models.DeviceCommandWipe, models.DeviceCommandResync}
+
+func (s *deviceService) ListCommands(ctx context.Context, id uuid.UUID) ([]models.DeviceCommand, error) {
+	if _, err := s.Get(ctx, id); err != nil {
+		return nil, err
+	}
+	commands, err := s.deviceRepo.ListCommands(ctx, id)
+	if err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+	return commands, nil
+}
+
+func (s *deviceService) IssueCommand(ctx context.Context, id uuid.UUID, req *portservices.IssueDeviceCommandRequest) (*models.DeviceCommand, error) {
+	if !slices.Contains(deviceCommands, req.Command) {
+		return nil, apperrors.NewValidationError("command must be one of: lock, wipe, resync", nil)
+	}
+
+	device, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if device.Status == models.DeviceStatusRetired {
+		return nil, apperrors.NewConflict("Commands cannot be sent to a retired device")
+	}
+
+	cmd := &models.DeviceCommand{
+		DeviceID: id,
+		Command:  req.Command,
+		Reason:   req.Reason,
+		IssuedBy: middleware.GetUserID(ctx),
+	}
+	if err := s.deviceRepo.CreateCommand(ctx, cmd); err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+	return cmd, nil
+}
+
+func (s *deviceService) CancelCommand(ctx context.Context, id, commandID uuid.UUID) error {
+	if _, err := s.Get(ctx, id); err != nil {
+		return err
+	}
+	if err := s.deviceRepo.CancelCommand(ctx, id, commandID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return apperrors.NewNotFound("Outstanding command")
+		}
+		return apperrors.NewInternal(err)
+	}
+	return nil
+}
+
+func (s *deviceService) DeliverCommands(ctx context.Context, deviceRef uuid.UUID, acked []uuid.UUID) ([]models.DeviceCommand, error) {
+	if len(acked) > 0 {
+		if err := s.deviceRepo.AcknowledgeCommands(ctx, deviceRef, acked); err != nil {
+			return nil, err
+		}
+	}
+	return s.deviceRepo.TakeOutstandingCommands(ctx, deviceRef)
+}
+
+func (s *deviceService) Touch(ctx context.Context, deviceRef uuid.UUID, appVersion *string) {
+	if err := s.deviceRepo.TouchLastSeen(ctx, deviceRef, appVersion); err != nil {
+		s.logger.Error("failed to record device contact", zap.Error(err))
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Enrollment & authorization
+// ---------------------------------------------------------------------------
+
+func (s *deviceService) Enroll(ctx context.Context, req *portservices.EnrollDeviceRequest) (*portservices.EnrollDeviceResult, error) {
+	code := strings.ToUpper(strings.TrimSpace(req.EnrollmentCode))
+	deviceID := strings.TrimSpace(req.DeviceID)
+	if code == "" || deviceID == "" {
+		return nil, apperrors.NewValidationError("enrollmentCode and deviceId are required", nil)
+	}
+
+	codeHash := hash.HashToken(code)
+	device, err := s.deviceRepo.GetByEnrollmentCode(ctx, codeHash)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NewUnauthorized("Invalid enrollment code")
+		}
+		return nil, apperrors.NewInternal(err)
+	}
+	if device.EnrollmentExpiresAt == nil || time.Now().After(*device.EnrollmentExpiresAt) {
+		return nil, apperrors.NewUnauthorized("Enrollment code has expired")
+	}
+	if err := s.checkEnrollingOfficer(ctx, device, req); err != nil {
+		return nil, err
+	}
+
+	// The hardware ID may only be held by one registry entry
+	existing, err := s.deviceRepo.GetByDeviceID(ctx, deviceID)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, apperrors.NewInternal(err)
+	}
+	if existing != nil && existing.ID != device.ID {
+		return nil, apperrors.NewConflict("Device is already registered; re-enroll it from the registry instead")
+	}
+
+	key := hash.GenerateSecret()
+	if err := s.deviceRepo.Enroll(ctx, device.ID, codeHash, deviceID, req.Platform, req.Model, req.AppVersion, hash.HashToken(key)); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NewConflict("Enrollment code has already been used")
+		}
+		return nil, apperrors.NewInternal(err)
+	}
+
+	enrolled, err := s.deviceRepo.GetByID(ctx, device.ID)
+	if err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+
+	s.logger.Info("device enrolled", zap.String("device", device.ID.String()), zap.String("deviceId", deviceID))
+	return &portservices.EnrollDeviceResult{Device: enrolled, DeviceKey: key}, nil
+}
+
+// checkEnrollingOfficer requires the officer a device was issued to to enroll
+// it with their own badge number and password, so a leaked code cannot bind
+// another handset to their account. Unassigned devices need no credentials.
+func (s *deviceService) checkEnrollingOfficer(ctx context.Context, device *models.Device, req *portservices.EnrollDeviceRequest) error {
+	if device.OfficerID == nil {
+		return nil
+	}
+	badge := strings.TrimSpace(req.BadgeNumber)
+	if badge == "" || req.Password == "" {
+		return apperrors.NewValidationError("badgeNumber and password of the assigned officer are required", nil)
+	}
+
+	user, err := s.userRepo.FindByBadgeNumber(ctx, badge)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return apperrors.NewInvalidCredentials("Invalid credentials")
+		}
+		return apperrors.NewInternal(err)
+	}
+	if !user.IsActive || !hash.CheckPassword(req.Password, user.PasswordHash) {
+		return apperrors.NewInvalidCredentials("Invalid credentials")
+	}
+	if user.Officer == nil || user.Officer.ID != *device.OfficerID {
+		return apperrors.NewForbidden("This device was issued to another officer")
+	}
+	return nil
+}
+
+func (s *deviceService) Authorize(ctx context.Context, hardwareID, deviceKey string, mandatory bool) (*models.Device, error) {
+	enforce := mandatory && s.requireEnrollment
+
+	if hardwareID == "" {
+		if enforce {
+			return nil, errDeviceNotRegistered()
+		}
+		return nil, nil
+	}
+
+	device, err := s.deviceRepo.GetByDeviceID(ctx, hardwareID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			if enforce {
+				return nil, errDeviceNotRegistered()
+			}
+			return nil, nil
+		}
+		return nil, apperrors.NewInternal(err)
+	}
+
+	// A device awaiting (re-)enrollment has no valid key yet
+	if device.Status == models.DeviceStatusPending {
+		return nil, errDeviceNotRegistered()
+	}
+
+	if !deviceKeyMatches(device, deviceKey) {
+		return nil, &apperrors.AppError{
+			Code:       "INVALID_DEVICE_KEY",
+			Message:    "Device key is missing or does not match the registered device",
+			HTTPStatus: http.StatusUnauthorized,
+		}
+	}
+
+	switch device.Status {
+	case models.DeviceStatusActive:
+		return device, nil
+	case models.DeviceStatusLost:
+		// Still hand a lost device its outstanding commands (e.g. wipe)
+		appErr := &apperrors.AppError{
+			Code:       "DEVICE_LOST",
+			Message:    "This device has been reported lost",
+			HTTPStatus: http.StatusForbidden,
+		}
+		commands, err := s.deviceRepo.TakeOutstandingCommands(ctx, device.ID)
+		if err != nil {
+			s.logger.Error("failed to load commands for lost device", zap.Error(err))
+		}
+		if len(commands) > 0 {
+			names := make([]string, 0, len(commands))
+			for _, c := range commands {
+				names = append(names, c.Command)
+			}
+			appErr.Details = map[string][]string{"commands": names}
+		}
+		return nil, appErr
+	default:
+		return nil, &apperrors.AppError{
+			Code:       "DEVICE_RETIRED",
+			Message:    "This device has been retired",
+			HTTPStatus: http.StatusForbidden,
+		}
+	}
+}
+
+func (s *deviceService) VerifyBinding(ctx context.Context, deviceRef uuid.UUID, deviceKey string) error {
+	device, err := s.deviceRepo.GetByID(ctx, deviceRef)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return apperrors.NewUnauthorized("Refresh token is bound to an unknown device")
+		}
+		return apperrors.NewInternal(err)
+	}
+	if !deviceKeyMatches(device, deviceKey) {
+		return apperrors.NewUnauthorized("Refresh token is bound to another device")
+	}
+	if device.Status != models.DeviceStatusActive {
+		return apperrors.NewForbidden("Device is not active")
+	}
+	return nil
+}
+
+func deviceKeyMatches(device *models.Device, deviceKey string) bool {
+	if device.KeyHash == nil || deviceKey == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(*device.KeyHash), []byte(hash.HashToken(deviceKey))) == 1
+}
+
+func errDeviceNotRegistered() *apperrors.AppError {
+	return &apperrors.AppError{
+		Code:       "DEVICE_NOT_REGISTERED",
+		Message:    "This device is not enrolled in the device registry",
+		HTTPStatus: http.StatusForbidden,
+	}
+}
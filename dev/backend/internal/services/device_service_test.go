@@ -0,0 +1,137 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	apperrors "github.com/ghana-police/ticketing-backend/internal/domain/errors"
+	"github.com/ghana-police/ticketing-backend/internal/domain/models"
+	portservices "github.com/ghana-police/ticketing-backend/internal/ports/services"
+	"github.com/ghana-police/ticketing-backend/pkg/hash"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const testEnrollmentCode = "K7QM-4TXH"
+
+// pendingDevice is a device awaiting enrollment with testEnrollmentCode.
+func pendingDevice(officerID *uuid.UUID) *models.Device {
+	codeHash := hash.HashToken(testEnrollmentCode)
+	expires := time.Now().Add(time.Hour)
+	return &models.Device{
+		ID:                  uuid.New(),
+		Status:              models.DeviceStatusPending,
+		OfficerID:           officerID,
+		EnrollmentCodeHash:  &codeHash,
+		EnrollmentExpiresAt: &expires,
+	}
+}
+
+func newTestDeviceService(devices *fakeDeviceRepo, users *fakeUserRepo) *deviceService {
+	return &deviceService{deviceRepo: devices, userRepo: users, logger: zap.NewNop()}
+}
+
+func wantCode(t *testing.T, err error, code string) {
+	t.Helper()
+	var appErr *apperrors.AppError
+	if !errors.As(err, &appErr) || appErr.Code != code {
+		t.Fatalf("err = %v, want %s", err, code)
+	}
+}
+
+func TestEnrollCodeReuse(t *testing.T) {
+	device := pendingDevice(nil)
+	repo := &fakeDeviceRepo{devices: map[uuid.UUID]*models.Device{device.ID: device}}
+	s := newTestDeviceService(repo, &fakeUserRepo{})
+
+	first := &portservices.EnrollDeviceRequest{EnrollmentCode: testEnrollmentCode, DeviceID: "handset-1"}
+	if _, err := s.Enroll(context.Background(), first); err != nil {
+		t.Fatalf("first enrollment: %v", err)
+	}
+
+	// The code is consumed: a later attempt does not find it
+	second := &portservices.EnrollDeviceRequest{EnrollmentCode: testEnrollmentCode, DeviceID: "handset-2"}
+	_, err := s.Enroll(context.Background(), second)
+	wantCode(t, err, apperrors.CodeUnauthorized)
+
+	if got := *repo.devices[device.ID].DeviceID; got != "handset-1" {
+		t.Errorf("device bound to %s, want handset-1", got)
+	}
+}
+
+func TestEnrollCodeReuseConcurrent(t *testing.T) {
+	device := pendingDevice(nil)
+	repo := &fakeDeviceRepo{
+		devices: map[uuid.UUID]*models.Device{device.ID: device},
+		// Both requests read the device while it was still pending
+		codeReads: map[string]models.Device{*device.EnrollmentCodeHash: *device},
+	}
+	s := newTestDeviceService(repo, &fakeUserRepo{})
+
+	if _, err := s.Enroll(context.Background(), &portservices.EnrollDeviceRequest{
+		EnrollmentCode: testEnrollmentCode, DeviceID: "handset-1",
+	}); err != nil {
+		t.Fatalf("first enrollment: %v", err)
+	}
+	_, err := s.Enroll(context.Background(), &portservices.EnrollDeviceRequest{
+		EnrollmentCode: testEnrollmentCode, DeviceID: "handset-2",
+	})
+	wantCode(t, err, apperrors.CodeConflict)
+
+	if got := *repo.devices[device.ID].DeviceID; got != "handset-1" {
+		t.Errorf("device bound to %s, want handset-1", got)
+	}
+}
+
+func TestEnrollAssignedOfficer(t *testing.T) {
+	passwordHash, err := hash.HashPassword("correct-horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	owner := &models.User{IsActive: true, PasswordHash: passwordHash,
+		Officer: &models.OfficerInfo{ID: uuid.New(), BadgeNumber: "GPS-1"}}
+	other := &models.User{IsActive: true, PasswordHash: passwordHash,
+		Officer: &models.OfficerInfo{ID: uuid.New(), BadgeNumber: "GPS-2"}}
+	inactive := &models.User{IsActive: false, PasswordHash: passwordHash,
+		Officer: &models.OfficerInfo{ID: owner.Officer.ID, BadgeNumber: "GPS-3"}}
+	users := &fakeUserRepo{users: []*models.User{owner, other, inactive}}
+
+	tests := []struct {
+		name     string
+		badge    string
+		password string
+		wantErr  string
+	}{
+		{name: "assigned officer", badge: "GPS-1", password: "correct-horse"},
+		{name: "no credentials", wantErr: apperrors.CodeValidation},
+		{name: "wrong password", badge: "GPS-1", password: "guess", wantErr: apperrors.CodeInvalidCreds},
+		{name: "unknown badge", badge: "GPS-9", password: "correct-horse", wantErr: apperrors.CodeInvalidCreds},
+		{name: "inactive account", badge: "GPS-3", password: "correct-horse", wantErr: apperrors.CodeInvalidCreds},
+		{name: "another officer", badge: "GPS-2", password: "correct-horse", wantErr: apperrors.CodeForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			device := pendingDevice(&owner.Officer.ID)
+			repo := &fakeDeviceRepo{devices: map[uuid.UUID]*models.Device{device.ID: device}}
+			s := newTestDeviceService(repo, users)
+
+			_, err := s.Enroll(context.Background(), &portservices.EnrollDeviceRequest{
+				EnrollmentCode: testEnrollmentCode, DeviceID: "handset-1",
+				BadgeNumber: tt.badge, Password: tt.password,
+			})
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			wantCode(t, err, tt.wantErr)
+			if repo.devices[device.ID].Status != models.DeviceStatusPending {
+				t.Error("device left pending state after a refused enrollment")
+			}
+		})
+	}
+}
@@ -0,0 +1,526 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"time"
+
+	apperrors "github.com/ghana-police/ticketing-backend/internal/domain/errors"
+	"github.com/ghana-police/ticketing-backend/internal/domain/models"
+	"github.com/ghana-police/ticketing-backend/internal/middleware"
+	"github.com/ghana-police/ticketing-backend/internal/ports/repositories"
+	portservices "github.com/ghana-police/ticketing-backend/internal/ports/services"
+	"github.com/ghana-police/ticketing-backend/pkg/pagination"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// The fakes embed the interface they stand in for, so a test that reaches a
+// method the fake does not implement panics rather than passing silently.
+
+type fakeTicketRepo struct {
+	repositories.TicketRepository
+	tickets  map[uuid.UUID]*models.TicketResponse
+	statuses []string // statuses written by UpdateStatus
+	balance  float64  // receivable of every ticket; negative when overpaid
+}
+
+func (f *fakeTicketRepo) GetByID(_ context.Context, id uuid.UUID) (*models.TicketResponse, error) {
+	t, ok := f.tickets[id]
+	if !ok {
+		return nil, pgx.ErrNoRows
+	}
+	return t, nil
+}
+
+func (f *fakeTicketRepo) UpdateStatus(_ context.Context, _ uuid.UUID, status string) error {
+	f.statuses = append(f.statuses, status)
+	return nil
+}
+
+func (f *fakeTicketRepo) AppendNote(context.Context, uuid.UUID, uuid.UUID, string) error {
+	return nil
+}
+
+func (f *fakeTicketRepo) SavePhoto(_ context.Context, photo *models.TicketPhoto, _ uuid.UUID, _, _, _ string, _ int) error {
+	photo.ID = uuid.New()
+	return nil
+}
+
+type fakeStorage struct {
+	portservices.StorageService
+}
+
+func (fakeStorage) SaveFile(_ []byte, dir, filename string) (string, error) {
+	return dir + "/" + filename, nil
+}
+
+func (fakeStorage) FileURL(storagePath string) string {
+	return "/files/" + storagePath
+}
+
+// place is where a scoped row sits in the hierarchy.
+type place struct {
+	station, district, region uuid.UUID
+}
+
+// fakeJurisdictions answers Contains from the places of known rows, the way
+// jurisdictionCondition does in SQL.
+type fakeJurisdictions struct {
+	repositories.JurisdictionRepository
+	places    map[uuid.UUID]place
+	districts map[uuid.UUID]uuid.UUID // station → district
+}
+
+func (f *fakeJurisdictions) Contains(_ context.Context, _ string, id uuid.UUID, j models.Jurisdiction) (bool, error) {
+	p, ok := f.places[id]
+	if !ok {
+		return false, nil
+	}
+	switch j.Level {
+	case models.ScopeNational:
+		return true, nil
+	case models.ScopeStation:
+		return p.station == *j.StationID, nil
+	case models.ScopeDistrict:
+		return p.district == f.districts[*j.StationID], nil
+	case models.ScopeRegion:
+		return p.region == *j.RegionID, nil
+	}
+	return false, nil
+}
+
+type fakeTicketService struct {
+	portservices.TicketService
+	updates []string // statuses set through Update
+}
+
+func (f *fakeTicketService) Update(_ context.Context, _ uuid.UUID, req *portservices.UpdateTicketRequest) (*models.TicketResponse, error) {
+	f.updates = append(f.updates, *req.Status)
+	return &models.TicketResponse{}, nil
+}
+
+// fakePermissions grants permissions by role.
+type fakePermissions struct {
+	portservices.PermissionService
+	roles map[string][]string
+}
+
+func (f *fakePermissions) Has(_ context.Context, _ uuid.UUID, role, permission string) (bool, error) {
+	for _, p := range f.roles[role] {
+		if p == permission {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// callerCtx is the context of an authenticated caller.
+func callerCtx(role string, stationID, regionID *uuid.UUID) context.Context {
+	ctx := context.WithValue(context.Background(), middleware.UserIDKey, uuid.New())
+	ctx = context.WithValue(ctx, middleware.UserRoleKey, role)
+	if stationID != nil {
+		ctx = context.WithValue(ctx, middleware.StationIDKey, *stationID)
+	}
+	if regionID != nil {
+		ctx = context.WithValue(ctx, middleware.RegionIDKey, *regionID)
+	}
+	return ctx
+}
+
+// fakeDeviceRepo keeps devices in memory. Enroll applies the same guard as
+// the SQL: the device must still be pending with the given code hash.
+type fakeDeviceRepo struct {
+	repositories.DeviceRepository
+	devices map[uuid.UUID]*models.Device
+	// codeReads, when set, is what GetByEnrollmentCode sees instead of the
+	// live devices, standing in for a read taken before a concurrent enrollment.
+	codeReads map[string]models.Device
+}
+
+func (f *fakeDeviceRepo) GetByID(_ context.Context, id uuid.UUID) (*models.Device, error) {
+	d, ok := f.devices[id]
+	if !ok {
+		return nil, pgx.ErrNoRows
+	}
+	cp := *d
+	return &cp, nil
+}
+
+func (f *fakeDeviceRepo) GetByDeviceID(_ context.Context, deviceID string) (*models.Device, error) {
+	for _, d := range f.devices {
+		if d.DeviceID != nil && *d.DeviceID == deviceID {
+			cp := *d
+			return &cp, nil
+		}
+	}
+	return nil, pgx.ErrNoRows
+}
+
+func (f *fakeDeviceRepo) GetByEnrollmentCode(_ context.Context, codeHash string) (*models.Device, error) {
+	if f.codeReads != nil {
+		if d, ok := f.codeReads[codeHash]; ok {
+			return &d, nil
+		}
+		return nil, pgx.ErrNoRows
+	}
+	for _, d := range f.devices {
+		if d.Status == models.DeviceStatusPending && d.EnrollmentCodeHash != nil && *d.EnrollmentCodeHash == codeHash {
+			cp := *d
+			return &cp, nil
+		}
+	}
+	return nil, pgx.ErrNoRows
+}
+
+func (f *fakeDeviceRepo) Enroll(_ context.Context, id uuid.UUID, codeHash, deviceID string, _, _, _ *string, keyHash string) error {
+	d, ok := f.devices[id]
+	if !ok || d.Status != models.DeviceStatusPending || d.EnrollmentCodeHash == nil || *d.EnrollmentCodeHash != codeHash {
+		return pgx.ErrNoRows
+	}
+	d.Status = models.DeviceStatusActive
+	d.DeviceID = &deviceID
+	d.KeyHash = &keyHash
+	d.EnrollmentCodeHash = nil
+	return nil
+}
+
+type fakeUserRepo struct {
+	repositories.UserRepository
+	users           []*models.User
+	refreshTokens   map[string]*models.RefreshToken // by token hash
+	revokedFamilies []uuid.UUID
+}
+
+func (f *fakeUserRepo) FindByID(_ context.Context, id uuid.UUID) (*models.User, error) {
+	for _, u := range f.users {
+		if u.ID == id {
+			return u, nil
+		}
+	}
+	return nil, pgx.ErrNoRows
+}
+
+func (f *fakeUserRepo) FindRefreshTokenByHash(_ context.Context, tokenHash string) (*models.RefreshToken, error) {
+	rt, ok := f.refreshTokens[tokenHash]
+	if !ok {
+		return nil, pgx.ErrNoRows
+	}
+	return rt, nil
+}
+
+func (f *fakeUserRepo) RotateRefreshToken(_ context.Context, currentID uuid.UUID, next *models.RefreshToken) error {
+	for _, rt := range f.refreshTokens {
+		if rt.ID == currentID {
+			if rt.RevokedAt != nil {
+				return pgx.ErrNoRows
+			}
+			now := time.Now()
+			rt.RevokedAt, rt.ReplacedBy = &now, &next.ID
+		}
+	}
+	f.refreshTokens[next.TokenHash] = next
+	return nil
+}
+
+func (f *fakeUserRepo) RevokeTokenFamily(_ context.Context, familyID uuid.UUID) error {
+	f.revokedFamilies = append(f.revokedFamilies, familyID)
+	return nil
+}
+
+func (f *fakeUserRepo) FindByBadgeNumber(_ context.Context, badgeNumber string) (*models.User, error) {
+	for _, u := range f.users {
+		if u.Officer != nil && u.Officer.BadgeNumber == badgeNumber {
+			return u, nil
+		}
+	}
+	return nil, pgx.ErrNoRows
+}
+
+// fakeSettings returns sections by name; err, when set, fails every lookup.
+type fakeSettings struct {
+	repositories.SettingsRepository
+	sections map[string]string
+	err      error
+}
+
+func (f *fakeSettings) GetBySection(_ context.Context, section string) (json.RawMessage, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	raw, ok := f.sections[section]
+	if !ok {
+		return nil, pgx.ErrNoRows
+	}
+	return json.RawMessage(raw), nil
+}
+
+// fakeDevices checks device bindings against known keys.
+type fakeDevices struct {
+	portservices.DeviceService
+	keys map[uuid.UUID]string // device → key
+}
+
+func (f *fakeDevices) VerifyBinding(_ context.Context, deviceRef uuid.UUID, deviceKey string) error {
+	if key, ok := f.keys[deviceRef]; !ok || key != deviceKey {
+		return apperrors.NewUnauthorized("Refresh token is bound to another device")
+	}
+	return nil
+}
+
+type fakePasswords struct {
+	portservices.PasswordPolicyService
+}
+
+func (fakePasswords) MustChange(context.Context, *models.User) bool { return false }
+
+type fakeAudit struct {
+	portservices.AuditService
+	entries []*portservices.AuditEntry
+}
+
+func (f *fakeAudit) Log(_ context.Context, entry *portservices.AuditEntry) {
+	f.entries = append(f.entries, entry)
+}
+
+// fakeLedger records posted journals.
+type fakeLedger struct {
+	repositories.LedgerRepository
+	journals []*models.LedgerJournal
+}
+
+func (f *fakeLedger) Post(_ context.Context, j *models.LedgerJournal) error {
+	f.journals = append(f.journals, j)
+	return nil
+}
+
+// fakeUOW runs fn directly and tracks whether a unit of work is open.
+type fakeUOW struct {
+	inTx bool
+}
+
+func (u *fakeUOW) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	u.inTx = true
+	defer func() { u.inTx = false }()
+	return fn(ctx)
+}
+
+func (f *fakeTicketRepo) LockForUpdate(_ context.Context, id uuid.UUID) (string, float64, error) {
+	t, ok := f.tickets[id]
+	if !ok {
+		return "", 0, pgx.ErrNoRows
+	}
+	return t.Status, t.TotalFine, nil
+}
+
+type fakePaymentRepo struct {
+	repositories.PaymentRepository
+	payments map[uuid.UUID]*models.Payment
+}
+
+func (f *fakePaymentRepo) Create(_ context.Context, p *models.Payment) error {
+	p.ID = uuid.New()
+	cp := *p
+	f.payments[p.ID] = &cp
+	return nil
+}
+
+func (f *fakePaymentRepo) GetByID(_ context.Context, id uuid.UUID) (*models.Payment, error) {
+	p, ok := f.payments[id]
+	if !ok {
+		return nil, pgx.ErrNoRows
+	}
+	cp := *p
+	return &cp, nil
+}
+
+func (f *fakePaymentRepo) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*models.Payment, error) {
+	return f.GetByID(ctx, id)
+}
+
+func (f *fakePaymentRepo) HasPending(_ context.Context, ticketID uuid.UUID) (bool, error) {
+	for _, p := range f.payments {
+		if p.TicketID == ticketID && (p.Status == "pending" || p.Status == "processing") {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (f *fakePaymentRepo) UpdateStatus(_ context.Context, id uuid.UUID, status string, transactionID, statusMessage, _ *string) error {
+	p := f.payments[id]
+	p.Status = status
+	if transactionID != nil {
+		p.TransactionID = transactionID
+	}
+	if statusMessage != nil {
+		p.StatusMessage = statusMessage
+	}
+	return nil
+}
+
+func (f *fakePaymentRepo) SetProviderRef(_ context.Context, id uuid.UUID, transactionID string, network *string, status string) error {
+	p := f.payments[id]
+	if p.Status != "pending" || p.TransactionID != nil {
+		return pgx.ErrNoRows
+	}
+	p.TransactionID, p.Network, p.Status = &transactionID, network, status
+	return nil
+}
+
+// fakeProvider handles "momo" and records whether it was called inside a
+// unit of work.
+type fakeProvider struct {
+	uow           *fakeUOW
+	initiateErr   error
+	verify        *portservices.ProviderVerifyResult
+	initiatedInTx bool
+	verified      int
+}
+
+func (p *fakeProvider) Name() string               { return "fake" }
+func (p *fakeProvider) SupportedMethods() []string { return []string{"momo"} }
+
+func (p *fakeProvider) Initiate(context.Context, *portservices.ProviderInitiateRequest) (*portservices.ProviderInitiateResult, error) {
+	p.initiatedInTx = p.uow.inTx
+	if p.initiateErr != nil {
+		return nil, p.initiateErr
+	}
+	return &portservices.ProviderInitiateResult{ProviderRef: "ref-1", Status: "pending", Network: "MTN"}, nil
+}
+
+func (p *fakeProvider) Verify(context.Context, string) (*portservices.ProviderVerifyResult, error) {
+	p.verified++
+	return p.verify, nil
+}
+
+func (f *fakeDevices) Authorize(context.Context, string, string, bool) (*models.Device, error) {
+	return nil, nil
+}
+
+// fakeSyncRepo serves a fixed change feed and device health list and keeps
+// recorded sessions.
+type fakeSyncRepo struct {
+	repositories.SyncRepository
+	changes  []models.ServerTicketUpdate
+	health   []models.DeviceHealth
+	sessions []*models.SyncSession
+}
+
+func (f *fakeSyncRepo) GetTicketChanges(_ context.Context, after models.ChangeCursor, _, _ *uuid.UUID, limit int) ([]models.ServerTicketUpdate, error) {
+	var page []models.ServerTicketUpdate
+	for _, c := range f.changes {
+		if c.UpdatedAt.After(after.UpdatedAt) || (c.UpdatedAt.Equal(after.UpdatedAt) && bytes.Compare(c.ID[:], after.ID[:]) > 0) {
+			page = append(page, c)
+		}
+	}
+	if len(page) > limit {
+		page = page[:limit]
+	}
+	return page, nil
+}
+
+func (f *fakeSyncRepo) UpsertDeviceSync(context.Context, uuid.UUID, string, time.Time, int) error {
+	return nil
+}
+
+func (f *fakeSyncRepo) RecordSession(_ context.Context, session *models.SyncSession) error {
+	f.sessions = append(f.sessions, session)
+	return nil
+}
+
+func (f *fakeSyncRepo) ListDeviceHealth(_ context.Context, _ models.DeviceHealthFilter, _ models.DeviceHealthThresholds, p pagination.Params) ([]models.DeviceHealth, int, error) {
+	items := f.health
+	if len(items) > p.Limit {
+		items = items[:p.Limit]
+	}
+	return items, len(f.health), nil
+}
+
+func (f *fakeTicketRepo) Receivable(context.Context, uuid.UUID) (float64, error) {
+	return f.balance, nil
+}
+
+func (f *fakePaymentRepo) Refund(_ context.Context, id uuid.UUID, reason string, _ float64) error {
+	p := f.payments[id]
+	p.Status, p.StatusMessage = "refunded", &reason
+	return nil
+}
+
+type fakeAllocations struct {
+	repositories.AllocationRepository
+}
+
+func (fakeAllocations) ReverseForPayment(context.Context, uuid.UUID, string) error { return nil }
+
+// fakeInstallments records payments taken back off installment plans.
+type fakeInstallments struct {
+	repositories.InstallmentRepository
+	reversed []float64
+	inTx     []bool
+	uow      *fakeUOW
+}
+
+func (f *fakeInstallments) ReversePayment(_ context.Context, _ uuid.UUID, _ time.Time, amount float64) error {
+	f.reversed = append(f.reversed, amount)
+	f.inTx = append(f.inTx, f.uow.inTx)
+	return nil
+}
+
+// fakeOfficerRepo keeps officers in memory by ID.
+type fakeOfficerRepo struct {
+	repositories.OfficerRepository
+	officers map[uuid.UUID]*models.OfficerResponse
+}
+
+func (f *fakeOfficerRepo) GetByID(_ context.Context, id uuid.UUID) (*models.OfficerResponse, error) {
+	o, ok := f.officers[id]
+	if !ok {
+		return nil, pgx.ErrNoRows
+	}
+	cp := *o
+	return &cp, nil
+}
+
+func (f *fakeOfficerRepo) BadgeNumberExists(context.Context, string, *uuid.UUID) (bool, error) {
+	return false, nil
+}
+
+func (f *fakeOfficerRepo) Create(_ context.Context, user *models.User, officer *models.Officer) error {
+	officer.ID, user.ID = uuid.New(), uuid.New()
+	f.officers[officer.ID] = &models.OfficerResponse{ID: officer.ID, UserID: user.ID, StationID: officer.StationID, RegionID: officer.RegionID}
+	return nil
+}
+
+func (f *fakeOfficerRepo) Update(_ context.Context, _ *models.User, officer *models.Officer) error {
+	o := f.officers[officer.ID]
+	o.StationID, o.RegionID = officer.StationID, officer.RegionID
+	return nil
+}
+
+// fakeHierarchy knows stations by ID.
+type fakeHierarchy struct {
+	repositories.HierarchyRepository
+	stations map[uuid.UUID]*models.Station
+}
+
+func (f *fakeHierarchy) GetStationByID(_ context.Context, id uuid.UUID) (*models.Station, error) {
+	st, ok := f.stations[id]
+	if !ok {
+		return nil, pgx.ErrNoRows
+	}
+	return st, nil
+}
+
+type fakeRevocations struct {
+	portservices.TokenRevocationService
+	revoked []uuid.UUID
+}
+
+func (f *fakeRevocations) RevokeUser(_ context.Context, userID uuid.UUID, _ string) error {
+	f.revoked = append(f.revoked, userID)
+	return nil
+}
+
+func (fakePasswords) Policy(context.Context) models.PasswordPolicy { return models.PasswordPolicy{} }
@@ -0,0 +1,70 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	apperrors "github.com/ghana-police/ticketing-backend/internal/domain/errors"
+	"github.com/ghana-police/ticketing-backend/internal/domain/models"
+	"github.com/ghana-police/ticketing-backend/internal/ports/repositories"
+	portservices "github.com/ghana-police/ticketing-backend/internal/ports/services"
+	"go.uber.org/zap"
+)
+
+// idempotencyLockTTL is how long a key stays claimed by a request that has not
+// finished. It outlives the server's write timeout, and frees the key if the
+// process dies mid-request.
+const idempotencyLockTTL = time.Minute
+
+type idempotencyService struct {
+	repo   repositories.IdempotencyRepository
+	ttl    time.Duration
+	logger *zap.Logger
+}
+
+func NewIdempotencyService(
+	repo repositories.IdempotencyRepository,
+	ttl time.Duration,
+	logger *zap.Logger,
+) portservices.IdempotencyService {
+	return &idempotencyService{
+		repo:   repo,
+		ttl:    ttl,
+		logger: logger,
+	}
+}
+
+func (s *idempotencyService) Begin(ctx context.Context, key, fingerprint string) (*models.IdempotencyRecord, error) {
+	existing, reserved, err := s.repo.Reserve(ctx, key, &models.IdempotencyRecord{
+		Fingerprint: fingerprint,
+		CreatedAt:   time.Now().UTC(),
+	}, idempotencyLockTTL)
+	if err != nil {
+		// Without the store the request runs unprotected rather than failing
+		s.logger.Warn("idempotency key check failed", zap.Error(err))
+		return nil, nil
+	}
+	if reserved {
+		return nil, nil
+	}
+
+	switch {
+	case existing == nil || !existing.Completed():
+		return nil, apperrors.NewConflict("A request with this Idempotency-Key is still being processed")
+	case existing.Fingerprint != fingerprint:
+		return nil, apperrors.NewConflict("Idempotency-Key has already been used for a different request")
+	}
+	return existing, nil
+}
+
+func (s *idempotencyService) Complete(ctx context.Context, key string, record *models.IdempotencyRecord) {
+	if err := s.repo.Save(ctx, key, record, s.ttl); err != nil {
+		s.logger.Warn("failed to store idempotent response", zap.Error(err))
+	}
+}
+
+func (s *idempotencyService) Release(ctx context.Context, key string) {
+	if err := s.repo.Delete(ctx, key); err != nil {
+		s.logger.Warn("failed to release idempotency key", zap.Error(err))
+	}
+}
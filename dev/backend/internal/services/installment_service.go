@@ -0,0 +1,235 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	apperrors "github.com/ghana-police/ticketing-backend/internal/domain/errors"
+	"github.com/ghana-police/ticketing-backend/internal/domain/models"
+	"github.com/ghana-police/ticketing-backend/internal/middleware"
+	"github.com/ghana-police/ticketing-backend/internal/ports/repositories"
+	portservices "github.com/ghana-police/ticketing-backend/internal/ports/services"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+const (
+	minInstallments            = 2
+	maxInstallments            = 12
+	defaultInstallmentInterval = 30 // days
+	maxInstallmentInterval     = 365
+)
+
+type installmentService struct {
+	uow           repositories.UnitOfWork
+	repo          repositories.InstallmentRepository
+	ticketRepo    repositories.TicketRepository
+	jurisdictions repositories.JurisdictionRepository
+	logger        *zap.Logger
+}
+
+func NewInstallmentService(
+	uow repositories.UnitOfWork,
+	repo repositories.InstallmentRepository,
+	ticketRepo repositories.TicketRepository,
+	jurisdictions repositories.JurisdictionRepository,
+	logger *zap.Logger,
+) portservices.InstallmentService {
+	return &installmentService{
+		uow:           uow,
+		repo:          repo,
+		ticketRepo:    ticketRepo,
+		jurisdictions: jurisdictions,
+		logger:        logger,
+	}
+}
+
+func (s *installmentService) CreatePlan(ctx context.Context, ticketID uuid.UUID, req *portservices.CreateInstallmentPlanRequest) (*models.InstallmentPlan, error) {
+	if err := requireInJurisdiction(ctx, s.jurisdictions, models.EntityTicket, ticketID, "Ticket"); err != nil {
+		return nil, err
+	}
+
+	userID := middleware.GetUserID(ctx)
+	var plan *models.InstallmentPlan
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		// Payments take the same lock, so the balance cannot move under us
+		status, outstanding, err := s.ticketRepo.LockForUpdate(ctx, ticketID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return apperrors.NewNotFound("Ticket")
+			}
+			return err
+		}
+		if status != "unpaid" && status != "overdue" {
+			return apperrors.NewValidationError("Installment plans are only for unpaid or overdue tickets (status: "+status+")", nil)
+		}
+		if outstanding < 0.005 {
+			return apperrors.NewValidationError("Ticket has no outstanding balance", nil)
+		}
+
+		if _, err := s.repo.GetActiveByTicket(ctx, ticketID); err == nil {
+			return apperrors.NewConflict("Ticket already has an active installment plan")
+		} else if !errors.Is(err, pgx.ErrNoRows) {
+			return err
+		}
+
+		schedule, err := buildSchedule(req, outstanding, time.Now())
+		if err != nil {
+			return err
+		}
+
+		plan = &models.InstallmentPlan{
+			TicketID:     ticketID,
+			TotalAmount:  outstanding,
+			Status:       models.InstallmentPlanActive,
+			Notes:        req.Notes,
+			CreatedByID:  &userID,
+			Installments: schedule,
+		}
+		return s.repo.Create(ctx, plan)
+	})
+	if err != nil {
+		return nil, asAppError(err)
+	}
+
+	s.logger.Info("installment plan created",
+		zap.String("ticket_id", ticketID.String()),
+		zap.Int("installments", len(plan.Installments)),
+		zap.Float64("total", plan.TotalAmount))
+	return s.GetPlan(ctx, ticketID)
+}
+
+func (s *installmentService) GetPlan(ctx context.Context, ticketID uuid.UUID) (*models.InstallmentPlan, error) {
+	if err := requireInJurisdiction(ctx, s.jurisdictions, models.EntityTicket, ticketID, "Ticket"); err != nil {
+		return nil, err
+	}
+	plan, err := s.repo.GetLatestByTicket(ctx, ticketID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NewNotFound("Installment plan")
+		}
+		return nil, apperrors.NewInternal(err)
+	}
+	return plan, nil
+}
+
+func (s *installmentService) CancelPlan(ctx context.Context, ticketID uuid.UUID, req *portservices.CancelInstallmentPlanRequest) (*models.InstallmentPlan, error) {
+	if err := requireInJurisdiction(ctx, s.jurisdictions, models.EntityTicket, ticketID, "Ticket"); err != nil {
+		return nil, err
+	}
+
+	userID := middleware.GetUserID(ctx)
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		if _, _, err := s.ticketRepo.LockForUpdate(ctx, ticketID); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return apperrors.NewNotFound("Ticket")
+			}
+			return err
+		}
+		plan, err := s.repo.GetActiveByTicket(ctx, ticketID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return apperrors.NewNotFound("Active installment plan")
+			}
+			return err
+		}
+		return s.repo.Cancel(ctx, plan.ID, userID, req.Reason)
+	})
+	if err != nil {
+		return nil, asAppError(err)
+	}
+	return s.GetPlan(ctx, ticketID)
+}
+
+// buildSchedule validates an explicit schedule or splits total into equal
+// installments, the last one taking the rounding remainder.
+func buildSchedule(req *portservices.CreateInstallmentPlanRequest, total float64, now time.Time) ([]models.Installment, error) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	if len(req.Installments) > 0 {
+		if req.Count != 0 {
+			return nil, apperrors.NewValidationError("Give either installments or count, not both", nil)
+		}
+		n := len(req.Installments)
+		if n < minInstallments || n > maxInstallments {
+			return nil, apperrors.NewValidationError(fmt.Sprintf("A plan has %d to %d installments", minInstallments, maxInstallments),
+				map[string][]string{"installments": {fmt.Sprintf("must have %d to %d entries", minInstallments, maxInstallments)}})
+		}
+
+		schedule := make([]models.Installment, n)
+		details := map[string][]string{}
+		var sum float64
+		for i, in := range req.Installments {
+			field := fmt.Sprintf("installments[%d]", i)
+			due, err := time.Parse("2006-01-02", in.DueDate)
+			switch {
+			case err != nil:
+				details[field+".dueDate"] = []string{"must be a date (YYYY-MM-DD)"}
+			case due.Before(today):
+				details[field+".dueDate"] = []string{"must not be in the past"}
+			case i > 0 && !due.After(schedule[i-1].DueDate):
+				details[field+".dueDate"] = []string{"must be after the previous installment"}
+			}
+			amount := math.Round(in.Amount*100) / 100
+			if amount <= 0 {
+				details[field+".amount"] = []string{"must be greater than 0"}
+			}
+			sum += amount
+			schedule[i] = models.Installment{Sequence: i + 1, DueDate: due, Amount: amount}
+		}
+		if len(details) > 0 {
+			return nil, apperrors.NewValidationError("Invalid installment schedule", details)
+		}
+		if math.Abs(sum-total) >= 0.005 {
+			return nil, apperrors.NewValidationError(fmt.Sprintf("Installments add up to GHS %.2f but GHS %.2f is outstanding", sum, total),
+				map[string][]string{"installments": {fmt.Sprintf("amounts must add up to %.2f", total)}})
+		}
+		return schedule, nil
+	}
+
+	if req.Count < minInstallments || req.Count > maxInstallments {
+		return nil, apperrors.NewValidationError(fmt.Sprintf("A plan has %d to %d installments", minInstallments, maxInstallments),
+			map[string][]string{"count": {fmt.Sprintf("must be between %d and %d", minInstallments, maxInstallments)}})
+	}
+	interval := req.IntervalDays
+	if interval == 0 {
+		interval = defaultInstallmentInterval
+	}
+	if interval < 1 || interval > maxInstallmentInterval {
+		return nil, apperrors.NewValidationError("Invalid installment interval",
+			map[string][]string{"intervalDays": {fmt.Sprintf("must be between 1 and %d", maxInstallmentInterval)}})
+	}
+	first := today.AddDate(0, 0, interval)
+	if req.FirstDueDate != nil {
+		d, err := time.Parse("2006-01-02", *req.FirstDueDate)
+		if err != nil || d.Before(today) {
+			return nil, apperrors.NewValidationError("Invalid first due date",
+				map[string][]string{"firstDueDate": {"must be a date (YYYY-MM-DD), today or later"}})
+		}
+		first = d
+	}
+
+	// Work in pesewas so the parts add up exactly
+	totalMinor := int64(math.Round(total * 100))
+	part := totalMinor / int64(req.Count)
+	schedule := make([]models.Installment, req.Count)
+	for i := range schedule {
+		amount := part
+		if i == req.Count-1 {
+			amount = totalMinor - part*int64(req.Count-1)
+		}
+		schedule[i] = models.Installment{
+			Sequence: i + 1,
+			DueDate:  first.AddDate(0, 0, i*interval),
+			Amount:   float64(amount) / 100,
+		}
+	}
+	if part == 0 {
+		return nil, apperrors.NewValidationError("Balance is too small to split into that many installments", nil)
+	}
+	return schedule, nil
+}
@@ -0,0 +1,35 @@
+package services
+
+import (
+	"context"
+
+	apperrors "github.com/ghana-police/ticketing-backend/internal/domain/errors"
+	"github.com/ghana-police/ticketing-backend/internal/domain/models"
+	"github.com/ghana-police/ticketing-backend/internal/middleware"
+	"github.com/ghana-police/ticketing-backend/internal/ports/repositories"
+	"github.com/google/uuid"
+)
+
+// callerScope returns the jurisdiction of the authenticated caller, for use
+// as a filter's Scope.
+func callerScope(ctx context.Context) *models.Jurisdiction {
+	j := models.JurisdictionFor(
+		middleware.GetUserRole(ctx),
+		middleware.GetStationID(ctx),
+		middleware.GetRegionID(ctx),
+	)
+	return &j
+}
+
+// requireInJurisdiction returns a not-found error for name when the entity
+// lies outside the caller's jurisdiction, so its existence is not revealed.
+func requireInJurisdiction(ctx context.Context, jurisdictions repositories.JurisdictionRepository, entity string, id uuid.UUID, name string) error {
+	ok, err := jurisdictions.Contains(ctx, entity, id, *callerScope(ctx))
+	if err != nil {
+		return apperrors.NewInternal(err)
+	}
+	if !ok {
+		return apperrors.NewNotFound(name)
+	}
+	return nil
+}
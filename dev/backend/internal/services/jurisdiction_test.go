@@ -0,0 +1,85 @@
+package services
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/ghana-police/ticketing-backend/internal/domain/models"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// TestSyncJurisdiction checks that sync only touches tickets within the
+// caller's jurisdiction, for each role and each level of the hierarchy.
+func TestSyncJurisdiction(t *testing.T) {
+	region, otherRegion := uuid.New(), uuid.New()
+	district, otherDistrict := uuid.New(), uuid.New()
+	station, neighbour, farStation, outside := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+
+	// One ticket at the caller's station, one at another station in the same
+	// district, one in another district of the region and one in another region.
+	atStation, inDistrict, inRegion, elsewhere := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	jurisdictions := &fakeJurisdictions{
+		places: map[uuid.UUID]place{
+			atStation:  {station, district, region},
+			inDistrict: {neighbour, district, region},
+			inRegion:   {farStation, otherDistrict, region},
+			elsewhere:  {outside, uuid.New(), otherRegion},
+		},
+		districts: map[uuid.UUID]uuid.UUID{station: district, neighbour: district, farStation: otherDistrict},
+	}
+
+	tests := []struct {
+		role      string
+		stationID *uuid.UUID
+		regionID  *uuid.UUID
+		visible   []uuid.UUID
+	}{
+		{role: "officer", stationID: &station, regionID: &region, visible: []uuid.UUID{atStation}},
+		{role: "supervisor", stationID: &station, regionID: &region, visible: []uuid.UUID{atStation, inDistrict}},
+		{role: "admin", regionID: &region, visible: []uuid.UUID{atStation, inDistrict, inRegion}},
+		{role: "accountant", regionID: &region, visible: []uuid.UUID{atStation, inDistrict, inRegion}},
+		{role: "super_admin", visible: []uuid.UUID{atStation, inDistrict, inRegion, elsewhere}},
+		{role: "officer", regionID: &region}, // no station assigned
+		{role: "unknown", stationID: &station, regionID: &region},
+	}
+
+	targets := []struct {
+		name string
+		id   uuid.UUID
+	}{
+		{"at station", atStation}, {"in district", inDistrict}, {"in region", inRegion}, {"elsewhere", elsewhere},
+	}
+
+	photo := base64.StdEncoding.EncodeToString([]byte("jpeg"))
+	for _, tt := range tests {
+		ctx := callerCtx(tt.role, tt.stationID, tt.regionID)
+		for _, target := range targets {
+			id := target.id
+			want := "error"
+			if slices.Contains(tt.visible, id) {
+				want = "success"
+			}
+
+			repo := &fakeTicketRepo{tickets: map[uuid.UUID]*models.TicketResponse{
+				id: {ID: id, Status: "unpaid"},
+			}}
+			s := &syncService{ticketRepo: repo, jurisdictions: jurisdictions, storage: fakeStorage{}, logger: zap.NewNop()}
+
+			objection := "objection"
+			data, _ := json.Marshal(models.SyncTicketUpdateData{ID: id, Status: &objection})
+			item := models.SyncTicketItem{ID: "local-1", Action: "update", Data: data, Timestamp: time.Now()}
+			if got := s.processTicketUpdate(ctx, item, "server_wins", ""); got.Status != want {
+				t.Errorf("%s: update ticket %s = %s, want %s", tt.role, target.name, got.Status, want)
+			}
+
+			photoItem := models.SyncPhotoItem{TicketID: id.String(), PhotoID: "photo-1", Data: photo, Type: "evidence"}
+			if got := s.processPhotoItem(ctx, photoItem, nil); got.Status != want {
+				t.Errorf("%s: photo for ticket %s = %s, want %s", tt.role, target.name, got.Status, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/ghana-police/ticketing-backend/internal/domain/models"
+	"github.com/ghana-police/ticketing-backend/internal/middleware"
+	"github.com/ghana-police/ticketing-backend/internal/ports/repositories"
+	"github.com/google/uuid"
+)
+
+// postJournal appends a journal to the revenue ledger, dropping empty
+// entries. A journal that does not balance is a bug and is refused.
+func postJournal(ctx context.Context, ledger repositories.LedgerRepository, j *models.LedgerJournal) error {
+	j.Entries = slices.DeleteFunc(j.Entries, func(e models.LedgerEntry) bool {
+		return e.Debit < 0.005 && e.Credit < 0.005
+	})
+	if !j.Balanced() {
+		return fmt.Errorf("ledger journal %s for ticket %s does not balance", j.Kind, j.TicketID)
+	}
+	if j.PostedByID == nil {
+		if userID := middleware.GetUserID(ctx); userID != uuid.Nil {
+			j.PostedByID = &userID
+		}
+	}
+	return ledger.Post(ctx, j)
+}
+
+// postTicketIssued books a new ticket's fine as revenue owed to the service.
+func postTicketIssued(ctx context.Context, ledger repositories.LedgerRepository, ticket *models.Ticket) error {
+	return postJournal(ctx, ledger, &models.LedgerJournal{
+		Kind:       models.JournalTicketIssued,
+		OccurredAt: ticket.IssuedAt,
+		TicketID:   ticket.ID,
+		Memo:       "Ticket " + ticket.TicketNumber + " issued",
+		Entries: []models.LedgerEntry{
+			{AccountCode: models.AccountFinesReceivable, Debit: ticket.TotalFine},
+			{AccountCode: models.AccountFineRevenue, Credit: ticket.TotalFine},
+		},
+	})
+}
+
+// trackReceivable runs fn in a unit of work under the ticket's row lock and
+// posts whatever fn changed the ticket's receivable by. A rise is a fine
+// adjustment; a fall is booked as kind, either a fine adjustment against
+// fine revenue or a waiver.
+func trackReceivable(
+	ctx context.Context,
+	uow repositories.UnitOfWork,
+	tickets repositories.TicketRepository,
+	ledger repositories.LedgerRepository,
+	ticketID uuid.UUID,
+	kind, memo string,
+	fn func(ctx context.Context) error,
+) error {
+	return uow.Do(ctx, func(ctx context.Context) error {
+		if _, _, err := tickets.LockForUpdate(ctx, ticketID); err != nil {
+			return err
+		}
+		before, err := tickets.Receivable(ctx, ticketID)
+		if err != nil {
+			return err
+		}
+		if err := fn(ctx); err != nil {
+			return err
+		}
+		after, err := tickets.Receivable(ctx, ticketID)
+		if err != nil {
+			return err
+		}
+		return postReceivableChange(ctx, ledger, ticketID, kind, memo, after-before)
+	})
+}
+
+// postReceivableChange books a change of delta in what a ticket is owed.
+func postReceivableChange(ctx context.Context, ledger repositories.LedgerRepository, ticketID uuid.UUID, kind, memo string, delta float64) error {
+	delta = math.Round(delta*100) / 100
+	if delta == 0 {
+		return nil
+	}
+
+	j := &models.LedgerJournal{Kind: kind, OccurredAt: time.Now(), TicketID: ticketID, Memo: memo}
+	switch {
+	case delta > 0:
+		j.Kind = models.JournalFineAdjusted
+		j.Entries = []models.LedgerEntry{
+			{AccountCode: models.AccountFinesReceivable, Debit: delta},
+			{AccountCode: models.AccountFineRevenue, Credit: delta},
+		}
+	case kind == models.JournalTicketWaived:
+		j.Entries = []models.LedgerEntry{
+			{AccountCode: models.AccountWaivers, Debit: -delta},
+			{AccountCode: models.AccountFinesReceivable, Credit: -delta},
+		}
+	default:
+		j.Entries = []models.LedgerEntry{
+			{AccountCode: models.AccountFineRevenue, Debit: -delta},
+			{AccountCode: models.AccountFinesReceivable, Credit: -delta},
+		}
+	}
+	return postJournal(ctx, ledger, j)
+}
+
+// postPaymentReceived books a completed payment: the cash or e-money
+// collected settles the fine, and any late fee is revenue of its own.
+func postPaymentReceived(ctx context.Context, ledger repositories.LedgerRepository, payment *models.Payment) error {
+	occurredAt := time.Now()
+	if payment.CompletedAt != nil {
+		occurredAt = *payment.CompletedAt
+	}
+	lateFee := math.Min(payment.LateFee, payment.Amount)
+	return postJournal(ctx, ledger, &models.LedgerJournal{
+		Kind:       models.JournalPaymentReceived,
+		OccurredAt: occurredAt,
+		TicketID:   payment.TicketID,
+		PaymentID:  &payment.ID,
+		Memo:       fmt.Sprintf("Payment %s received by %s", payment.PaymentReference, payment.Method),
+		Entries: []models.LedgerEntry{
+			{AccountCode: models.AccountCollections, Debit: payment.Amount},
+			{AccountCode: models.AccountFinesReceivable, Credit: math.Round((payment.Amount-lateFee)*100) / 100},
+			{AccountCode: models.AccountLateFeeRevenue, Credit: lateFee},
+		},
+	})
+}
@@ -0,0 +1,223 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	apperrors "github.com/ghana-police/ticketing-backend/internal/domain/errors"
+	"github.com/ghana-police/ticketing-backend/internal/domain/models"
+	"github.com/ghana-police/ticketing-backend/internal/middleware"
+	"github.com/ghana-police/ticketing-backend/internal/ports/repositories"
+	portservices "github.com/ghana-police/ticketing-backend/internal/ports/services"
+	"github.com/ghana-police/ticketing-backend/pkg/pagination"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+const periodLayout = "2006-01"
+
+type ledgerService struct {
+	repo          repositories.LedgerRepository
+	jurisdictions repositories.JurisdictionRepository
+	logger        *zap.Logger
+}
+
+func NewLedgerService(
+	repo repositories.LedgerRepository,
+	jurisdictions repositories.JurisdictionRepository,
+	logger *zap.Logger,
+) portservices.LedgerService {
+	return &ledgerService{
+		repo:          repo,
+		jurisdictions: jurisdictions,
+		logger:        logger,
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Accounts and journals
+// ---------------------------------------------------------------------------
+
+func (s *ledgerService) ListAccounts(ctx context.Context) ([]models.LedgerAccount, error) {
+	accounts, err := s.repo.ListAccounts(ctx)
+	if err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+	return accounts, nil
+}
+
+func (s *ledgerService) GetJournal(ctx context.Context, id uuid.UUID) (*models.LedgerJournal, error) {
+	journal, err := s.repo.GetJournal(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NewNotFound("Journal")
+		}
+		return nil, apperrors.NewInternal(err)
+	}
+	if err := requireInJurisdiction(ctx, s.jurisdictions, models.EntityLedgerJournal, id, "Journal"); err != nil {
+		return nil, err
+	}
+	return journal, nil
+}
+
+func (s *ledgerService) ListJournals(ctx context.Context, filter models.LedgerJournalFilter, p pagination.Params) ([]models.LedgerJournal, int, error) {
+	if filter.Period != nil {
+		if _, err := parsePeriod(*filter.Period); err != nil {
+			return nil, 0, err
+		}
+	}
+	filter.Scope = callerScope(ctx)
+	return s.repo.ListJournals(ctx, filter, p)
+}
+
+// ---------------------------------------------------------------------------
+// Trial balance
+// ---------------------------------------------------------------------------
+
+func (s *ledgerService) TrialBalance(ctx context.Context, req *portservices.TrialBalanceRequest) (*models.TrialBalance, error) {
+	period := req.Period
+	if period == "" {
+		period = time.Now().UTC().Format(periodLayout)
+	}
+	if _, err := parsePeriod(period); err != nil {
+		return nil, err
+	}
+	if req.GroupBy != "" && req.GroupBy != "station" && req.GroupBy != "region" {
+		return nil, apperrors.NewValidationError("groupBy must be 'station' or 'region'",
+			map[string][]string{"groupBy": {"must be station or region"}})
+	}
+
+	status := models.PeriodOpen
+	if lp, err := s.repo.GetPeriod(ctx, period); err == nil {
+		status = lp.Status
+	} else if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, apperrors.NewInternal(err)
+	}
+
+	filter := models.LedgerJournalFilter{
+		StationID: req.StationID,
+		RegionID:  req.RegionID,
+		Scope:     callerScope(ctx),
+	}
+	lines, err := s.repo.TrialBalance(ctx, period, filter, req.GroupBy)
+	if err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+
+	tb := &models.TrialBalance{Period: period, Status: status, Lines: lines}
+	if tb.Lines == nil {
+		tb.Lines = []models.TrialBalanceLine{}
+	}
+	for _, l := range tb.Lines {
+		tb.PeriodDebits += l.Debits
+		tb.PeriodCredits += l.Credits
+		tb.ClosingDebit += l.ClosingDebit
+		tb.ClosingCredit += l.ClosingCredit
+	}
+	tb.PeriodDebits = math.Round(tb.PeriodDebits*100) / 100
+	tb.PeriodCredits = math.Round(tb.PeriodCredits*100) / 100
+	tb.ClosingDebit = math.Round(tb.ClosingDebit*100) / 100
+	tb.ClosingCredit = math.Round(tb.ClosingCredit*100) / 100
+	tb.Balanced = tb.PeriodDebits == tb.PeriodCredits && tb.ClosingDebit == tb.ClosingCredit
+	if !tb.Balanced {
+		s.logger.Error("trial balance does not balance",
+			zap.String("period", period),
+			zap.Float64("closing_debit", tb.ClosingDebit),
+			zap.Float64("closing_credit", tb.ClosingCredit))
+	}
+	return tb, nil
+}
+
+// ---------------------------------------------------------------------------
+// Periods
+// ---------------------------------------------------------------------------
+
+func (s *ledgerService) ListPeriods(ctx context.Context) ([]models.LedgerPeriod, error) {
+	periods, err := s.repo.ListPeriods(ctx)
+	if err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+	return periods, nil
+}
+
+func (s *ledgerService) ClosePeriod(ctx context.Context, period string) (*models.LedgerPeriod, error) {
+	start, err := parsePeriod(period)
+	if err != nil {
+		return nil, err
+	}
+	if !start.AddDate(0, 1, 0).Before(time.Now()) {
+		return nil, apperrors.NewValidationError("Only months that have ended can be closed", nil)
+	}
+
+	periods, err := s.repo.ListPeriods(ctx)
+	if err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+	for _, lp := range periods {
+		if lp.Period == period && lp.Status == models.PeriodClosed {
+			return nil, apperrors.NewConflict("Period " + period + " is already closed")
+		}
+		if lp.Period < period && lp.Status == models.PeriodOpen {
+			return nil, apperrors.NewConflict(fmt.Sprintf("Close %s before %s", lp.Period, period))
+		}
+	}
+
+	if err := s.repo.ClosePeriod(ctx, period, middleware.GetUserID(ctx)); err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+	s.logger.Info("ledger period closed", zap.String("period", period))
+	return s.getPeriod(ctx, period)
+}
+
+func (s *ledgerService) ReopenPeriod(ctx context.Context, period string, req *portservices.ReopenPeriodRequest) (*models.LedgerPeriod, error) {
+	if _, err := parsePeriod(period); err != nil {
+		return nil, err
+	}
+	reason := strings.TrimSpace(req.Reason)
+	if len(reason) < 10 {
+		return nil, apperrors.NewValidationError("Reopen reason must be at least 10 characters",
+			map[string][]string{"reason": {"must be at least 10 characters"}})
+	}
+
+	periods, err := s.repo.ListPeriods(ctx)
+	if err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+	for _, lp := range periods {
+		if lp.Period > period && lp.Status == models.PeriodClosed {
+			return nil, apperrors.NewConflict(fmt.Sprintf("Reopen %s before %s", lp.Period, period))
+		}
+	}
+
+	if err := s.repo.ReopenPeriod(ctx, period, middleware.GetUserID(ctx), reason); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NewValidationError("Period "+period+" is not closed", nil)
+		}
+		return nil, apperrors.NewInternal(err)
+	}
+	s.logger.Info("ledger period reopened", zap.String("period", period), zap.String("reason", reason))
+	return s.getPeriod(ctx, period)
+}
+
+func (s *ledgerService) getPeriod(ctx context.Context, period string) (*models.LedgerPeriod, error) {
+	lp, err := s.repo.GetPeriod(ctx, period)
+	if err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+	return lp, nil
+}
+
+// parsePeriod returns the first instant of a YYYY-MM period, in UTC.
+func parsePeriod(period string) (time.Time, error) {
+	t, err := time.Parse(periodLayout, period)
+	if err != nil || t.Format(periodLayout) != period {
+		return time.Time{}, apperrors.NewValidationError("Period must be a month in the form YYYY-MM",
+			map[string][]string{"period": {"must be YYYY-MM"}})
+	}
+	return t, nil
+}
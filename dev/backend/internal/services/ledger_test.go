@@ -0,0 +1,55 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ghana-police/ticketing-backend/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+func TestPostPaymentReceived(t *testing.T) {
+	tests := []struct {
+		name    string
+		amount  float64
+		lateFee float64
+		want    map[string][2]float64 // account → debit, credit
+	}{
+		{name: "fine only", amount: 250, want: map[string][2]float64{
+			models.AccountCollections:     {250, 0},
+			models.AccountFinesReceivable: {0, 250},
+		}},
+		{name: "fine with late fee", amount: 250, lateFee: 20, want: map[string][2]float64{
+			models.AccountCollections:     {250, 0},
+			models.AccountFinesReceivable: {0, 230},
+			models.AccountLateFeeRevenue:  {0, 20},
+		}},
+		{name: "late fee capped at the payment", amount: 15, lateFee: 20, want: map[string][2]float64{
+			models.AccountCollections:    {15, 0},
+			models.AccountLateFeeRevenue: {0, 15},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ledger := &fakeLedger{}
+			payment := &models.Payment{ID: uuid.New(), TicketID: uuid.New(), Amount: tt.amount, LateFee: tt.lateFee, Method: "cash"}
+			if err := postPaymentReceived(context.Background(), ledger, payment); err != nil {
+				t.Fatal(err)
+			}
+
+			got := map[string][2]float64{}
+			for _, e := range ledger.journals[0].Entries {
+				got[e.AccountCode] = [2]float64{e.Debit, e.Credit}
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("entries = %v, want %v", got, tt.want)
+			}
+			for account, w := range tt.want {
+				if got[account] != w {
+					t.Errorf("%s = %v, want %v", account, got[account], w)
+				}
+			}
+		})
+	}
+}
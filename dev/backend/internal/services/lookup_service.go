@@ -2,6 +2,11 @@ package services
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
 	"time"
 
 	apperrors "github.com/ghana-police/ticketing-backend/internal/domain/errors"
@@ -20,21 +25,104 @@ func NewLookupService(repo repositories.LookupRepository, logger *zap.Logger) po
 	return &lookupService{repo: repo, logger: logger}
 }
 
-func (s *lookupService) GetLookupData(ctx context.Context, ifModifiedSince *time.Time) (*models.LookupData, bool, error) {
-	// If client sent If-Modified-Since, check whether anything changed
-	if ifModifiedSince != nil {
-		lastUpdated, err := s.repo.GetLastUpdated(ctx)
-		if err != nil {
-			return nil, false, apperrors.NewInternal(err)
-		}
-		if !lastUpdated.After(*ifModifiedSince) {
-			return nil, false, nil // not modified
+func (s *lookupService) GetLookupData(ctx context.Context, req *portservices.LookupRequest) (*models.LookupData, bool, error) {
+	versions, err := s.repo.GetVersions(ctx)
+	if err != nil {
+		return nil, false, apperrors.NewInternal(err)
+	}
+	notModified := &models.LookupData{ETag: lookupETag(versions), LastUpdated: versions.Latest()}
+
+	// If-None-Match takes precedence over If-Modified-Since
+	if req.IfNoneMatch != "" {
+		if etagMatches(req.IfNoneMatch, notModified.ETag) {
+			return notModified, false, nil
 		}
+	} else if req.IfModifiedSince != nil && !versions.Latest().Truncate(time.Second).After(*req.IfModifiedSince) {
+		return notModified, false, nil
+	}
+
+	since, err := decodeLookupCursor(req.Cursor)
+	if err != nil {
+		return nil, false, apperrors.NewValidationError("Invalid lookup cursor", nil)
+	}
+	if since != nil && since.Covers(versions) {
+		return notModified, false, nil
 	}
 
-	data, err := s.repo.GetLookupData(ctx)
+	data, err := loadLookupData(ctx, s.repo, versions, since)
 	if err != nil {
 		return nil, false, apperrors.NewInternal(err)
 	}
 	return data, true, nil
 }
+
+// ---------------------------------------------------------------------------
+// Cursor helpers (shared with the sync service)
+// ---------------------------------------------------------------------------
+
+// lookupDelta returns reference-data changes since cursor ("" for the full
+// catalogue), or nil when the device is already current.
+func lookupDelta(ctx context.Context, repo repositories.LookupRepository, cursor string) (*models.LookupData, error) {
+	versions, err := repo.GetVersions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	since, err := decodeLookupCursor(cursor)
+	if err != nil {
+		since = nil // unreadable cursor — resend the full catalogue
+	}
+	if since != nil && since.Covers(versions) {
+		return nil, nil
+	}
+	return loadLookupData(ctx, repo, versions, since)
+}
+
+// loadLookupData fetches the rows and stamps them with versions read before the
+// fetch, so a row committed mid-request is re-sent next time rather than skipped.
+func loadLookupData(ctx context.Context, repo repositories.LookupRepository, versions models.LookupVersions, since *models.LookupVersions) (*models.LookupData, error) {
+	data, err := repo.GetLookupData(ctx, since)
+	if err != nil {
+		return nil, err
+	}
+	data.Versions = versions
+	data.Cursor = encodeLookupCursor(versions)
+	data.LastUpdated = versions.Latest()
+	data.ETag = lookupETag(versions)
+	return data, nil
+}
+
+func encodeLookupCursor(v models.LookupVersions) string {
+	raw, _ := json.Marshal(v)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func decodeLookupCursor(cursor string) (*models.LookupVersions, error) {
+	if cursor == "" {
+		return nil, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, err
+	}
+	var v models.LookupVersions
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+func lookupETag(v models.LookupVersions) string {
+	sum := sha256.Sum256([]byte(encodeLookupCursor(v)))
+	return `"` + hex.EncodeToString(sum[:8]) + `"`
+}
+
+// etagMatches checks an If-None-Match header value (possibly a list or weak tags) against etag.
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
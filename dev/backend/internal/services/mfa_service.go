@@ -0,0 +1,301 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	apperrors "github.com/ghana-police/ticketing-backend/internal/domain/errors"
+	"github.com/ghana-police/ticketing-backend/internal/domain/models"
+	"github.com/ghana-police/ticketing-backend/internal/middleware"
+	"github.com/ghana-police/ticketing-backend/internal/ports/repositories"
+	portservices "github.com/ghana-police/ticketing-backend/internal/ports/services"
+	"github.com/ghana-police/ticketing-backend/pkg/hash"
+	"github.com/ghana-police/ticketing-backend/pkg/secretbox"
+	"github.com/ghana-police/ticketing-backend/pkg/totp"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+const recoveryCodeCount = 10
+
+const codeInvalidMFA = "INVALID_MFA_CODE"
+
+type mfaService struct {
+	repo         repositories.MFARepository
+	userRepo     repositories.UserRepository
+	settingsRepo repositories.SettingsRepository
+	revocations  portservices.TokenRevocationService
+	audit        portservices.AuditService
+	box          *secretbox.Box
+	issuer       string
+	logger       *zap.Logger
+}
+
+func NewMFAService(
+	repo repositories.MFARepository,
+	userRepo repositories.UserRepository,
+	settingsRepo repositories.SettingsRepository,
+	revocations portservices.TokenRevocationService,
+	audit portservices.AuditService,
+	box *secretbox.Box,
+	issuer string,
+	logger *zap.Logger,
+) portservices.MFAService {
+	return &mfaService{
+		repo:         repo,
+		userRepo:     userRepo,
+		settingsRepo: settingsRepo,
+		revocations:  revocations,
+		audit:        audit,
+		box:          box,
+		issuer:       issuer,
+		logger:       logger,
+	}
+}
+
+func errInvalidMFACode() *apperrors.AppError {
+	return &apperrors.AppError{
+		Code:       codeInvalidMFA,
+		Message:    "Invalid verification code",
+		HTTPStatus: 401,
+	}
+}
+
+func (s *mfaService) Status(ctx context.Context, userID uuid.UUID) (*models.MFAStatus, error) {
+	user, err := s.findUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	m, err := s.repo.Get(ctx, userID)
+	if err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+	status := &models.MFAStatus{
+		Enabled:    m.Enabled,
+		Required:   s.Required(ctx, user.Role),
+		EnrolledAt: m.EnrolledAt,
+	}
+	if m.Enabled {
+		if status.RecoveryCodesRemaining, err = s.repo.CountRecoveryCodes(ctx, userID); err != nil {
+			return nil, apperrors.NewInternal(err)
+		}
+	}
+	return status, nil
+}
+
+func (s *mfaService) Enroll(ctx context.Context, userID uuid.UUID) (*portservices.MFAEnrollment, error) {
+	user, err := s.findUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !slices.Contains(models.MFARoles, user.Role) {
+		return nil, apperrors.NewForbidden("Multi-factor authentication is not available for this role")
+	}
+	if user.MFAEnabled {
+		return nil, apperrors.NewConflict("Multi-factor authentication is already enabled")
+	}
+
+	secret := totp.GenerateSecret()
+	sealed, err := s.box.Seal(secret)
+	if err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+	if err := s.repo.SetPendingSecret(ctx, userID, sealed); err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+
+	return &portservices.MFAEnrollment{
+		Secret:          secret,
+		ProvisioningURI: totp.ProvisioningURI(secret, s.issuer, user.Email),
+		Issuer:          s.issuer,
+		Account:         user.Email,
+	}, nil
+}
+
+func (s *mfaService) Activate(ctx context.Context, userID uuid.UUID, code string) (*portservices.MFARecoveryCodes, error) {
+	m, err := s.repo.Get(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NewNotFound("User")
+		}
+		return nil, apperrors.NewInternal(err)
+	}
+	if m.PendingSecret == nil {
+		return nil, apperrors.NewValidationError("Start enrollment before activating", nil)
+	}
+
+	secret, err := s.box.Open(*m.PendingSecret)
+	if err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+	step, ok := totp.Validate(secret, code, time.Now())
+	if !ok {
+		return nil, errInvalidMFACode()
+	}
+
+	codes, hashes := generateRecoveryCodes()
+	if err := s.repo.Activate(ctx, userID, step, hashes); err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+	s.record(ctx, userID, "activate", "Enabled multi-factor authentication", "info")
+	return &portservices.MFARecoveryCodes{RecoveryCodes: codes}, nil
+}
+
+func (s *mfaService) Disable(ctx context.Context, userID uuid.UUID, code string) error {
+	user, err := s.findUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if s.Required(ctx, user.Role) {
+		return apperrors.NewForbidden("Multi-factor authentication is mandatory for your role")
+	}
+	if err := s.Verify(ctx, userID, code); err != nil {
+		return err
+	}
+	if err := s.repo.Disable(ctx, userID); err != nil {
+		return apperrors.NewInternal(err)
+	}
+	s.record(ctx, userID, "deactivate", "Disabled multi-factor authentication", "warning")
+	return nil
+}
+
+func (s *mfaService) RegenerateRecoveryCodes(ctx context.Context, userID uuid.UUID, code string) (*portservices.MFARecoveryCodes, error) {
+	if err := s.Verify(ctx, userID, code); err != nil {
+		return nil, err
+	}
+	codes, hashes := generateRecoveryCodes()
+	if err := s.repo.ReplaceRecoveryCodes(ctx, userID, hashes); err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+	return &portservices.MFARecoveryCodes{RecoveryCodes: codes}, nil
+}
+
+func (s *mfaService) Reset(ctx context.Context, userID uuid.UUID) error {
+	user, err := s.findUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.Disable(ctx, userID); err != nil {
+		return apperrors.NewInternal(err)
+	}
+	// Whoever held the old factor may hold a session too
+	if err := s.revocations.RevokeUser(ctx, userID, "mfa_reset"); err != nil {
+		return err
+	}
+
+	actorID := middleware.GetUserID(ctx)
+	s.audit.Log(ctx, &portservices.AuditEntry{
+		UserID:      &actorID,
+		UserRole:    middleware.GetUserRole(ctx),
+		Action:      "reset_mfa",
+		EntityType:  "user",
+		EntityID:    userID.String(),
+		EntityName:  user.FullName(),
+		Description: "Reset multi-factor authentication for " + user.Email,
+		Severity:    "warning",
+		Success:     true,
+	})
+	return nil
+}
+
+func (s *mfaService) Required(ctx context.Context, role string) bool {
+	if !slices.Contains(models.MFARoles, role) {
+		return false
+	}
+	// Fail closed: a role that may need MFA is treated as needing it when the
+	// settings cannot be read
+	raw, err := s.settingsRepo.GetBySection(ctx, "security")
+	if err != nil {
+		s.logger.Error("failed to load security settings; requiring MFA", zap.Error(err))
+		return true
+	}
+	var security struct {
+		MFARequiredRoles []string `json:"mfaRequiredRoles"`
+	}
+	if err := json.Unmarshal(raw, &security); err != nil {
+		s.logger.Error("invalid security settings; requiring MFA", zap.Error(err))
+		return true
+	}
+	return slices.Contains(security.MFARequiredRoles, role)
+}
+
+func (s *mfaService) Verify(ctx context.Context, userID uuid.UUID, code string) error {
+	m, err := s.repo.Get(ctx, userID)
+	if err != nil {
+		return apperrors.NewInternal(err)
+	}
+	if !m.Enabled || m.Secret == nil {
+		return apperrors.NewValidationError("Multi-factor authentication is not enabled", nil)
+	}
+
+	code = strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(code), " ", ""))
+	if strings.Contains(code, "-") {
+		used, err := s.repo.UseRecoveryCode(ctx, userID, hash.HashToken(code))
+		if err != nil {
+			return apperrors.NewInternal(err)
+		}
+		if !used {
+			return errInvalidMFACode()
+		}
+		s.logger.Info("mfa recovery code used", zap.String("userId", userID.String()))
+		return nil
+	}
+
+	secret, err := s.box.Open(*m.Secret)
+	if err != nil {
+		return apperrors.NewInternal(err)
+	}
+	step, ok := totp.Validate(secret, code, time.Now())
+	if !ok {
+		return errInvalidMFACode()
+	}
+	// Each code is accepted once
+	advanced, err := s.repo.AdvanceStep(ctx, userID, step)
+	if err != nil {
+		return apperrors.NewInternal(err)
+	}
+	if !advanced {
+		return errInvalidMFACode()
+	}
+	return nil
+}
+
+// record writes an audit entry for a user changing their own MFA state.
+func (s *mfaService) record(ctx context.Context, userID uuid.UUID, action, description, severity string) {
+	s.audit.Log(ctx, &portservices.AuditEntry{
+		UserID:      &userID,
+		Action:      action,
+		EntityType:  "user",
+		EntityID:    userID.String(),
+		Description: description,
+		Severity:    severity,
+		Success:     true,
+	})
+}
+
+func (s *mfaService) findUser(ctx context.Context, userID uuid.UUID) (*models.User, error) {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NewNotFound("User")
+		}
+		return nil, apperrors.NewInternal(err)
+	}
+	return user, nil
+}
+
+// generateRecoveryCodes returns new single-use codes (XXXX-XXXX) and their hashes.
+func generateRecoveryCodes() ([]string, []string) {
+	codes := make([]string, recoveryCodeCount)
+	hashes := make([]string, recoveryCodeCount)
+	for i := range codes {
+		codes[i] = hash.GenerateEnrollmentCode()
+		hashes[i] = hash.HashToken(codes[i])
+	}
+	return codes, hashes
+}
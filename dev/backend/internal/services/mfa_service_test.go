@@ -0,0 +1,40 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestMFARequired(t *testing.T) {
+	configured := &fakeSettings{sections: map[string]string{
+		"security": `{"mfaRequiredRoles": ["super_admin"]}`,
+	}}
+	unreadable := &fakeSettings{err: errors.New("connection refused")}
+	malformed := &fakeSettings{sections: map[string]string{"security": `{"mfaRequiredRoles": "admin"}`}}
+
+	tests := []struct {
+		name     string
+		settings *fakeSettings
+		role     string
+		want     bool
+	}{
+		{"listed role", configured, "super_admin", true},
+		{"unlisted role", configured, "admin", false},
+		{"role that cannot enrol", configured, "officer", false},
+		{"settings unreadable", unreadable, "admin", true},
+		{"settings unreadable, role that cannot enrol", unreadable, "officer", false},
+		{"settings malformed", malformed, "accountant", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &mfaService{settingsRepo: tt.settings, logger: zap.NewNop()}
+			if got := s.Required(context.Background(), tt.role); got != tt.want {
+				t.Errorf("Required(%q) = %t, want %t", tt.role, got, tt.want)
+			}
+		})
+	}
+}
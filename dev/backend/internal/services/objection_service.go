@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	apperrors "github.com/ghana-police/ticketing-backend/internal/domain/errors"
@@ -20,19 +21,28 @@ const objectionDeadlineDays = 7
 const reviewDeadlineDays = 14
 
 type objectionService struct {
+	uow           repositories.UnitOfWork
 	objectionRepo repositories.ObjectionRepository
 	ticketRepo    repositories.TicketRepository
+	jurisdictions repositories.JurisdictionRepository
+	ledger        repositories.LedgerRepository
 	logger        *zap.Logger
 }
 
 func NewObjectionService(
+	uow repositories.UnitOfWork,
 	objectionRepo repositories.ObjectionRepository,
 	ticketRepo repositories.TicketRepository,
+	jurisdictions repositories.JurisdictionRepository,
+	ledger repositories.LedgerRepository,
 	logger *zap.Logger,
 ) portservices.ObjectionService {
 	return &objectionService{
+		uow:           uow,
 		objectionRepo: objectionRepo,
 		ticketRepo:    ticketRepo,
+		jurisdictions: jurisdictions,
+		ledger:        ledger,
 		logger:        logger,
 	}
 }
@@ -61,6 +71,9 @@ func (s *objectionService) File(ctx context.Context, req *portservices.FileObjec
 		}
 		return nil, apperrors.NewInternal(err)
 	}
+	if err := requireInJurisdiction(ctx, s.jurisdictions, models.EntityTicket, req.TicketID, "Ticket"); err != nil {
+		return nil, err
+	}
 
 	// Check ticket status — only unpaid or overdue can be objected
 	if ticket.Status != "unpaid" && ticket.Status != "overdue" {
@@ -149,14 +162,19 @@ func (s *objectionService) GetByID(ctx context.Context, id uuid.UUID) (*models.O
 		}
 		return nil, apperrors.NewInternal(err)
 	}
+	if err := requireInJurisdiction(ctx, s.jurisdictions, models.EntityObjection, id, "Objection"); err != nil {
+		return nil, err
+	}
 	return resp, nil
 }
 
 func (s *objectionService) List(ctx context.Context, filter models.ObjectionFilter, search string, p pagination.Params) ([]models.ObjectionResponse, int, error) {
+	filter.Scope = callerScope(ctx)
 	return s.objectionRepo.List(ctx, filter, search, p)
 }
 
 func (s *objectionService) Stats(ctx context.Context, filter models.ObjectionFilter) (*models.ObjectionStats, error) {
+	filter.Scope = callerScope(ctx)
 	return s.objectionRepo.GetStats(ctx, filter)
 }
 
@@ -184,6 +202,9 @@ func (s *objectionService) Review(ctx context.Context, id uuid.UUID, req *portse
 		}
 		return nil, apperrors.NewInternal(err)
 	}
+	if err := requireInJurisdiction(ctx, s.jurisdictions, models.EntityObjection, id, "Objection"); err != nil {
+		return nil, err
+	}
 
 	if objection.Status != models.ObjectionStatusPending {
 		return nil, apperrors.NewValidationError("Only pending objections can be reviewed", nil)
@@ -191,25 +212,38 @@ func (s *objectionService) Review(ctx context.Context, id uuid.UUID, req *portse
 
 	reviewerID := middleware.GetUserID(ctx)
 
-	// Update objection status
-	if err := s.objectionRepo.Review(ctx, id, req.Decision, reviewerID, req.ReviewNotes, req.AdjustedFine); err != nil {
-		return nil, apperrors.NewInternal(err)
-	}
-
-	// Update ticket status based on decision
+	// An approval changes what the ticket is owed, so the objection, the
+	// ticket and the revenue ledger are updated together
 	if req.Decision == models.ObjectionStatusApproved {
+		kind := models.JournalTicketWaived
+		memo := fmt.Sprintf("Objection to ticket %s upheld", objection.TicketNumber)
 		if req.AdjustedFine != nil {
-			// Fine adjusted — revert ticket to unpaid with new fine
-			if err := s.ticketRepo.UpdateStatus(ctx, objection.TicketID, "unpaid"); err != nil {
-				return nil, apperrors.NewInternal(err)
-			}
-		} else {
-			// Full approval — cancel the ticket
-			if err := s.ticketRepo.UpdateStatus(ctx, objection.TicketID, "cancelled"); err != nil {
-				return nil, apperrors.NewInternal(err)
-			}
+			kind = models.JournalFineAdjusted
+			memo = fmt.Sprintf("Fine on ticket %s adjusted to GHS %.2f on objection", objection.TicketNumber, *req.AdjustedFine)
+		}
+		err := trackReceivable(ctx, s.uow, s.ticketRepo, s.ledger, objection.TicketID, kind, memo,
+			func(ctx context.Context) error {
+				if err := s.objectionRepo.Review(ctx, id, req.Decision, reviewerID, req.ReviewNotes, req.AdjustedFine); err != nil {
+					return err
+				}
+				if req.AdjustedFine != nil {
+					// Fine adjusted — revert ticket to unpaid with new fine
+					if err := s.ticketRepo.SetTotalFine(ctx, objection.TicketID, *req.AdjustedFine); err != nil {
+						return err
+					}
+					return s.ticketRepo.UpdateStatus(ctx, objection.TicketID, "unpaid")
+				}
+				// Full approval — cancel the ticket
+				return s.ticketRepo.UpdateStatus(ctx, objection.TicketID, "cancelled")
+			})
+		if err != nil {
+			return nil, apperrors.NewInternal(err)
 		}
 	} else {
+		if err := s.objectionRepo.Review(ctx, id, req.Decision, reviewerID, req.ReviewNotes, req.AdjustedFine); err != nil {
+			return nil, apperrors.NewInternal(err)
+		}
+
 		// Rejected — revert to unpaid (or overdue based on due date)
 		newStatus := "unpaid"
 		ticket, ticketErr := s.ticketRepo.GetByID(ctx, objection.TicketID)
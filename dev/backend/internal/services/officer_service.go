@@ -8,7 +8,6 @@ import (
 
 	apperrors "github.com/ghana-police/ticketing-backend/internal/domain/errors"
 	"github.com/ghana-police/ticketing-backend/internal/domain/models"
-	"github.com/ghana-police/ticketing-backend/internal/middleware"
 	"github.com/ghana-police/ticketing-backend/internal/ports/repositories"
 	portservices "github.com/ghana-police/ticketing-backend/internal/ports/services"
 	"github.com/ghana-police/ticketing-backend/pkg/hash"
@@ -22,6 +21,9 @@ type officerService struct {
 	officerRepo   repositories.OfficerRepository
 	hierarchyRepo repositories.HierarchyRepository
 	userRepo      repositories.UserRepository
+	jurisdictions repositories.JurisdictionRepository
+	revocations   portservices.TokenRevocationService
+	passwords     portservices.PasswordPolicyService
 	logger        *zap.Logger
 }
 
@@ -29,36 +31,24 @@ func NewOfficerService(
 	officerRepo repositories.OfficerRepository,
 	hierarchyRepo repositories.HierarchyRepository,
 	userRepo repositories.UserRepository,
+	jurisdictions repositories.JurisdictionRepository,
+	revocations portservices.TokenRevocationService,
+	passwords portservices.PasswordPolicyService,
 	logger *zap.Logger,
 ) portservices.OfficerService {
 	return &officerService{
 		officerRepo:   officerRepo,
 		hierarchyRepo: hierarchyRepo,
 		userRepo:      userRepo,
+		jurisdictions: jurisdictions,
+		revocations:   revocations,
+		passwords:     passwords,
 		logger:        logger,
 	}
 }
 
-// applyJurisdictionFilter scopes the filter based on the caller's role.
-func applyJurisdictionFilter(ctx context.Context, filter *models.OfficerFilter) {
-	role := middleware.GetUserRole(ctx)
-	switch role {
-	case "officer", "supervisor":
-		stationID := middleware.GetStationID(ctx)
-		if stationID != nil {
-			filter.StationID = stationID
-		}
-	case "admin", "accountant":
-		regionID := middleware.GetRegionID(ctx)
-		if regionID != nil && filter.RegionID == nil {
-			filter.RegionID = regionID
-		}
-	// super_admin: no additional filter
-	}
-}
-
 func (s *officerService) List(ctx context.Context, filter models.OfficerFilter, search string, p pagination.Params) ([]models.OfficerResponse, int, error) {
-	applyJurisdictionFilter(ctx, &filter)
+	filter.Scope = callerScope(ctx)
 	return s.officerRepo.List(ctx, filter, search, p)
 }
 
@@ -70,6 +60,9 @@ func (s *officerService) Get(ctx context.Context, officerID uuid.UUID) (*models.
 		}
 		return nil, apperrors.NewInternal(err)
 	}
+	if err := requireInJurisdiction(ctx, s.jurisdictions, models.EntityOfficer, officerID, "Officer"); err != nil {
+		return nil, err
+	}
 	return officer, nil
 }
 
@@ -108,13 +101,17 @@ func (s *officerService) Create(ctx context.Context, req *portservices.CreateOff
 		}
 		return nil, apperrors.NewInternal(err)
 	}
+	// Officers can only be placed within the caller's own jurisdiction
+	if err := requireInJurisdiction(ctx, s.jurisdictions, models.EntityStation, req.StationID, "Station"); err != nil {
+		return nil, err
+	}
 
 	// Password
 	var tempPassword *string
 	password := ""
 	if req.Password != nil && *req.Password != "" {
-		if len(*req.Password) < 8 {
-			return nil, apperrors.NewValidationError("Password must be at least 8 characters", nil)
+		if err := s.passwords.Validate(ctx, nil, *req.Password); err != nil {
+			return nil, err
 		}
 		password = *req.Password
 	} else {
@@ -141,6 +138,8 @@ func (s *officerService) Create(ctx context.Context, req *portservices.CreateOff
 		LastName:     req.LastName,
 		Phone:        &req.Phone,
 		Role:         role,
+		// Passwords chosen by an administrator are temporary under the policy
+		MustChangePassword: s.passwords.Policy(ctx).RequireChange,
 	}
 
 	officer := &models.Officer{
@@ -174,6 +173,9 @@ func (s *officerService) Update(ctx context.Context, officerID uuid.UUID, req *p
 		}
 		return nil, apperrors.NewInternal(err)
 	}
+	if err := requireInJurisdiction(ctx, s.jurisdictions, models.EntityOfficer, officerID, "Officer"); err != nil {
+		return nil, err
+	}
 
 	// Build user update
 	user := &models.User{
@@ -234,6 +236,9 @@ func (s *officerService) Update(ctx context.Context, officerID uuid.UUID, req *p
 			}
 			return nil, apperrors.NewInternal(err)
 		}
+		if err := requireInJurisdiction(ctx, s.jurisdictions, models.EntityStation, *req.StationID, "Station"); err != nil {
+			return nil, err
+		}
 		officer.StationID = *req.StationID
 		officer.RegionID = station.RegionID
 	}
@@ -242,19 +247,45 @@ func (s *officerService) Update(ctx context.Context, officerID uuid.UUID, req *p
 		return nil, apperrors.NewInternal(err)
 	}
 
+	// Issued tokens carry the role and jurisdiction, so sign the officer out
+	// when those change or the account is deactivated.
+	switch {
+	case current.IsActive && !user.IsActive:
+		s.revokeSessions(ctx, current.UserID, "deactivated")
+	case user.Role != current.Role:
+		s.revokeSessions(ctx, current.UserID, "role_changed")
+	case officer.StationID != current.StationID:
+		s.revokeSessions(ctx, current.UserID, "station_changed")
+	}
+
 	return s.officerRepo.GetByID(ctx, officerID)
 }
 
+// revokeSessions signs a user out everywhere after a change has been saved;
+// failures are logged rather than undoing the change.
+func (s *officerService) revokeSessions(ctx context.Context, userID uuid.UUID, reason string) {
+	if err := s.revocations.RevokeUser(ctx, userID, reason); err != nil {
+		s.logger.Error("failed to revoke user tokens", zap.String("userId", userID.String()), zap.String("reason", reason), zap.Error(err))
+	}
+}
+
 func (s *officerService) Delete(ctx context.Context, officerID uuid.UUID) error {
-	_, err := s.officerRepo.GetByID(ctx, officerID)
+	current, err := s.officerRepo.GetByID(ctx, officerID)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return apperrors.NewNotFound("Officer")
 		}
 		return apperrors.NewInternal(err)
 	}
+	if err := requireInJurisdiction(ctx, s.jurisdictions, models.EntityOfficer, officerID, "Officer"); err != nil {
+		return err
+	}
 
-	return s.officerRepo.Deactivate(ctx, officerID)
+	if err := s.officerRepo.Deactivate(ctx, officerID); err != nil {
+		return apperrors.NewInternal(err)
+	}
+	s.revokeSessions(ctx, current.UserID, "deactivated")
+	return nil
 }
 
 func (s *officerService) GetStats(ctx context.Context, officerID uuid.UUID) (*models.OfficerStats, error) {
@@ -266,6 +297,9 @@ func (s *officerService) GetStats(ctx context.Context, officerID uuid.UUID) (*mo
 		}
 		return nil, apperrors.NewInternal(err)
 	}
+	if err := requireInJurisdiction(ctx, s.jurisdictions, models.EntityOfficer, officerID, "Officer"); err != nil {
+		return nil, err
+	}
 
 	stats, err := s.officerRepo.GetStats(ctx, officerID)
 	if err != nil {
@@ -282,6 +316,9 @@ func (s *officerService) ResetPassword(ctx context.Context, officerID uuid.UUID)
 		}
 		return nil, apperrors.NewInternal(err)
 	}
+	if err := requireInJurisdiction(ctx, s.jurisdictions, models.EntityOfficer, officerID, "Officer"); err != nil {
+		return nil, err
+	}
 
 	tempPassword := hash.GenerateTemporaryPassword()
 	passwordHash, err := hash.HashPassword(tempPassword)
@@ -289,9 +326,10 @@ func (s *officerService) ResetPassword(ctx context.Context, officerID uuid.UUID)
 		return nil, apperrors.NewInternal(err)
 	}
 
-	if err := s.userRepo.UpdatePassword(ctx, userID, passwordHash); err != nil {
+	if err := s.userRepo.UpdatePassword(ctx, userID, passwordHash, s.passwords.Policy(ctx).RequireChange); err != nil {
 		return nil, apperrors.NewInternal(err)
 	}
+	s.revokeSessions(ctx, userID, "password_reset")
 
 	return &portservices.ResetPasswordResult{
 		TemporaryPassword: tempPassword,
@@ -0,0 +1,76 @@
+package services
+
+import (
+	"testing"
+
+	apperrors "github.com/ghana-police/ticketing-backend/internal/domain/errors"
+	"github.com/ghana-police/ticketing-backend/internal/domain/models"
+	portservices "github.com/ghana-police/ticketing-backend/internal/ports/services"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+func TestOfficerStationScope(t *testing.T) {
+	home, away := uuid.New(), uuid.New() // regions
+	homeStation := &models.Station{ID: uuid.New(), RegionID: home}
+	awayStation := &models.Station{ID: uuid.New(), RegionID: away}
+	officerID := uuid.New()
+
+	newService := func() (*officerService, *fakeOfficerRepo) {
+		officers := &fakeOfficerRepo{officers: map[uuid.UUID]*models.OfficerResponse{
+			officerID: {ID: officerID, UserID: uuid.New(), StationID: homeStation.ID, RegionID: home, IsActive: true},
+		}}
+		return &officerService{
+			officerRepo: officers,
+			hierarchyRepo: &fakeHierarchy{stations: map[uuid.UUID]*models.Station{
+				homeStation.ID: homeStation, awayStation.ID: awayStation,
+			}},
+			jurisdictions: &fakeJurisdictions{places: map[uuid.UUID]place{
+				homeStation.ID: {station: homeStation.ID, region: home},
+				awayStation.ID: {station: awayStation.ID, region: away},
+				officerID:      {station: homeStation.ID, region: home},
+			}},
+			revocations: &fakeRevocations{},
+			passwords:   fakePasswords{},
+			logger:      zap.NewNop(),
+		}, officers
+	}
+	ctx := callerCtx("admin", nil, &home)
+
+	tests := []struct {
+		name    string
+		station *models.Station
+		wantErr string // AppError code
+	}{
+		{name: "station in the caller's region", station: homeStation},
+		{name: "station in another region", station: awayStation, wantErr: apperrors.CodeNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run("create at "+tt.name, func(t *testing.T) {
+			s, _ := newService()
+			_, err := s.Create(ctx, &portservices.CreateOfficerRequest{
+				FirstName: "Kofi", LastName: "Mensah", BadgeNumber: "GPS-2001", Rank: "constable",
+				Phone: "0241234567", StationID: tt.station.ID,
+			})
+			if tt.wantErr != "" {
+				wantCode(t, err, tt.wantErr)
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+
+		t.Run("move to "+tt.name, func(t *testing.T) {
+			s, officers := newService()
+			_, err := s.Update(ctx, officerID, &portservices.UpdateOfficerRequest{StationID: &tt.station.ID})
+			if tt.wantErr != "" {
+				wantCode(t, err, tt.wantErr)
+				if got := officers.officers[officerID].StationID; got != homeStation.ID {
+					t.Errorf("officer moved to %s despite the error", got)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
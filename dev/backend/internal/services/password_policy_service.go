@@ -0,0 +1,164 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+	"unicode"
+
+	apperrors "github.com/ghana-police/ticketing-backend/internal/domain/errors"
+	"github.com/ghana-police/ticketing-backend/internal/domain/models"
+	"github.com/ghana-police/ticketing-backend/internal/ports/repositories"
+	portservices "github.com/ghana-police/ticketing-backend/internal/ports/services"
+	"github.com/ghana-police/ticketing-backend/pkg/hash"
+	"github.com/ghana-police/ticketing-backend/pkg/passwordlist"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// passwordPolicyTTL is how long the password policy is cached before the
+// security settings are re-read.
+const passwordPolicyTTL = 30 * time.Second
+
+type passwordPolicyService struct {
+	userRepo     repositories.UserRepository
+	settingsRepo repositories.SettingsRepository
+	breached     *passwordlist.List
+	logger       *zap.Logger
+
+	mu       sync.Mutex
+	policy   models.PasswordPolicy
+	loadedAt time.Time
+}
+
+func NewPasswordPolicyService(
+	userRepo repositories.UserRepository,
+	settingsRepo repositories.SettingsRepository,
+	breached *passwordlist.List,
+	logger *zap.Logger,
+) portservices.PasswordPolicyService {
+	return &passwordPolicyService{
+		userRepo:     userRepo,
+		settingsRepo: settingsRepo,
+		breached:     breached,
+		logger:       logger,
+	}
+}
+
+func (s *passwordPolicyService) Policy(ctx context.Context) models.PasswordPolicy {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.loadedAt.IsZero() && time.Since(s.loadedAt) < passwordPolicyTTL {
+		return s.policy
+	}
+
+	// Keys missing from settings keep their defaults
+	policy := models.DefaultPasswordPolicy()
+	if raw, err := s.settingsRepo.GetBySection(ctx, "security"); err == nil {
+		if err := json.Unmarshal(raw, &policy); err != nil {
+			s.logger.Warn("invalid password policy settings", zap.Error(err))
+			policy = models.DefaultPasswordPolicy()
+		}
+	}
+	s.policy = policy.Clamp()
+	s.loadedAt = time.Now()
+	return s.policy
+}
+
+func (s *passwordPolicyService) Validate(ctx context.Context, userID *uuid.UUID, password string) error {
+	policy := s.Policy(ctx)
+
+	var problems []string
+	if len(password) > models.MaxPasswordBytes {
+		problems = append(problems, fmt.Sprintf("must be at most %d bytes", models.MaxPasswordBytes))
+	}
+	if len([]rune(password)) < policy.MinLength {
+		problems = append(problems, fmt.Sprintf("must be at least %d characters", policy.MinLength))
+	}
+
+	var upper, lower, digit, symbol bool
+	for _, c := range password {
+		switch {
+		case unicode.IsUpper(c):
+			upper = true
+		case unicode.IsLower(c):
+			lower = true
+		case unicode.IsDigit(c):
+			digit = true
+		case unicode.IsPunct(c) || unicode.IsSymbol(c) || unicode.IsSpace(c):
+			symbol = true
+		}
+	}
+	if policy.RequireUppercase && !upper {
+		problems = append(problems, "must contain an uppercase letter")
+	}
+	if policy.RequireLowercase && !lower {
+		problems = append(problems, "must contain a lowercase letter")
+	}
+	if policy.RequireDigit && !digit {
+		problems = append(problems, "must contain a digit")
+	}
+	if policy.RequireSymbol && !symbol {
+		problems = append(problems, "must contain a symbol")
+	}
+	if s.breached.Contains(password) {
+		problems = append(problems, "appears in a list of breached passwords")
+	}
+
+	if len(problems) == 0 && userID != nil {
+		reused, err := s.reused(ctx, *userID, password, policy.HistoryCount)
+		if err != nil {
+			return apperrors.NewInternal(err)
+		}
+		if reused {
+			problems = append(problems, fmt.Sprintf("must not match any of the last %d passwords", policy.HistoryCount))
+		}
+	}
+
+	if len(problems) > 0 {
+		return apperrors.NewValidationError("Password does not meet the password policy", map[string][]string{
+			"password": problems,
+		})
+	}
+	return nil
+}
+
+// reused reports whether password matches the user's current password or one
+// of the count-1 passwords before it.
+func (s *passwordPolicyService) reused(ctx context.Context, userID uuid.UUID, password string, count int) (bool, error) {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	if hash.CheckPassword(password, user.PasswordHash) {
+		return true, nil
+	}
+	if count <= 1 {
+		return false, nil
+	}
+
+	history, err := s.userRepo.ListPasswordHistory(ctx, userID, count-1)
+	if err != nil {
+		return false, err
+	}
+	for _, h := range history {
+		if hash.CheckPassword(password, h) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (s *passwordPolicyService) MustChange(ctx context.Context, user *models.User) bool {
+	if user.MustChangePassword {
+		return true
+	}
+	changedAt := user.CreatedAt
+	if user.PasswordChangedAt != nil {
+		changedAt = *user.PasswordChangedAt
+	}
+	return s.Policy(ctx).Expired(changedAt, time.Now())
+}
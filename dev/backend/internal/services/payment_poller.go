@@ -0,0 +1,98 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	portservices "github.com/ghana-police/ticketing-backend/internal/ports/services"
+	"github.com/ghana-police/ticketing-backend/pkg/metrics"
+	"go.uber.org/zap"
+)
+
+// maxPollPasses bounds how many full batches one tick works through, so a
+// large backlog cannot keep the poller from yielding.
+const maxPollPasses = 10
+
+type paymentPoller struct {
+	payments portservices.PaymentService
+	interval time.Duration
+	req      portservices.PollPaymentsRequest
+	logger   *zap.Logger
+
+	runs     *metrics.Counter
+	outcomes *metrics.CounterVec
+	pending  *metrics.Gauge
+	lastRun  *metrics.Gauge
+	duration *metrics.Gauge
+}
+
+func NewPaymentPoller(
+	payments portservices.PaymentService,
+	interval time.Duration,
+	req portservices.PollPaymentsRequest,
+	registry *metrics.Registry,
+	logger *zap.Logger,
+) portservices.PaymentPoller {
+	return &paymentPoller{
+		payments: payments,
+		interval: interval,
+		req:      req,
+		logger:   logger,
+		runs:     registry.Counter("payment_poller_runs_total", "Payment poller ticks."),
+		outcomes: registry.CounterVec("payment_poller_payments_total", "Payments checked by the poller, by outcome.", "outcome"),
+		pending:  registry.Gauge("payment_poller_pending_payments", "Payments awaiting the provider after the last tick."),
+		lastRun:  registry.Gauge("payment_poller_last_run_timestamp_seconds", "Unix time the last tick finished."),
+		duration: registry.Gauge("payment_poller_last_run_duration_seconds", "How long the last tick took."),
+	}
+}
+
+func (p *paymentPoller) Run(ctx context.Context) {
+	p.logger.Info("payment poller started", zap.Duration("interval", p.interval))
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			p.logger.Info("payment poller stopped")
+			return
+		case <-ticker.C:
+			p.tick(ctx)
+		}
+	}
+}
+
+func (p *paymentPoller) tick(ctx context.Context) {
+	start := time.Now()
+	p.runs.Inc()
+
+	for pass := 0; pass < maxPollPasses; pass++ {
+		result, err := p.payments.PollPending(ctx, &p.req)
+		if err != nil {
+			p.outcomes.Inc("error")
+			p.logger.Error("payment poll pass failed", zap.Error(err))
+			break
+		}
+		p.outcomes.Add("completed", uint64(result.Completed))
+		p.outcomes.Add("failed", uint64(result.Failed))
+		p.outcomes.Add("expired", uint64(result.Expired))
+		p.outcomes.Add("pending", uint64(result.Pending))
+		p.outcomes.Add("error", uint64(result.Errors))
+
+		if result.Completed+result.Failed+result.Expired > 0 {
+			p.logger.Info("payments settled by poller",
+				zap.Int("completed", result.Completed),
+				zap.Int("failed", result.Failed),
+				zap.Int("expired", result.Expired),
+				zap.Int("still_pending", result.Pending),
+				zap.Int("errors", result.Errors))
+		}
+		p.pending.Set(float64(result.Remaining))
+		if result.Polled < p.req.Limit {
+			break
+		}
+	}
+
+	p.lastRun.Set(float64(time.Now().Unix()))
+	p.duration.Set(time.Since(start).Seconds())
+}
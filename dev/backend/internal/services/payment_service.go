@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
+	"strings"
 	"time"
 
 	apperrors "github.com/ghana-police/ticketing-backend/internal/domain/errors"
@@ -17,24 +19,48 @@ import (
 	"go.uber.org/zap"
 )
 
+// Status messages of payments closed by expiry rather than by the provider.
+const (
+	paymentExpiredMessage     = "Payment expired before the payer completed it"
+	paymentUnconfirmedMessage = "Payment expired and could not be confirmed with the provider"
+)
+
 type paymentService struct {
-	paymentRepo  repositories.PaymentRepository
-	ticketRepo   repositories.TicketRepository
-	providers    *portservices.ProviderRegistry
-	logger       *zap.Logger
+	uow           repositories.UnitOfWork
+	paymentRepo   repositories.PaymentRepository
+	ticketRepo    repositories.TicketRepository
+	installments  repositories.InstallmentRepository
+	cash          repositories.CashRepository
+	ledger        repositories.LedgerRepository
+	allocations   repositories.AllocationRepository
+	jurisdictions repositories.JurisdictionRepository
+	providers     *portservices.ProviderRegistry
+	logger        *zap.Logger
 }
 
 func NewPaymentService(
+	uow repositories.UnitOfWork,
 	paymentRepo repositories.PaymentRepository,
 	ticketRepo repositories.TicketRepository,
+	installments repositories.InstallmentRepository,
+	cash repositories.CashRepository,
+	ledger repositories.LedgerRepository,
+	allocations repositories.AllocationRepository,
+	jurisdictions repositories.JurisdictionRepository,
 	providers *portservices.ProviderRegistry,
 	logger *zap.Logger,
 ) portservices.PaymentService {
 	return &paymentService{
-		paymentRepo: paymentRepo,
-		ticketRepo:  ticketRepo,
-		providers:   providers,
-		logger:      logger,
+		uow:           uow,
+		paymentRepo:   paymentRepo,
+		ticketRepo:    ticketRepo,
+		installments:  installments,
+		cash:          cash,
+		ledger:        ledger,
+		allocations:   allocations,
+		jurisdictions: jurisdictions,
+		providers:     providers,
+		logger:        logger,
 	}
 }
 
@@ -60,77 +86,103 @@ func (s *paymentService) InitiateDigital(ctx context.Context, req *portservices.
 		}
 		return nil, apperrors.NewInternal(err)
 	}
+	if err := requireInJurisdiction(ctx, s.jurisdictions, models.EntityTicket, req.TicketID, "Ticket"); err != nil {
+		return nil, err
+	}
 
 	if ticket.Status != "unpaid" && ticket.Status != "overdue" {
 		return nil, apperrors.NewValidationError("Ticket is not eligible for payment (status: "+ticket.Status+")", nil)
 	}
 
-	// Check for existing pending/completed payment
-	exists, err := s.paymentRepo.HasPendingOrCompleted(ctx, req.TicketID)
-	if err != nil {
-		return nil, apperrors.NewInternal(err)
-	}
-	if exists {
-		return nil, apperrors.NewConflict("Ticket already has a pending or completed payment")
-	}
-
-	// Create payment record
-	paymentRef := ""
-	if ticket.PaymentReference != nil {
-		paymentRef = *ticket.PaymentReference
-	} else {
-		paymentRef = fmt.Sprintf("PAY-%s", uuid.New().String()[:8])
-	}
-
 	expiresAt := time.Now().Add(30 * time.Minute)
 	phone := ""
 	if req.PhoneNumber != nil {
 		phone = *req.PhoneNumber
 	}
 
-	// Initiate with provider
-	providerResult, err := provider.Initiate(ctx, &portservices.ProviderInitiateRequest{
-		Amount:           ticket.TotalFine,
-		Currency:         "GHS",
-		PaymentReference: paymentRef,
-		PhoneNumber:      phone,
-		PayerName:        derefStrOr(req.PayerName, "Unknown"),
-		Description:      fmt.Sprintf("Fine payment for ticket %s", ticket.TicketNumber),
+	userID := middleware.GetUserID(ctx)
+	stationID := middleware.GetStationID(ctx)
+
+	// The pending payment is committed under the ticket lock before the
+	// provider is asked, so concurrent requests cannot open two payments for
+	// the ticket and no lock is held while the provider answers.
+	var payment *models.Payment
+	err = s.uow.Do(ctx, func(ctx context.Context) error {
+		outstanding, err := s.checkPayable(ctx, req.TicketID)
+		if err != nil {
+			return err
+		}
+		amount, err := s.digitalAmount(ctx, req, outstanding)
+		if err != nil {
+			return err
+		}
+		paymentRef, err := s.paymentReference(ctx, ticket, "PAY")
+		if err != nil {
+			return err
+		}
+
+		payment = &models.Payment{
+			PaymentReference: paymentRef,
+			TicketID:         req.TicketID,
+			TicketNumber:     ticket.TicketNumber,
+			Amount:           amount,
+			Currency:         "GHS",
+			OriginalFine:     ticket.TotalFine,
+			Method:           req.Method,
+			PhoneNumber:      req.PhoneNumber,
+			Status:           "pending",
+			PayerName:        derefStrOr(req.PayerName, "Unknown"),
+			PayerPhone:       req.PhoneNumber,
+			PayerEmail:       req.PayerEmail,
+			ProcessedByID:    &userID,
+			StationID:        stationID,
+			ExpiresAt:        &expiresAt,
+		}
+		if err := s.paymentRepo.Create(ctx, payment); err != nil {
+			return fmt.Errorf("create payment: %w", err)
+		}
+		return nil
 	})
 	if err != nil {
-		return nil, apperrors.NewInternal(fmt.Errorf("provider initiate: %w", err))
+		return nil, asAppError(err)
 	}
 
-	userID := middleware.GetUserID(ctx)
-	stationID := middleware.GetStationID(ctx)
-
-	payment := &models.Payment{
-		PaymentReference: paymentRef,
-		TicketID:         req.TicketID,
-		TicketNumber:     ticket.TicketNumber,
-		Amount:           ticket.TotalFine,
-		Currency:         "GHS",
-		OriginalFine:     ticket.TotalFine,
+	providerResult, err := provider.Initiate(ctx, &portservices.ProviderInitiateRequest{
 		Method:           req.Method,
-		PhoneNumber:      req.PhoneNumber,
-		Status:           providerResult.Status,
-		PayerName:        derefStrOr(req.PayerName, "Unknown"),
-		PayerPhone:       req.PhoneNumber,
-		PayerEmail:       req.PayerEmail,
-		ProcessedByID:    &userID,
-		StationID:        stationID,
-		ExpiresAt:        &expiresAt,
-		TransactionID:    strPtrIfNotEmpty(providerResult.ProviderRef),
+		Amount:           payment.Amount,
+		Currency:         payment.Currency,
+		PaymentReference: payment.PaymentReference,
+		PhoneNumber:      phone,
+		PayerName:        payment.PayerName,
+		PayerEmail:       derefStrOr(req.PayerEmail, ""),
+		Description:      fmt.Sprintf("Fine payment for ticket %s", ticket.TicketNumber),
+	})
+	if err != nil {
+		// The payment never reached the provider; failing it frees the ticket
+		msg := "Payment could not be started with the provider"
+		if failErr := s.paymentRepo.UpdateStatus(ctx, payment.ID, "failed", nil, &msg, nil); failErr != nil {
+			s.logger.Error("failed to fail unstarted payment",
+				zap.String("payment_reference", payment.PaymentReference), zap.Error(failErr))
+		}
+		// Providers reject bad payer details with a validation error, which
+		// asAppError finds through the wrapping
+		return nil, asAppError(fmt.Errorf("provider initiate: %w", err))
 	}
 
-	if err := s.paymentRepo.Create(ctx, payment); err != nil {
-		return nil, apperrors.NewInternal(fmt.Errorf("create payment: %w", err))
+	// A single statement, so a second short transaction of its own
+	err = s.paymentRepo.SetProviderRef(ctx, payment.ID, providerResult.ProviderRef,
+		strPtrIfNotEmpty(providerResult.Network), providerResult.Status)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NewConflict("Payment expired before the provider accepted it")
+		}
+		return nil, apperrors.NewInternal(err)
 	}
 
 	result := &portservices.InitiatePaymentResult{
 		PaymentID:        payment.ID,
-		PaymentReference: paymentRef,
-		Amount:           ticket.TotalFine,
+		PaymentReference: payment.PaymentReference,
+		Amount:           payment.Amount,
 		Method:           req.Method,
 		Instructions:     providerResult.Instructions,
 	}
@@ -166,62 +218,81 @@ func (s *paymentService) RecordCash(ctx context.Context, req *portservices.Recor
 		}
 		return nil, apperrors.NewInternal(err)
 	}
+	if err := requireInJurisdiction(ctx, s.jurisdictions, models.EntityTicket, req.TicketID, "Ticket"); err != nil {
+		return nil, err
+	}
 
 	if ticket.Status != "unpaid" && ticket.Status != "overdue" {
 		return nil, apperrors.NewValidationError("Ticket is not eligible for payment (status: "+ticket.Status+")", nil)
 	}
 
-	exists, err := s.paymentRepo.HasPendingOrCompleted(ctx, req.TicketID)
-	if err != nil {
-		return nil, apperrors.NewInternal(err)
-	}
-	if exists {
-		return nil, apperrors.NewConflict("Ticket already has a pending or completed payment")
-	}
-
-	// Generate receipt
-	receiptNum, err := s.paymentRepo.NextReceiptNumber(ctx)
-	if err != nil {
-		return nil, apperrors.NewInternal(err)
-	}
-
-	paymentRef := ""
-	if ticket.PaymentReference != nil {
-		paymentRef = *ticket.PaymentReference
-	} else {
-		paymentRef = fmt.Sprintf("PAY-CASH-%s", uuid.New().String()[:8])
-	}
-
 	now := time.Now()
 	userID := middleware.GetUserID(ctx)
-	stationID := middleware.GetStationID(ctx)
 
-	payment := &models.Payment{
-		PaymentReference: paymentRef,
-		TicketID:         req.TicketID,
-		TicketNumber:     ticket.TicketNumber,
-		Amount:           req.Amount,
-		Currency:         "GHS",
-		OriginalFine:     ticket.TotalFine,
-		Method:           "cash",
-		Status:           "completed",
-		PayerName:        req.PayerName,
-		PayerPhone:       req.PayerPhone,
-		ReceiptNumber:    &receiptNum,
-		ProcessedByID:    &userID,
-		StationID:        stationID,
-		ProcessedAt:      &now,
-		CompletedAt:      &now,
-		TransactionID:    strPtrIfNotEmpty(fmt.Sprintf("CASH-%s", paymentRef)),
-	}
+	// The payment and the ticket's new balance are recorded together or not
+	// at all
+	var payment *models.Payment
+	err = s.uow.Do(ctx, func(ctx context.Context) error {
+		outstanding, err := s.checkPayable(ctx, req.TicketID)
+		if err != nil {
+			return err
+		}
+		// Cash goes into the cashier's open shift, locked so it cannot be
+		// cashed up mid-payment
+		shift, err := s.cash.GetOpenShiftForUpdate(ctx, userID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return apperrors.NewValidationError("Open a cashier shift before recording cash payments", nil)
+			}
+			return err
+		}
+		if req.Amount > outstanding+0.005 {
+			return apperrors.NewValidationError(fmt.Sprintf("Amount exceeds the outstanding balance of GHS %.2f", outstanding),
+				map[string][]string{"amount": {fmt.Sprintf("must not exceed %.2f", outstanding)}})
+		}
+		paymentRef, err := s.paymentReference(ctx, ticket, "PAY-CASH")
+		if err != nil {
+			return err
+		}
 
-	if err := s.paymentRepo.Create(ctx, payment); err != nil {
-		return nil, apperrors.NewInternal(fmt.Errorf("create cash payment: %w", err))
-	}
+		// Generate receipt
+		receiptNum, err := s.paymentRepo.NextReceiptNumber(ctx)
+		if err != nil {
+			return err
+		}
+
+		payment = &models.Payment{
+			PaymentReference: paymentRef,
+			TicketID:         req.TicketID,
+			TicketNumber:     ticket.TicketNumber,
+			Amount:           req.Amount,
+			Currency:         "GHS",
+			OriginalFine:     ticket.TotalFine,
+			Method:           "cash",
+			Status:           "completed",
+			PayerName:        req.PayerName,
+			PayerPhone:       req.PayerPhone,
+			ReceiptNumber:    &receiptNum,
+			ProcessedByID:    &userID,
+			StationID:        &shift.StationID,
+			ShiftID:          &shift.ID,
+			ProcessedAt:      &now,
+			CompletedAt:      &now,
+			TransactionID:    strPtrIfNotEmpty(fmt.Sprintf("CASH-%s", paymentRef)),
+		}
+
+		if err := s.paymentRepo.Create(ctx, payment); err != nil {
+			return fmt.Errorf("create cash payment: %w", err)
+		}
 
-	// Complete: update ticket to paid
-	if err := s.paymentRepo.Complete(ctx, payment.ID, payment.TransactionID, receiptNum); err != nil {
-		return nil, apperrors.NewInternal(fmt.Errorf("complete cash payment: %w", err))
+		// Complete: reduce the ticket's balance
+		if err := s.complete(ctx, payment, payment.TransactionID, receiptNum); err != nil {
+			return fmt.Errorf("complete cash payment: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, asAppError(err)
 	}
 
 	return s.paymentRepo.GetByID(ctx, payment.ID)
@@ -239,22 +310,122 @@ func (s *paymentService) Verify(ctx context.Context, req *portservices.VerifyPay
 		}
 		return nil, apperrors.NewInternal(err)
 	}
+	if err := requireInJurisdiction(ctx, s.jurisdictions, models.EntityPayment, payment.ID, "Payment"); err != nil {
+		return nil, err
+	}
+
+	payment, err = s.refresh(ctx, payment)
+	if err != nil {
+		return nil, err
+	}
+
+	ticket, _ := s.ticketRepo.GetByID(ctx, payment.TicketID)
+	info := &portservices.VerifyTicketInfo{
+		ID:           payment.TicketID,
+		TicketNumber: payment.TicketNumber,
+	}
+	if ticket != nil {
+		info.Status = ticket.Status
+		info.Outstanding = ticket.Outstanding
+	}
+
+	return &portservices.VerifyPaymentResult{
+		Payment: payment,
+		Ticket:  info,
+	}, nil
+}
+
+// HandleReturn verifies a hosted-checkout payment when the gateway sends the
+// payer back. The caller is anonymous, so only the provider's answer counts.
+func (s *paymentService) HandleReturn(ctx context.Context, providerRef string) (*portservices.CheckoutReturnResult, error) {
+	if providerRef == "" {
+		return nil, apperrors.NewValidationError("reference is required", nil)
+	}
+	payment, err := s.paymentRepo.GetByTransactionID(ctx, providerRef)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NewNotFound("Payment")
+		}
+		return nil, apperrors.NewInternal(err)
+	}
+
+	payment, err = s.refresh(ctx, payment)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &portservices.CheckoutReturnResult{
+		PaymentReference: payment.PaymentReference,
+		TicketNumber:     payment.TicketNumber,
+		Amount:           payment.Amount,
+		Currency:         payment.Currency,
+		Status:           payment.Status,
+		StatusMessage:    payment.StatusMessage,
+		ReceiptNumber:    payment.ReceiptNumber,
+	}
+	return result, nil
+}
+
+// PollPending checks due pending payments with their providers. Payments that
+// are still unpaid when their session runs out are marked failed, which frees
+// the ticket for a new attempt.
+func (s *paymentService) PollPending(ctx context.Context, req *portservices.PollPaymentsRequest) (*models.PaymentPollResult, error) {
+	payments, err := s.paymentRepo.ClaimForPoll(ctx, req.Limit, req.BackoffBase, req.BackoffMax)
+	if err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+
+	result := &models.PaymentPollResult{}
+	for i := range payments {
+		payment := &payments[i]
+		result.Polled++
+
+		updated, err := s.refresh(ctx, payment)
+		if err != nil {
+			result.Errors++
+			s.logger.Warn("payment poll failed",
+				zap.String("payment_reference", payment.PaymentReference),
+				zap.Error(err))
+
+			// The provider may be down or have lost the transaction; do not
+			// hold the ticket forever.
+			if payment.ExpiresAt != nil && time.Since(*payment.ExpiresAt) > req.GiveUpAfter {
+				gaveUp, err := s.giveUp(ctx, payment)
+				if err != nil {
+					s.logger.Error("failed to give up on payment", zap.String("payment_reference", payment.PaymentReference), zap.Error(err))
+					continue
+				}
+				if gaveUp {
+					result.Expired++
+				}
+			}
+			continue
+		}
 
+		switch {
+		case updated.Status == "completed":
+			result.Completed++
+		case updated.Status == "failed" && updated.StatusMessage != nil && *updated.StatusMessage == paymentExpiredMessage:
+			result.Expired++
+		case updated.Status == "failed":
+			result.Failed++
+		default:
+			result.Pending++
+		}
+	}
+
+	if result.Remaining, err = s.paymentRepo.CountPending(ctx); err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+	return result, nil
+}
+
+// refresh asks the provider for the status of a payment that is not final yet,
+// records the answer and returns the updated payment.
+func (s *paymentService) refresh(ctx context.Context, payment *models.Payment) (*models.Payment, error) {
 	if payment.Status == "completed" || payment.Status == "refunded" {
 		// Already final
-		ticket, _ := s.ticketRepo.GetByID(ctx, payment.TicketID)
-		ticketStatus := ""
-		if ticket != nil {
-			ticketStatus = ticket.Status
-		}
-		return &portservices.VerifyPaymentResult{
-			Payment: payment,
-			Ticket: &portservices.VerifyTicketInfo{
-				ID:           payment.TicketID,
-				TicketNumber: payment.TicketNumber,
-				Status:       ticketStatus,
-			},
-		}, nil
+		return payment, nil
 	}
 
 	provider := s.providers.Get(payment.Method)
@@ -262,52 +433,279 @@ func (s *paymentService) Verify(ctx context.Context, req *portservices.VerifyPay
 		return nil, apperrors.NewInternal(fmt.Errorf("no provider for method %s", payment.Method))
 	}
 
-	providerRef := ""
-	if payment.TransactionID != nil {
-		providerRef = *payment.TransactionID
-	}
-
-	verifyResult, err := provider.Verify(ctx, providerRef)
-	if err != nil {
-		return nil, apperrors.NewInternal(fmt.Errorf("provider verify: %w", err))
+	var verifyResult *portservices.ProviderVerifyResult
+	if payment.TransactionID == nil {
+		// The provider request is still being made, or never got recorded;
+		// such a payment has nothing to verify and can only expire
+		if payment.ExpiresAt == nil || time.Now().Before(*payment.ExpiresAt) {
+			return payment, nil
+		}
+		verifyResult = &portservices.ProviderVerifyResult{Status: "pending"}
+	} else {
+		var err error
+		verifyResult, err = provider.Verify(ctx, *payment.TransactionID)
+		if err != nil {
+			return nil, apperrors.NewInternal(fmt.Errorf("provider verify: %w", err))
+		}
 	}
 
 	rawResp := strPtrIfNotEmpty(verifyResult.RawResponse)
 
-	if verifyResult.Status == "completed" {
-		receiptNum, err := s.paymentRepo.NextReceiptNumber(ctx)
+	// A session the payer never completed ends here
+	if verifyResult.Status == "pending" && payment.ExpiresAt != nil && time.Now().After(*payment.ExpiresAt) {
+		verifyResult.Status = "failed"
+		verifyResult.StatusMessage = paymentExpiredMessage
+	}
+
+	// A provider that reports what it collected must have collected the fine
+	if verifyResult.Status == "completed" && verifyResult.Amount > 0 &&
+		(math.Abs(verifyResult.Amount-payment.Amount) >= 0.005 || (verifyResult.Currency != "" && verifyResult.Currency != payment.Currency)) {
+		s.logger.Error("provider amount does not match payment",
+			zap.String("payment_reference", payment.PaymentReference),
+			zap.Float64("expected", payment.Amount),
+			zap.Float64("collected", verifyResult.Amount),
+			zap.String("currency", verifyResult.Currency))
+		verifyResult.Status = "failed"
+		verifyResult.StatusMessage = fmt.Sprintf("Provider collected %s %.2f but %s %.2f is due; needs manual review",
+			verifyResult.Currency, verifyResult.Amount, payment.Currency, payment.Amount)
+	}
+
+	// The provider is asked outside the transaction; the answer is recorded
+	// under the ticket and payment locks, and only if nobody else recorded
+	// one since the payment was read. Concurrent verifies of a completed
+	// payment therefore issue one receipt.
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		ticketStatus, _, err := s.ticketRepo.LockForUpdate(ctx, payment.TicketID)
 		if err != nil {
-			return nil, apperrors.NewInternal(err)
+			return err
 		}
+		current, err := s.paymentRepo.GetByIDForUpdate(ctx, payment.ID)
+		if err != nil {
+			return err
+		}
+		if current.Status != payment.Status {
+			return nil
+		}
+
 		txID := strPtrIfNotEmpty(verifyResult.TransactionID)
-		if err := s.paymentRepo.UpdateStatus(ctx, payment.ID, "completed", txID, &verifyResult.StatusMessage, rawResp); err != nil {
-			return nil, apperrors.NewInternal(err)
+
+		// Money collected for a ticket that was settled or cancelled while the
+		// payer was approving is not applied; it is left for a refund
+		if verifyResult.Status == "completed" && (ticketStatus == "paid" || ticketStatus == "cancelled") {
+			s.logger.Error("provider collected a payment for a closed ticket",
+				zap.String("payment_reference", payment.PaymentReference),
+				zap.String("ticket_status", ticketStatus))
+			msg := fmt.Sprintf("Provider collected %s %.2f but the ticket is already %s; needs refund",
+				payment.Currency, payment.Amount, ticketStatus)
+			return s.paymentRepo.UpdateStatus(ctx, payment.ID, "failed", txID, &msg, rawResp)
 		}
-		if err := s.paymentRepo.Complete(ctx, payment.ID, txID, receiptNum); err != nil {
-			return nil, apperrors.NewInternal(err)
+
+		if verifyResult.Status != "completed" {
+			return s.paymentRepo.UpdateStatus(ctx, payment.ID, verifyResult.Status, txID, &verifyResult.StatusMessage, rawResp)
 		}
-	} else {
-		if err := s.paymentRepo.UpdateStatus(ctx, payment.ID, verifyResult.Status, strPtrIfNotEmpty(verifyResult.TransactionID), &verifyResult.StatusMessage, rawResp); err != nil {
-			return nil, apperrors.NewInternal(err)
+
+		receiptNum, err := s.paymentRepo.NextReceiptNumber(ctx)
+		if err != nil {
+			return err
 		}
+		if err := s.paymentRepo.UpdateStatus(ctx, payment.ID, "completed", txID, &verifyResult.StatusMessage, rawResp); err != nil {
+			return err
+		}
+		return s.complete(ctx, current, txID, receiptNum)
+	})
+	if err != nil {
+		return nil, apperrors.NewInternal(err)
 	}
 
 	// Re-fetch
-	payment, _ = s.paymentRepo.GetByID(ctx, payment.ID)
-	ticket, _ := s.ticketRepo.GetByID(ctx, payment.TicketID)
-	ticketStatus := ""
-	if ticket != nil {
-		ticketStatus = ticket.Status
+	updated, err := s.paymentRepo.GetByID(ctx, payment.ID)
+	if err != nil {
+		return nil, apperrors.NewInternal(err)
 	}
+	return updated, nil
+}
 
-	return &portservices.VerifyPaymentResult{
-		Payment: payment,
-		Ticket: &portservices.VerifyTicketInfo{
-			ID:           payment.TicketID,
-			TicketNumber: payment.TicketNumber,
-			Status:       ticketStatus,
-		},
-	}, nil
+// giveUp fails a payment the provider could not confirm long after it
+// expired, unless its status changed in the meantime.
+func (s *paymentService) giveUp(ctx context.Context, payment *models.Payment) (bool, error) {
+	gaveUp := false
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		if _, _, err := s.ticketRepo.LockForUpdate(ctx, payment.TicketID); err != nil {
+			return err
+		}
+		current, err := s.paymentRepo.GetByIDForUpdate(ctx, payment.ID)
+		if err != nil {
+			return err
+		}
+		if current.Status != "pending" && current.Status != "processing" {
+			return nil
+		}
+		msg := paymentUnconfirmedMessage
+		if err := s.paymentRepo.UpdateStatus(ctx, payment.ID, "failed", current.TransactionID, &msg, nil); err != nil {
+			return err
+		}
+		gaveUp = true
+		return nil
+	})
+	return gaveUp, err
+}
+
+// checkPayable locks the ticket, checks that it can take a new payment and
+// returns its outstanding balance. It must run in a unit of work, which then
+// holds the lock until the payment is recorded.
+func (s *paymentService) checkPayable(ctx context.Context, ticketID uuid.UUID) (float64, error) {
+	status, outstanding, err := s.ticketRepo.LockForUpdate(ctx, ticketID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, apperrors.NewNotFound("Ticket")
+		}
+		return 0, err
+	}
+	if status != "unpaid" && status != "overdue" {
+		return 0, apperrors.NewValidationError("Ticket is not eligible for payment (status: "+status+")", nil)
+	}
+	if outstanding < 0.005 {
+		return 0, apperrors.NewValidationError("Ticket has no outstanding balance", nil)
+	}
+
+	exists, err := s.paymentRepo.HasPending(ctx, ticketID)
+	if err != nil {
+		return 0, err
+	}
+	if exists {
+		return 0, apperrors.NewConflict("Ticket already has a pending payment")
+	}
+	return outstanding, nil
+}
+
+// digitalAmount is the amount of a digital payment: the one requested, else
+// what is left of the next installment when the ticket is on a plan, else the
+// whole outstanding balance.
+func (s *paymentService) digitalAmount(ctx context.Context, req *portservices.InitiatePaymentRequest, outstanding float64) (float64, error) {
+	if req.Amount != nil {
+		amount := math.Round(*req.Amount*100) / 100
+		if amount <= 0 || amount > outstanding+0.005 {
+			return 0, apperrors.NewValidationError(fmt.Sprintf("Amount must be greater than 0 and at most the outstanding balance of GHS %.2f", outstanding),
+				map[string][]string{"amount": {fmt.Sprintf("must be between 0.01 and %.2f", outstanding)}})
+		}
+		return amount, nil
+	}
+
+	plan, err := s.installments.GetActiveByTicket(ctx, req.TicketID)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return 0, err
+	}
+	if plan != nil {
+		if next := plan.NextDue(); next != nil {
+			return math.Min(next.Remaining(), outstanding), nil
+		}
+	}
+	return outstanding, nil
+}
+
+// paymentReference returns the reference of a new payment on the ticket. The
+// first payment takes the ticket's payment reference and later ones number
+// on from it, so every payment can be told apart.
+func (s *paymentService) paymentReference(ctx context.Context, ticket *models.TicketResponse, prefix string) (string, error) {
+	if ticket.PaymentReference == nil {
+		return fmt.Sprintf("%s-%s", prefix, uuid.New().String()[:8]), nil
+	}
+	n, err := s.paymentRepo.CountByTicket(ctx, ticket.ID)
+	if err != nil {
+		return "", err
+	}
+	if n == 0 {
+		return *ticket.PaymentReference, nil
+	}
+	return fmt.Sprintf("%s-%d", *ticket.PaymentReference, n+1), nil
+}
+
+// complete marks the payment completed, takes it off the ticket's balance,
+// pays down the ticket's installment plan, if it has one, posts it to the
+// revenue ledger and allocates it between the revenue beneficiaries.
+func (s *paymentService) complete(ctx context.Context, payment *models.Payment, txID *string, receiptNum string) error {
+	if _, err := s.paymentRepo.Complete(ctx, payment.ID, txID, receiptNum); err != nil {
+		return err
+	}
+	if err := s.installments.ApplyPayment(ctx, payment.TicketID, payment.Amount); err != nil {
+		return err
+	}
+	if err := postPaymentReceived(ctx, s.ledger, payment); err != nil {
+		return err
+	}
+	return allocatePayment(ctx, s.allocations, s.ticketRepo, payment)
+}
+
+// ---------------------------------------------------------------------------
+// Refund
+// ---------------------------------------------------------------------------
+
+func (s *paymentService) Refund(ctx context.Context, id uuid.UUID, req *portservices.RefundPaymentRequest) (*models.Payment, error) {
+	reason := strings.TrimSpace(req.Reason)
+	if len(reason) < 10 {
+		return nil, apperrors.NewValidationError("Refund reason must be at least 10 characters",
+			map[string][]string{"reason": {"must be at least 10 characters"}})
+	}
+
+	payment, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.uow.Do(ctx, func(ctx context.Context) error {
+		if _, _, err := s.ticketRepo.LockForUpdate(ctx, payment.TicketID); err != nil {
+			return err
+		}
+		current, err := s.paymentRepo.GetByIDForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		if current.Status != "completed" {
+			return apperrors.NewValidationError("Only completed payments can be refunded (status: "+current.Status+")", nil)
+		}
+
+		// Money paid over the fine is owed back to the payer and goes back
+		// first; the rest of the refund gives up that much of the fine
+		receivable, err := s.ticketRepo.Receivable(ctx, current.TicketID)
+		if err != nil {
+			return err
+		}
+		returned := math.Min(current.Amount, math.Max(-receivable, 0))
+		returned = math.Round(returned*100) / 100
+		withdrawn := math.Round((current.Amount-returned)*100) / 100
+
+		if err := s.paymentRepo.Refund(ctx, id, reason, withdrawn); err != nil {
+			return err
+		}
+		if err := s.allocations.ReverseForPayment(ctx, id, time.Now().UTC().Format(periodLayout)); err != nil {
+			return err
+		}
+		// Only the part that settled the fine was paid off installments
+		paidAt := current.CreatedAt
+		if current.CompletedAt != nil {
+			paidAt = *current.CompletedAt
+		}
+		if err := s.installments.ReversePayment(ctx, current.TicketID, paidAt, withdrawn); err != nil {
+			return err
+		}
+		return postJournal(ctx, s.ledger, &models.LedgerJournal{
+			Kind:       models.JournalPaymentRefunded,
+			OccurredAt: time.Now(),
+			TicketID:   current.TicketID,
+			PaymentID:  &current.ID,
+			Memo:       fmt.Sprintf("Payment %s refunded: %s", current.PaymentReference, reason),
+			Entries: []models.LedgerEntry{
+				{AccountCode: models.AccountFinesReceivable, Debit: returned},
+				{AccountCode: models.AccountRefunds, Debit: withdrawn},
+				{AccountCode: models.AccountCollections, Credit: current.Amount},
+			},
+		})
+	})
+	if err != nil {
+		return nil, asAppError(err)
+	}
+
+	return s.paymentRepo.GetByID(ctx, id)
 }
 
 // ---------------------------------------------------------------------------
@@ -322,18 +720,26 @@ func (s *paymentService) GetByID(ctx context.Context, id uuid.UUID) (*models.Pay
 		}
 		return nil, apperrors.NewInternal(err)
 	}
+	if err := requireInJurisdiction(ctx, s.jurisdictions, models.EntityPayment, id, "Payment"); err != nil {
+		return nil, err
+	}
 	return payment, nil
 }
 
 func (s *paymentService) List(ctx context.Context, filter models.PaymentFilter, search string, p pagination.Params) ([]models.Payment, int, error) {
+	filter.Scope = callerScope(ctx)
 	return s.paymentRepo.List(ctx, filter, search, p)
 }
 
 func (s *paymentService) Stats(ctx context.Context, filter models.PaymentFilter) (*models.PaymentStats, error) {
+	filter.Scope = callerScope(ctx)
 	return s.paymentRepo.GetStats(ctx, filter)
 }
 
 func (s *paymentService) Receipt(ctx context.Context, id uuid.UUID) (*models.PaymentReceipt, error) {
+	if err := requireInJurisdiction(ctx, s.jurisdictions, models.EntityPayment, id, "Payment receipt"); err != nil {
+		return nil, err
+	}
 	receipt, err := s.paymentRepo.GetReceipt(ctx, id)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -348,6 +754,16 @@ func (s *paymentService) Receipt(ctx context.Context, id uuid.UUID) (*models.Pay
 // Helpers
 // ---------------------------------------------------------------------------
 
+// asAppError passes application errors through and wraps anything else as
+// an internal error.
+func asAppError(err error) *apperrors.AppError {
+	var appErr *apperrors.AppError
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+	return apperrors.NewInternal(err)
+}
+
 func derefStrOr(s *string, fallback string) string {
 	if s != nil && *s != "" {
 		return *s
@@ -0,0 +1,169 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	apperrors "github.com/ghana-police/ticketing-backend/internal/domain/errors"
+	"github.com/ghana-police/ticketing-backend/internal/domain/models"
+	portservices "github.com/ghana-police/ticketing-backend/internal/ports/services"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// newTestPaymentService returns a payment service over one ticket with the
+// given status and a fine of GHS 200, reachable by a super_admin.
+func newTestPaymentService(ticketStatus string, provider *fakeProvider) (*paymentService, *fakePaymentRepo, uuid.UUID) {
+	ticketID := uuid.New()
+	tickets := &fakeTicketRepo{tickets: map[uuid.UUID]*models.TicketResponse{
+		ticketID: {ID: ticketID, TicketNumber: "GR-0001", Status: ticketStatus, TotalFine: 200},
+	}}
+	payments := &fakePaymentRepo{payments: map[uuid.UUID]*models.Payment{}}
+	providers := portservices.NewProviderRegistry()
+	providers.Register(provider)
+
+	s := &paymentService{
+		uow:           provider.uow,
+		paymentRepo:   payments,
+		ticketRepo:    tickets,
+		jurisdictions: &fakeJurisdictions{places: map[uuid.UUID]place{ticketID: {}}},
+		providers:     providers,
+		logger:        zap.NewNop(),
+	}
+	return s, payments, ticketID
+}
+
+func TestInitiateDigitalCallsProviderOutsideTransaction(t *testing.T) {
+	provider := &fakeProvider{uow: &fakeUOW{}}
+	s, payments, ticketID := newTestPaymentService("unpaid", provider)
+
+	phone, amount := "0241234567", 200.0
+	result, err := s.InitiateDigital(callerCtx("super_admin", nil, nil), &portservices.InitiatePaymentRequest{
+		TicketID: ticketID, Method: "momo", PhoneNumber: &phone, Amount: &amount,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if provider.initiatedInTx {
+		t.Error("provider was called inside the unit of work")
+	}
+
+	p := payments.payments[result.PaymentID]
+	if p.Status != "pending" || p.TransactionID == nil || *p.TransactionID != "ref-1" || p.Network == nil || *p.Network != "MTN" {
+		t.Errorf("payment = %s ref %v network %v, want pending with the provider's reference and network",
+			p.Status, p.TransactionID, p.Network)
+	}
+}
+
+func TestInitiateDigitalProviderRejects(t *testing.T) {
+	provider := &fakeProvider{uow: &fakeUOW{}, initiateErr: apperrors.NewValidationError("Phone number is not a Ghanaian mobile money number", nil)}
+	s, payments, ticketID := newTestPaymentService("unpaid", provider)
+
+	phone, amount := "12345", 200.0
+	_, err := s.InitiateDigital(callerCtx("super_admin", nil, nil), &portservices.InitiatePaymentRequest{
+		TicketID: ticketID, Method: "momo", PhoneNumber: &phone, Amount: &amount,
+	})
+	wantCode(t, err, apperrors.CodeValidation)
+
+	// The payment is failed so it does not hold the ticket
+	if pending, _ := payments.HasPending(context.Background(), ticketID); pending {
+		t.Error("ticket still has a pending payment after the provider rejected it")
+	}
+}
+
+func TestRefreshPayability(t *testing.T) {
+	completed := &portservices.ProviderVerifyResult{Status: "completed", TransactionID: "tx-1", Amount: 200, Currency: "GHS"}
+	ref := "ref-1"
+	past, future := time.Now().Add(-time.Minute), time.Now().Add(time.Minute)
+
+	tests := []struct {
+		name         string
+		ticketStatus string
+		providerRef  *string
+		expiresAt    time.Time
+		wantStatus   string
+		wantMessage  string
+		wantVerified int
+	}{
+		{name: "ticket paid meanwhile", ticketStatus: "paid", providerRef: &ref, expiresAt: future,
+			wantStatus: "failed", wantMessage: "needs refund", wantVerified: 1},
+		{name: "ticket cancelled meanwhile", ticketStatus: "cancelled", providerRef: &ref, expiresAt: future,
+			wantStatus: "failed", wantMessage: "needs refund", wantVerified: 1},
+		{name: "provider request not recorded yet", ticketStatus: "unpaid", expiresAt: future,
+			wantStatus: "pending"},
+		{name: "provider request never recorded", ticketStatus: "unpaid", expiresAt: past,
+			wantStatus: "failed", wantMessage: paymentExpiredMessage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := &fakeProvider{uow: &fakeUOW{}, verify: completed}
+			s, payments, ticketID := newTestPaymentService(tt.ticketStatus, provider)
+			payment := &models.Payment{TicketID: ticketID, Method: "momo", Status: "pending", Amount: 200,
+				Currency: "GHS", TransactionID: tt.providerRef, ExpiresAt: &tt.expiresAt}
+			if err := payments.Create(context.Background(), payment); err != nil {
+				t.Fatal(err)
+			}
+
+			updated, err := s.refresh(context.Background(), payment)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if updated.Status != tt.wantStatus {
+				t.Errorf("status = %s, want %s", updated.Status, tt.wantStatus)
+			}
+			if tt.wantMessage != "" && (updated.StatusMessage == nil || !strings.Contains(*updated.StatusMessage, tt.wantMessage)) {
+				t.Errorf("status message = %v, want mention of %q", updated.StatusMessage, tt.wantMessage)
+			}
+			if provider.verified != tt.wantVerified {
+				t.Errorf("provider verified %d times, want %d", provider.verified, tt.wantVerified)
+			}
+		})
+	}
+}
+
+func TestRefundReversesInstallments(t *testing.T) {
+	tests := []struct {
+		name         string
+		balance      float64 // ticket receivable before the refund
+		wantReversed float64
+	}{
+		{name: "payment settled the fine", balance: 0, wantReversed: 100},
+		{name: "part of the payment was over the fine", balance: -30, wantReversed: 70},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := &fakeProvider{uow: &fakeUOW{}}
+			s, payments, ticketID := newTestPaymentService("paid", provider)
+			s.ticketRepo.(*fakeTicketRepo).balance = tt.balance
+			installments := &fakeInstallments{uow: provider.uow}
+			s.installments = installments
+			s.allocations = fakeAllocations{}
+			s.ledger = &fakeLedger{}
+
+			completedAt := time.Now().Add(-time.Hour)
+			payment := &models.Payment{TicketID: ticketID, Method: "cash", Status: "completed", Amount: 100,
+				Currency: "GHS", CompletedAt: &completedAt}
+			if err := payments.Create(context.Background(), payment); err != nil {
+				t.Fatal(err)
+			}
+			s.jurisdictions.(*fakeJurisdictions).places[payment.ID] = place{}
+
+			refunded, err := s.Refund(callerCtx("super_admin", nil, nil), payment.ID,
+				&portservices.RefundPaymentRequest{Reason: "Ticket issued in error"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if refunded.Status != "refunded" {
+				t.Errorf("status = %s, want refunded", refunded.Status)
+			}
+			if len(installments.reversed) != 1 || installments.reversed[0] != tt.wantReversed || !installments.inTx[0] {
+				t.Errorf("installment reversals = %v (in unit of work %v), want %v once inside it",
+					installments.reversed, installments.inTx, tt.wantReversed)
+			}
+		})
+	}
+}
@@ -0,0 +1,339 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	apperrors "github.com/ghana-police/ticketing-backend/internal/domain/errors"
+	"github.com/ghana-police/ticketing-backend/internal/domain/models"
+	"github.com/ghana-police/ticketing-backend/internal/middleware"
+	"github.com/ghana-police/ticketing-backend/internal/ports/repositories"
+	portservices "github.com/ghana-police/ticketing-backend/internal/ports/services"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+// permissionCacheTTL bounds how long a change made on another API instance
+// takes to apply here. Changes made through this instance apply at once.
+const permissionCacheTTL = 30 * time.Second
+
+type permissionService struct {
+	repo     repositories.PermissionRepository
+	userRepo repositories.UserRepository
+	audit    portservices.AuditService
+	logger   *zap.Logger
+
+	mu        sync.Mutex
+	catalogue map[string]bool
+	roles     map[string]map[string]bool
+	loadedAt  time.Time
+	users     map[uuid.UUID]userOverrides
+}
+
+type userOverrides struct {
+	grants   map[string]bool
+	denies   map[string]bool
+	loadedAt time.Time
+}
+
+func NewPermissionService(
+	repo repositories.PermissionRepository,
+	userRepo repositories.UserRepository,
+	audit portservices.AuditService,
+	logger *zap.Logger,
+) portservices.PermissionService {
+	return &permissionService{
+		repo:     repo,
+		userRepo: userRepo,
+		audit:    audit,
+		logger:   logger,
+		users:    make(map[uuid.UUID]userOverrides),
+	}
+}
+
+func (s *permissionService) Has(ctx context.Context, userID uuid.UUID, role, permission string) (bool, error) {
+	roles, _, err := s.roleSets(ctx)
+	if err != nil {
+		return false, err
+	}
+	o, err := s.overrides(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	if o.denies[permission] {
+		return false, nil
+	}
+	return roles[role][permission] || o.grants[permission], nil
+}
+
+func (s *permissionService) ListPermissions(ctx context.Context) ([]models.Permission, error) {
+	perms, err := s.repo.ListPermissions(ctx)
+	if err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+	return perms, nil
+}
+
+func (s *permissionService) ListRoles(ctx context.Context) ([]models.RolePermissions, error) {
+	roles, _, err := s.roleSets(ctx)
+	if err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+	result := make([]models.RolePermissions, 0, len(models.Roles))
+	for _, role := range models.Roles {
+		result = append(result, models.RolePermissions{Role: role, Permissions: sortedKeys(roles[role])})
+	}
+	return result, nil
+}
+
+func (s *permissionService) GetRole(ctx context.Context, role string) (*models.RolePermissions, error) {
+	if !slices.Contains(models.Roles, role) {
+		return nil, apperrors.NewNotFound("Role")
+	}
+	roles, _, err := s.roleSets(ctx)
+	if err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+	return &models.RolePermissions{Role: role, Permissions: sortedKeys(roles[role])}, nil
+}
+
+func (s *permissionService) UpdateRole(ctx context.Context, role string, req *portservices.UpdateRolePermissionsRequest) (*models.RolePermissions, error) {
+	if !slices.Contains(models.Roles, role) {
+		return nil, apperrors.NewNotFound("Role")
+	}
+	if err := s.validatePermissions(ctx, req.Permissions...); err != nil {
+		return nil, err
+	}
+	// Someone must always be able to manage roles
+	if role == "super_admin" && !slices.Contains(req.Permissions, models.PermRoleManage) {
+		return nil, apperrors.NewValidationError("super_admin must keep "+models.PermRoleManage, nil)
+	}
+
+	perms := slices.Clone(req.Permissions)
+	slices.Sort(perms)
+	perms = slices.Compact(perms)
+
+	if err := s.repo.ReplaceRolePermissions(ctx, role, perms); err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+	s.invalidate(nil)
+
+	s.record(ctx, "update", "role", role, fmt.Sprintf("Set %s permissions: %s", role, strings.Join(perms, ", ")))
+	return &models.RolePermissions{Role: role, Permissions: perms}, nil
+}
+
+func (s *permissionService) GetUserPermissions(ctx context.Context, userID uuid.UUID) (*models.UserPermissions, error) {
+	user, err := s.findUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	roles, _, err := s.roleSets(ctx)
+	if err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+	overrides, err := s.repo.ListUserOverrides(ctx, userID)
+	if err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+
+	effective := make(map[string]bool, len(roles[user.Role]))
+	for p := range roles[user.Role] {
+		effective[p] = true
+	}
+	for _, o := range overrides {
+		effective[o.Permission] = o.Effect == models.PermissionGrant
+	}
+	var perms []string
+	for p, ok := range effective {
+		if ok {
+			perms = append(perms, p)
+		}
+	}
+	slices.Sort(perms)
+
+	return &models.UserPermissions{
+		UserID:      userID,
+		Role:        user.Role,
+		Permissions: perms,
+		Overrides:   overrides,
+	}, nil
+}
+
+func (s *permissionService) SetUserPermission(ctx context.Context, userID uuid.UUID, req *portservices.SetUserPermissionRequest) (*models.UserPermission, error) {
+	if req.Effect != models.PermissionGrant && req.Effect != models.PermissionDeny {
+		return nil, apperrors.NewValidationError("Effect must be grant or deny", nil)
+	}
+	if err := s.validatePermissions(ctx, req.Permission); err != nil {
+		return nil, err
+	}
+	actorID := middleware.GetUserID(ctx)
+	if actorID == userID {
+		return nil, apperrors.NewForbidden("You cannot change your own permissions")
+	}
+	user, err := s.findUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &models.UserPermission{
+		UserID:     userID,
+		Permission: req.Permission,
+		Effect:     req.Effect,
+		Reason:     req.Reason,
+		GrantedBy:  &actorID,
+	}
+	if err := s.repo.SetUserOverride(ctx, p); err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+	s.invalidate(&userID)
+
+	s.record(ctx, "assign", "user", userID.String(), fmt.Sprintf("%s %s for %s", req.Effect, req.Permission, user.Email))
+	return p, nil
+}
+
+func (s *permissionService) RemoveUserPermission(ctx context.Context, userID uuid.UUID, permission string) error {
+	if err := s.repo.DeleteUserOverride(ctx, userID, permission); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return apperrors.NewNotFound("Permission override")
+		}
+		return apperrors.NewInternal(err)
+	}
+	s.invalidate(&userID)
+
+	s.record(ctx, "delete", "user", userID.String(), "Removed "+permission+" override")
+	return nil
+}
+
+// roleSets returns each role's permissions and the catalogue, reloading
+// them when the cached copy is stale.
+func (s *permissionService) roleSets(ctx context.Context) (map[string]map[string]bool, map[string]bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.roles != nil && time.Since(s.loadedAt) < permissionCacheTTL {
+		return s.roles, s.catalogue, nil
+	}
+
+	perms, err := s.repo.ListPermissions(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	byRole, err := s.repo.ListRolePermissions(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	catalogue := make(map[string]bool, len(perms))
+	for _, p := range perms {
+		catalogue[p.Key] = true
+	}
+	roles := make(map[string]map[string]bool, len(byRole))
+	for role, keys := range byRole {
+		set := make(map[string]bool, len(keys))
+		for _, k := range keys {
+			set[k] = true
+		}
+		roles[role] = set
+	}
+
+	s.catalogue, s.roles, s.loadedAt = catalogue, roles, time.Now()
+	return roles, catalogue, nil
+}
+
+// overrides returns a user's grants and denies, cached like the role sets.
+func (s *permissionService) overrides(ctx context.Context, userID uuid.UUID) (userOverrides, error) {
+	s.mu.Lock()
+	cached, ok := s.users[userID]
+	s.mu.Unlock()
+	if ok && time.Since(cached.loadedAt) < permissionCacheTTL {
+		return cached, nil
+	}
+
+	list, err := s.repo.ListUserOverrides(ctx, userID)
+	if err != nil {
+		return userOverrides{}, err
+	}
+	o := userOverrides{grants: map[string]bool{}, denies: map[string]bool{}, loadedAt: time.Now()}
+	for _, p := range list {
+		if p.Effect == models.PermissionDeny {
+			o.denies[p.Permission] = true
+		} else {
+			o.grants[p.Permission] = true
+		}
+	}
+
+	s.mu.Lock()
+	s.users[userID] = o
+	s.mu.Unlock()
+	return o, nil
+}
+
+// invalidate drops cached role sets (userID nil) or one user's overrides.
+func (s *permissionService) invalidate(userID *uuid.UUID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if userID == nil {
+		s.roles = nil
+		return
+	}
+	delete(s.users, *userID)
+}
+
+func (s *permissionService) validatePermissions(ctx context.Context, keys ...string) error {
+	_, catalogue, err := s.roleSets(ctx)
+	if err != nil {
+		return apperrors.NewInternal(err)
+	}
+	var unknown []string
+	for _, k := range keys {
+		if !catalogue[k] {
+			unknown = append(unknown, k)
+		}
+	}
+	if len(unknown) > 0 {
+		return apperrors.NewValidationError("Unknown permissions", map[string][]string{
+			"permissions": unknown,
+		})
+	}
+	return nil
+}
+
+func (s *permissionService) findUser(ctx context.Context, userID uuid.UUID) (*models.User, error) {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NewNotFound("User")
+		}
+		return nil, apperrors.NewInternal(err)
+	}
+	return user, nil
+}
+
+func (s *permissionService) record(ctx context.Context, action, entityType, entityID, description string) {
+	actorID := middleware.GetUserID(ctx)
+	s.audit.Log(ctx, &portservices.AuditEntry{
+		UserID:      &actorID,
+		UserRole:    middleware.GetUserRole(ctx),
+		Action:      action,
+		EntityType:  entityType,
+		EntityID:    entityID,
+		Description: description,
+		Severity:    "warning",
+		Success:     true,
+	})
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
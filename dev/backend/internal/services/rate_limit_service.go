@@ -0,0 +1,98 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/ghana-police/ticketing-backend/internal/domain/models"
+	"github.com/ghana-police/ticketing-backend/internal/ports/repositories"
+	portservices "github.com/ghana-police/ticketing-backend/internal/ports/services"
+	"go.uber.org/zap"
+)
+
+// rateLimitSettingsTTL is how long the security.rateLimits settings are cached
+// before being re-read, so limit changes apply without a restart.
+const rateLimitSettingsTTL = 30 * time.Second
+
+type rateLimitService struct {
+	repo         repositories.RateLimitRepository
+	settingsRepo repositories.SettingsRepository
+	defaults     models.RateLimits
+	logger       *zap.Logger
+
+	mu       sync.Mutex
+	limits   models.RateLimits
+	loadedAt time.Time
+}
+
+func NewRateLimitService(
+	repo repositories.RateLimitRepository,
+	settingsRepo repositories.SettingsRepository,
+	defaults models.RateLimits,
+	logger *zap.Logger,
+) portservices.RateLimitService {
+	return &rateLimitService{
+		repo:         repo,
+		settingsRepo: settingsRepo,
+		defaults:     defaults,
+		logger:       logger,
+	}
+}
+
+func (s *rateLimitService) Allow(ctx context.Context, class, key string) (*models.RateLimitResult, error) {
+	limits := s.currentLimits(ctx)
+	limit := limits.For(class)
+	if limit <= 0 {
+		return nil, nil
+	}
+	window := time.Duration(limits.WindowSeconds) * time.Second
+
+	allowed, count, oldest, err := s.repo.Hit(ctx, class+":"+key, limit, window)
+	if err != nil {
+		s.logger.Warn("rate limit check failed", zap.String("class", class), zap.Error(err))
+		return nil, err
+	}
+
+	reset := time.Until(oldest.Add(window))
+	if reset < 0 {
+		reset = 0
+	}
+	remaining := limit - count
+	if remaining < 0 {
+		remaining = 0
+	}
+	return &models.RateLimitResult{
+		Allowed:   allowed,
+		Limit:     limit,
+		Remaining: remaining,
+		Window:    window,
+		Reset:     reset,
+	}, nil
+}
+
+// currentLimits returns the configured limits, refreshing them from the
+// security settings section when the cached copy is stale. Values missing
+// from settings fall back to the environment defaults.
+func (s *rateLimitService) currentLimits(ctx context.Context) models.RateLimits {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.loadedAt.IsZero() && time.Since(s.loadedAt) < rateLimitSettingsTTL {
+		return s.limits
+	}
+
+	limits := s.defaults
+	if raw, err := s.settingsRepo.GetBySection(ctx, "security"); err == nil {
+		var security struct {
+			RateLimits models.RateLimits `json:"rateLimits"`
+		}
+		if json.Unmarshal(raw, &security) == nil {
+			limits = security.RateLimits.Merge(s.defaults)
+		}
+	}
+	s.limits = limits
+	s.loadedAt = time.Now()
+	return limits
+}
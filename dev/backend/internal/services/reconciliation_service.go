@@ -0,0 +1,342 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	apperrors "github.com/ghana-police/ticketing-backend/internal/domain/errors"
+	"github.com/ghana-police/ticketing-backend/internal/domain/models"
+	"github.com/ghana-police/ticketing-backend/internal/middleware"
+	"github.com/ghana-police/ticketing-backend/internal/ports/repositories"
+	portservices "github.com/ghana-police/ticketing-backend/internal/ports/services"
+	"github.com/ghana-police/ticketing-backend/pkg/pagination"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+const (
+	maxSettlementLines     = 50000
+	maxReconciliationDays  = 92
+	defaultReconcileWindow = 7 // days shown when the report is asked for without dates
+)
+
+type reconciliationService struct {
+	uow         repositories.UnitOfWork
+	repo        repositories.SettlementRepository
+	paymentRepo repositories.PaymentRepository
+	parsers     *portservices.SettlementParserRegistry
+	logger      *zap.Logger
+}
+
+func NewReconciliationService(
+	uow repositories.UnitOfWork,
+	repo repositories.SettlementRepository,
+	paymentRepo repositories.PaymentRepository,
+	parsers *portservices.SettlementParserRegistry,
+	logger *zap.Logger,
+) portservices.ReconciliationService {
+	return &reconciliationService{
+		uow:         uow,
+		repo:        repo,
+		paymentRepo: paymentRepo,
+		parsers:     parsers,
+		logger:      logger,
+	}
+}
+
+func (s *reconciliationService) Import(ctx context.Context, provider, fileName string, data []byte) (*portservices.ImportSettlementResult, error) {
+	parser, err := s.parser(provider)
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(data)
+	checksum := hex.EncodeToString(sum[:])
+	if prev, err := s.repo.GetImportByChecksum(ctx, provider, checksum); err == nil {
+		return nil, apperrors.NewConflict(fmt.Sprintf("This statement was already imported on %s (import %s)",
+			prev.CreatedAt.Format("2006-01-02"), prev.ID))
+	} else if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, apperrors.NewInternal(err)
+	}
+
+	records, err := parser.Parse(bytes.NewReader(data))
+	if err != nil {
+		var pe *portservices.SettlementParseError
+		if errors.As(err, &pe) {
+			return nil, apperrors.NewValidationError("Could not read the settlement statement",
+				map[string][]string{"file": {fmt.Sprintf("line %d: %s", pe.Line, pe.Message)}})
+		}
+		return nil, apperrors.NewInternal(err)
+	}
+	if len(records) == 0 {
+		return nil, apperrors.NewValidationError("The statement has no settled transactions", nil)
+	}
+	if len(records) > maxSettlementLines {
+		return nil, apperrors.NewValidationError(fmt.Sprintf("The statement has %d lines; split it into files of at most %d", len(records), maxSettlementLines), nil)
+	}
+
+	userID := middleware.GetUserID(ctx)
+	imp := &models.SettlementImport{
+		Provider:  provider,
+		FileName:  fileName,
+		Checksum:  checksum,
+		LineCount: len(records),
+	}
+	if userID != uuid.Nil {
+		imp.ImportedByID = &userID
+	}
+
+	var lines []models.SettlementLine
+	err = s.uow.Do(ctx, func(ctx context.Context) error {
+		lines, err = s.match(ctx, parser, records)
+		if err != nil {
+			return err
+		}
+		for _, l := range lines {
+			imp.TotalAmount += l.Amount
+			switch l.MatchStatus {
+			case models.SettlementMatched:
+				imp.MatchedCount++
+			case models.SettlementUnmatched:
+				imp.UnmatchedCount++
+			case models.SettlementAmountMismatch:
+				imp.MismatchCount++
+			case models.SettlementDuplicate:
+				imp.DuplicateCount++
+			}
+		}
+		imp.TotalAmount = math.Round(imp.TotalAmount*100) / 100
+		return s.repo.CreateImport(ctx, imp, lines)
+	})
+	if err != nil {
+		return nil, asAppError(err)
+	}
+
+	s.logger.Info("settlement statement imported",
+		zap.String("provider", provider),
+		zap.String("import_id", imp.ID.String()),
+		zap.Int("lines", imp.LineCount),
+		zap.Int("matched", imp.MatchedCount),
+		zap.Int("unmatched", imp.UnmatchedCount),
+		zap.Int("amount_mismatch", imp.MismatchCount),
+		zap.Int("duplicate", imp.DuplicateCount))
+
+	items := make([]models.SettlementLine, 0, len(lines)-imp.MatchedCount)
+	for _, l := range lines {
+		if l.MatchStatus != models.SettlementMatched {
+			items = append(items, l)
+		}
+	}
+	return &portservices.ImportSettlementResult{Import: imp, Items: items}, nil
+}
+
+// match pairs each settled record with a payment. A payment settled by an
+// earlier line, in this statement or a previous one, makes the record a
+// duplicate; a settled amount or currency other than the payment's makes it
+// a mismatch.
+func (s *reconciliationService) match(ctx context.Context, parser portservices.SettlementParser, records []portservices.SettlementRecord) ([]models.SettlementLine, error) {
+	open := models.ReviewOpen
+	settled := map[uuid.UUID]bool{}
+	lines := make([]models.SettlementLine, len(records))
+	for i, rec := range records {
+		l := models.SettlementLine{
+			Provider:      parser.Provider(),
+			LineNumber:    rec.LineNumber,
+			TransactionID: strPtrIfNotEmpty(rec.TransactionID),
+			Reference:     strPtrIfNotEmpty(rec.Reference),
+			Amount:        rec.Amount,
+			Fee:           rec.Fee,
+			Currency:      rec.Currency,
+			SettledAt:     rec.SettledAt,
+			MatchStatus:   models.SettlementUnmatched,
+		}
+
+		payment, err := s.findPayment(ctx, parser.Methods(), rec)
+		if err != nil {
+			return nil, err
+		}
+		if payment != nil {
+			l.PaymentID = &payment.ID
+			l.PaymentReference = &payment.PaymentReference
+			l.PaymentAmount = &payment.Amount
+		}
+
+		switch {
+		case payment == nil || payment.Status != "completed":
+			// A payment we have not completed cannot be matched; the line
+			// still points at it so the worklist shows which one it was
+		case settled[payment.ID]:
+			l.MatchStatus = models.SettlementDuplicate
+		default:
+			already, err := s.repo.IsPaymentSettled(ctx, payment.ID)
+			if err != nil {
+				return nil, err
+			}
+			switch {
+			case already:
+				l.MatchStatus = models.SettlementDuplicate
+			case math.Abs(rec.Amount-payment.Amount) >= 0.005 || !strings.EqualFold(rec.Currency, payment.Currency):
+				l.MatchStatus = models.SettlementAmountMismatch
+				settled[payment.ID] = true
+			default:
+				l.MatchStatus = models.SettlementMatched
+				settled[payment.ID] = true
+			}
+		}
+		if l.MatchStatus != models.SettlementMatched {
+			l.ReviewStatus = &open
+		}
+		lines[i] = l
+	}
+	return lines, nil
+}
+
+// findPayment looks the record up by the provider's transaction ID, then by
+// reference, which may be the one we gave the provider or our payment
+// reference. Only payments made with the provider's methods count.
+func (s *reconciliationService) findPayment(ctx context.Context, methods []string, rec portservices.SettlementRecord) (*models.Payment, error) {
+	type lookup struct {
+		key string
+		get func(context.Context, string) (*models.Payment, error)
+	}
+	for _, l := range []lookup{
+		{rec.TransactionID, s.paymentRepo.GetByTransactionID},
+		{rec.Reference, s.paymentRepo.GetByTransactionID},
+		{rec.Reference, s.paymentRepo.GetByReference},
+	} {
+		if l.key == "" {
+			continue
+		}
+		payment, err := l.get(ctx, l.key)
+		if errors.Is(err, pgx.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if slices.Contains(methods, payment.Method) {
+			return payment, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *reconciliationService) GetImport(ctx context.Context, id uuid.UUID) (*models.SettlementImport, error) {
+	imp, err := s.repo.GetImport(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NewNotFound("Settlement import")
+		}
+		return nil, apperrors.NewInternal(err)
+	}
+	return imp, nil
+}
+
+func (s *reconciliationService) ListImports(ctx context.Context, provider *string, p pagination.Params) ([]models.SettlementImport, int, error) {
+	items, total, err := s.repo.ListImports(ctx, provider, p)
+	if err != nil {
+		return nil, 0, apperrors.NewInternal(err)
+	}
+	if items == nil {
+		items = []models.SettlementImport{}
+	}
+	return items, total, nil
+}
+
+func (s *reconciliationService) ListItems(ctx context.Context, filter models.SettlementLineFilter, p pagination.Params) ([]models.SettlementLine, int, error) {
+	items, total, err := s.repo.ListLines(ctx, filter, p)
+	if err != nil {
+		return nil, 0, apperrors.NewInternal(err)
+	}
+	if items == nil {
+		items = []models.SettlementLine{}
+	}
+	return items, total, nil
+}
+
+func (s *reconciliationService) ResolveItem(ctx context.Context, id uuid.UUID, req *portservices.ResolveReconciliationItemRequest) (*models.SettlementLine, error) {
+	resolution := strings.TrimSpace(req.Resolution)
+	if len(resolution) < 5 {
+		return nil, apperrors.NewValidationError("Describe how the item was resolved",
+			map[string][]string{"resolution": {"must be at least 5 characters"}})
+	}
+
+	line, err := s.repo.GetLine(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NewNotFound("Reconciliation item")
+		}
+		return nil, apperrors.NewInternal(err)
+	}
+	if line.ReviewStatus == nil {
+		return nil, apperrors.NewValidationError("Matched lines are not on the worklist", nil)
+	}
+
+	if err := s.repo.ResolveLine(ctx, id, middleware.GetUserID(ctx), resolution); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NewConflict("Reconciliation item is already resolved")
+		}
+		return nil, apperrors.NewInternal(err)
+	}
+
+	s.logger.Info("reconciliation item resolved",
+		zap.String("line_id", id.String()),
+		zap.String("match_status", line.MatchStatus))
+	line, err = s.repo.GetLine(ctx, id)
+	if err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+	return line, nil
+}
+
+func (s *reconciliationService) Report(ctx context.Context, provider *string, from, to time.Time) ([]models.ReconciliationDay, error) {
+	if to.IsZero() {
+		now := time.Now().UTC()
+		to = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	}
+	if from.IsZero() {
+		from = to.AddDate(0, 0, -(defaultReconcileWindow - 1))
+	}
+	if to.Before(from) {
+		return nil, apperrors.NewValidationError("dateTo is before dateFrom", nil)
+	}
+	if to.Sub(from) >= maxReconciliationDays*24*time.Hour {
+		return nil, apperrors.NewValidationError(fmt.Sprintf("The report covers at most %d days", maxReconciliationDays), nil)
+	}
+
+	providers := s.parsers.Providers()
+	if provider != nil {
+		if _, err := s.parser(*provider); err != nil {
+			return nil, err
+		}
+		providers = []string{*provider}
+	}
+
+	report := []models.ReconciliationDay{}
+	for _, name := range providers {
+		days, err := s.repo.DailyReport(ctx, name, s.parsers.Get(name).Methods(), from, to)
+		if err != nil {
+			return nil, apperrors.NewInternal(err)
+		}
+		report = append(report, days...)
+	}
+	return report, nil
+}
+
+func (s *reconciliationService) parser(provider string) (portservices.SettlementParser, error) {
+	parser := s.parsers.Get(provider)
+	if parser == nil {
+		return nil, apperrors.NewValidationError("Unknown settlement provider",
+			map[string][]string{"provider": {"must be one of: " + strings.Join(s.parsers.Providers(), ", ")}})
+	}
+	return parser, nil
+}
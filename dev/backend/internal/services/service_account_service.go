@@ -0,0 +1,326 @@
+package services
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"net/netip"
+	"slices"
+	"strings"
+	"time"
+
+	apperrors "github.com/ghana-police/ticketing-backend/internal/domain/errors"
+	"github.com/ghana-police/ticketing-backend/internal/domain/models"
+	"github.com/ghana-police/ticketing-backend/internal/middleware"
+	"github.com/ghana-police/ticketing-backend/internal/ports/repositories"
+	portservices "github.com/ghana-police/ticketing-backend/internal/ports/services"
+	"github.com/ghana-police/ticketing-backend/pkg/hash"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+// unusablePasswordHash is stored for service accounts. It is not a bcrypt
+// hash, so no password ever matches it.
+const unusablePasswordHash = "!"
+
+type serviceAccountService struct {
+	repo          repositories.ServiceAccountRepository
+	hierarchyRepo repositories.HierarchyRepository
+	permissions   portservices.PermissionService
+	logger        *zap.Logger
+}
+
+func NewServiceAccountService(
+	repo repositories.ServiceAccountRepository,
+	hierarchyRepo repositories.HierarchyRepository,
+	permissions portservices.PermissionService,
+	logger *zap.Logger,
+) portservices.ServiceAccountService {
+	return &serviceAccountService{
+		repo:          repo,
+		hierarchyRepo: hierarchyRepo,
+		permissions:   permissions,
+		logger:        logger,
+	}
+}
+
+func (s *serviceAccountService) List(ctx context.Context) ([]models.ServiceAccount, error) {
+	accounts, err := s.repo.List(ctx)
+	if err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+	if accounts == nil {
+		accounts = []models.ServiceAccount{}
+	}
+	return accounts, nil
+}
+
+func (s *serviceAccountService) Get(ctx context.Context, id uuid.UUID) (*models.ServiceAccount, error) {
+	sa, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NewNotFound("Service account")
+		}
+		return nil, apperrors.NewInternal(err)
+	}
+	return sa, nil
+}
+
+func (s *serviceAccountService) Create(ctx context.Context, req *portservices.CreateServiceAccountRequest) (*models.ServiceAccount, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" || len(name) > 100 {
+		return nil, apperrors.NewValidationError("Name is required (at most 100 characters)", nil)
+	}
+	if !slices.Contains(models.ServiceAccountRoles, req.Role) {
+		return nil, apperrors.NewValidationError("Role must be one of "+strings.Join(models.ServiceAccountRoles, ", "), nil)
+	}
+
+	// The account's jurisdiction works as for a user of the same role
+	stationID, regionID := req.StationID, req.RegionID
+	switch models.JurisdictionPolicy[req.Role] {
+	case models.ScopeStation, models.ScopeDistrict:
+		if stationID == nil {
+			return nil, apperrors.NewValidationError("stationId is required for role "+req.Role, nil)
+		}
+		station, err := s.hierarchyRepo.GetStationByID(ctx, *stationID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, apperrors.NewValidationError("Station not found", nil)
+			}
+			return nil, apperrors.NewInternal(err)
+		}
+		regionID = &station.RegionID
+	case models.ScopeRegion:
+		if regionID == nil {
+			return nil, apperrors.NewValidationError("regionId is required for role "+req.Role, nil)
+		}
+		if _, err := s.hierarchyRepo.GetRegionByID(ctx, *regionID); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, apperrors.NewValidationError("Region not found", nil)
+			}
+			return nil, apperrors.NewInternal(err)
+		}
+		stationID = nil
+	default:
+		stationID, regionID = nil, nil
+	}
+
+	actorID := middleware.GetUserID(ctx)
+	sa := &models.ServiceAccount{
+		Name:        name,
+		Description: req.Description,
+		Role:        req.Role,
+		StationID:   stationID,
+		RegionID:    regionID,
+		CreatedBy:   &actorID,
+	}
+	// The backing users row needs a unique email that can never receive mail
+	email := "svc-" + uuid.NewString() + "@service-accounts.invalid"
+	if err := s.repo.Create(ctx, sa, email, unusablePasswordHash); err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+	return sa, nil
+}
+
+func (s *serviceAccountService) Update(ctx context.Context, id uuid.UUID, req *portservices.UpdateServiceAccountRequest) (*models.ServiceAccount, error) {
+	sa, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" || len(name) > 100 {
+			return nil, apperrors.NewValidationError("Name is required (at most 100 characters)", nil)
+		}
+		sa.Name = name
+	}
+	if req.Description != nil {
+		sa.Description = req.Description
+	}
+	if req.IsActive != nil {
+		sa.IsActive = *req.IsActive
+	}
+
+	if err := s.repo.Update(ctx, sa); err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+	return sa, nil
+}
+
+func (s *serviceAccountService) ListKeys(ctx context.Context, id uuid.UUID) ([]models.APIKey, error) {
+	if _, err := s.Get(ctx, id); err != nil {
+		return nil, err
+	}
+	keys, err := s.repo.ListKeys(ctx, id)
+	if err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+	if keys == nil {
+		keys = []models.APIKey{}
+	}
+	return keys, nil
+}
+
+func (s *serviceAccountService) CreateKey(ctx context.Context, id uuid.UUID, req *portservices.CreateAPIKeyRequest) (*portservices.CreateAPIKeyResult, error) {
+	sa, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !sa.IsActive {
+		return nil, apperrors.NewValidationError("Service account is deactivated", nil)
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" || len(name) > 100 {
+		return nil, apperrors.NewValidationError("Name is required (at most 100 characters)", nil)
+	}
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		return nil, apperrors.NewValidationError("expiresAt must be in the future", nil)
+	}
+
+	details := map[string][]string{}
+	scopes := slices.Clone(req.Scopes)
+	slices.Sort(scopes)
+	scopes = slices.Compact(scopes)
+	if len(scopes) == 0 {
+		details["scopes"] = append(details["scopes"], "at least one scope is required")
+	}
+	// A key can only narrow what the account itself may do
+	for _, scope := range scopes {
+		ok, err := s.permissions.Has(ctx, sa.ID, sa.Role, scope)
+		if err != nil {
+			return nil, apperrors.NewInternal(err)
+		}
+		if !ok {
+			details["scopes"] = append(details["scopes"], scope+" is not held by the service account")
+		}
+	}
+	allowedIPs, problems := parseAllowedIPs(req.AllowedIPs)
+	if len(problems) > 0 {
+		details["allowedIps"] = problems
+	}
+	if len(details) > 0 {
+		return nil, apperrors.NewValidationError("Invalid API key request", details)
+	}
+
+	// The key is <prefix>_<secret>; the prefix is kept to find and display it
+	prefix := models.APIKeyPrefix + hash.GenerateSecret()[:8]
+	apiKey := prefix + "_" + hash.GenerateSecret()
+
+	actorID := middleware.GetUserID(ctx)
+	key := &models.APIKey{
+		ServiceAccountID: sa.ID,
+		Name:             name,
+		Prefix:           prefix,
+		KeyHash:          hash.HashToken(apiKey),
+		Scopes:           scopes,
+		AllowedIPs:       allowedIPs,
+		ExpiresAt:        req.ExpiresAt,
+		CreatedBy:        &actorID,
+	}
+	if err := s.repo.CreateKey(ctx, key); err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+	return &portservices.CreateAPIKeyResult{Key: key, APIKey: apiKey}, nil
+}
+
+func (s *serviceAccountService) RevokeKey(ctx context.Context, id, keyID uuid.UUID) error {
+	if err := s.repo.RevokeKey(ctx, id, keyID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return apperrors.NewNotFound("API key")
+		}
+		return apperrors.NewInternal(err)
+	}
+	return nil
+}
+
+func (s *serviceAccountService) Authenticate(ctx context.Context, apiKey, clientIP string) (*models.APIKeyPrincipal, error) {
+	rest, ok := strings.CutPrefix(apiKey, models.APIKeyPrefix)
+	if !ok {
+		return nil, apperrors.NewUnauthorized("Invalid API key")
+	}
+	id, _, ok := strings.Cut(rest, "_")
+	if !ok {
+		return nil, apperrors.NewUnauthorized("Invalid API key")
+	}
+
+	key, sa, err := s.repo.FindKey(ctx, models.APIKeyPrefix+id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NewUnauthorized("Invalid API key")
+		}
+		return nil, apperrors.NewInternal(err)
+	}
+	if subtle.ConstantTimeCompare([]byte(key.KeyHash), []byte(hash.HashToken(apiKey))) != 1 {
+		return nil, apperrors.NewUnauthorized("Invalid API key")
+	}
+
+	if key.RevokedAt != nil {
+		return nil, apperrors.NewUnauthorized("API key has been revoked")
+	}
+	if key.ExpiresAt != nil && time.Now().After(*key.ExpiresAt) {
+		return nil, apperrors.NewUnauthorized("API key has expired")
+	}
+	if !sa.IsActive {
+		return nil, apperrors.NewForbidden("Service account is deactivated")
+	}
+	if !ipAllowed(key.AllowedIPs, clientIP) {
+		return nil, apperrors.NewForbidden("API key is not allowed from this address")
+	}
+
+	if err := s.repo.TouchKey(ctx, key.ID, clientIP); err != nil {
+		s.logger.Warn("failed to record API key use", zap.String("prefix", key.Prefix), zap.Error(err))
+	}
+
+	return &models.APIKeyPrincipal{
+		KeyID:            key.ID,
+		ServiceAccountID: sa.ID,
+		Name:             sa.Name,
+		Role:             sa.Role,
+		StationID:        sa.StationID,
+		RegionID:         sa.RegionID,
+		Scopes:           key.Scopes,
+	}, nil
+}
+
+// parseAllowedIPs normalises addresses and CIDR blocks to CIDR form and
+// reports the entries that are neither.
+func parseAllowedIPs(entries []string) ([]string, []string) {
+	allowed := []string{}
+	var problems []string
+	for _, e := range entries {
+		e = strings.TrimSpace(e)
+		if p, err := netip.ParsePrefix(e); err == nil {
+			allowed = append(allowed, p.Masked().String())
+			continue
+		}
+		if a, err := netip.ParseAddr(e); err == nil {
+			a = a.Unmap()
+			allowed = append(allowed, netip.PrefixFrom(a, a.BitLen()).String())
+			continue
+		}
+		problems = append(problems, e+" is not an IP address or CIDR block")
+	}
+	return allowed, problems
+}
+
+// ipAllowed reports whether ip falls in one of the CIDR blocks. An empty
+// allow-list admits any address.
+func ipAllowed(allowed []string, ip string) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, block := range allowed {
+		if p, err := netip.ParsePrefix(block); err == nil && p.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,112 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	apperrors "github.com/ghana-police/ticketing-backend/internal/domain/errors"
+	"github.com/ghana-police/ticketing-backend/internal/domain/models"
+	"github.com/ghana-police/ticketing-backend/internal/middleware"
+	"github.com/ghana-police/ticketing-backend/internal/ports/repositories"
+	portservices "github.com/ghana-police/ticketing-backend/internal/ports/services"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+type sessionService struct {
+	userRepo      repositories.UserRepository
+	jurisdictions repositories.JurisdictionRepository
+	revocations   portservices.TokenRevocationService
+	audit         portservices.AuditService
+	logger        *zap.Logger
+}
+
+func NewSessionService(
+	userRepo repositories.UserRepository,
+	jurisdictions repositories.JurisdictionRepository,
+	revocations portservices.TokenRevocationService,
+	audit portservices.AuditService,
+	logger *zap.Logger,
+) portservices.SessionService {
+	return &sessionService{
+		userRepo:      userRepo,
+		jurisdictions: jurisdictions,
+		revocations:   revocations,
+		audit:         audit,
+		logger:        logger,
+	}
+}
+
+func (s *sessionService) List(ctx context.Context, userID uuid.UUID) ([]models.Session, error) {
+	if _, err := s.findUser(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	sessions, err := s.userRepo.ListSessions(ctx, userID)
+	if err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+	if current := middleware.GetSessionID(ctx); current != nil {
+		for i := range sessions {
+			sessions[i].Current = sessions[i].ID == *current
+		}
+	}
+	return sessions, nil
+}
+
+func (s *sessionService) Revoke(ctx context.Context, userID, sessionID uuid.UUID) error {
+	user, err := s.findUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	actorID := middleware.GetUserID(ctx)
+	reason, severity := "signed_out", "info"
+	if actorID != userID {
+		reason, severity = "revoked_by_admin", "warning"
+	}
+	if err := s.revocations.RevokeSession(ctx, userID, sessionID, reason); err != nil {
+		return err
+	}
+
+	s.audit.Log(ctx, &portservices.AuditEntry{
+		UserID:      &actorID,
+		UserRole:    middleware.GetUserRole(ctx),
+		Action:      "revoke_session",
+		EntityType:  "user",
+		EntityID:    userID.String(),
+		EntityName:  user.FullName(),
+		Description: "Signed out session " + sessionID.String() + " of " + user.Email,
+		Severity:    severity,
+		Success:     true,
+	})
+	return nil
+}
+
+// findUser loads the user whose sessions are being managed. Another user's
+// sessions are only reachable within the caller's jurisdiction: officers by
+// their station, and staff without a station by national callers only.
+func (s *sessionService) findUser(ctx context.Context, userID uuid.UUID) (*models.User, error) {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NewNotFound("User")
+		}
+		return nil, apperrors.NewInternal(err)
+	}
+	if userID == middleware.GetUserID(ctx) {
+		return user, nil
+	}
+
+	if user.Officer != nil {
+		if err := requireInJurisdiction(ctx, s.jurisdictions, models.EntityOfficer, user.Officer.ID, "User"); err != nil {
+			return nil, err
+		}
+		return user, nil
+	}
+	if !callerScope(ctx).Unrestricted() {
+		return nil, apperrors.NewNotFound("User")
+	}
+	return user, nil
+}
@@ -0,0 +1,224 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	apperrors "github.com/ghana-police/ticketing-backend/internal/domain/errors"
+	"github.com/ghana-police/ticketing-backend/internal/domain/models"
+	"github.com/ghana-police/ticketing-backend/internal/ports/repositories"
+	portservices "github.com/ghana-police/ticketing-backend/internal/ports/services"
+	jwtpkg "github.com/ghana-police/ticketing-backend/pkg/jwt"
+	"go.uber.org/zap"
+)
+
+const (
+	// signingKeyReload is how often keys are re-read so rotations made by
+	// other instances are picked up.
+	signingKeyReload = time.Minute
+	// signingKeyMissReload throttles reloads caused by unknown kids.
+	signingKeyMissReload = 5 * time.Second
+	// signingKeyPrepublish is how long a new key sits in the JWKS before it
+	// signs, so verifiers caching the JWKS see it first.
+	signingKeyPrepublish = 10 * time.Minute
+	signingKeyTimeout    = 5 * time.Second
+)
+
+type loadedSigningKey struct {
+	key         *jwtpkg.SigningKey
+	activatesAt time.Time
+}
+
+type signingKeyService struct {
+	repo         repositories.SigningKeyRepository
+	algorithm    string
+	rotation     time.Duration
+	accessExpiry time.Duration
+	logger       *zap.Logger
+
+	mu       sync.Mutex
+	keys     []loadedSigningKey // newest activation first
+	loadedAt time.Time
+}
+
+func NewSigningKeyService(
+	repo repositories.SigningKeyRepository,
+	algorithm string,
+	rotation, accessExpiry time.Duration,
+	logger *zap.Logger,
+) portservices.SigningKeyService {
+	if rotation < 2*signingKeyPrepublish {
+		rotation = 2 * signingKeyPrepublish
+	}
+	return &signingKeyService{
+		repo:         repo,
+		algorithm:    algorithm,
+		rotation:     rotation,
+		accessExpiry: accessExpiry,
+		logger:       logger,
+	}
+}
+
+// SigningKey returns the newest active key for the configured algorithm,
+// creating the first key on demand and scheduling the next one once the
+// current key is due for rotation.
+func (s *signingKeyService) SigningKey() (*jwtpkg.SigningKey, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), signingKeyTimeout)
+	defer cancel()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.reload(ctx, false); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	active, pending := s.current(now)
+	if active == nil {
+		// First start, or the algorithm was changed: sign with a new key right away
+		if err := s.rotate(ctx, now, now); err != nil {
+			return nil, err
+		}
+		if active, _ = s.current(now); active == nil {
+			return nil, fmt.Errorf("no active %s signing key", s.algorithm)
+		}
+		return active.key, nil
+	}
+
+	if pending == nil && now.Sub(active.activatesAt) >= s.rotation-signingKeyPrepublish {
+		if err := s.rotate(ctx, now.Add(signingKeyPrepublish), active.activatesAt); err != nil {
+			s.logger.Warn("signing key rotation failed", zap.Error(err))
+		}
+	}
+	return active.key, nil
+}
+
+// VerificationKey returns a live key by kid, reloading once if it is unknown
+// (it may have been created by another instance).
+func (s *signingKeyService) VerificationKey(kid string) (*jwtpkg.SigningKey, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), signingKeyTimeout)
+	defer cancel()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.reload(ctx, false); err != nil {
+		return nil, err
+	}
+	if k := s.find(kid); k != nil {
+		return k, nil
+	}
+	if time.Since(s.loadedAt) >= signingKeyMissReload {
+		if err := s.reload(ctx, true); err != nil {
+			return nil, err
+		}
+		if k := s.find(kid); k != nil {
+			return k, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown signing key %q", kid)
+}
+
+func (s *signingKeyService) JWKS(ctx context.Context) (*jwtpkg.JWKS, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.reload(ctx, false); err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+
+	set := &jwtpkg.JWKS{Keys: []jwtpkg.JWK{}}
+	for _, k := range s.keys {
+		jwk, err := jwtpkg.PublicJWK(k.key)
+		if err != nil {
+			return nil, apperrors.NewInternal(err)
+		}
+		set.Keys = append(set.Keys, jwk)
+	}
+	return set, nil
+}
+
+// current returns the newest key of the configured algorithm that has
+// activated, and a newer one that has not yet (if any).
+func (s *signingKeyService) current(now time.Time) (active, pending *loadedSigningKey) {
+	for i := range s.keys {
+		k := &s.keys[i]
+		if k.key.Algorithm != s.algorithm {
+			continue
+		}
+		if k.activatesAt.After(now) {
+			if pending == nil {
+				pending = k
+			}
+			continue
+		}
+		return k, pending
+	}
+	return nil, pending
+}
+
+func (s *signingKeyService) find(kid string) *jwtpkg.SigningKey {
+	for _, k := range s.keys {
+		if k.key.ID == kid {
+			return k.key
+		}
+	}
+	return nil
+}
+
+// rotate creates a key activating at activatesAt. Keys it replaces stay
+// valid for verification until tokens they signed have expired.
+func (s *signingKeyService) rotate(ctx context.Context, activatesAt, dueBefore time.Time) error {
+	key, err := jwtpkg.GenerateKey(s.algorithm)
+	if err != nil {
+		return err
+	}
+	pemKey, err := jwtpkg.EncodePrivateKey(key)
+	if err != nil {
+		return err
+	}
+
+	retireAt := activatesAt.Add(s.accessExpiry + time.Minute)
+	created, err := s.repo.Rotate(ctx, &models.SigningKey{
+		KID:         key.ID,
+		Algorithm:   key.Algorithm,
+		PrivateKey:  pemKey,
+		ActivatesAt: activatesAt,
+	}, dueBefore, retireAt)
+	if err != nil {
+		return err
+	}
+	if created {
+		s.logger.Info("signing key created",
+			zap.String("kid", key.ID),
+			zap.String("algorithm", key.Algorithm),
+			zap.Time("activatesAt", activatesAt))
+	}
+	return s.reload(ctx, true)
+}
+
+func (s *signingKeyService) reload(ctx context.Context, force bool) error {
+	if !force && !s.loadedAt.IsZero() && time.Since(s.loadedAt) < signingKeyReload {
+		return nil
+	}
+
+	rows, err := s.repo.ListLive(ctx)
+	if err != nil {
+		return err
+	}
+	keys := make([]loadedSigningKey, 0, len(rows))
+	for _, row := range rows {
+		key, err := jwtpkg.ParsePrivateKey(row.KID, row.Algorithm, row.PrivateKey)
+		if err != nil {
+			s.logger.Error("skipping unreadable signing key", zap.String("kid", row.KID), zap.Error(err))
+			continue
+		}
+		keys = append(keys, loadedSigningKey{key: key, activatesAt: row.ActivatesAt})
+	}
+	s.keys = keys
+	s.loadedAt = time.Now()
+	return nil
+}
@@ -6,6 +6,8 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	apperrors "github.com/ghana-police/ticketing-backend/internal/domain/errors"
@@ -13,40 +15,84 @@ import (
 	"github.com/ghana-police/ticketing-backend/internal/middleware"
 	"github.com/ghana-police/ticketing-backend/internal/ports/repositories"
 	portservices "github.com/ghana-police/ticketing-backend/internal/ports/services"
+	"github.com/ghana-police/ticketing-backend/pkg/pagination"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"go.uber.org/zap"
 )
 
 const (
-	maxBatchSize  = 50
-	maxPhotoBytes = 5 * 1024 * 1024 // 5 MB
+	maxBatchSize   = 50
+	maxPhotoBytes  = 5 * 1024 * 1024 // 5 MB
+	changePageSize = 200             // server updates per sync response / change feed page
+	maxChangePage  = 500
+	// changeLookback is how far before its timestamp a pull without a cursor
+	// starts, so a change stamped before rows the device already has, but
+	// committed after it read them, is still delivered. Re-sent changes
+	// overwrite the device's copy with the same data.
+	changeLookback = 30 * time.Second
+
+	maxSessionErrors    = 20             // error messages kept per sync session
+	healthWindow        = 24 * time.Hour // session statistics window for device health
+	defaultSyncInterval = 300            // device.autoSyncIntervalSeconds fallback
+	maxHealthAlerts     = 500            // overdue devices listed per health alert response
 )
 
 type syncService struct {
+	uow           repositories.UnitOfWork
 	syncRepo      repositories.SyncRepository
 	ticketRepo    repositories.TicketRepository
 	offenceRepo   repositories.OffenceRepository
 	hierarchyRepo repositories.HierarchyRepository
+	settingsRepo  repositories.SettingsRepository
+	lookupRepo    repositories.LookupRepository
+	jurisdictions repositories.JurisdictionRepository
+	ledger        repositories.LedgerRepository
+	tickets       portservices.TicketService
+	permissions   portservices.PermissionService
+	devices       portservices.DeviceService
 	storage       portservices.StorageService
-	logger        *zap.Logger
+	// staleMultiplier: a device is stale after autoSyncIntervalSeconds × staleMultiplier without a sync
+	staleMultiplier int
+	logger          *zap.Logger
 }
 
 func NewSyncService(
+	uow repositories.UnitOfWork,
 	syncRepo repositories.SyncRepository,
 	ticketRepo repositories.TicketRepository,
 	offenceRepo repositories.OffenceRepository,
 	hierarchyRepo repositories.HierarchyRepository,
+	settingsRepo repositories.SettingsRepository,
+	lookupRepo repositories.LookupRepository,
+	jurisdictions repositories.JurisdictionRepository,
+	ledger repositories.LedgerRepository,
+	tickets portservices.TicketService,
+	permissions portservices.PermissionService,
+	devices portservices.DeviceService,
 	storage portservices.StorageService,
+	staleMultiplier int,
 	logger *zap.Logger,
 ) portservices.SyncService {
+	if staleMultiplier < 1 {
+		staleMultiplier = 1
+	}
 	return &syncService{
-		syncRepo:      syncRepo,
-		ticketRepo:    ticketRepo,
-		offenceRepo:   offenceRepo,
-		hierarchyRepo: hierarchyRepo,
-		storage:       storage,
-		logger:        logger,
+		uow:             uow,
+		syncRepo:        syncRepo,
+		ticketRepo:      ticketRepo,
+		offenceRepo:     offenceRepo,
+		hierarchyRepo:   hierarchyRepo,
+		settingsRepo:    settingsRepo,
+		lookupRepo:      lookupRepo,
+		jurisdictions:   jurisdictions,
+		ledger:          ledger,
+		tickets:         tickets,
+		permissions:     permissions,
+		devices:         devices,
+		storage:         storage,
+		staleMultiplier: staleMultiplier,
+		logger:          logger,
 	}
 }
 
@@ -54,7 +100,36 @@ func NewSyncService(
 // BatchSync
 // ---------------------------------------------------------------------------
 
-func (s *syncService) BatchSync(ctx context.Context, req *models.SyncRequest, deviceID string) (*models.SyncResponse, error) {
+func (s *syncService) BatchSync(ctx context.Context, req *models.SyncRequest, client models.SyncClientInfo) (json.RawMessage, error) {
+	session := &models.SyncSession{
+		DeviceID:     client.DeviceID,
+		AppVersion:   client.AppVersion,
+		BatteryLevel: client.BatteryLevel,
+		Charging:     client.Charging,
+		NetworkType:  client.NetworkType,
+		PendingItems: client.PendingItems,
+		RequestBytes: client.RequestBytes,
+		StartedAt:    time.Now().UTC(),
+	}
+
+	resp, err := s.batchSync(ctx, req, client, session)
+	var body json.RawMessage
+	if err == nil {
+		if body, err = json.Marshal(resp); err != nil {
+			err = apperrors.NewInternal(fmt.Errorf("encode sync response: %w", err))
+		}
+	}
+	if client.DeviceID != "" {
+		s.recordSession(ctx, session, req, resp, len(body), err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return body, nil
+}
+
+func (s *syncService) batchSync(ctx context.Context, req *models.SyncRequest, client models.SyncClientInfo, session *models.SyncSession) (*models.SyncResponse, error) {
+	deviceID := client.DeviceID
 	totalItems := len(req.Tickets) + len(req.Photos)
 	if totalItems > maxBatchSize {
 		return nil, apperrors.NewValidationError(
@@ -65,8 +140,28 @@ func (s *syncService) BatchSync(ctx context.Context, req *models.SyncRequest, de
 		return nil, apperrors.NewValidationError("Device ID is required (X-Device-ID header)", nil)
 	}
 
+	// Only enrolled, active devices may sync
+	device, err := s.devices.Authorize(ctx, deviceID, client.DeviceKey, true)
+	if err != nil {
+		return nil, err
+	}
+	if device != nil {
+		session.DeviceRef = &device.ID
+	}
+
+	// A cursor continues the feed exactly; a timestamp starts a new pull
+	feedStart := withLookback(models.ChangeCursor{UpdatedAt: req.LastSyncTimestamp, ID: uuid.Max})
+	if req.ServerCursor != nil && *req.ServerCursor != "" {
+		cursor, err := decodeChangeCursor(*req.ServerCursor)
+		if err != nil {
+			return nil, apperrors.NewValidationError("Invalid serverCursor", nil)
+		}
+		feedStart = cursor
+	}
+
 	syncTimestamp := time.Now().UTC()
 	userID := middleware.GetUserID(ctx)
+	strategy := s.conflictStrategy(ctx)
 
 	// Process tickets
 	ticketResults := make([]models.SyncTicketResult, 0, len(req.Tickets))
@@ -74,7 +169,7 @@ func (s *syncService) BatchSync(ctx context.Context, req *models.SyncRequest, de
 	localToServerID := make(map[string]uuid.UUID)
 
 	for _, item := range req.Tickets {
-		result := s.processTicketItem(ctx, item)
+		result := s.processTicketItem(ctx, item, strategy, deviceID)
 		ticketResults = append(ticketResults, result)
 
 		if result.Status == "success" && result.ServerID != "" {
@@ -92,13 +187,41 @@ func (s *syncService) BatchSync(ctx context.Context, req *models.SyncRequest, de
 		photoResults = append(photoResults, result)
 	}
 
-	// Get server updates since lastSyncTimestamp
-	stationID := middleware.GetStationID(ctx)
-	regionID := middleware.GetRegionID(ctx)
-	serverTickets, err := s.syncRepo.GetTicketsUpdatedSince(ctx, req.LastSyncTimestamp, stationID, regionID, 200)
+	// First page of server changes since the device's position in the feed
+	serverUpdates, err := s.changeFeedPage(ctx, feedStart, changePageSize)
 	if err != nil {
 		s.logger.Error("failed to get server updates", zap.Error(err))
-		serverTickets = []models.ServerTicketUpdate{}
+		serverUpdates = &models.ServerUpdates{
+			Tickets:    []models.ServerTicketUpdate{},
+			NextCursor: encodeChangeCursor(feedStart),
+		}
+	}
+	if serverUpdates.HasMore {
+		// Hold the timestamp just below the last delivered change so devices that
+		// only track lastSyncTimestamp re-read ties instead of skipping the rest.
+		last := serverUpdates.Tickets[len(serverUpdates.Tickets)-1]
+		syncTimestamp = last.UpdatedAt.Add(-time.Microsecond)
+	}
+
+	// Reference-data delta for devices that send a lookup cursor
+	var lookup *models.LookupData
+	if req.LookupCursor != nil {
+		lookup, err = lookupDelta(ctx, s.lookupRepo, *req.LookupCursor)
+		if err != nil {
+			s.logger.Error("failed to get lookup delta", zap.Error(err))
+			lookup = nil
+		}
+	}
+
+	// Remote commands for the device, after applying its acknowledgements
+	var commands []models.DeviceCommand
+	if device != nil {
+		commands, err = s.devices.DeliverCommands(ctx, device.ID, req.AckCommands)
+		if err != nil {
+			s.logger.Error("failed to deliver device commands", zap.Error(err))
+			commands = nil
+		}
+		s.devices.Touch(ctx, device.ID, client.AppVersion)
 	}
 
 	// Update device sync record
@@ -113,21 +236,89 @@ func (s *syncService) BatchSync(ctx context.Context, req *models.SyncRequest, de
 			Photos:  photoResults,
 		},
 		ServerUpdates: models.ServerUpdates{
-			Tickets: serverTickets,
+			Tickets:    serverUpdates.Tickets,
+			HasMore:    serverUpdates.HasMore,
+			NextCursor: serverUpdates.NextCursor,
+			Lookup:     lookup,
 		},
+		Commands: commands,
 	}, nil
 }
 
+// recordSession stores the telemetry of one BatchSync call, whose encoded
+// response was responseBytes long. Failures are only logged.
+func (s *syncService) recordSession(ctx context.Context, session *models.SyncSession, req *models.SyncRequest, resp *models.SyncResponse, responseBytes int, syncErr error) {
+	session.DurationMs = int(time.Since(session.StartedAt).Milliseconds())
+	session.UserID = middleware.GetUserID(ctx)
+	session.OfficerID = middleware.GetOfficerID(ctx)
+	session.StationID = middleware.GetStationID(ctx)
+	session.RegionID = middleware.GetRegionID(ctx)
+	session.TicketsReceived = len(req.Tickets)
+	session.PhotosReceived = len(req.Photos)
+	session.Errors = []string{}
+
+	if syncErr != nil {
+		session.Status = models.SyncSessionRejected
+		var appErr *apperrors.AppError
+		if errors.As(syncErr, &appErr) {
+			session.ErrorCode = &appErr.Code
+			session.Errors = append(session.Errors, appErr.Message)
+			if appErr.HTTPStatus >= 500 {
+				session.Status = models.SyncSessionFailed
+			}
+		} else {
+			session.Status = models.SyncSessionFailed
+			session.Errors = append(session.Errors, syncErr.Error())
+		}
+	} else {
+		for _, t := range resp.Results.Tickets {
+			switch t.Status {
+			case "success":
+				session.TicketsSucceeded++
+			case "conflict":
+				session.TicketsConflicted++
+			default:
+				session.TicketsFailed++
+				if t.Error != nil {
+					session.Errors = append(session.Errors, fmt.Sprintf("ticket %s: %s", t.LocalID, *t.Error))
+				}
+			}
+		}
+		for _, p := range resp.Results.Photos {
+			if p.Status == "success" {
+				session.PhotosSucceeded++
+			} else {
+				session.PhotosFailed++
+				session.Errors = append(session.Errors, fmt.Sprintf("photo %s: not stored", p.LocalID))
+			}
+		}
+		session.UpdatesSent = len(resp.ServerUpdates.Tickets)
+
+		session.Status = models.SyncSessionCompleted
+		if session.TicketsFailed+session.PhotosFailed > 0 {
+			session.Status = models.SyncSessionPartial
+		}
+		session.ResponseBytes = int64(responseBytes)
+	}
+	if len(session.Errors) > maxSessionErrors {
+		session.Errors = session.Errors[:maxSessionErrors]
+	}
+
+	if err := s.syncRepo.RecordSession(ctx, session); err != nil {
+		s.logger.Error("failed to record sync session", zap.Error(err), zap.String("deviceId", session.DeviceID))
+	}
+}
+
 // ---------------------------------------------------------------------------
 // Process individual ticket
 // ---------------------------------------------------------------------------
 
-func (s *syncService) processTicketItem(ctx context.Context, item models.SyncTicketItem) models.SyncTicketResult {
+func (s *syncService) processTicketItem(ctx context.Context, item models.SyncTicketItem, strategy, deviceID string) models.SyncTicketResult {
 	switch item.Action {
 	case "create":
 		return s.processTicketCreate(ctx, item)
 	case "update":
-		return s.processTicketUpdate(ctx, item)
+		return s.processTicketUpdate(ctx, item, strategy, deviceID)
 	default:
 		errMsg := fmt.Sprintf("invalid action: %s", item.Action)
 		return models.SyncTicketResult{LocalID: item.ID, Status: "error", Error: &errMsg}
@@ -228,7 +419,12 @@ func (s *syncService) processTicketCreate(ctx context.Context, item models.SyncT
 		DueDate:           &dueDate,
 	}
 
-	_, err = s.ticketRepo.Create(ctx, ticket, offenceInputs)
+	err = s.uow.Do(ctx, func(ctx context.Context) error {
+		if _, err := s.ticketRepo.Create(ctx, ticket, offenceInputs); err != nil {
+			return err
+		}
+		return postTicketIssued(ctx, s.ledger, ticket)
+	})
 	if err != nil {
 		errMsg := fmt.Sprintf("create ticket: %s", err.Error())
 		return models.SyncTicketResult{LocalID: item.ID, Status: "error", Error: &errMsg}
@@ -241,7 +437,7 @@ func (s *syncService) processTicketCreate(ctx context.Context, item models.SyncT
 	}
 }
 
-func (s *syncService) processTicketUpdate(ctx context.Context, item models.SyncTicketItem) models.SyncTicketResult {
+func (s *syncService) processTicketUpdate(ctx context.Context, item models.SyncTicketItem, strategy, deviceID string) models.SyncTicketResult {
 	var data models.SyncTicketUpdateData
 	if err := json.Unmarshal(item.Data, &data); err != nil {
 		errMsg := fmt.Sprintf("invalid update data: %s", err.Error())
@@ -252,8 +448,11 @@ func (s *syncService) processTicketUpdate(ctx context.Context, item models.SyncT
 		errMsg := "server ticket ID required for updates"
 		return models.SyncTicketResult{LocalID: item.ID, Status: "error", Error: &errMsg}
 	}
+	if err := requireInJurisdiction(ctx, s.jurisdictions, models.EntityTicket, data.ID, "Ticket"); err != nil {
+		errMsg := err.Error()
+		return models.SyncTicketResult{LocalID: item.ID, ServerID: data.ID.String(), Status: "error", Error: &errMsg}
+	}
 
-	// Check server version — server-wins conflict resolution
 	existing, err := s.ticketRepo.GetByID(ctx, data.ID)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -264,25 +463,205 @@ func (s *syncService) processTicketUpdate(ctx context.Context, item models.SyncT
 		return models.SyncTicketResult{LocalID: item.ID, ServerID: data.ID.String(), Status: "error", Error: &errMsg}
 	}
 
-	// Server-wins: if the server version was updated after the device's change timestamp, report conflict
-	if existing.UpdatedAt.After(item.Timestamp) {
-		errMsg := "server version is newer — server-wins conflict resolution applied"
-		return models.SyncTicketResult{LocalID: item.ID, ServerID: data.ID.String(), Status: "conflict", Error: &errMsg}
+	result := models.SyncTicketResult{LocalID: item.ID, ServerID: data.ID.String(), Status: "success"}
+
+	// No newer server version — apply the device changes as-is
+	if !existing.UpdatedAt.After(item.Timestamp) {
+		if statusChanged(data, existing.Status) && !isValidStatusTransition(existing.Status, *data.Status) {
+			errMsg := fmt.Sprintf("cannot transition from %s to %s", existing.Status, *data.Status)
+			result.Status = "error"
+			result.Error = &errMsg
+			return result
+		}
+		if err := s.applyTicketUpdate(ctx, data, existing.Status, middleware.GetOfficerID(ctx), []string{"status", "notes"}); err != nil {
+			errMsg := err.Error()
+			result.Status = "error"
+			result.Error = &errMsg
+		}
+		return result
+	}
+
+	merge := mergeTicketUpdate(strategy, data, existing.Status)
+	officerID := middleware.GetOfficerID(ctx)
+
+	if err := s.applyTicketUpdate(ctx, data, existing.Status, officerID, merge.applied); err != nil {
+		errMsg := err.Error()
+		result.Status = "error"
+		result.Error = &errMsg
+		return result
 	}
 
-	// Apply updates
-	if data.Notes != nil && *data.Notes != "" {
-		officerID := middleware.GetOfficerID(ctx)
-		if officerID != nil {
-			_ = s.ticketRepo.AppendNote(ctx, data.ID, *officerID, *data.Notes)
+	if len(merge.held) == 0 && (len(merge.applied) == 0 || strategy == models.ConflictStrategyFieldMerge) {
+		// Nothing contended — no conflict record needed
+		return result
+	}
+
+	serverData, _ := json.Marshal(map[string]any{
+		"status":    existing.Status,
+		"updatedAt": existing.UpdatedAt,
+	})
+	conflict := &models.SyncConflict{
+		TicketID:        data.ID,
+		UserID:          middleware.GetUserID(ctx),
+		OfficerID:       officerID,
+		DeviceID:        deviceID,
+		LocalID:         item.ID,
+		Strategy:        strategy,
+		Fields:          merge.held,
+		ClientData:      item.Data,
+		ServerData:      serverData,
+		ClientTimestamp: item.Timestamp,
+		ServerUpdatedAt: existing.UpdatedAt,
+		Status:          merge.status,
+		StationID:       existing.StationID,
+		RegionID:        existing.RegionID,
+	}
+	if merge.status == models.SyncConflictStatusAutoResolved {
+		now := time.Now()
+		conflict.Resolution = &merge.resolution
+		conflict.ResolvedAt = &now
+	}
+	if err := s.syncRepo.CreateConflict(ctx, conflict); err != nil {
+		s.logger.Error("failed to record sync conflict", zap.Error(err), zap.String("ticket_id", data.ID.String()))
+	} else {
+		result.ConflictID = &conflict.ID
+	}
+
+	if len(merge.held) > 0 {
+		errMsg := fmt.Sprintf("server version is newer — %s conflict resolution applied, not applied: %s",
+			strategy, strings.Join(merge.held, ", "))
+		result.Status = "conflict"
+		result.Error = &errMsg
+	}
+	return result
+}
+
+// ---------------------------------------------------------------------------
+// Merge engine
+// ---------------------------------------------------------------------------
+
+// ticketMerge is the outcome of merging a stale device update into the server ticket.
+type ticketMerge struct {
+	applied    []string // device fields written to the ticket
+	held       []string // device fields not applied (kept on the conflict record)
+	status     string   // conflict record status: pending or auto_resolved
+	resolution string   // server, client or merged when auto-resolved
+}
+
+// mergeTicketUpdate decides, field by field, which device changes survive a
+// collision with a newer server version. Notes are append-only and never
+// contend with the server; status is only applied along allowed transitions.
+func mergeTicketUpdate(strategy string, data models.SyncTicketUpdateData, serverStatus string) ticketMerge {
+	var changed []string
+	if statusChanged(data, serverStatus) {
+		changed = append(changed, "status")
+	}
+	if hasNote(data) {
+		changed = append(changed, "notes")
+	}
+
+	switch strategy {
+	case models.ConflictStrategyClientWins:
+		m := ticketMerge{status: models.SyncConflictStatusAutoResolved, resolution: models.SyncConflictResolutionClient}
+		for _, f := range changed {
+			if f == "status" && !isValidStatusTransition(serverStatus, *data.Status) {
+				m.held = append(m.held, f)
+				continue
+			}
+			m.applied = append(m.applied, f)
+		}
+		if len(m.held) > 0 {
+			m.status = models.SyncConflictStatusPending
+			m.resolution = ""
+		}
+		return m
+
+	case models.ConflictStrategyFieldMerge:
+		m := ticketMerge{status: models.SyncConflictStatusAutoResolved, resolution: models.SyncConflictResolutionMerged}
+		for _, f := range changed {
+			if f == "status" {
+				// Apply only when the server has not moved the status since the device last saw it
+				serverUntouched := data.BaseStatus != nil && *data.BaseStatus == serverStatus
+				if !serverUntouched || !isValidStatusTransition(serverStatus, *data.Status) {
+					m.held = append(m.held, f)
+					continue
+				}
+			}
+			m.applied = append(m.applied, f)
+		}
+		if len(m.held) > 0 {
+			m.status = models.SyncConflictStatusPending
+			m.resolution = ""
 		}
+		return m
+
+	case models.ConflictStrategyManual:
+		return ticketMerge{held: changed, status: models.SyncConflictStatusPending}
+
+	default: // server-wins
+		return ticketMerge{held: changed, status: models.SyncConflictStatusAutoResolved, resolution: models.SyncConflictResolutionServer}
 	}
+}
 
-	return models.SyncTicketResult{
-		LocalID:  item.ID,
-		ServerID: data.ID.String(),
-		Status:   "success",
+// deviceStatuses are the statuses an officer's device may set on its own.
+// None of them changes what the ticket owes.
+var deviceStatuses = []string{"objection"}
+
+// applyTicketUpdate writes the listed device fields to the ticket.
+func (s *syncService) applyTicketUpdate(ctx context.Context, data models.SyncTicketUpdateData, currentStatus string, officerID *uuid.UUID, fields []string) error {
+	if slices.Contains(fields, "status") && statusChanged(data, currentStatus) {
+		if err := s.applyStatus(ctx, data.ID, *data.Status); err != nil {
+			return fmt.Errorf("update status: %w", err)
+		}
+	}
+	if slices.Contains(fields, "notes") && hasNote(data) && officerID != nil {
+		if err := s.ticketRepo.AppendNote(ctx, data.ID, *officerID, *data.Notes); err != nil {
+			return fmt.Errorf("append note: %w", err)
+		}
 	}
+	return nil
+}
+
+// applyStatus sets a status that came from a device. Any status other than
+// the device statuses, paid and cancelled in particular, needs ticket.update
+// and goes through the ticket service as PATCH /tickets/{id} does.
+func (s *syncService) applyStatus(ctx context.Context, ticketID uuid.UUID, status string) error {
+	if slices.Contains(deviceStatuses, status) {
+		return s.ticketRepo.UpdateStatus(ctx, ticketID, status)
+	}
+
+	ok, err := s.permissions.Has(ctx, middleware.GetUserID(ctx), middleware.GetUserRole(ctx), models.PermTicketUpdate)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return apperrors.NewForbidden("Status " + status + " cannot be set from a device")
+	}
+	_, err = s.tickets.Update(ctx, ticketID, &portservices.UpdateTicketRequest{Status: &status})
+	return err
+}
+
+// conflictStrategy reads data.conflictResolution, falling back to server-wins.
+func (s *syncService) conflictStrategy(ctx context.Context) string {
+	raw, err := s.settingsRepo.GetBySection(ctx, "data")
+	if err != nil {
+		raw = models.DefaultSettings()["data"]
+	}
+	var data struct {
+		ConflictResolution string `json:"conflictResolution"`
+	}
+	if err := json.Unmarshal(raw, &data); err != nil || !slices.Contains(models.ConflictStrategies, data.ConflictResolution) {
+		return models.ConflictStrategyServerWins
+	}
+	return data.ConflictResolution
+}
+
+func statusChanged(data models.SyncTicketUpdateData, serverStatus string) bool {
+	return data.Status != nil && *data.Status != "" && *data.Status != serverStatus
+}
+
+func hasNote(data models.SyncTicketUpdateData) bool {
+	return data.Notes != nil && *data.Notes != ""
 }
 
 // ---------------------------------------------------------------------------
@@ -311,6 +690,9 @@ func (s *syncService) processPhotoItem(ctx context.Context, item models.SyncPhot
 	if err != nil {
 		return models.SyncPhotoResult{LocalID: item.PhotoID, Status: "error"}
 	}
+	if err := requireInJurisdiction(ctx, s.jurisdictions, models.EntityTicket, ticketID, "Ticket"); err != nil {
+		return models.SyncPhotoResult{LocalID: item.PhotoID, Status: "error"}
+	}
 
 	// Save file
 	photoID := uuid.New()
@@ -336,6 +718,99 @@ func (s *syncService) processPhotoItem(ctx context.Context, item models.SyncPhot
 	}
 }
 
+// ---------------------------------------------------------------------------
+// Change feed
+// ---------------------------------------------------------------------------
+
+func (s *syncService) GetChanges(ctx context.Context, cursor string, since *time.Time, limit int) (*models.ServerUpdates, error) {
+	var after models.ChangeCursor
+	switch {
+	case cursor != "":
+		c, err := decodeChangeCursor(cursor)
+		if err != nil {
+			return nil, apperrors.NewValidationError("Invalid cursor", nil)
+		}
+		after = c
+	case since != nil:
+		after = withLookback(models.ChangeCursor{UpdatedAt: *since, ID: uuid.Max})
+	default:
+		return nil, apperrors.NewValidationError("cursor or since is required", nil)
+	}
+
+	if limit <= 0 || limit > maxChangePage {
+		limit = changePageSize
+	}
+
+	updates, err := s.changeFeedPage(ctx, after, limit)
+	if err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+	return updates, nil
+}
+
+// changeFeedPage reads one page of ticket changes after the cursor, scoped to the
+// caller's station/region. NextCursor always points at the last delivered change
+// (or back at the start cursor when the page is empty).
+func (s *syncService) changeFeedPage(ctx context.Context, after models.ChangeCursor, limit int) (*models.ServerUpdates, error) {
+	stationID := middleware.GetStationID(ctx)
+	regionID := middleware.GetRegionID(ctx)
+
+	// Fetch one extra row to learn whether another page exists
+	tickets, err := s.syncRepo.GetTicketChanges(ctx, after, stationID, regionID, limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	hasMore := len(tickets) > limit
+	if hasMore {
+		tickets = tickets[:limit]
+	}
+
+	next := after
+	if len(tickets) > 0 {
+		last := tickets[len(tickets)-1]
+		next = models.ChangeCursor{UpdatedAt: last.UpdatedAt, ID: last.ID}
+	}
+
+	return &models.ServerUpdates{
+		Tickets:    tickets,
+		HasMore:    hasMore,
+		NextCursor: encodeChangeCursor(next),
+	}, nil
+}
+
+// withLookback moves the start of a pull from a timestamp back by
+// changeLookback. Cursors are never moved back, so many changes within the
+// window cannot keep a device paging over the same rows.
+func withLookback(c models.ChangeCursor) models.ChangeCursor {
+	return models.ChangeCursor{UpdatedAt: c.UpdatedAt.Add(-changeLookback), ID: uuid.Nil}
+}
+
+func encodeChangeCursor(c models.ChangeCursor) string {
+	raw := c.UpdatedAt.UTC().Format(time.RFC3339Nano) + "|" + c.ID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeChangeCursor(cursor string) (models.ChangeCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return models.ChangeCursor{}, err
+	}
+	ts, id, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return models.ChangeCursor{}, fmt.Errorf("malformed cursor")
+	}
+	updatedAt, err := time.Parse(time.RFC3339Nano, ts)
+	if err != nil {
+		return models.ChangeCursor{}, err
+	}
+	parsedID, err := uuid.Parse(id)
+	if err != nil {
+		return models.ChangeCursor{}, err
+	}
+	return models.ChangeCursor{UpdatedAt: updatedAt, ID: parsedID}, nil
+}
+
 // ---------------------------------------------------------------------------
 // GetStatus
 // ---------------------------------------------------------------------------
@@ -360,6 +835,10 @@ func (s *syncService) GetStatus(ctx context.Context, deviceID string) (*models.S
 		}
 		return nil, apperrors.NewInternal(err)
 	}
+	if ds.LastSuccessAt == nil {
+		// Only rejected attempts so far
+		return status, nil
+	}
 
 	status.LastSyncTimestamp = &ds.LastSyncTimestamp
 
@@ -373,6 +852,82 @@ func (s *syncService) GetStatus(ctx context.Context, deviceID string) (*models.S
 	return status, nil
 }
 
+// ---------------------------------------------------------------------------
+// Conflict queue
+// ---------------------------------------------------------------------------
+
+func (s *syncService) ListConflicts(ctx context.Context, filter models.SyncConflictFilter, p pagination.Params) ([]models.SyncConflict, int, error) {
+	filter.Scope = callerScope(ctx)
+	items, total, err := s.syncRepo.ListConflicts(ctx, filter, p)
+	if err != nil {
+		return nil, 0, apperrors.NewInternal(err)
+	}
+	return items, total, nil
+}
+
+func (s *syncService) GetConflict(ctx context.Context, id uuid.UUID) (*models.SyncConflict, error) {
+	conflict, err := s.syncRepo.GetConflictByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NewNotFound("Sync conflict")
+		}
+		return nil, apperrors.NewInternal(err)
+	}
+
+	if err := requireInJurisdiction(ctx, s.jurisdictions, models.EntitySyncConflict, id, "Sync conflict"); err != nil {
+		return nil, err
+	}
+	return conflict, nil
+}
+
+func (s *syncService) ResolveConflict(ctx context.Context, id uuid.UUID, req *portservices.ResolveConflictRequest) (*models.SyncConflict, error) {
+	if req.Resolution != models.SyncConflictResolutionServer && req.Resolution != models.SyncConflictResolutionClient {
+		return nil, apperrors.NewValidationError("Resolution must be 'server' or 'client'", nil)
+	}
+
+	conflict, err := s.GetConflict(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if conflict.Status != models.SyncConflictStatusPending {
+		return nil, apperrors.NewValidationError("Only pending conflicts can be resolved", nil)
+	}
+
+	if req.Resolution == models.SyncConflictResolutionClient {
+		var data models.SyncTicketUpdateData
+		if err := json.Unmarshal(conflict.ClientData, &data); err != nil {
+			return nil, apperrors.NewInternal(err)
+		}
+		data.ID = conflict.TicketID
+
+		ticket, err := s.ticketRepo.GetByID(ctx, conflict.TicketID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, apperrors.NewNotFound("Ticket")
+			}
+			return nil, apperrors.NewInternal(err)
+		}
+		if slices.Contains(conflict.Fields, "status") && statusChanged(data, ticket.Status) &&
+			!isValidStatusTransition(ticket.Status, *data.Status) {
+			return nil, apperrors.NewValidationError(
+				fmt.Sprintf("Cannot transition from %s to %s", ticket.Status, *data.Status), nil)
+		}
+		if err := s.applyTicketUpdate(ctx, data, ticket.Status, conflict.OfficerID, conflict.Fields); err != nil {
+			return nil, asAppError(err)
+		}
+	}
+
+	if err := s.syncRepo.ResolveConflict(ctx, id, req.Resolution, middleware.GetUserID(ctx), req.Notes); err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+
+	result, err := s.syncRepo.GetConflictByID(ctx, id)
+	if err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+	return result, nil
+}
+
 // ---------------------------------------------------------------------------
 // Helpers
 // ---------------------------------------------------------------------------
@@ -411,3 +966,105 @@ func (s *syncService) resolveTicketID(ticketRef string, localToServerID map[stri
 	}
 	return id, nil
 }
+
+// ---------------------------------------------------------------------------
+// Device health
+// ---------------------------------------------------------------------------
+
+// healthThresholds derives the health classification from the device and data
+// settings. It also returns the stale threshold in seconds.
+func (s *syncService) healthThresholds(ctx context.Context) (models.DeviceHealthThresholds, int) {
+	interval := defaultSyncInterval
+	if raw, err := s.settingsRepo.GetBySection(ctx, "device"); err == nil {
+		var device struct {
+			AutoSyncIntervalSeconds int `json:"autoSyncIntervalSeconds"`
+		}
+		if json.Unmarshal(raw, &device) == nil && device.AutoSyncIntervalSeconds > 0 {
+			interval = device.AutoSyncIntervalSeconds
+		}
+	}
+
+	backlog := maxBatchSize
+	if raw, err := s.settingsRepo.GetBySection(ctx, "data"); err == nil {
+		var data struct {
+			SyncBatchSize int `json:"syncBatchSize"`
+		}
+		if json.Unmarshal(raw, &data) == nil && data.SyncBatchSize > 0 {
+			backlog = data.SyncBatchSize
+		}
+	}
+
+	threshold := interval * s.staleMultiplier
+	now := time.Now()
+	return models.DeviceHealthThresholds{
+		StaleBefore:  now.Add(-time.Duration(threshold) * time.Second),
+		WindowStart:  now.Add(-healthWindow),
+		BacklogItems: backlog,
+	}, threshold
+}
+
+func (s *syncService) ListDeviceHealth(ctx context.Context, filter models.DeviceHealthFilter, p pagination.Params) ([]models.DeviceHealth, int, error) {
+	filter.Scope = callerScope(ctx)
+	thresholds, threshold := s.healthThresholds(ctx)
+
+	items, total, err := s.syncRepo.ListDeviceHealth(ctx, filter, thresholds, p)
+	if err != nil {
+		return nil, 0, apperrors.NewInternal(err)
+	}
+	setOverdue(items, threshold)
+	return items, total, nil
+}
+
+func (s *syncService) ListStationHealth(ctx context.Context, filter models.DeviceHealthFilter) ([]models.StationSyncHealth, error) {
+	filter.Scope = callerScope(ctx)
+	thresholds, _ := s.healthThresholds(ctx)
+
+	items, err := s.syncRepo.ListStationHealth(ctx, filter, thresholds)
+	if err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+	return items, nil
+}
+
+func (s *syncService) GetHealthAlerts(ctx context.Context, filter models.DeviceHealthFilter) (*models.SyncHealthAlerts, error) {
+	filter.Scope = callerScope(ctx)
+	thresholds, threshold := s.healthThresholds(ctx)
+
+	stale := models.DeviceHealthStale
+	filter.Health = &stale
+	p := pagination.Params{Page: 1, Limit: maxHealthAlerts, SortBy: "lastSessionAt", SortOrder: "asc"}
+
+	items, total, err := s.syncRepo.ListDeviceHealth(ctx, filter, thresholds, p)
+	if err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+	setOverdue(items, threshold)
+	return &models.SyncHealthAlerts{
+		ThresholdSeconds: threshold,
+		Total:            total,
+		Truncated:        total > len(items),
+		Devices:          items,
+	}, nil
+}
+
+func (s *syncService) ListSessions(ctx context.Context, deviceID string, p pagination.Params) ([]models.SyncSession, int, error) {
+	items, total, err := s.syncRepo.ListSessions(ctx, deviceID, callerScope(ctx), p)
+	if err != nil {
+		return nil, 0, apperrors.NewInternal(err)
+	}
+	return items, total, nil
+}
+
+// setOverdue fills in how long stale devices are past the expected sync interval.
+func setOverdue(items []models.DeviceHealth, thresholdSeconds int) {
+	now := time.Now()
+	for i := range items {
+		if items[i].Health != models.DeviceHealthStale {
+			continue
+		}
+		overdue := int64(now.Sub(items[i].LastSessionAt).Seconds()) - int64(thresholdSeconds)
+		if overdue > 0 {
+			items[i].OverdueSeconds = overdue
+		}
+	}
+}
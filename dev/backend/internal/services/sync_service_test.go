@@ -0,0 +1,210 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	apperrors "github.com/ghana-police/ticketing-backend/internal/domain/errors"
+	"github.com/ghana-police/ticketing-backend/internal/domain/models"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+func TestApplyTicketUpdateStatus(t *testing.T) {
+	permissions := &fakePermissions{roles: map[string][]string{
+		"admin": {models.PermTicketUpdate},
+	}}
+
+	tests := []struct {
+		name        string
+		role        string
+		status      string
+		wantRepo    []string // statuses written directly
+		wantService []string // statuses set through the ticket service
+		wantErr     string   // AppError code
+	}{
+		{name: "officer raises objection", role: "officer", status: "objection", wantRepo: []string{"objection"}},
+		{name: "officer cannot mark paid", role: "officer", status: "paid", wantErr: apperrors.CodeForbidden},
+		{name: "officer cannot cancel", role: "officer", status: "cancelled", wantErr: apperrors.CodeForbidden},
+		{name: "officer cannot mark overdue", role: "officer", status: "overdue", wantErr: apperrors.CodeForbidden},
+		{name: "admin marks paid via ticket service", role: "admin", status: "paid", wantService: []string{"paid"}},
+		{name: "admin cancels via ticket service", role: "admin", status: "cancelled", wantService: []string{"cancelled"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeTicketRepo{}
+			tickets := &fakeTicketService{}
+			s := &syncService{ticketRepo: repo, tickets: tickets, permissions: permissions, logger: zap.NewNop()}
+
+			status := tt.status
+			data := models.SyncTicketUpdateData{ID: uuid.New(), Status: &status}
+			err := s.applyTicketUpdate(callerCtx(tt.role, nil, nil), data, "unpaid", nil, []string{"status", "notes"})
+
+			if tt.wantErr != "" {
+				var appErr *apperrors.AppError
+				if !errors.As(err, &appErr) || appErr.Code != tt.wantErr {
+					t.Fatalf("err = %v, want %s", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !slices.Equal(repo.statuses, tt.wantRepo) {
+				t.Errorf("repo statuses = %v, want %v", repo.statuses, tt.wantRepo)
+			}
+			if !slices.Equal(tickets.updates, tt.wantService) {
+				t.Errorf("ticket service statuses = %v, want %v", tickets.updates, tt.wantService)
+			}
+		})
+	}
+}
+
+func TestBatchSyncRecordsResponseSize(t *testing.T) {
+	repo := &fakeSyncRepo{changes: []models.ServerTicketUpdate{
+		{ID: uuid.New(), UpdatedAt: time.Now().UTC()},
+	}}
+	s := &syncService{syncRepo: repo, settingsRepo: &fakeSettings{}, devices: &fakeDevices{}, logger: zap.NewNop()}
+
+	body, err := s.BatchSync(callerCtx("officer", nil, nil), &models.SyncRequest{}, models.SyncClientInfo{DeviceID: "dev-1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var resp models.SyncResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		t.Fatalf("response is not a SyncResponse: %v", err)
+	}
+	if len(resp.ServerUpdates.Tickets) != 1 {
+		t.Errorf("server updates = %d, want 1", len(resp.ServerUpdates.Tickets))
+	}
+
+	if len(repo.sessions) != 1 {
+		t.Fatalf("sessions recorded = %d, want 1", len(repo.sessions))
+	}
+	if got := repo.sessions[0].ResponseBytes; got != int64(len(body)) {
+		t.Errorf("session responseBytes = %d, want the %d bytes returned", got, len(body))
+	}
+}
+
+func TestGetHealthAlertsTruncation(t *testing.T) {
+	tests := []struct {
+		name          string
+		stale         int
+		wantListed    int
+		wantTruncated bool
+	}{
+		{name: "all listed", stale: 3, wantListed: 3},
+		{name: "at the cap", stale: maxHealthAlerts, wantListed: maxHealthAlerts},
+		{name: "over the cap", stale: maxHealthAlerts + 1, wantListed: maxHealthAlerts, wantTruncated: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			health := make([]models.DeviceHealth, tt.stale)
+			for i := range health {
+				health[i].Health = models.DeviceHealthStale
+			}
+			s := &syncService{syncRepo: &fakeSyncRepo{health: health}, settingsRepo: &fakeSettings{}, staleMultiplier: 3, logger: zap.NewNop()}
+
+			alerts, err := s.GetHealthAlerts(callerCtx("super_admin", nil, nil), models.DeviceHealthFilter{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(alerts.Devices) != tt.wantListed || alerts.Total != tt.stale || alerts.Truncated != tt.wantTruncated {
+				t.Errorf("listed %d of %d (truncated %v), want %d of %d (truncated %v)",
+					len(alerts.Devices), alerts.Total, alerts.Truncated, tt.wantListed, tt.stale, tt.wantTruncated)
+			}
+		})
+	}
+}
+
+func TestChangeFeedLookback(t *testing.T) {
+	now := time.Now().UTC()
+	early := models.ServerTicketUpdate{ID: uuid.New(), UpdatedAt: now.Add(-10 * time.Second)}
+	late := models.ServerTicketUpdate{ID: uuid.New(), UpdatedAt: now.Add(-5 * time.Second)}
+	repo := &fakeSyncRepo{changes: []models.ServerTicketUpdate{late}}
+	s := &syncService{syncRepo: repo, settingsRepo: &fakeSettings{}, devices: &fakeDevices{}, logger: zap.NewNop()}
+	ctx := callerCtx("officer", nil, nil)
+
+	first := batchSyncResponse(t, s, &models.SyncRequest{})
+	if len(first.ServerUpdates.Tickets) != 1 {
+		t.Fatalf("first sync delivered %d changes, want 1", len(first.ServerUpdates.Tickets))
+	}
+	cursor := first.ServerUpdates.NextCursor
+
+	// A change stamped before the delivered one commits only now
+	repo.changes = []models.ServerTicketUpdate{early, late}
+
+	// A cursor continues exactly, on sync and on the change feed
+	next := batchSyncResponse(t, s, &models.SyncRequest{ServerCursor: &cursor})
+	if len(next.ServerUpdates.Tickets) != 0 {
+		t.Errorf("sync from cursor delivered %d changes, want 0", len(next.ServerUpdates.Tickets))
+	}
+	page, err := s.GetChanges(ctx, cursor, nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Tickets) != 0 {
+		t.Errorf("change feed from cursor delivered %d changes, want 0", len(page.Tickets))
+	}
+
+	// A sync from the last timestamp looks back and picks it up
+	next = batchSyncResponse(t, s, &models.SyncRequest{LastSyncTimestamp: first.SyncTimestamp})
+	var ids []uuid.UUID
+	for _, u := range next.ServerUpdates.Tickets {
+		ids = append(ids, u.ID)
+	}
+	if !slices.Contains(ids, early.ID) {
+		t.Errorf("sync from timestamp delivered %v, want the late-committed change %s", ids, early.ID)
+	}
+}
+
+func TestChangeFeedPagingEnds(t *testing.T) {
+	// More than a page of changes sharing one timestamp, inside the lookback window
+	stamp := time.Now().UTC().Add(-time.Second)
+	changes := make([]models.ServerTicketUpdate, 2*changePageSize+50)
+	for i := range changes {
+		changes[i] = models.ServerTicketUpdate{ID: uuid.New(), UpdatedAt: stamp}
+	}
+	slices.SortFunc(changes, func(a, b models.ServerTicketUpdate) int { return bytes.Compare(a.ID[:], b.ID[:]) })
+	s := &syncService{syncRepo: &fakeSyncRepo{changes: changes}, settingsRepo: &fakeSettings{}, devices: &fakeDevices{}, logger: zap.NewNop()}
+
+	seen := map[uuid.UUID]bool{}
+	req := &models.SyncRequest{LastSyncTimestamp: stamp}
+	for pages := 1; ; pages++ {
+		if pages > len(changes)/changePageSize+1 {
+			t.Fatalf("still paging after %d pages; %d of %d changes seen", pages-1, len(seen), len(changes))
+		}
+		resp := batchSyncResponse(t, s, req)
+		for _, u := range resp.ServerUpdates.Tickets {
+			if seen[u.ID] {
+				t.Fatalf("page %d repeated change %s", pages, u.ID)
+			}
+			seen[u.ID] = true
+		}
+		if !resp.ServerUpdates.HasMore {
+			break
+		}
+		cursor := resp.ServerUpdates.NextCursor
+		req = &models.SyncRequest{LastSyncTimestamp: resp.SyncTimestamp, ServerCursor: &cursor}
+	}
+	if len(seen) != len(changes) {
+		t.Errorf("delivered %d changes, want %d", len(seen), len(changes))
+	}
+}
+
+// batchSyncResponse runs a sync as an officer's device and decodes the response.
+func batchSyncResponse(t *testing.T, s *syncService, req *models.SyncRequest) models.SyncResponse {
+	t.Helper()
+	body, err := s.BatchSync(callerCtx("officer", nil, nil), req, models.SyncClientInfo{DeviceID: "dev-1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var resp models.SyncResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		t.Fatal(err)
+	}
+	return resp
+}
@@ -21,49 +21,38 @@ import (
 const dueDateDays = 14
 
 type ticketService struct {
+	uow           repositories.UnitOfWork
 	ticketRepo    repositories.TicketRepository
 	offenceRepo   repositories.OffenceRepository
 	hierarchyRepo repositories.HierarchyRepository
+	jurisdictions repositories.JurisdictionRepository
+	ledger        repositories.LedgerRepository
 	storage       portservices.StorageService
 	logger        *zap.Logger
 }
 
 func NewTicketService(
+	uow repositories.UnitOfWork,
 	ticketRepo repositories.TicketRepository,
 	offenceRepo repositories.OffenceRepository,
 	hierarchyRepo repositories.HierarchyRepository,
+	jurisdictions repositories.JurisdictionRepository,
+	ledger repositories.LedgerRepository,
 	storage portservices.StorageService,
 	logger *zap.Logger,
 ) portservices.TicketService {
 	return &ticketService{
+		uow:           uow,
 		ticketRepo:    ticketRepo,
 		offenceRepo:   offenceRepo,
 		hierarchyRepo: hierarchyRepo,
+		jurisdictions: jurisdictions,
+		ledger:        ledger,
 		storage:       storage,
 		logger:        logger,
 	}
 }
 
-// ---------------------------------------------------------------------------
-// Jurisdiction filter (applied to list/search/stats)
-// ---------------------------------------------------------------------------
-
-func applyTicketJurisdiction(ctx context.Context, filter *models.TicketFilter) {
-	role := middleware.GetUserRole(ctx)
-	switch role {
-	case "officer", "supervisor":
-		stationID := middleware.GetStationID(ctx)
-		if stationID != nil {
-			filter.StationID = stationID
-		}
-	case "admin", "accountant":
-		regionID := middleware.GetRegionID(ctx)
-		if regionID != nil && filter.RegionID == nil {
-			filter.RegionID = regionID
-		}
-	}
-}
-
 // ---------------------------------------------------------------------------
 // Create
 // ---------------------------------------------------------------------------
@@ -161,7 +150,13 @@ func (s *ticketService) Create(ctx context.Context, req *portservices.CreateTick
 		DueDate:           &dueDate,
 	}
 
-	_, err = s.ticketRepo.Create(ctx, ticket, offenceInputs)
+	// The ticket and its fine in the revenue ledger are recorded together
+	err = s.uow.Do(ctx, func(ctx context.Context) error {
+		if _, err := s.ticketRepo.Create(ctx, ticket, offenceInputs); err != nil {
+			return err
+		}
+		return postTicketIssued(ctx, s.ledger, ticket)
+	})
 	if err != nil {
 		return nil, apperrors.NewInternal(fmt.Errorf("create ticket: %w", err))
 	}
@@ -192,6 +187,9 @@ func (s *ticketService) GetByID(ctx context.Context, ticketID uuid.UUID) (*model
 		}
 		return nil, apperrors.NewInternal(err)
 	}
+	if err := requireInJurisdiction(ctx, s.jurisdictions, models.EntityTicket, resp.ID, "Ticket"); err != nil {
+		return nil, err
+	}
 	return resp, nil
 }
 
@@ -203,11 +201,14 @@ func (s *ticketService) GetByNumber(ctx context.Context, ticketNumber string) (*
 		}
 		return nil, apperrors.NewInternal(err)
 	}
+	if err := requireInJurisdiction(ctx, s.jurisdictions, models.EntityTicket, resp.ID, "Ticket"); err != nil {
+		return nil, err
+	}
 	return resp, nil
 }
 
 func (s *ticketService) List(ctx context.Context, filter models.TicketFilter, search string, p pagination.Params) ([]models.TicketListItem, int, error) {
-	applyTicketJurisdiction(ctx, &filter)
+	filter.Scope = callerScope(ctx)
 	return s.ticketRepo.List(ctx, filter, search, p)
 }
 
@@ -215,8 +216,7 @@ func (s *ticketService) Search(ctx context.Context, query string) ([]models.Tick
 	if len(strings.TrimSpace(query)) < 2 {
 		return nil, apperrors.NewValidationError("Search query must be at least 2 characters", nil)
 	}
-	var filter models.TicketFilter
-	applyTicketJurisdiction(ctx, &filter)
+	filter := models.TicketFilter{Scope: callerScope(ctx)}
 	return s.ticketRepo.Search(ctx, query, filter)
 }
 
@@ -232,6 +232,9 @@ func (s *ticketService) Update(ctx context.Context, ticketID uuid.UUID, req *por
 		}
 		return nil, apperrors.NewInternal(err)
 	}
+	if err := requireInJurisdiction(ctx, s.jurisdictions, models.EntityTicket, ticketID, "Ticket"); err != nil {
+		return nil, err
+	}
 
 	if existing.Status == "paid" || existing.Status == "cancelled" {
 		return nil, apperrors.NewValidationError("Cannot edit a ticket that is "+existing.Status, nil)
@@ -242,7 +245,13 @@ func (s *ticketService) Update(ctx context.Context, ticketID uuid.UUID, req *por
 			return nil, apperrors.NewValidationError(
 				fmt.Sprintf("Cannot transition from %s to %s", existing.Status, *req.Status), nil)
 		}
-		if err := s.ticketRepo.UpdateStatus(ctx, ticketID, *req.Status); err != nil {
+		// Marking a ticket paid or cancelled by hand waives what is left
+		memo := fmt.Sprintf("Ticket %s changed from %s to %s", existing.TicketNumber, existing.Status, *req.Status)
+		err := trackReceivable(ctx, s.uow, s.ticketRepo, s.ledger, ticketID, models.JournalTicketWaived, memo,
+			func(ctx context.Context) error {
+				return s.ticketRepo.UpdateStatus(ctx, ticketID, *req.Status)
+			})
+		if err != nil {
 			return nil, apperrors.NewInternal(err)
 		}
 	}
@@ -252,7 +261,13 @@ func (s *ticketService) Update(ctx context.Context, ticketID uuid.UUID, req *por
 		if err != nil {
 			return nil, err
 		}
-		if _, err := s.ticketRepo.ReplaceOffences(ctx, ticketID, offenceInputs); err != nil {
+		memo := fmt.Sprintf("Offences on ticket %s amended", existing.TicketNumber)
+		err = trackReceivable(ctx, s.uow, s.ticketRepo, s.ledger, ticketID, models.JournalFineAdjusted, memo,
+			func(ctx context.Context) error {
+				_, err := s.ticketRepo.ReplaceOffences(ctx, ticketID, offenceInputs)
+				return err
+			})
+		if err != nil {
 			return nil, apperrors.NewInternal(err)
 		}
 	}
@@ -282,6 +297,9 @@ func (s *ticketService) Void(ctx context.Context, ticketID uuid.UUID, reason str
 		}
 		return nil, apperrors.NewInternal(err)
 	}
+	if err := requireInJurisdiction(ctx, s.jurisdictions, models.EntityTicket, ticketID, "Ticket"); err != nil {
+		return nil, err
+	}
 
 	if existing.Status == "paid" || existing.Status == "cancelled" {
 		return nil, apperrors.NewValidationError("Cannot void a ticket that is "+existing.Status, nil)
@@ -292,7 +310,12 @@ func (s *ticketService) Void(ctx context.Context, ticketID uuid.UUID, reason str
 	}
 
 	userID := middleware.GetUserID(ctx)
-	if err := s.ticketRepo.VoidTicket(ctx, ticketID, userID, reason); err != nil {
+	memo := fmt.Sprintf("Ticket %s voided: %s", existing.TicketNumber, reason)
+	err = trackReceivable(ctx, s.uow, s.ticketRepo, s.ledger, ticketID, models.JournalTicketWaived, memo,
+		func(ctx context.Context) error {
+			return s.ticketRepo.VoidTicket(ctx, ticketID, userID, reason)
+		})
+	if err != nil {
 		return nil, apperrors.NewInternal(err)
 	}
 
@@ -304,7 +327,7 @@ func (s *ticketService) Void(ctx context.Context, ticketID uuid.UUID, reason str
 // ---------------------------------------------------------------------------
 
 func (s *ticketService) Stats(ctx context.Context, filter models.TicketFilter) (*models.TicketStats, error) {
-	applyTicketJurisdiction(ctx, &filter)
+	filter.Scope = callerScope(ctx)
 	stats, err := s.ticketRepo.GetStats(ctx, filter)
 	if err != nil {
 		return nil, apperrors.NewInternal(err)
@@ -325,6 +348,9 @@ func (s *ticketService) UploadPhoto(ctx context.Context, ticketID uuid.UUID, pho
 		}
 		return nil, apperrors.NewInternal(err)
 	}
+	if err := requireInJurisdiction(ctx, s.jurisdictions, models.EntityTicket, ticketID, "Ticket"); err != nil {
+		return nil, err
+	}
 
 	// Validate
 	if mimeType != "image/jpeg" && mimeType != "image/png" {
@@ -0,0 +1,59 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	apperrors "github.com/ghana-police/ticketing-backend/internal/domain/errors"
+	"github.com/ghana-police/ticketing-backend/internal/ports/repositories"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// fakeDenylist answers every lookup with denied, or fails with err.
+type fakeDenylist struct {
+	repositories.TokenDenylistRepository
+	denied bool
+	err    error
+}
+
+func (f *fakeDenylist) IsDenied(context.Context, string, uuid.UUID, *uuid.UUID, time.Time) (bool, error) {
+	return f.denied, f.err
+}
+
+func TestIsRevoked(t *testing.T) {
+	down := errors.New("redis: connection refused")
+
+	tests := []struct {
+		name        string
+		denylist    *fakeDenylist
+		failOpen    bool
+		wantRevoked bool
+		wantErr     string // AppError code
+	}{
+		{name: "valid token", denylist: &fakeDenylist{}},
+		{name: "revoked token", denylist: &fakeDenylist{denied: true}, wantRevoked: true},
+		{name: "denylist down fails closed", denylist: &fakeDenylist{err: down}, wantErr: apperrors.CodeServiceDown},
+		{name: "denylist down fails open when configured", denylist: &fakeDenylist{err: down}, failOpen: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewTokenRevocationService(tt.denylist, &fakeUserRepo{}, 15*time.Minute, tt.failOpen, zap.NewNop())
+
+			revoked, err := s.IsRevoked(context.Background(), "jti", uuid.New(), nil, time.Now())
+			if tt.wantErr != "" {
+				wantCode(t, err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if revoked != tt.wantRevoked {
+				t.Errorf("revoked = %v, want %v", revoked, tt.wantRevoked)
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+-- Offline update conflicts (device version vs newer server version)
+CREATE TABLE IF NOT EXISTS sync_conflicts (
+    id                UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    ticket_id         UUID NOT NULL REFERENCES tickets(id),
+    user_id           UUID NOT NULL REFERENCES users(id),
+    officer_id        UUID REFERENCES officers(id),
+    device_id         VARCHAR(255) NOT NULL,
+    local_id          VARCHAR(255) NOT NULL,
+    strategy          VARCHAR(20) NOT NULL CHECK (strategy IN (
+                          'server-wins', 'client-wins', 'field-merge', 'manual'
+                      )),
+    fields            TEXT[] NOT NULL DEFAULT '{}',
+    client_data       JSONB NOT NULL,
+    server_data       JSONB NOT NULL,
+    client_timestamp  TIMESTAMPTZ NOT NULL,
+    server_updated_at TIMESTAMPTZ NOT NULL,
+    status            VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN (
+                          'pending', 'auto_resolved', 'resolved'
+                      )),
+    resolution        VARCHAR(20) CHECK (resolution IN ('server', 'client', 'merged')),
+    resolved_by       UUID REFERENCES users(id),
+    resolved_at       TIMESTAMPTZ,
+    resolution_notes  TEXT,
+    station_id        UUID NOT NULL REFERENCES stations(id),
+    region_id         UUID NOT NULL REFERENCES regions(id),
+    created_at        TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at        TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_sync_conflicts_ticket_id ON sync_conflicts(ticket_id);
+CREATE INDEX idx_sync_conflicts_status ON sync_conflicts(status);
+CREATE INDEX idx_sync_conflicts_station_id ON sync_conflicts(station_id);
+CREATE INDEX idx_sync_conflicts_region_id ON sync_conflicts(region_id);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_vehicle_types_updated_at;
+DROP INDEX IF EXISTS idx_stations_updated_at;
+DROP INDEX IF EXISTS idx_regions_updated_at;
+DROP INDEX IF EXISTS idx_offences_updated_at;
+
+ALTER TABLE vehicle_types DROP COLUMN IF EXISTS updated_at;
@@ -0,0 +1,8 @@
+-- Per-entity version cursors for lookup delta sync
+ALTER TABLE vehicle_types ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW();
+UPDATE vehicle_types SET updated_at = created_at;
+
+CREATE INDEX idx_offences_updated_at ON offences(updated_at);
+CREATE INDEX idx_regions_updated_at ON regions(updated_at);
+CREATE INDEX idx_stations_updated_at ON stations(updated_at);
+CREATE INDEX idx_vehicle_types_updated_at ON vehicle_types(updated_at);
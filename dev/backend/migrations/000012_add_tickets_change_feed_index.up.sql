@@ -0,0 +1,2 @@
+-- Keyset index for the sync change feed ordered by (updated_at, id)
+CREATE INDEX IF NOT EXISTS idx_tickets_updated_at_id ON tickets(updated_at, id);
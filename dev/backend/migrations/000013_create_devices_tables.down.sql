@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_refresh_tokens_device_ref;
+ALTER TABLE refresh_tokens DROP COLUMN IF EXISTS device_ref;
+
+DROP TABLE IF EXISTS device_commands;
+DROP TABLE IF EXISTS devices;
@@ -0,0 +1,49 @@
+-- Managed device registry
+CREATE TABLE IF NOT EXISTS devices (
+    id                    UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    device_id             VARCHAR(255) UNIQUE,
+    name                  VARCHAR(100) NOT NULL,
+    platform              VARCHAR(50),
+    model                 VARCHAR(100),
+    app_version           VARCHAR(50),
+    status                VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN (
+                              'pending', 'active', 'lost', 'retired'
+                          )),
+    status_reason         TEXT,
+    station_id            UUID REFERENCES stations(id),
+    officer_id            UUID REFERENCES officers(id),
+    enrollment_code_hash  VARCHAR(64) UNIQUE,
+    enrollment_expires_at TIMESTAMPTZ,
+    key_hash              VARCHAR(64),
+    registered_by         UUID NOT NULL REFERENCES users(id),
+    enrolled_at           TIMESTAMPTZ,
+    last_seen_at          TIMESTAMPTZ,
+    created_at            TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at            TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_devices_status ON devices(status);
+CREATE INDEX idx_devices_station_id ON devices(station_id);
+CREATE INDEX idx_devices_officer_id ON devices(officer_id);
+
+-- Remote commands queued for delivery in the sync response
+CREATE TABLE IF NOT EXISTS device_commands (
+    id              UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    device_id       UUID NOT NULL REFERENCES devices(id) ON DELETE CASCADE,
+    command         VARCHAR(20) NOT NULL CHECK (command IN ('lock', 'wipe', 'resync')),
+    status          VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN (
+                        'pending', 'delivered', 'acknowledged', 'cancelled'
+                    )),
+    reason          TEXT,
+    issued_by       UUID NOT NULL REFERENCES users(id),
+    delivered_at    TIMESTAMPTZ,
+    acknowledged_at TIMESTAMPTZ,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at      TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_device_commands_device_status ON device_commands(device_id, status);
+
+-- Bind refresh tokens to the enrolled device they were issued to
+ALTER TABLE refresh_tokens ADD COLUMN IF NOT EXISTS device_ref UUID REFERENCES devices(id);
+CREATE INDEX idx_refresh_tokens_device_ref ON refresh_tokens(device_ref);
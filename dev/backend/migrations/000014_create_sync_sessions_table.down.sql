@@ -0,0 +1,12 @@
+ALTER TABLE device_syncs
+    DROP COLUMN IF EXISTS last_session_at,
+    DROP COLUMN IF EXISTS last_success_at,
+    DROP COLUMN IF EXISTS last_status,
+    DROP COLUMN IF EXISTS last_error,
+    DROP COLUMN IF EXISTS app_version,
+    DROP COLUMN IF EXISTS battery_level,
+    DROP COLUMN IF EXISTS charging,
+    DROP COLUMN IF EXISTS network_type,
+    DROP COLUMN IF EXISTS pending_items;
+
+DROP TABLE IF EXISTS sync_sessions;
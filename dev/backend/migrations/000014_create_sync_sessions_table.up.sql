@@ -0,0 +1,52 @@
+-- One row per BatchSync call (telemetry)
+CREATE TABLE IF NOT EXISTS sync_sessions (
+    id                 UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id            UUID NOT NULL REFERENCES users(id),
+    officer_id         UUID REFERENCES officers(id),
+    device_id          VARCHAR(255) NOT NULL,
+    device_ref         UUID REFERENCES devices(id),
+    station_id         UUID REFERENCES stations(id),
+    region_id          UUID REFERENCES regions(id),
+    status             VARCHAR(20) NOT NULL CHECK (status IN (
+                           'completed', 'partial', 'rejected', 'failed'
+                       )),
+    error_code         VARCHAR(50),
+    errors             TEXT[] NOT NULL DEFAULT '{}',
+    tickets_received   INT NOT NULL DEFAULT 0,
+    tickets_succeeded  INT NOT NULL DEFAULT 0,
+    tickets_conflicted INT NOT NULL DEFAULT 0,
+    tickets_failed     INT NOT NULL DEFAULT 0,
+    photos_received    INT NOT NULL DEFAULT 0,
+    photos_succeeded   INT NOT NULL DEFAULT 0,
+    photos_failed      INT NOT NULL DEFAULT 0,
+    updates_sent       INT NOT NULL DEFAULT 0,
+    request_bytes      BIGINT NOT NULL DEFAULT 0,
+    response_bytes     BIGINT NOT NULL DEFAULT 0,
+    duration_ms        INT NOT NULL DEFAULT 0,
+    app_version        VARCHAR(50),
+    battery_level      SMALLINT CHECK (battery_level BETWEEN 0 AND 100),
+    charging           BOOLEAN,
+    network_type       VARCHAR(20),
+    pending_items      INT,
+    started_at         TIMESTAMPTZ NOT NULL,
+    created_at         TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_sync_sessions_device_started ON sync_sessions(device_id, started_at DESC);
+CREATE INDEX idx_sync_sessions_station_started ON sync_sessions(station_id, started_at DESC);
+
+-- Latest telemetry per user+device, for the health views
+ALTER TABLE device_syncs
+    ADD COLUMN IF NOT EXISTS last_session_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS last_success_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS last_status     VARCHAR(20),
+    ADD COLUMN IF NOT EXISTS last_error      TEXT,
+    ADD COLUMN IF NOT EXISTS app_version     VARCHAR(50),
+    ADD COLUMN IF NOT EXISTS battery_level   SMALLINT,
+    ADD COLUMN IF NOT EXISTS charging        BOOLEAN,
+    ADD COLUMN IF NOT EXISTS network_type    VARCHAR(20),
+    ADD COLUMN IF NOT EXISTS pending_items   INT;
+
+UPDATE device_syncs
+SET last_session_at = updated_at, last_success_at = updated_at, last_status = 'completed'
+WHERE last_session_at IS NULL;
@@ -0,0 +1,4 @@
+UPDATE system_settings
+SET value = value - 'rateLimits',
+    updated_at = NOW()
+WHERE section = 'security';
@@ -0,0 +1,5 @@
+-- Per-route-class rate limits (requests per sliding window) in the security settings
+UPDATE system_settings
+SET value = value || '{"rateLimits":{"windowSeconds":60,"auth":10,"write":60,"read":120,"upload":20,"sync":10}}'::jsonb,
+    updated_at = NOW()
+WHERE section = 'security' AND NOT value ? 'rateLimits';
@@ -0,0 +1,12 @@
+-- Asymmetric JWT signing keys. The newest key whose activates_at has passed
+-- signs new tokens; keys are published in the JWKS until retires_at.
+CREATE TABLE signing_keys (
+    kid          VARCHAR(64) PRIMARY KEY,
+    algorithm    VARCHAR(10) NOT NULL CHECK (algorithm IN ('RS256', 'EdDSA')),
+    private_key  TEXT NOT NULL,
+    activates_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    retires_at   TIMESTAMPTZ,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_signing_keys_activates_at ON signing_keys(activates_at DESC);
@@ -0,0 +1,13 @@
+UPDATE system_settings
+SET value = value - 'mfaRequiredRoles',
+    updated_at = NOW()
+WHERE section = 'security';
+
+DROP TABLE IF EXISTS mfa_challenges;
+DROP TABLE IF EXISTS mfa_recovery_codes;
+
+ALTER TABLE users DROP COLUMN IF EXISTS mfa_last_step;
+ALTER TABLE users DROP COLUMN IF EXISTS mfa_enrolled_at;
+ALTER TABLE users DROP COLUMN IF EXISTS mfa_pending_secret;
+ALTER TABLE users DROP COLUMN IF EXISTS mfa_secret;
+ALTER TABLE users DROP COLUMN IF EXISTS mfa_enabled;
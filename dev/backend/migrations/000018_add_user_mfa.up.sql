@@ -0,0 +1,40 @@
+-- TOTP multi-factor authentication for privileged accounts
+ALTER TABLE users ADD COLUMN IF NOT EXISTS mfa_enabled        BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS mfa_secret         TEXT;   -- sealed TOTP seed
+ALTER TABLE users ADD COLUMN IF NOT EXISTS mfa_pending_secret TEXT;   -- sealed seed awaiting first code
+ALTER TABLE users ADD COLUMN IF NOT EXISTS mfa_enrolled_at    TIMESTAMPTZ;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS mfa_last_step      BIGINT NOT NULL DEFAULT 0; -- last accepted TOTP step (replay guard)
+
+CREATE TABLE mfa_recovery_codes (
+    id         UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id    UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    code_hash  VARCHAR(64) NOT NULL,
+    used_at    TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_mfa_recovery_codes_user_id ON mfa_recovery_codes(user_id);
+
+-- Second login step: issued after the password check, exchanged for tokens
+-- once a valid code is presented.
+CREATE TABLE mfa_challenges (
+    id          UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id     UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    token_hash  VARCHAR(64) NOT NULL UNIQUE,
+    setup       BOOLEAN NOT NULL DEFAULT false, -- user must enrol before completing login
+    device_id   VARCHAR(100),
+    device_info JSONB,
+    device_ref  UUID REFERENCES devices(id),
+    attempts    INT NOT NULL DEFAULT 0,
+    expires_at  TIMESTAMPTZ NOT NULL,
+    used_at     TIMESTAMPTZ,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_mfa_challenges_user_id ON mfa_challenges(user_id);
+
+-- Roles that must use MFA (empty: optional for every eligible role)
+UPDATE system_settings
+SET value = value || '{"mfaRequiredRoles":[]}'::jsonb,
+    updated_at = NOW()
+WHERE section = 'security' AND NOT value ? 'mfaRequiredRoles';
@@ -0,0 +1,79 @@
+-- Fine-grained permissions: a catalogue of actions, the permissions each role
+-- holds, and per-user grants and denies on top of the role.
+
+CREATE TABLE permissions (
+    key         VARCHAR(100) PRIMARY KEY,
+    category    VARCHAR(50)  NOT NULL,
+    description TEXT         NOT NULL,
+    created_at  TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE role_permissions (
+    role       VARCHAR(20)  NOT NULL CHECK (role IN (
+                   'officer', 'supervisor', 'admin', 'accountant', 'super_admin'
+               )),
+    permission VARCHAR(100) NOT NULL REFERENCES permissions(key) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (role, permission)
+);
+
+CREATE TABLE user_permissions (
+    user_id    UUID         NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    permission VARCHAR(100) NOT NULL REFERENCES permissions(key) ON DELETE CASCADE,
+    effect     VARCHAR(10)  NOT NULL CHECK (effect IN ('grant', 'deny')),
+    reason     TEXT,
+    granted_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, permission)
+);
+
+-- ============================================================
+-- SEED: catalogue and the access the router previously hard-coded
+-- ============================================================
+INSERT INTO permissions (key, category, description) VALUES
+    ('region.manage',        'hierarchy',  'Create, update and delete regions, divisions and districts'),
+    ('station.manage',       'hierarchy',  'Create and update stations and view station statistics'),
+    ('station.delete',       'hierarchy',  'Delete stations'),
+    ('officer.manage',       'officers',   'Create, update and deactivate officers and reset their passwords'),
+    ('user.mfa.reset',       'users',      'Reset another user''s multi-factor authentication'),
+    ('ticket.update',        'tickets',    'Edit issued tickets'),
+    ('ticket.void',          'tickets',    'Void tickets'),
+    ('payment.initiate',     'payments',   'Initiate payments on behalf of offenders'),
+    ('payment.cash.record',  'payments',   'Record cash payments'),
+    ('objection.read',       'objections', 'View objections and objection statistics'),
+    ('objection.review',     'objections', 'Approve or reject objections'),
+    ('audit.read',           'audit',      'View audit logs'),
+    ('sync.conflict.manage', 'sync',       'View and resolve sync conflicts'),
+    ('sync.health.read',     'sync',       'View device and station sync health'),
+    ('device.manage',        'devices',    'Register devices, change their status and issue remote commands'),
+    ('analytics.read',       'analytics',  'View analytics and revenue reports'),
+    ('settings.read',        'settings',   'View system settings'),
+    ('settings.write',       'settings',   'Change system settings'),
+    ('offence.manage',       'offences',   'Create, update and enable or disable offences'),
+    ('offence.delete',       'offences',   'Delete offences'),
+    ('role.manage',          'roles',      'Change role permissions and per-user grants');
+
+INSERT INTO role_permissions (role, permission)
+SELECT 'super_admin', key FROM permissions;
+
+INSERT INTO role_permissions (role, permission) VALUES
+    ('admin', 'station.manage'),
+    ('admin', 'officer.manage'),
+    ('admin', 'ticket.update'),
+    ('admin', 'ticket.void'),
+    ('admin', 'payment.initiate'),
+    ('admin', 'payment.cash.record'),
+    ('admin', 'objection.read'),
+    ('admin', 'objection.review'),
+    ('admin', 'audit.read'),
+    ('admin', 'sync.conflict.manage'),
+    ('admin', 'sync.health.read'),
+    ('admin', 'device.manage'),
+    ('admin', 'analytics.read'),
+    ('admin', 'settings.read'),
+    ('admin', 'offence.manage'),
+    ('accountant', 'payment.initiate'),
+    ('accountant', 'payment.cash.record'),
+    ('supervisor', 'ticket.void'),
+    ('supervisor', 'sync.conflict.manage'),
+    ('supervisor', 'sync.health.read');
@@ -0,0 +1,5 @@
+DELETE FROM permissions WHERE key = 'user.session.manage';
+
+DROP INDEX IF EXISTS idx_refresh_tokens_user_live;
+ALTER TABLE refresh_tokens DROP COLUMN IF EXISTS user_agent;
+ALTER TABLE refresh_tokens DROP COLUMN IF EXISTS ip_address;
@@ -0,0 +1,13 @@
+-- Session management: each refresh token family is one signed-in session.
+-- The client address is recorded at login and refreshed on every rotation.
+ALTER TABLE refresh_tokens ADD COLUMN IF NOT EXISTS ip_address VARCHAR(45);
+ALTER TABLE refresh_tokens ADD COLUMN IF NOT EXISTS user_agent TEXT;
+
+CREATE INDEX idx_refresh_tokens_user_live ON refresh_tokens(user_id) WHERE revoked_at IS NULL;
+
+INSERT INTO permissions (key, category, description) VALUES
+    ('user.session.manage', 'users', 'View and sign out another user''s sessions');
+
+INSERT INTO role_permissions (role, permission) VALUES
+    ('super_admin', 'user.session.manage'),
+    ('admin', 'user.session.manage');
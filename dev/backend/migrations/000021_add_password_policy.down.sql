@@ -0,0 +1,9 @@
+UPDATE system_settings
+SET value = value - 'passwordRequireUppercase' - 'passwordRequireLowercase' - 'passwordRequireDigit'
+                  - 'passwordRequireSymbol' - 'passwordHistoryCount' - 'passwordMaxAgeDays',
+    updated_at = NOW()
+WHERE section = 'security';
+
+DROP TABLE IF EXISTS password_history;
+
+ALTER TABLE users DROP COLUMN IF EXISTS must_change_password;
@@ -0,0 +1,17 @@
+-- Password policy: forced changes and a history of replaced passwords
+ALTER TABLE users ADD COLUMN IF NOT EXISTS must_change_password BOOLEAN NOT NULL DEFAULT false;
+
+CREATE TABLE password_history (
+    id            UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id       UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    password_hash VARCHAR(255) NOT NULL,
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_password_history_user_id ON password_history(user_id, created_at DESC);
+
+-- Policy defaults; values already set are kept
+UPDATE system_settings
+SET value = '{"passwordRequireUppercase":true,"passwordRequireLowercase":true,"passwordRequireDigit":true,"passwordRequireSymbol":false,"passwordHistoryCount":5,"passwordMaxAgeDays":90}'::jsonb || value,
+    updated_at = NOW()
+WHERE section = 'security';
@@ -0,0 +1,12 @@
+DELETE FROM permissions WHERE key = 'service_account.manage';
+
+DROP INDEX IF EXISTS idx_audit_logs_api_key_id;
+ALTER TABLE audit_logs DROP COLUMN IF EXISTS api_key_id;
+
+DROP TABLE IF EXISTS api_keys;
+DROP TABLE IF EXISTS service_accounts;
+
+-- The backing users rows are still referenced by the records they created;
+-- keep them, deactivated.
+UPDATE users SET is_active = false WHERE is_service_account;
+ALTER TABLE users DROP COLUMN IF EXISTS is_service_account;
@@ -0,0 +1,45 @@
+-- Service accounts: non-interactive identities for machine integrations
+-- (payment aggregators, court systems, reporting tools). Each one is backed
+-- by a users row so that its actions carry a user ID like any other caller;
+-- that row has no usable password and cannot log in.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS is_service_account BOOLEAN NOT NULL DEFAULT false;
+
+CREATE TABLE service_accounts (
+    user_id     UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    description TEXT,
+    station_id  UUID REFERENCES stations(id),
+    region_id   UUID REFERENCES regions(id),
+    created_by  UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- API keys are shown once at creation; only the SHA-256 hash is stored. The
+-- prefix identifies the key in listings and on lookup.
+CREATE TABLE api_keys (
+    id                 UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    service_account_id UUID         NOT NULL REFERENCES service_accounts(user_id) ON DELETE CASCADE,
+    name               VARCHAR(100) NOT NULL,
+    prefix             VARCHAR(20)  NOT NULL UNIQUE,
+    key_hash           VARCHAR(64)  NOT NULL,
+    scopes             TEXT[]       NOT NULL DEFAULT '{}', -- permission keys
+    allowed_ips        TEXT[]       NOT NULL DEFAULT '{}', -- CIDR blocks; empty allows any address
+    expires_at         TIMESTAMPTZ,
+    last_used_at       TIMESTAMPTZ,
+    last_used_ip       VARCHAR(45),
+    revoked_at         TIMESTAMPTZ,
+    created_by         UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at         TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_api_keys_service_account ON api_keys(service_account_id);
+
+-- Attribute audit entries to the API key that made the request
+ALTER TABLE audit_logs ADD COLUMN IF NOT EXISTS api_key_id UUID;
+CREATE INDEX idx_audit_logs_api_key_id ON audit_logs(api_key_id) WHERE api_key_id IS NOT NULL;
+
+INSERT INTO permissions (key, category, description) VALUES
+    ('service_account.manage', 'users', 'Create service accounts and issue and revoke their API keys');
+
+INSERT INTO role_permissions (role, permission) VALUES
+    ('super_admin', 'service_account.manage');
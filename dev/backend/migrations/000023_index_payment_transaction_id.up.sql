@@ -0,0 +1,3 @@
+-- Hosted checkout returns and provider callbacks find payments by the
+-- provider's reference.
+CREATE INDEX IF NOT EXISTS idx_payments_transaction_id ON payments(transaction_id) WHERE transaction_id IS NOT NULL;
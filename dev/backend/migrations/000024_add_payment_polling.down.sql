@@ -0,0 +1,7 @@
+DELETE FROM permissions WHERE key = 'metrics.read';
+
+DROP INDEX IF EXISTS idx_payments_pending_poll;
+ALTER TABLE payments
+    DROP COLUMN IF EXISTS last_polled_at,
+    DROP COLUMN IF EXISTS next_poll_at,
+    DROP COLUMN IF EXISTS poll_attempts;
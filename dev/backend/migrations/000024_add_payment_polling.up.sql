@@ -0,0 +1,14 @@
+-- The payment poller checks pending digital payments with the provider,
+-- backing off between attempts, and expires the ones nobody completed.
+ALTER TABLE payments
+    ADD COLUMN IF NOT EXISTS poll_attempts  INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS next_poll_at   TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS last_polled_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_payments_pending_poll ON payments(next_poll_at) WHERE status IN ('pending', 'processing');
+
+INSERT INTO permissions (key, category, description) VALUES
+    ('metrics.read', 'system', 'Scrape operational metrics');
+
+INSERT INTO role_permissions (role, permission) VALUES
+    ('super_admin', 'metrics.read');
@@ -0,0 +1,7 @@
+DELETE FROM permissions WHERE key = 'payment.installment.manage';
+
+DROP TABLE IF EXISTS installments;
+DROP TABLE IF EXISTS installment_plans;
+
+ALTER TABLE payments DROP COLUMN IF EXISTS balance_after;
+ALTER TABLE tickets DROP COLUMN IF EXISTS outstanding_balance;
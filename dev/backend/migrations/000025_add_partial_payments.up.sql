@@ -0,0 +1,51 @@
+-- Partial payments: a ticket takes any number of payments, paid_amount sums
+-- the completed ones and the ticket is paid once nothing is outstanding.
+-- Supervisors can spread a large fine over an installment plan.
+ALTER TABLE tickets
+    ADD COLUMN IF NOT EXISTS outstanding_balance DECIMAL(10, 2) GENERATED ALWAYS AS (
+        CASE WHEN status IN ('paid', 'cancelled') THEN 0
+             ELSE GREATEST(total_fine - COALESCE(paid_amount, 0), 0)
+        END
+    ) STORED;
+
+-- Ticket balance left after the payment, printed on its receipt
+ALTER TABLE payments
+    ADD COLUMN IF NOT EXISTS balance_after DECIMAL(10, 2);
+
+CREATE TABLE installment_plans (
+    id              UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    ticket_id       UUID           NOT NULL REFERENCES tickets(id),
+    total_amount    DECIMAL(10, 2) NOT NULL CHECK (total_amount > 0),
+    status          VARCHAR(20)    NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'completed', 'cancelled')),
+    notes           TEXT,
+    created_by_id   UUID           REFERENCES users(id),
+    cancelled_by_id UUID           REFERENCES users(id),
+    cancel_reason   TEXT,
+    cancelled_at    TIMESTAMPTZ,
+    completed_at    TIMESTAMPTZ,
+    created_at      TIMESTAMPTZ    NOT NULL DEFAULT NOW(),
+    updated_at      TIMESTAMPTZ    NOT NULL DEFAULT NOW()
+);
+
+-- One running plan per ticket
+CREATE UNIQUE INDEX idx_installment_plans_active_ticket ON installment_plans(ticket_id) WHERE status = 'active';
+CREATE INDEX idx_installment_plans_ticket_id ON installment_plans(ticket_id);
+
+CREATE TABLE installments (
+    id          UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    plan_id     UUID           NOT NULL REFERENCES installment_plans(id) ON DELETE CASCADE,
+    sequence    INTEGER        NOT NULL,
+    due_date    DATE           NOT NULL,
+    amount      DECIMAL(10, 2) NOT NULL CHECK (amount > 0),
+    paid_amount DECIMAL(10, 2) NOT NULL DEFAULT 0,
+    paid_at     TIMESTAMPTZ,
+    UNIQUE (plan_id, sequence)
+);
+
+INSERT INTO permissions (key, category, description) VALUES
+    ('payment.installment.manage', 'payments', 'Create and cancel installment plans');
+
+INSERT INTO role_permissions (role, permission) VALUES
+    ('super_admin', 'payment.installment.manage'),
+    ('admin', 'payment.installment.manage'),
+    ('supervisor', 'payment.installment.manage');
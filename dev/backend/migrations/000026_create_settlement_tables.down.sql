@@ -0,0 +1,5 @@
+DELETE FROM permissions WHERE key = 'payment.reconcile';
+
+DROP INDEX IF EXISTS idx_payments_completed_at;
+DROP TABLE IF EXISTS settlement_lines;
+DROP TABLE IF EXISTS settlement_imports;
@@ -0,0 +1,59 @@
+-- Provider settlement statements and their reconciliation against payments.
+-- Each imported line is matched to at most one payment; lines that do not
+-- match cleanly stay open on the reconciliation worklist until resolved.
+CREATE TABLE settlement_imports (
+    id              UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    provider        VARCHAR(30)    NOT NULL,
+    file_name       VARCHAR(255)   NOT NULL,
+    checksum        VARCHAR(64)    NOT NULL, -- SHA-256 of the file, to refuse the same statement twice
+    line_count      INTEGER        NOT NULL DEFAULT 0,
+    matched_count   INTEGER        NOT NULL DEFAULT 0,
+    unmatched_count INTEGER        NOT NULL DEFAULT 0,
+    mismatch_count  INTEGER        NOT NULL DEFAULT 0,
+    duplicate_count INTEGER        NOT NULL DEFAULT 0,
+    total_amount    DECIMAL(12, 2) NOT NULL DEFAULT 0,
+    imported_by_id  UUID           REFERENCES users(id),
+    created_at      TIMESTAMPTZ    NOT NULL DEFAULT NOW(),
+    UNIQUE (provider, checksum)
+);
+
+CREATE INDEX idx_settlement_imports_created_at ON settlement_imports(created_at);
+
+CREATE TABLE settlement_lines (
+    id             UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    import_id      UUID           NOT NULL REFERENCES settlement_imports(id) ON DELETE CASCADE,
+    provider       VARCHAR(30)    NOT NULL,
+    line_number    INTEGER        NOT NULL,
+    transaction_id VARCHAR(100),
+    reference      VARCHAR(100),
+    amount         DECIMAL(10, 2) NOT NULL,
+    fee            DECIMAL(10, 2) NOT NULL DEFAULT 0,
+    currency       VARCHAR(5)     NOT NULL DEFAULT 'GHS',
+    settled_at     TIMESTAMPTZ    NOT NULL,
+    payment_id     UUID           REFERENCES payments(id),
+    payment_amount DECIMAL(10, 2), -- what we recorded for the matched payment
+    match_status   VARCHAR(20)    NOT NULL CHECK (match_status IN ('matched', 'unmatched', 'amount_mismatch', 'duplicate')),
+    review_status  VARCHAR(20)    CHECK (review_status IN ('open', 'resolved')), -- NULL for matched lines
+    resolution     TEXT,
+    resolved_by_id UUID           REFERENCES users(id),
+    resolved_at    TIMESTAMPTZ,
+    created_at     TIMESTAMPTZ    NOT NULL DEFAULT NOW(),
+    UNIQUE (import_id, line_number)
+);
+
+-- A payment is settled once: a second line for it is a duplicate
+CREATE UNIQUE INDEX idx_settlement_lines_payment ON settlement_lines(payment_id)
+    WHERE match_status IN ('matched', 'amount_mismatch');
+CREATE INDEX idx_settlement_lines_worklist ON settlement_lines(review_status, provider) WHERE review_status IS NOT NULL;
+CREATE INDEX idx_settlement_lines_settled_at ON settlement_lines(provider, settled_at);
+CREATE INDEX idx_settlement_lines_transaction_id ON settlement_lines(provider, transaction_id);
+
+CREATE INDEX IF NOT EXISTS idx_payments_completed_at ON payments(completed_at) WHERE status = 'completed';
+
+INSERT INTO permissions (key, category, description) VALUES
+    ('payment.reconcile', 'payments', 'Import settlement statements and work the reconciliation list');
+
+INSERT INTO role_permissions (role, permission) VALUES
+    ('super_admin', 'payment.reconcile'),
+    ('admin', 'payment.reconcile'),
+    ('accountant', 'payment.reconcile');
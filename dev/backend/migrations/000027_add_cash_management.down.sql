@@ -0,0 +1,7 @@
+DELETE FROM permissions WHERE key IN ('cash.remittance.approve', 'cash.report.read');
+
+DROP INDEX IF EXISTS idx_payments_shift_id;
+ALTER TABLE payments DROP COLUMN IF EXISTS shift_id;
+
+DROP TABLE IF EXISTS cashier_shifts;
+DROP TABLE IF EXISTS cash_remittances;
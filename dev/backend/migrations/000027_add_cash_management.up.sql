@@ -0,0 +1,66 @@
+-- Station cash handling: cash is taken during a cashier shift, counted at
+-- cash-up, and banked with a deposit slip that a supervisor signs off.
+CREATE TABLE cash_remittances (
+    id                  UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    station_id          UUID           NOT NULL REFERENCES stations(id),
+    deposit_slip_number VARCHAR(50)    NOT NULL,
+    bank_name           VARCHAR(100)   NOT NULL,
+    bank_account        VARCHAR(50),
+    deposited_at        TIMESTAMPTZ    NOT NULL,
+    expected_amount     DECIMAL(12, 2) NOT NULL, -- counted cash of the shifts, less their floats
+    deposited_amount    DECIMAL(12, 2) NOT NULL CHECK (deposited_amount > 0),
+    variance            DECIMAL(12, 2) NOT NULL, -- deposited - expected
+    status              VARCHAR(20)    NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'approved', 'rejected')),
+    notes               TEXT,
+    submitted_by_id     UUID           NOT NULL REFERENCES users(id),
+    reviewed_by_id      UUID           REFERENCES users(id),
+    review_notes        TEXT,
+    reviewed_at         TIMESTAMPTZ,
+    created_at          TIMESTAMPTZ    NOT NULL DEFAULT NOW(),
+    updated_at          TIMESTAMPTZ    NOT NULL DEFAULT NOW(),
+    UNIQUE (bank_name, deposit_slip_number)
+);
+
+CREATE INDEX idx_cash_remittances_station ON cash_remittances(station_id, deposited_at);
+CREATE INDEX idx_cash_remittances_status ON cash_remittances(status);
+
+CREATE TABLE cashier_shifts (
+    id            UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    station_id    UUID           NOT NULL REFERENCES stations(id),
+    cashier_id    UUID           NOT NULL REFERENCES users(id),
+    status        VARCHAR(20)    NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'closed')),
+    opening_float DECIMAL(10, 2) NOT NULL DEFAULT 0 CHECK (opening_float >= 0),
+    opened_at     TIMESTAMPTZ    NOT NULL DEFAULT NOW(),
+    closed_at     TIMESTAMPTZ,
+    closed_by_id  UUID           REFERENCES users(id),
+    expected_cash DECIMAL(12, 2), -- opening float plus the shift's cash payments
+    counted_cash  DECIMAL(12, 2),
+    variance      DECIMAL(12, 2), -- counted - expected
+    close_notes   TEXT,
+    remittance_id UUID           REFERENCES cash_remittances(id),
+    created_at    TIMESTAMPTZ    NOT NULL DEFAULT NOW(),
+    updated_at    TIMESTAMPTZ    NOT NULL DEFAULT NOW()
+);
+
+-- A cashier has at most one open shift
+CREATE UNIQUE INDEX idx_cashier_shifts_open ON cashier_shifts(cashier_id) WHERE status = 'open';
+CREATE INDEX idx_cashier_shifts_station ON cashier_shifts(station_id, opened_at);
+CREATE INDEX idx_cashier_shifts_remittance ON cashier_shifts(remittance_id);
+
+ALTER TABLE payments
+    ADD COLUMN IF NOT EXISTS shift_id UUID REFERENCES cashier_shifts(id);
+
+CREATE INDEX IF NOT EXISTS idx_payments_shift_id ON payments(shift_id);
+
+INSERT INTO permissions (key, category, description) VALUES
+    ('cash.remittance.approve', 'payments', 'Sign off or reject cash remittances'),
+    ('cash.report.read', 'payments', 'View cashier shifts, remittances and the treasury report');
+
+INSERT INTO role_permissions (role, permission) VALUES
+    ('super_admin', 'cash.remittance.approve'),
+    ('admin', 'cash.remittance.approve'),
+    ('supervisor', 'cash.remittance.approve'),
+    ('super_admin', 'cash.report.read'),
+    ('admin', 'cash.report.read'),
+    ('accountant', 'cash.report.read'),
+    ('supervisor', 'cash.report.read');
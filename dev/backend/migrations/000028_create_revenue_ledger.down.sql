@@ -0,0 +1,6 @@
+DELETE FROM permissions WHERE key IN ('ledger.read', 'ledger.period.close', 'ledger.period.reopen', 'payment.refund');
+
+DROP TABLE IF EXISTS ledger_entries;
+DROP TABLE IF EXISTS ledger_journals;
+DROP TABLE IF EXISTS ledger_periods;
+DROP TABLE IF EXISTS ledger_accounts;
@@ -0,0 +1,113 @@
+-- Revenue ledger: an append-only double-entry record of every financial event
+-- on a ticket. Journals are never updated or deleted; corrections are new
+-- journals. Each journal is tagged with the ticket's station and region.
+CREATE TABLE ledger_accounts (
+    code           VARCHAR(40)  PRIMARY KEY,
+    name           VARCHAR(100) NOT NULL,
+    type           VARCHAR(20)  NOT NULL CHECK (type IN ('asset', 'revenue', 'contra_revenue')),
+    normal_balance VARCHAR(6)   NOT NULL CHECK (normal_balance IN ('debit', 'credit')),
+    description    TEXT         NOT NULL
+);
+
+INSERT INTO ledger_accounts (code, name, type, normal_balance, description) VALUES
+    ('fines_receivable', 'Fines receivable', 'asset', 'debit', 'Fines issued and not yet paid, waived or cancelled'),
+    ('collections', 'Collections', 'asset', 'debit', 'Money received for fines, by cash, mobile money or card'),
+    ('fine_revenue', 'Fine revenue', 'revenue', 'credit', 'Fines issued'),
+    ('late_fee_revenue', 'Late fee revenue', 'revenue', 'credit', 'Late fees charged with payments'),
+    ('waivers', 'Waivers', 'contra_revenue', 'debit', 'Fines given up: tickets voided, cancelled, upheld on objection or closed with a balance left'),
+    ('refunds', 'Refunds', 'contra_revenue', 'debit', 'Fines given up by returning their payment');
+
+-- Accounting periods are calendar months, with a row from the first journal
+-- posted in them or from their close. A closed period takes no journals; anything dated in it is
+-- posted to the current month instead.
+CREATE TABLE ledger_periods (
+    period          CHAR(7)        PRIMARY KEY, -- YYYY-MM
+    status          VARCHAR(10)    NOT NULL CHECK (status IN ('open', 'closed')),
+    journal_count   INTEGER        NOT NULL DEFAULT 0, -- at close
+    total_debits    DECIMAL(14, 2) NOT NULL DEFAULT 0,
+    total_credits   DECIMAL(14, 2) NOT NULL DEFAULT 0,
+    closed_by_id    UUID           REFERENCES users(id),
+    closed_at       TIMESTAMPTZ,
+    reopened_by_id  UUID           REFERENCES users(id),
+    reopened_at     TIMESTAMPTZ,
+    reopen_reason   TEXT,
+    updated_at      TIMESTAMPTZ    NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE ledger_journals (
+    id           UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    kind         VARCHAR(30) NOT NULL CHECK (kind IN (
+                     'opening_balance', 'ticket_issued', 'fine_adjusted', 'ticket_waived',
+                     'payment_received', 'payment_refunded')),
+    period       CHAR(7)     NOT NULL,
+    occurred_at  TIMESTAMPTZ NOT NULL, -- when the event happened
+    posted_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    ticket_id    UUID        NOT NULL REFERENCES tickets(id),
+    payment_id   UUID        REFERENCES payments(id),
+    station_id   UUID        REFERENCES stations(id),
+    region_id    UUID        REFERENCES regions(id),
+    memo         TEXT        NOT NULL,
+    posted_by_id UUID        REFERENCES users(id)
+);
+
+CREATE INDEX idx_ledger_journals_period ON ledger_journals(period);
+CREATE INDEX idx_ledger_journals_ticket_id ON ledger_journals(ticket_id);
+CREATE INDEX idx_ledger_journals_payment_id ON ledger_journals(payment_id);
+CREATE INDEX idx_ledger_journals_station ON ledger_journals(station_id, period);
+
+CREATE TABLE ledger_entries (
+    id           UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    journal_id   UUID           NOT NULL REFERENCES ledger_journals(id),
+    account_code VARCHAR(40)    NOT NULL REFERENCES ledger_accounts(code),
+    debit        DECIMAL(12, 2) NOT NULL DEFAULT 0 CHECK (debit >= 0),
+    credit       DECIMAL(12, 2) NOT NULL DEFAULT 0 CHECK (credit >= 0),
+    CHECK ((debit = 0) <> (credit = 0))
+);
+
+CREATE INDEX idx_ledger_entries_journal_id ON ledger_entries(journal_id);
+CREATE INDEX idx_ledger_entries_account ON ledger_entries(account_code);
+
+-- Bring forward what existing tickets owe and have paid
+WITH opening AS (
+    SELECT id AS ticket_id, station_id, region_id,
+           outstanding_balance AS owed, COALESCE(paid_amount, 0) AS paid
+    FROM tickets
+    WHERE outstanding_balance > 0 OR COALESCE(paid_amount, 0) > 0
+), journals AS (
+    INSERT INTO ledger_journals (kind, period, occurred_at, ticket_id, station_id, region_id, memo)
+    SELECT 'opening_balance', TO_CHAR(NOW() AT TIME ZONE 'UTC', 'YYYY-MM'), NOW(),
+           ticket_id, station_id, region_id, 'Balance brought forward when the ledger started'
+    FROM opening
+    RETURNING id, ticket_id
+)
+INSERT INTO ledger_entries (journal_id, account_code, debit, credit)
+SELECT j.id, e.account_code, e.debit, e.credit
+FROM journals j
+JOIN opening o ON o.ticket_id = j.ticket_id
+CROSS JOIN LATERAL (VALUES
+    ('fines_receivable', o.owed, 0),
+    ('collections', o.paid, 0),
+    ('fine_revenue', 0, o.owed + o.paid)
+) AS e(account_code, debit, credit)
+WHERE e.debit > 0 OR e.credit > 0;
+
+INSERT INTO ledger_periods (period, status)
+SELECT DISTINCT period, 'open' FROM ledger_journals;
+
+INSERT INTO permissions (key, category, description) VALUES
+    ('ledger.read', 'ledger', 'View the revenue ledger and trial balance'),
+    ('ledger.period.close', 'ledger', 'Close ledger periods'),
+    ('ledger.period.reopen', 'ledger', 'Reopen closed ledger periods'),
+    ('payment.refund', 'payments', 'Refund completed payments');
+
+INSERT INTO role_permissions (role, permission) VALUES
+    ('super_admin', 'ledger.read'),
+    ('admin', 'ledger.read'),
+    ('accountant', 'ledger.read'),
+    ('super_admin', 'ledger.period.close'),
+    ('admin', 'ledger.period.close'),
+    ('accountant', 'ledger.period.close'),
+    ('super_admin', 'ledger.period.reopen'),
+    ('super_admin', 'payment.refund'),
+    ('admin', 'payment.refund'),
+    ('accountant', 'payment.refund');
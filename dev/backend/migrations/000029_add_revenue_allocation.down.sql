@@ -0,0 +1,6 @@
+DELETE FROM permissions WHERE key IN ('allocation.manage', 'disbursement.read');
+
+DROP TABLE IF EXISTS payment_allocations;
+DROP TABLE IF EXISTS allocation_rule_shares;
+DROP TABLE IF EXISTS allocation_rules;
+DROP TABLE IF EXISTS revenue_beneficiaries;
@@ -0,0 +1,97 @@
+-- Revenue allocation: every completed payment is split between the bodies
+-- that share fine revenue. For each offence category on the ticket the most
+-- specific active rule for that category, the ticket's region and the payment
+-- method decides the shares. Allocations are append-only; a refund adds
+-- negative allocations rather than removing the original ones.
+CREATE TABLE revenue_beneficiaries (
+    id           UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    code         VARCHAR(40)  NOT NULL UNIQUE,
+    name         VARCHAR(150) NOT NULL,
+    bank_name    VARCHAR(100),
+    bank_account VARCHAR(50),
+    is_active    BOOLEAN      NOT NULL DEFAULT TRUE,
+    created_at   TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    updated_at   TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+INSERT INTO revenue_beneficiaries (code, name) VALUES
+    ('consolidated_fund', 'Consolidated Fund'),
+    ('police_service', 'Ghana Police Service'),
+    ('assemblies_common_fund', 'District Assemblies Common Fund');
+
+-- A rule key left NULL matches any value
+CREATE TABLE allocation_rules (
+    id               UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name             VARCHAR(150) NOT NULL,
+    offence_category VARCHAR(30),
+    region_id        UUID         REFERENCES regions(id),
+    payment_method   VARCHAR(20),
+    is_active        BOOLEAN      NOT NULL DEFAULT TRUE,
+    created_by_id    UUID         REFERENCES users(id),
+    updated_by_id    UUID         REFERENCES users(id),
+    created_at       TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    updated_at       TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+-- One active rule per combination of keys, so the most specific match is
+-- never a tie
+CREATE UNIQUE INDEX idx_allocation_rules_keys ON allocation_rules (
+    COALESCE(offence_category, ''),
+    COALESCE(region_id, '00000000-0000-0000-0000-000000000000'::uuid),
+    COALESCE(payment_method, '')
+) WHERE is_active;
+
+CREATE TABLE allocation_rule_shares (
+    rule_id        UUID          NOT NULL REFERENCES allocation_rules(id) ON DELETE CASCADE,
+    beneficiary_id UUID          NOT NULL REFERENCES revenue_beneficiaries(id),
+    percentage     DECIMAL(5, 2) NOT NULL CHECK (percentage > 0 AND percentage <= 100),
+    PRIMARY KEY (rule_id, beneficiary_id)
+);
+
+-- The default rule matches every payment; it cannot be deactivated
+WITH rule AS (
+    INSERT INTO allocation_rules (name) VALUES ('Default: all revenue to the Consolidated Fund')
+    RETURNING id
+)
+INSERT INTO allocation_rule_shares (rule_id, beneficiary_id, percentage)
+SELECT rule.id, b.id, 100 FROM rule, revenue_beneficiaries b WHERE b.code = 'consolidated_fund';
+
+CREATE TABLE payment_allocations (
+    id               UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    payment_id       UUID           NOT NULL REFERENCES payments(id),
+    beneficiary_id   UUID           NOT NULL REFERENCES revenue_beneficiaries(id),
+    rule_id          UUID           NOT NULL REFERENCES allocation_rules(id),
+    offence_category VARCHAR(30), -- NULL on allocations brought forward
+    percentage       DECIMAL(5, 2)  NOT NULL,
+    amount           DECIMAL(10, 2) NOT NULL, -- negative when a refund reverses it
+    reversal         BOOLEAN        NOT NULL DEFAULT FALSE,
+    period           CHAR(7)        NOT NULL, -- YYYY-MM the payment completed or was refunded
+    station_id       UUID           REFERENCES stations(id),
+    region_id        UUID           REFERENCES regions(id),
+    created_at       TIMESTAMPTZ    NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_payment_allocations_payment_id ON payment_allocations(payment_id);
+CREATE INDEX idx_payment_allocations_period ON payment_allocations(period, beneficiary_id);
+
+-- Payments completed before allocation started fall under the default rule
+INSERT INTO payment_allocations (payment_id, beneficiary_id, rule_id, percentage, amount, period, station_id, region_id)
+SELECT p.id, s.beneficiary_id, s.rule_id, s.percentage, p.amount,
+       TO_CHAR(COALESCE(p.completed_at, p.created_at) AT TIME ZONE 'UTC', 'YYYY-MM'),
+       t.station_id, t.region_id
+FROM payments p
+JOIN tickets t ON t.id = p.ticket_id
+CROSS JOIN allocation_rule_shares s
+WHERE p.status = 'completed';
+
+INSERT INTO permissions (key, category, description) VALUES
+    ('allocation.manage', 'ledger', 'Manage revenue beneficiaries and allocation rules'),
+    ('disbursement.read', 'ledger', 'View revenue allocations and the disbursement report');
+
+INSERT INTO role_permissions (role, permission) VALUES
+    ('super_admin', 'allocation.manage'),
+    ('admin', 'allocation.manage'),
+    ('accountant', 'allocation.manage'),
+    ('super_admin', 'disbursement.read'),
+    ('admin', 'disbursement.read'),
+    ('accountant', 'disbursement.read');
@@ -41,3 +41,26 @@ func GenerateTemporaryPassword() string {
 	}
 	return string(b)
 }
+
+// GenerateEnrollmentCode creates a random one-time code in the form XXXX-XXXX,
+// avoiding characters that are easily confused when typed on a handset.
+func GenerateEnrollmentCode() string {
+	const chars = "ABCDEFGHJKMNPQRSTUVWXYZ23456789"
+	b := make([]byte, 9)
+	for i := range b {
+		if i == 4 {
+			b[i] = '-'
+			continue
+		}
+		n, _ := rand.Int(rand.Reader, big.NewInt(int64(len(chars))))
+		b[i] = chars[n.Int64()]
+	}
+	return string(b)
+}
+
+// GenerateSecret creates a random 256-bit secret encoded as hex (for device keys).
+func GenerateSecret() string {
+	b := make([]byte, 32)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
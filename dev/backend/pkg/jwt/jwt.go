@@ -8,6 +8,13 @@ import (
 	"github.com/google/uuid"
 )
 
+func init() {
+	// Issue times carry microseconds, so a token issued just after a user's
+	// tokens were revoked is told apart from one issued just before, even
+	// within the same second.
+	jwt.TimePrecision = time.Microsecond
+}
+
 // Claims represents the JWT access token claims.
 type Claims struct {
 	UserID      uuid.UUID `json:"sub"`
@@ -16,14 +23,19 @@ type Claims struct {
 	StationID   *uuid.UUID `json:"station_id,omitempty"`
 	RegionID    *uuid.UUID `json:"region_id,omitempty"`
 	BadgeNumber *string    `json:"badge_number,omitempty"`
+	SessionID   *uuid.UUID `json:"sid,omitempty"` // refresh token family the token was issued to
+	MustChangePassword bool `json:"pwd_change,omitempty"` // only the password may be changed
 	jwt.RegisteredClaims
 }
 
-// Manager handles JWT operations.
+// Manager handles JWT operations. It signs with the HS256 shared secret
+// unless a KeyProvider is configured with UseKeys.
 type Manager struct {
 	secret        []byte
 	accessExpiry  time.Duration
 	refreshExpiry time.Duration
+	keys          KeyProvider
+	acceptHS256   bool
 }
 
 func NewManager(secret string, accessExpiry, refreshExpiry time.Duration) *Manager {
@@ -34,6 +46,15 @@ func NewManager(secret string, accessExpiry, refreshExpiry time.Duration) *Manag
 	}
 }
 
+// UseKeys switches signing to the asymmetric keys supplied by provider.
+// Tokens are then verified by their kid header; HS256 tokens signed with the
+// shared secret are still accepted while acceptHS256 is set, so sessions
+// issued before the switch keep working until they expire.
+func (m *Manager) UseKeys(provider KeyProvider, acceptHS256 bool) {
+	m.keys = provider
+	m.acceptHS256 = acceptHS256
+}
+
 // GenerateAccessToken creates a signed JWT access token.
 func (m *Manager) GenerateAccessToken(claims *Claims) (string, error) {
 	now := time.Now()
@@ -43,18 +64,28 @@ func (m *Manager) GenerateAccessToken(claims *Claims) (string, error) {
 		ID:        uuid.New().String(),
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(m.secret)
+	if m.keys == nil {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+		return token.SignedString(m.secret)
+	}
+
+	key, err := m.keys.SigningKey()
+	if err != nil {
+		return "", err
+	}
+	method, err := signingMethod(key.Algorithm)
+	if err != nil {
+		return "", err
+	}
+	token := jwt.NewWithClaims(method, claims)
+	token.Header["kid"] = key.ID
+	return token.SignedString(key.PrivateKey)
 }
 
 // ValidateToken parses and validates a JWT token string.
 func (m *Manager) ValidateToken(tokenString string) (*Claims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (any, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return m.secret, nil
-	})
+	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, m.verificationKey,
+		jwt.WithValidMethods([]string{AlgHS256, AlgRS256, AlgEdDSA}))
 
 	if err != nil {
 		return nil, err
@@ -68,6 +99,32 @@ func (m *Manager) ValidateToken(tokenString string) (*Claims, error) {
 	return claims, nil
 }
 
+// verificationKey selects the key for a parsed token from its alg and kid.
+func (m *Manager) verificationKey(token *jwt.Token) (any, error) {
+	if _, ok := token.Method.(*jwt.SigningMethodHMAC); ok {
+		if m.keys != nil && !m.acceptHS256 {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return m.secret, nil
+	}
+	if m.keys == nil {
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" {
+		return nil, fmt.Errorf("missing kid header")
+	}
+	key, err := m.keys.VerificationKey(kid)
+	if err != nil {
+		return nil, err
+	}
+	if key.Algorithm != token.Method.Alg() {
+		return nil, fmt.Errorf("key %s does not use %s", kid, token.Method.Alg())
+	}
+	return key.PublicKey, nil
+}
+
 // GenerateRefreshToken creates a random token string for refresh tokens.
 func (m *Manager) GenerateRefreshToken() string {
 	return uuid.New().String() + "-" + uuid.New().String()
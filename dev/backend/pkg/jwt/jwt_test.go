@@ -0,0 +1,34 @@
+package jwt
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TestIssuedAtPrecision checks that iat keeps sub-second precision through
+// signing and parsing, which the user denylist relies on. The claim is
+// parsed from a float, so it may come back one microsecond early but never
+// late.
+func TestIssuedAtPrecision(t *testing.T) {
+	m := NewManager("test-secret", time.Minute, time.Hour)
+
+	for range 100 {
+		before := time.Now()
+		token, err := m.GenerateAccessToken(&Claims{UserID: uuid.New(), Role: "officer"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		after := time.Now()
+
+		claims, err := m.ValidateToken(token)
+		if err != nil {
+			t.Fatal(err)
+		}
+		iat := claims.IssuedAt.Time
+		if iat.Before(before.Truncate(time.Microsecond).Add(-time.Microsecond)) || iat.After(after) {
+			t.Fatalf("iat = %s, want between %s and %s", iat, before, after)
+		}
+	}
+}
@@ -0,0 +1,186 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Supported signing algorithms.
+const (
+	AlgHS256 = "HS256"
+	AlgRS256 = "RS256"
+	AlgEdDSA = "EdDSA"
+)
+
+const rsaKeyBits = 2048
+
+// SigningKey is an asymmetric key pair identified by kid.
+type SigningKey struct {
+	ID         string
+	Algorithm  string
+	PrivateKey crypto.Signer // nil when only the public half is known
+	PublicKey  crypto.PublicKey
+}
+
+// KeyProvider supplies asymmetric keys to the Manager.
+type KeyProvider interface {
+	// SigningKey returns the key new tokens are signed with.
+	SigningKey() (*SigningKey, error)
+
+	// VerificationKey returns the key with the given kid, if it is still
+	// accepted for verification.
+	VerificationKey(kid string) (*SigningKey, error)
+}
+
+// ValidAlgorithm reports whether alg is a supported signing algorithm.
+func ValidAlgorithm(alg string) bool {
+	return alg == AlgHS256 || alg == AlgRS256 || alg == AlgEdDSA
+}
+
+func signingMethod(alg string) (jwt.SigningMethod, error) {
+	switch alg {
+	case AlgRS256:
+		return jwt.SigningMethodRS256, nil
+	case AlgEdDSA:
+		return jwt.SigningMethodEdDSA, nil
+	}
+	return nil, fmt.Errorf("unsupported signing algorithm: %s", alg)
+}
+
+// GenerateKey creates a new key pair for an asymmetric algorithm. The kid is
+// derived from the public key (RFC 7638 thumbprint).
+func GenerateKey(alg string) (*SigningKey, error) {
+	var priv crypto.Signer
+	switch alg {
+	case AlgRS256:
+		k, err := rsa.GenerateKey(rand.Reader, rsaKeyBits)
+		if err != nil {
+			return nil, err
+		}
+		priv = k
+	case AlgEdDSA:
+		_, k, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, err
+		}
+		priv = k
+	default:
+		return nil, fmt.Errorf("unsupported signing algorithm: %s", alg)
+	}
+
+	key := &SigningKey{Algorithm: alg, PrivateKey: priv, PublicKey: priv.Public()}
+	kid, err := thumbprint(key)
+	if err != nil {
+		return nil, err
+	}
+	key.ID = kid
+	return key, nil
+}
+
+// EncodePrivateKey returns the PKCS#8 PEM encoding of the private key.
+func EncodePrivateKey(key *SigningKey) (string, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(key.PrivateKey)
+	if err != nil {
+		return "", err
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})), nil
+}
+
+// ParsePrivateKey decodes a PKCS#8 PEM private key into a SigningKey.
+func ParsePrivateKey(kid, alg, privatePEM string) (*SigningKey, error) {
+	block, _ := pem.Decode([]byte(privatePEM))
+	if block == nil {
+		return nil, fmt.Errorf("key %s: invalid PEM", kid)
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("key %s: %w", kid, err)
+	}
+
+	var priv crypto.Signer
+	switch k := parsed.(type) {
+	case *rsa.PrivateKey:
+		if alg != AlgRS256 {
+			return nil, fmt.Errorf("key %s: RSA key used with %s", kid, alg)
+		}
+		priv = k
+	case ed25519.PrivateKey:
+		if alg != AlgEdDSA {
+			return nil, fmt.Errorf("key %s: Ed25519 key used with %s", kid, alg)
+		}
+		priv = k
+	default:
+		return nil, fmt.Errorf("key %s: unsupported key type %T", kid, parsed)
+	}
+	return &SigningKey{ID: kid, Algorithm: alg, PrivateKey: priv, PublicKey: priv.Public()}, nil
+}
+
+// ---------------------------------------------------------------------------
+// JWKS
+// ---------------------------------------------------------------------------
+
+// JWK is a public key in JSON Web Key format.
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`   // RSA modulus
+	E   string `json:"e,omitempty"`   // RSA exponent
+	Crv string `json:"crv,omitempty"` // OKP curve
+	X   string `json:"x,omitempty"`   // OKP public key
+}
+
+// JWKS is a JSON Web Key Set.
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// PublicJWK returns the public half of key as a JWK.
+func PublicJWK(key *SigningKey) (JWK, error) {
+	jwk := JWK{Kid: key.ID, Use: "sig", Alg: key.Algorithm}
+	switch pub := key.PublicKey.(type) {
+	case *rsa.PublicKey:
+		jwk.Kty = "RSA"
+		jwk.N = b64(pub.N.Bytes())
+		jwk.E = b64(big.NewInt(int64(pub.E)).Bytes())
+	case ed25519.PublicKey:
+		jwk.Kty = "OKP"
+		jwk.Crv = "Ed25519"
+		jwk.X = b64(pub)
+	default:
+		return JWK{}, fmt.Errorf("key %s: unsupported public key type %T", key.ID, key.PublicKey)
+	}
+	return jwk, nil
+}
+
+// thumbprint computes the RFC 7638 JWK thumbprint used as kid.
+func thumbprint(key *SigningKey) (string, error) {
+	jwk, err := PublicJWK(key)
+	if err != nil {
+		return "", err
+	}
+	var canonical string
+	switch jwk.Kty {
+	case "RSA":
+		canonical = fmt.Sprintf(`{"e":"%s","kty":"RSA","n":"%s"}`, jwk.E, jwk.N)
+	default:
+		canonical = fmt.Sprintf(`{"crv":"%s","kty":"OKP","x":"%s"}`, jwk.Crv, jwk.X)
+	}
+	sum := sha256.Sum256([]byte(canonical))
+	return b64(sum[:]), nil
+}
+
+func b64(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
@@ -0,0 +1,187 @@
+// Package metrics keeps process counters and gauges and writes them in the
+// Prometheus text exposition format, so a scraper can read them without the
+// service pulling in a client library.
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// Registry holds named metrics in registration order.
+type Registry struct {
+	mu      sync.Mutex
+	metrics []metric
+	names   map[string]bool
+}
+
+type metric interface {
+	name() string
+	write(w io.Writer) error
+}
+
+func NewRegistry() *Registry {
+	return &Registry{names: map[string]bool{}}
+}
+
+func (r *Registry) register(m metric) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.names[m.name()] {
+		panic("metrics: duplicate metric " + m.name())
+	}
+	r.names[m.name()] = true
+	r.metrics = append(r.metrics, m)
+}
+
+// Counter registers a counter that only goes up.
+func (r *Registry) Counter(name, help string) *Counter {
+	c := &Counter{meta: meta{n: name, help: help, kind: "counter"}}
+	r.register(c)
+	return c
+}
+
+// CounterVec registers a counter split by the value of one label.
+func (r *Registry) CounterVec(name, help, label string) *CounterVec {
+	c := &CounterVec{meta: meta{n: name, help: help, kind: "counter"}, label: label, values: map[string]*atomic.Uint64{}}
+	r.register(c)
+	return c
+}
+
+// Gauge registers a value that can go up and down.
+func (r *Registry) Gauge(name, help string) *Gauge {
+	g := &Gauge{meta: meta{n: name, help: help, kind: "gauge"}}
+	r.register(g)
+	return g
+}
+
+// Write writes every metric in the text exposition format.
+func (r *Registry) Write(w io.Writer) error {
+	r.mu.Lock()
+	metrics := slices.Clone(r.metrics)
+	r.mu.Unlock()
+	for _, m := range metrics {
+		if err := m.write(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Handler serves the registry for scraping.
+func (r *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		_ = r.Write(w)
+	})
+}
+
+type meta struct {
+	n    string
+	help string
+	kind string
+}
+
+func (m meta) name() string { return m.n }
+
+func (m meta) header(w io.Writer) error {
+	_, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", m.n, m.help, m.n, m.kind)
+	return err
+}
+
+// Counter is a monotonically increasing count.
+type Counter struct {
+	meta
+	v atomic.Uint64
+}
+
+func (c *Counter) Inc()          { c.v.Add(1) }
+func (c *Counter) Add(n uint64)  { c.v.Add(n) }
+func (c *Counter) Value() uint64 { return c.v.Load() }
+
+func (c *Counter) write(w io.Writer) error {
+	if err := c.header(w); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(w, "%s %d\n", c.n, c.v.Load())
+	return err
+}
+
+// CounterVec is a set of counters told apart by one label.
+type CounterVec struct {
+	meta
+	label  string
+	mu     sync.RWMutex
+	values map[string]*atomic.Uint64
+}
+
+// Add increases the counter for the label value by n.
+func (c *CounterVec) Add(value string, n uint64) {
+	c.mu.RLock()
+	v, ok := c.values[value]
+	c.mu.RUnlock()
+	if !ok {
+		c.mu.Lock()
+		if v, ok = c.values[value]; !ok {
+			v = &atomic.Uint64{}
+			c.values[value] = v
+		}
+		c.mu.Unlock()
+	}
+	v.Add(n)
+}
+
+func (c *CounterVec) Inc(value string) { c.Add(value, 1) }
+
+// Value returns the counter for the label value.
+func (c *CounterVec) Value(value string) uint64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if v, ok := c.values[value]; ok {
+		return v.Load()
+	}
+	return 0
+}
+
+func (c *CounterVec) write(w io.Writer) error {
+	if err := c.header(w); err != nil {
+		return err
+	}
+	c.mu.RLock()
+	keys := make([]string, 0, len(c.values))
+	for k := range c.values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	lines := make([]string, 0, len(keys))
+	for _, k := range keys {
+		lines = append(lines, fmt.Sprintf("%s{%s=%q} %d\n", c.n, c.label, k, c.values[k].Load()))
+	}
+	c.mu.RUnlock()
+	_, err := io.WriteString(w, strings.Join(lines, ""))
+	return err
+}
+
+// Gauge is a value that is set rather than counted.
+type Gauge struct {
+	meta
+	bits atomic.Uint64
+}
+
+func (g *Gauge) Set(v float64)  { g.bits.Store(math.Float64bits(v)) }
+func (g *Gauge) Value() float64 { return math.Float64frombits(g.bits.Load()) }
+
+func (g *Gauge) write(w io.Writer) error {
+	if err := g.header(w); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(w, "%s %g\n", g.n, g.Value())
+	return err
+}
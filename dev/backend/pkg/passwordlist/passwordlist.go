@@ -0,0 +1,85 @@
+// Package passwordlist checks passwords against a local list of breached or
+// common passwords.
+package passwordlist
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"os"
+	"strings"
+)
+
+// List is a set of SHA-1 password hashes. The zero value and a nil *List are
+// empty lists.
+type List struct {
+	hashes map[string]struct{}
+}
+
+// Load reads one entry per line. A line of 40 hex digits, optionally followed
+// by ":count" as in the Have I Been Pwned downloads, is a SHA-1 hash of the
+// password; any other line is a plaintext password, matched case-insensitively.
+// Blank lines and lines starting with # are skipped. An empty path gives an
+// empty list.
+func Load(path string) (*List, error) {
+	l := &List{hashes: make(map[string]struct{})}
+	if path == "" {
+		return l, nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if h, _, _ := strings.Cut(line, ":"); isSHA1(h) {
+			l.hashes[strings.ToUpper(h)] = struct{}{}
+			continue
+		}
+		l.hashes[sum(strings.ToLower(line))] = struct{}{}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// Len returns the number of entries.
+func (l *List) Len() int {
+	if l == nil {
+		return 0
+	}
+	return len(l.hashes)
+}
+
+// Contains reports whether password, or its lower-case form, is listed.
+func (l *List) Contains(password string) bool {
+	if l.Len() == 0 {
+		return false
+	}
+	if _, ok := l.hashes[sum(password)]; ok {
+		return true
+	}
+	_, ok := l.hashes[sum(strings.ToLower(password))]
+	return ok
+}
+
+func sum(s string) string {
+	h := sha1.Sum([]byte(s))
+	return strings.ToUpper(hex.EncodeToString(h[:]))
+}
+
+func isSHA1(s string) bool {
+	if len(s) != 40 {
+		return false
+	}
+	_, err := hex.DecodeString(s)
+	return err == nil
+}